@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/di"
+
+	"github.com/google/uuid"
+)
+
+const catalogUsage = `Использование:
+  api catalog export [-format csv|json|ndjson] [-o файл]
+  api catalog import [-format csv|json|ndjson] [-dry-run] [-create-genres] файл
+
+Формат по умолчанию берется из расширения файла, иначе json.
+Файл "-" означает stdin/stdout.`
+
+// runCatalog выполняет подкоманду catalog и возвращает код выхода процесса.
+func runCatalog(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, catalogUsage)
+		return 2
+	}
+
+	switch args[0] {
+	case "export":
+		return runCatalogExport(args[1:])
+	case "import":
+		return runCatalogImport(args[1:])
+	default:
+		fmt.Fprintln(os.Stderr, catalogUsage)
+		return 2
+	}
+}
+
+func runCatalogExport(args []string) int {
+	fs := flag.NewFlagSet("catalog export", flag.ContinueOnError)
+	format := fs.String("format", "", "формат файла: csv, json или ndjson")
+	output := fs.String("o", "-", "файл для выгрузки")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	ctx := context.Background()
+	catalog, err := di.BuildCatalog(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "DI build error:", err)
+		return 1
+	}
+	defer func() { _ = catalog.Close() }()
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := catalog.Service.ExportCatalog(ctx, formatFor(*format, *output), w); err != nil {
+		fmt.Fprintln(os.Stderr, "Export error:", err)
+		return 1
+	}
+	return 0
+}
+
+func runCatalogImport(args []string) int {
+	fs := flag.NewFlagSet("catalog import", flag.ContinueOnError)
+	format := fs.String("format", "", "формат файла: csv, json или ndjson")
+	dryRun := fs.Bool("dry-run", false, "только проверить файл")
+	createGenres := fs.Bool("create-genres", false, "создавать недостающие жанры")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, catalogUsage)
+		return 2
+	}
+	input := fs.Arg(0)
+
+	ctx := context.Background()
+	catalog, err := di.BuildCatalog(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "DI build error:", err)
+		return 1
+	}
+	defer func() { _ = catalog.Close() }()
+
+	var r io.Reader = os.Stdin
+	if input != "-" {
+		f, err := os.Open(input)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+
+	req := dto.CatalogImportQueryDto{
+		Format:       formatFor(*format, input),
+		DryRun:       *dryRun,
+		CreateGenres: *createGenres,
+	}
+	// Изменения из консоли записываются в историю без автора
+	result, err := catalog.Service.ImportCatalog(ctx, req, r, uuid.Nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Import error:", err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(result)
+
+	if len(result.Errors) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// formatFor возвращает явно заданный формат или формат по расширению файла.
+func formatFor(format, path string) string {
+	if format != "" {
+		return format
+	}
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+}
@@ -17,6 +17,9 @@ import (
 // @name Authorization
 // @description  Введите значение заголовка целиком: "Bearer <JWT>"
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "catalog" {
+		os.Exit(runCatalog(os.Args[2:]))
+	}
 
 	ctx := context.Background()
 	app, err := di.Build(ctx)
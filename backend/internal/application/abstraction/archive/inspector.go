@@ -0,0 +1,32 @@
+package archive
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	// ErrUnsupported - файл не является архивом zip или tar.gz либо поврежден
+	ErrUnsupported = errors.New("unsupported archive")
+	// ErrUnsafePath - путь в архиве абсолютный или выходит за его пределы (zip slip)
+	ErrUnsafePath = errors.New("unsafe path in archive")
+	// ErrTooLarge - архив больше допустимого размера
+	ErrTooLarge = errors.New("archive is too large")
+)
+
+// Entry - элемент оглавления архива. Path нормализован: разделитель "/", без "./" в начале.
+type Entry struct {
+	Path  string
+	Size  int64
+	IsDir bool
+	// SHA256 - hex-хеш содержимого; пустой у каталогов и ссылок
+	SHA256 string
+}
+
+// Inspector определяет формат архива по содержимому, читает его оглавление и считает хеши файлов,
+// не распаковывая их на диск.
+// Concrete implementations must live in infrastructure.
+type Inspector interface {
+	// Inspect возвращает формат (model.ArchiveFormatZip или model.ArchiveFormatTarGz) и элементы архива.
+	Inspect(r io.ReaderAt, size int64) (format string, entries []Entry, err error)
+}
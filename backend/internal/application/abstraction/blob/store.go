@@ -0,0 +1,27 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Object - сохраненное содержимое. Key - SHA-256 содержимого в hex, поэтому
+// одинаковые файлы получают один ключ и хранятся один раз.
+type Object struct {
+	Key  string
+	Size int64
+}
+
+// Store - хранилище неизменяемых файлов, адресуемых по содержимому.
+// Concrete implementations (local filesystem, S3-compatible, etc.) must live in infrastructure.
+type Store interface {
+	// Put сохраняет содержимое r и возвращает его ключ; повторная запись того же содержимого ничего не меняет.
+	Put(ctx context.Context, r io.Reader) (Object, error)
+	// Open открывает содержимое на чтение; ErrNotFound, если ключа нет.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete удаляет содержимое; отсутствие ключа ошибкой не считается.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,13 @@
+package cache
+
+import "time"
+
+// Cache хранит вычисленные значения ограниченное время. Значения не копируются:
+// тот, кто кладет значение, больше его не меняет.
+// Concrete implementations (in-memory, etc.) must live in infrastructure.
+type Cache interface {
+	// Get возвращает значение по ключу; false, если его нет или срок истек.
+	Get(key string) (any, bool)
+	Set(key string, value any, ttl time.Duration)
+	Delete(key string)
+}
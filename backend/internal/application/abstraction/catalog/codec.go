@@ -0,0 +1,42 @@
+package catalog
+
+import (
+	"errors"
+	"io"
+)
+
+// Виды записей каталога
+const (
+	KindGenre = "genre"
+	KindGame  = "game"
+)
+
+var ErrUnknownFormat = errors.New("unknown catalog format")
+
+// Record - запись выгрузки каталога (жанр или игра). Значения хранятся строками, как в файле:
+// разбор и проверка выполняются при импорте, чтобы ошибка относилась к конкретной записи.
+type Record struct {
+	Kind        string `json:"kind"`
+	ID          string `json:"id,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	// ReleaseDate - RFC3339 или YYYY-MM-DD
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	// Genres - названия жанров игры, основной первый
+	Genres []string `json:"genres,omitempty"`
+	// Status - статус публикации игры; без него новая игра импортируется черновиком
+	Status string `json:"status,omitempty"`
+	// PublishAt - RFC3339, только у статуса scheduled
+	PublishAt string `json:"publishAt,omitempty"`
+}
+
+// Codec читает и пишет записи каталога в одном формате.
+// Concrete implementations (CSV, JSON, NDJSON) must live in infrastructure.
+type Codec interface {
+	// Format - имя формата в параметре format и в расширении файла
+	Format() string
+	ContentType() string
+	Encode(w io.Writer, records []Record) error
+	// Decode читает все записи; синтаксическая ошибка файла прерывает импорт целиком
+	Decode(r io.Reader) ([]Record, error)
+}
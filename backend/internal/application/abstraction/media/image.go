@@ -0,0 +1,28 @@
+package media
+
+import "errors"
+
+var (
+	// ErrUnsupportedImage - содержимое не является изображением поддерживаемого формата
+	ErrUnsupportedImage = errors.New("unsupported image")
+	// ErrTooLarge - файл или изображение превышает допустимый размер
+	ErrTooLarge = errors.New("media is too large")
+)
+
+// Image - сведения об изображении, определенные по его содержимому, а не по имени файла.
+type Image struct {
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// ImageProcessor определяет формат изображений и строит миниатюры.
+// Concrete implementations must live in infrastructure.
+type ImageProcessor interface {
+	// Inspect определяет тип по содержимому (MIME sniffing) и размеры;
+	// ErrUnsupportedImage для остальных файлов, ErrTooLarge для слишком больших изображений.
+	Inspect(data []byte) (Image, error)
+	// Thumbnail уменьшает изображение так, чтобы большая сторона не превышала maxSide.
+	// Изображения меньше maxSide не увеличиваются.
+	Thumbnail(data []byte, maxSide int) ([]byte, Image, error)
+}
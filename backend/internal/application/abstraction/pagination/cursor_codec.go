@@ -0,0 +1,16 @@
+package pagination
+
+import (
+	"errors"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorCodec превращает курсор keyset-пагинации в непрозрачный подписанный токен и обратно.
+// Concrete implementations (HMAC, etc.) must live in infrastructure.
+type CursorCodec interface {
+	Encode(cursor repository.Cursor) (string, error)
+	Decode(token string) (repository.Cursor, error)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAttemptNotFound = errors.New("attempt not found")
+	// ErrAttemptStatusChanged - статус попытки в хранилище уже не тот, из которого ее переводили
+	ErrAttemptStatusChanged = errors.New("attempt status changed")
+)
+
+type AttemptRepository interface {
+	// Create сохраняет попытку; ErrNotFound, если игры нет или она в корзине.
+	Create(ctx context.Context, attempt *model.Attempt) (*model.Attempt, error)
+
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Attempt, error)
+
+	// FindByLaunchTokenHash находит попытку по хэшу еще не предъявленного токена запуска.
+	FindByLaunchTokenHash(ctx context.Context, hash string) (*model.Attempt, error)
+
+	// FindByResultTokenHash находит попытку по хэшу токена результата, выданного при запуске.
+	FindByResultTokenHash(ctx context.Context, hash string) (*model.Attempt, error)
+
+	// FindOpen возвращает незавершенные (started и running) попытки пользователя в игре.
+	FindOpen(ctx context.Context, userID, gameID uuid.UUID) ([]*model.Attempt, error)
+
+	// Update сохраняет статус, отметки времени и токены попытки, если ее статус
+	// в хранилище равен fromStatus; иначе ErrAttemptStatusChanged. Так токен запуска
+	// предъявляется ровно один раз даже при одновременных запросах.
+	Update(ctx context.Context, attempt *model.Attempt, fromStatus string) (*model.Attempt, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"example/web-service-gin/internal/domain/model"
+)
+
+// CatalogImport - проверенные изменения каталога для пакетного применения.
+// Обновляемые записи несут версию, прочитанную при проверке.
+type CatalogImport struct {
+	CreateGenres []*model.Genre
+	UpdateGenres []*model.Genre
+	CreateGames  []*model.Game
+	UpdateGames  []*model.Game
+}
+
+// CatalogRepository применяет пакетный импорт жанров и игр.
+type CatalogRepository interface {
+	// Apply сохраняет все изменения одной транзакцией: при любой ошибке, в том числе
+	// ErrVersionConflict, не сохраняется ничего. Версии обновляются как при Create и Update.
+	Apply(ctx context.Context, in CatalogImport) error
+}
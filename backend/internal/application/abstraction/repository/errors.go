@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+// ErrVersionConflict - запись изменена после того, как ее прочитали:
+// версия в хранилище не совпадает с ожидаемой (оптимистическая блокировка).
+var ErrVersionConflict = errors.New("version conflict")
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBuildNotFound = errors.New("game build not found")
+	// ErrBuildAlreadyExists - у игры уже есть сборка этой версии для платформы
+	ErrBuildAlreadyExists = errors.New("game build already exists")
+)
+
+type GameBuildRepository interface {
+	// Create сохраняет сборку вместе со списком файлов; с IsCurrent она в той же транзакции
+	// становится текущей вместо прежней сборки платформы.
+	Create(ctx context.Context, build *model.GameBuild) (*model.GameBuild, error)
+
+	// FindByID возвращает сборку; ErrBuildNotFound, если ее нет.
+	FindByID(ctx context.Context, id uuid.UUID) (*model.GameBuild, error)
+
+	// FindFiles возвращает файлы сборки по пути; ErrBuildNotFound, если сборки нет.
+	FindFiles(ctx context.Context, buildID uuid.UUID) ([]model.BuildFile, error)
+
+	// FindByGameID возвращает сборки игры, новые первыми. Пустой platform - все платформы.
+	FindByGameID(ctx context.Context, gameID uuid.UUID, platform string) ([]*model.GameBuild, error)
+
+	// FindCurrent возвращает текущую сборку платформы; ErrBuildNotFound, если ее нет.
+	FindCurrent(ctx context.Context, gameID uuid.UUID, platform string) (*model.GameBuild, error)
+
+	// SetCurrent делает сборку текущей для ее платформы и возвращает ее.
+	SetCurrent(ctx context.Context, id uuid.UUID) (*model.GameBuild, error)
+
+	// Delete удаляет запись (архив в хранилище файлов не трогает).
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// BlobInUse сообщает, ссылается ли на ключ хранилища какая-нибудь сборка.
+	BlobInUse(ctx context.Context, key string) (bool, error)
+}
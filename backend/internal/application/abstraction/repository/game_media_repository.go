@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrMediaNotFound = errors.New("game media not found")
+	// ErrMediaOrderMismatch - новый порядок должен содержать ровно все скриншоты игры
+	ErrMediaOrderMismatch = errors.New("media order does not match game screenshots")
+)
+
+type GameMediaRepository interface {
+	// Create сохраняет запись вместе с миниатюрами. Скриншот добавляется в конец списка игры,
+	// обложка заменяет прежнюю обложку игры в той же транзакции.
+	Create(ctx context.Context, media *model.GameMedia) (*model.GameMedia, error)
+
+	// FindByID возвращает запись с миниатюрами; ErrMediaNotFound, если ее нет.
+	FindByID(ctx context.Context, id uuid.UUID) (*model.GameMedia, error)
+
+	// FindByGameID возвращает обложку и скриншоты игры: обложка первой, скриншоты по Position.
+	FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.GameMedia, error)
+
+	// Delete удаляет запись и ее миниатюры (содержимое в хранилище файлов не трогает).
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Reorder задает порядок скриншотов игры; ids должны содержать все ее скриншоты.
+	Reorder(ctx context.Context, gameID uuid.UUID, ids []uuid.UUID) error
+
+	// BlobInUse сообщает, ссылается ли на ключ хранилища какая-нибудь запись или миниатюра.
+	BlobInUse(ctx context.Context, key string) (bool, error)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+var ErrRelationNotFound = errors.New("game relation not found")
+
+type GameRelationRepository interface {
+	// Set сохраняет связь, заменяя прежнюю связь игры того же типа.
+	// Если одной из игр нет (в том числе в корзине), возвращает ErrNotFound.
+	Set(ctx context.Context, relation *model.GameRelation) (*model.GameRelation, error)
+
+	// Delete удаляет связь игры типа relationType; ErrRelationNotFound, если ее нет.
+	Delete(ctx context.Context, gameID uuid.UUID, relationType string) error
+
+	// FindByGameID возвращает связи, в которых игра участвует с любой стороны.
+	// Связи с играми из корзины не возвращаются.
+	FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.GameRelation, error)
+
+	// FindReachable возвращает связи, по которым от игры from можно пройти от игры к связанной,
+	// включая связи игр из корзины: после восстановления такие связи снова действуют,
+	// поэтому при проверке циклов они учитываются.
+	FindReachable(ctx context.Context, from uuid.UUID) ([]*model.GameRelation, error)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"example/web-service-gin/internal/domain/model"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,18 +12,79 @@ import (
 var (
 	ErrNotFound      = errors.New("game not found")
 	ErrAlreadyExists = errors.New("game already exists")
+	// ErrGameGenreDeleted - игру нельзя восстановить, пока ее жанр в корзине
+	ErrGameGenreDeleted = errors.New("game genre is deleted")
+	// ErrDuplicateTitle - среди активных игр уже есть игра с тем же нормализованным названием (model.NormalizeTitle)
+	ErrDuplicateTitle = errors.New("game title already exists")
 )
 
+// PurgedGames - итог окончательного удаления игр. Файлы в хранилищах остаются: их удаляет
+// вызывающий, если на те же ключи больше ничто не ссылается.
+type PurgedGames struct {
+	Count int
+	// MediaKeys - ключи изображений и миниатюр удаленных игр
+	MediaKeys []string
+	// BuildKeys - ключи архивов сборок удаленных игр
+	BuildKeys []string
+}
+
+// GameTitle - название активной игры для поиска похожих.
+type GameTitle struct {
+	ID    uuid.UUID
+	Title string
+}
+
 type GameRepository interface {
+	// Create возвращает ErrDuplicateTitle, если название занято другой активной игрой.
 	Create(ctx context.Context, game *model.Game) (*model.Game, error)
 
 	FindByID(ctx context.Context, id uuid.UUID) (*model.Game, error)
 
 	FindAll(ctx context.Context, limit, offset int) ([]*model.Game, error)
 
+	// FindByQuery возвращает страницу по критериям и общее количество подходящих записей.
+	FindByQuery(ctx context.Context, query GameQuery) ([]*model.Game, int, error)
+
+	// Search ищет игры по названию и описанию, упорядочивая по релевантности.
+	// Возвращает страницу результатов и общее количество совпадений.
+	Search(ctx context.Context, query GameSearchQuery) ([]*GameSearchHit, int, error)
+
+	// Update сохраняет запись, если ее версия в хранилище равна game.Version, и увеличивает версию;
+	// ErrVersionConflict, если запись успели изменить, ErrDuplicateTitle, если название занято.
 	Update(ctx context.Context, game *model.Game) (*model.Game, error)
 
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete перемещает игру с версией version в корзину (мягкое удаление);
+	// ErrVersionConflict, если версия в хранилище другая.
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+
+	// FindDeleted возвращает игры из корзины, недавно удаленные первыми.
+	FindDeleted(ctx context.Context) ([]*model.Game, error)
+
+	// Restore возвращает игру из корзины; ErrNotFound, если в корзине ее нет,
+	// ErrDuplicateTitle, если за это время ее название заняла другая игра.
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// Purge окончательно удаляет игры, попавшие в корзину раньше before, вместе с их медиа и сборками
+	// и возвращает их количество и ключи файлов, на которые ссылались удаленные записи.
+	Purge(ctx context.Context, before time.Time) (*PurgedGames, error)
 
 	Exists(ctx context.Context, id uuid.UUID) (bool, error)
+
+	// FindByTitle возвращает активную игру, название которой совпадает с title после нормализации;
+	// ErrNotFound, если такой нет.
+	FindByTitle(ctx context.Context, title string) (*model.Game, error)
+
+	// FindTitles возвращает названия всех активных игр.
+	FindTitles(ctx context.Context) ([]*GameTitle, error)
+
+	// UpdateStatus сохраняет статус и момент публикации игры с версией game.Version
+	// и увеличивает версию; остальные поля не меняются. Ошибки - как у Update.
+	UpdateStatus(ctx context.Context, game *model.Game) (*model.Game, error)
+
+	// PublishDue публикует активные игры, запланированные на момент не позже now,
+	// и возвращает их уже опубликованными.
+	PublishDue(ctx context.Context, now time.Time) ([]*model.Game, error)
+
+	// NextPublishAt - ближайший момент запланированной публикации; nil, если ничего не запланировано.
+	NextPublishAt(ctx context.Context) (*time.Time, error)
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// GameSimilarityRepository - заранее посчитанная похожесть игр (см. model.ComputeSimilarities).
+type GameSimilarityRepository interface {
+	// Replace заменяет всю таблицу похожести в одной транзакции. Пары с играми,
+	// окончательно удаленными во время расчета, пропускаются.
+	Replace(ctx context.Context, items []*model.GameSimilarity, computedAt time.Time) error
+
+	// FindByGameIDs возвращает похожие игры для каждой из игр gameIDs, самые похожие первыми.
+	// Игры из корзины в результат не попадают.
+	FindByGameIDs(ctx context.Context, gameIDs []uuid.UUID) (map[uuid.UUID][]*model.GameSimilarity, error)
+}
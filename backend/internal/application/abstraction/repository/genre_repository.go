@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"example/web-service-gin/internal/domain/model"
 
@@ -12,6 +13,8 @@ import (
 var (
 	ErrGenreNotFound      = errors.New("genre not found")
 	ErrGenreAlreadyExists = errors.New("genre already exists")
+	// ErrGenreInUse - жанр нельзя удалить, пока к нему относятся игры
+	ErrGenreInUse = errors.New("genre is in use")
 )
 
 type GenreRepository interface {
@@ -21,9 +24,28 @@ type GenreRepository interface {
 
 	FindAll(ctx context.Context, limit, offset int) ([]*model.Genre, error)
 
+	// FindByIDs возвращает активные жанры из ids; отсутствующие и удаленные пропускаются.
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*model.Genre, error)
+
+	// FindByQuery возвращает страницу по критериям и общее количество подходящих записей.
+	FindByQuery(ctx context.Context, query GenreQuery) ([]*model.Genre, int, error)
+
+	// Update сохраняет запись, если ее версия в хранилище равна genre.Version, и увеличивает версию;
+	// ErrVersionConflict, если запись успели изменить.
 	Update(ctx context.Context, genre *model.Genre) (*model.Genre, error)
 
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete перемещает жанр с версией version в корзину (мягкое удаление);
+	// ErrVersionConflict, если версия в хранилище другая.
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+
+	// FindDeleted возвращает жанры из корзины, недавно удаленные первыми.
+	FindDeleted(ctx context.Context) ([]*model.Genre, error)
+
+	// Restore возвращает жанр из корзины; ErrGenreNotFound, если в корзине его нет.
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// Purge окончательно удаляет жанры, попавшие в корзину раньше before, и возвращает их количество.
+	Purge(ctx context.Context, before time.Time) (int, error)
 
 	Exists(ctx context.Context, id uuid.UUID) (bool, error)
 }
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrResultAlreadySubmitted - у попытки уже есть результат
+	ErrResultAlreadySubmitted = errors.New("result already submitted")
+	// ErrNonceUsed - подписанный запрос с этим nonce уже принимался
+	ErrNonceUsed = errors.New("nonce already used")
+)
+
+type PlayResultRepository interface {
+	// UseNonce запоминает nonce подписанного запроса попытки attemptID; ErrNonceUsed, если
+	// он уже встречался. Так повтор перехваченного запроса отклоняется, даже если первый
+	// запрос результат не сохранил.
+	UseNonce(ctx context.Context, attemptID uuid.UUID, nonce string, at time.Time) error
+
+	// Submit в одной транзакции сохраняет результат и статус завершенной им попытки attempt,
+	// если попытка в хранилище еще в running; иначе ErrAttemptStatusChanged.
+	// ErrResultAlreadySubmitted, если у попытки уже есть результат.
+	Submit(ctx context.Context, result *model.PlayResult, attempt *model.Attempt) (*model.PlayResult, error)
+
+	// FindGameStatsByUser возвращает статистику пользователя по каждой игре (не из корзины),
+	// в которой у него есть результаты; недавно сыгранные первыми.
+	FindGameStatsByUser(ctx context.Context, userID uuid.UUID) ([]*model.GamePlayStats, error)
+
+	// FindGenreStatsByUser возвращает итоги пользователя по жанрам сыгранных игр;
+	// жанры с большим числом результатов первыми.
+	FindGenreStatsByUser(ctx context.Context, userID uuid.UUID) ([]*model.GenrePlayStats, error)
+
+	// FindLeaderboard ранжирует пользователей (не из корзины) по их лучшему результату
+	// в порядке model.CompareResults; при полном совпадении выше пользователь с меньшим ID.
+	FindLeaderboard(ctx context.Context, q LeaderboardQuery) (*model.Leaderboard, error)
+}
+
+// LeaderboardQuery - параметры таблицы рекордов игры GameID.
+type LeaderboardQuery struct {
+	GameID uuid.UUID
+	// TaskID - только результаты задачи; uuid.Nil - все результаты игры
+	TaskID uuid.UUID
+	// Since - только результаты не раньше; zero - за все время
+	Since         time.Time
+	ExcludeAdmins bool
+	Limit         int
+	// CallerID - чье место вернуть отдельно, даже если оно ниже первых Limit; uuid.Nil - ничье
+	CallerID uuid.UUID
+}
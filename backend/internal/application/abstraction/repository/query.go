@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"time"
+
+	"example/web-service-gin/internal/domain/model"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+
+	"github.com/google/uuid"
+)
+
+// Cursor - позиция keyset-пагинации: ключ сортировки последней выданной записи
+// и ее ID как тай-брейкер. Sort и Filter фиксируют сортировку и фильтры, для которых курсор выдан.
+type Cursor struct {
+	Sort string
+	// Filter - отпечаток фильтров списка (поиск, жанры, даты и т.п.)
+	Filter string
+	Key    string
+	ID     uuid.UUID
+}
+
+type GameSortField string
+
+const (
+	GameSortReleaseDate GameSortField = "releaseDate"
+	GameSortTitle       GameSortField = "title"
+	GameSortRating      GameSortField = "rating"
+)
+
+// GameQuery - критерии выборки игр. Пустые поля не участвуют в фильтрации,
+// Limit <= 0 означает "без ограничения". Если задан After, Offset игнорируется
+// и выдаются записи строго после курсора в порядке сортировки.
+type GameQuery struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+
+	// GenreIDs - игры хотя бы одного из жанров, а при MatchAllGenres - всех сразу
+	GenreIDs       []uuid.UUID
+	MatchAllGenres bool
+	ReleasedFrom   *time.Time
+	ReleasedTo     *time.Time
+	// Search - подстрока в названии или описании (без учета регистра)
+	Search string
+	// Status - только игры с этим статусом публикации
+	Status string
+
+	SortBy   GameSortField
+	SortDesc bool
+}
+
+// GenreQuery - критерии выборки жанров (сортировка всегда по названию).
+type GenreQuery struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+
+	Search string
+
+	SortDesc bool
+}
+
+// UserQuery - критерии выборки пользователей (сортировка всегда по логину).
+type UserQuery struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+
+	Search string
+	Role   specifictype.UserRole
+
+	SortDesc bool
+}
+
+// GameSearchQuery - полнотекстовый поиск игр. Text - пользовательский ввод как есть,
+// разбор на термы выполняет реализация репозитория.
+type GameSearchQuery struct {
+	Text   string
+	Limit  int
+	Offset int
+	// Status - только игры с этим статусом публикации; пустой - любые
+	Status string
+}
+
+// Маркеры, которыми выделяются найденные термы в TitleHighlight и DescriptionSnippet.
+// Оба поля - готовый HTML: текст игры в них экранирован, разметка - только эти маркеры.
+const (
+	SearchHighlightStart = "<mark>"
+	SearchHighlightEnd   = "</mark>"
+)
+
+// GameSearchHit - найденная игра. Rank - релевантность в терминах bm25:
+// чем меньше значение, тем выше игра в выдаче.
+type GameSearchHit struct {
+	Game               *model.Game
+	Rank               float64
+	TitleHighlight     string
+	DescriptionSnippet string
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// RevisionRepository хранит историю изменений; ревизии только добавляются.
+type RevisionRepository interface {
+	// Append сохраняет ревизию, присваивая ей следующий номер в истории сущности.
+	Append(ctx context.Context, revision *model.Revision) (*model.Revision, error)
+
+	// FindByEntity возвращает страницу истории сущности (последние ревизии первыми) и общее число ревизий.
+	FindByEntity(ctx context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]*model.Revision, int, error)
+
+	// FindByNumber возвращает ревизию по номеру; ErrRevisionNotFound, если ее нет.
+	FindByNumber(ctx context.Context, entityType string, entityID uuid.UUID, number int) (*model.Revision, error)
+
+	// CountByActor считает ревизии с действием action, которые actorID сохранил начиная с since.
+	CountByActor(ctx context.Context, entityType, action string, actorID uuid.UUID, since time.Time) (int, error)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSeriesNotFound = errors.New("series not found")
+	// ErrGameInAnotherSeries - игра из состава серии уже входит в другую серию
+	ErrGameInAnotherSeries = errors.New("game already belongs to another series")
+)
+
+type SeriesRepository interface {
+	// Create сохраняет серию вместе с составом. Если какой-то игры нет, возвращает ErrNotFound.
+	Create(ctx context.Context, series *model.Series) (*model.Series, error)
+
+	// FindByID возвращает серию с играми по порядку; ErrSeriesNotFound, если ее нет.
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Series, error)
+
+	// FindAll возвращает страницу серий по названию и общее число серий.
+	FindAll(ctx context.Context, limit, offset int) ([]*model.Series, int, error)
+
+	// FindByGameID возвращает серию, в которую входит игра; ErrSeriesNotFound, если такой нет.
+	FindByGameID(ctx context.Context, gameID uuid.UUID) (*model.Series, error)
+
+	// Update заменяет название и состав серии в одной транзакции. Как и в Create, все игры
+	// состава должны быть активными, поэтому игры из корзины при обновлении из серии выходят.
+	Update(ctx context.Context, series *model.Series) (*model.Series, error)
+
+	// Delete удаляет серию; игры остаются в каталоге.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
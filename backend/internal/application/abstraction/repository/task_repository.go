@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+var ErrTaskNotFound = errors.New("task not found")
+
+type TaskRepository interface {
+	// Create сохраняет задачу; задаче с Order 0 назначается номер после последней задачи игры.
+	// ErrNotFound, если игры нет или она в корзине.
+	Create(ctx context.Context, task *model.Task) (*model.Task, error)
+
+	// FindByID возвращает задачу; задачи игр из корзины не находятся (ErrTaskNotFound).
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Task, error)
+
+	// FindByGameID возвращает задачи игры по порядку.
+	FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.Task, error)
+
+	// Update сохраняет задачу; задаче с Order 0 назначается номер после последней задачи игры.
+	Update(ctx context.Context, task *model.Task) (*model.Task, error)
+
+	Delete(ctx context.Context, id uuid.UUID) error
+}
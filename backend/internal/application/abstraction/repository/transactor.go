@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// Transactor выполняет несколько операций репозиториев атомарно.
+type Transactor interface {
+	// WithinTx вызывает fn в транзакции: репозитории, получившие ctx из fn, работают в ней.
+	// Ошибка fn откатывает все изменения, сделанные внутри.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUserRatingNotFound      = errors.New("user rating not found")
+	ErrUserRatingAlreadyExists = errors.New("user rating already exists")
+)
+
+type UserRatingRepository interface {
+	Create(ctx context.Context, rating *model.UserRating) (*model.UserRating, error)
+
+	Update(ctx context.Context, rating *model.UserRating) (*model.UserRating, error)
+
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.UserRating, error)
+
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserRating, error)
+
+	FindByUserAndGame(ctx context.Context, userID, gameID uuid.UUID) (*model.UserRating, error)
+
+	// FindAll возвращает все оценки; используется при пересчете похожести игр.
+	FindAll(ctx context.Context) ([]*model.UserRating, error)
+
+	// FindStatsByGameIDs возвращает среднюю оценку и количество оценок для каждой игры.
+	// Игры без оценок в результат не попадают.
+	FindStatsByGameIDs(ctx context.Context, gameIDs []uuid.UUID) (map[uuid.UUID]*model.RatingStats, error)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"example/web-service-gin/internal/domain/model"
 
@@ -23,9 +24,25 @@ type UserRepository interface {
 
 	FindAll(ctx context.Context, limit, offset int) ([]*model.User, error)
 
+	// FindByQuery возвращает страницу по критериям и общее количество подходящих записей.
+	FindByQuery(ctx context.Context, query UserQuery) ([]*model.User, int, error)
+
+	// Update сохраняет запись, если ее версия в хранилище равна user.Version, и увеличивает версию;
+	// ErrVersionConflict, если запись успели изменить.
 	Update(ctx context.Context, user *model.User) (*model.User, error)
 
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete перемещает пользователя с версией version в корзину (мягкое удаление);
+	// ErrVersionConflict, если версия в хранилище другая.
+	Delete(ctx context.Context, id uuid.UUID, version int) error
+
+	// FindDeleted возвращает пользователей из корзины, недавно удаленные первыми.
+	FindDeleted(ctx context.Context) ([]*model.User, error)
+
+	// Restore возвращает пользователя из корзины; ErrUserNotFound, если в корзине его нет.
+	Restore(ctx context.Context, id uuid.UUID) error
+
+	// Purge окончательно удаляет пользователей, попавшие в корзину раньше before, и возвращает их количество.
+	Purge(ctx context.Context, before time.Time) (int, error)
 
 	Exists(ctx context.Context, id uuid.UUID) (bool, error)
 }
@@ -0,0 +1,44 @@
+package signing
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidSignature - подпись не совпадает с подписанными данными
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrExpired - срок действия подписанной ссылки истек
+	ErrExpired = errors.New("signature expired")
+)
+
+// URLSigner подписывает ссылки на ресурсы с ограниченным сроком действия.
+// Подпись проверяет только сервер, поэтому годится симметричный ключ.
+// Concrete implementations (HMAC, etc.) must live in infrastructure.
+type URLSigner interface {
+	Sign(resource string, expires time.Time) string
+	// Verify возвращает ErrExpired или ErrInvalidSignature, если ссылкой пользоваться нельзя.
+	Verify(resource string, expires time.Time, signature string) error
+}
+
+// ContentSigner подписывает документы закрытым ключом; клиент проверяет подпись открытым ключом,
+// который сервер публикует, поэтому подделать документ без доступа к серверу нельзя.
+// Concrete implementations must live in infrastructure.
+type ContentSigner interface {
+	// Algorithm - название алгоритма подписи, например "ed25519"
+	Algorithm() string
+	// KeyID - короткий идентификатор ключа, чтобы клиент заметил его смену
+	KeyID() string
+	PublicKey() []byte
+	Sign(data []byte) []byte
+}
+
+// RequestSigner проверяет подписи запросов клиентов, которым сервер выдал личный ключ.
+// Ключ выводится из секрета сервера и идентификатора клиента, поэтому хранить его не нужно.
+// Concrete implementations must live in infrastructure.
+type RequestSigner interface {
+	// ClientKey - ключ клиента clientID, который сервер передает ему один раз
+	ClientKey(clientID string) string
+	// Verify возвращает ErrInvalidSignature, если signature - не подпись message ключом клиента clientID.
+	Verify(clientID string, message []byte, signature string) error
+}
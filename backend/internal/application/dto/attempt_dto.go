@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttemptDto struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"userId"`
+	GameID uuid.UUID `json:"gameId"`
+	// Задача попытки; нет в свободном режиме
+	TaskID *uuid.UUID `json:"taskId,omitempty"`
+	// free | task
+	Mode string `json:"mode"`
+	Seed int64  `json:"seed"`
+	// started | running | completed | failed | abandoned
+	Status     string     `json:"status"`
+	StartedAt  time.Time  `json:"startedAt"`
+	RunningAt  *time.Time `json:"runningAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// StartAttemptDto - тело POST /games/:id/attempts; без taskId игра запускается в свободном режиме
+type StartAttemptDto struct {
+	TaskID *uuid.UUID `json:"taskId"`
+}
+
+// LaunchPayloadDto - данные, с которыми приложение запускает игру
+type LaunchPayloadDto struct {
+	AttemptID uuid.UUID  `json:"attemptId"`
+	GameID    uuid.UUID  `json:"gameId"`
+	UserID    uuid.UUID  `json:"userId"`
+	TaskID    *uuid.UUID `json:"taskId,omitempty"`
+	Mode      string     `json:"mode"`
+	Seed      int64      `json:"seed"`
+	// Параметры запуска задачи
+	Params map[string]string `json:"params"`
+	// CallbackURL - куда игра предъявляет токен запуска (POST /attempts/launch)
+	CallbackURL string `json:"callbackUrl"`
+	// LaunchToken - одноразовый токен запуска, действует до launchExpiresAt
+	LaunchToken     string    `json:"launchToken"`
+	LaunchExpiresAt time.Time `json:"launchExpiresAt"`
+	// Args - те же данные в виде аргументов командной строки (-gameId <id> ...) для Unity
+	Args []string `json:"args"`
+}
+
+// AttemptLaunchDto - ответ POST /games/:id/attempts
+type AttemptLaunchDto struct {
+	Attempt *AttemptDto       `json:"attempt"`
+	Launch  *LaunchPayloadDto `json:"launch"`
+}
+
+// LaunchAttemptDto - тело POST /attempts/launch
+type LaunchAttemptDto struct {
+	LaunchToken string `json:"launchToken" binding:"required"`
+}
+
+// ResultCredentialsDto - с чем игра отправляет результат попытки
+type ResultCredentialsDto struct {
+	// ResultURL - куда отправлять результат (POST /games/:id/results)
+	ResultURL string `json:"resultUrl"`
+	// ResultToken - токен результата для заголовка X-Result-Token
+	ResultToken string `json:"resultToken"`
+	// ResultKey - ключ HMAC-SHA256 для подписи запроса с результатом (X-Result-Signature)
+	ResultKey string    `json:"resultKey"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AttemptSessionDto - ответ POST /attempts/launch
+type AttemptSessionDto struct {
+	Attempt *AttemptDto           `json:"attempt"`
+	Result  *ResultCredentialsDto `json:"result"`
+}
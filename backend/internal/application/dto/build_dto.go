@@ -0,0 +1,114 @@
+package dto
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GameBuildDto struct {
+	ID      uuid.UUID `json:"id"`
+	GameID  uuid.UUID `json:"gameId"`
+	Version string    `json:"version"`
+	// windows-x86_64 | linux-x86_64 | macos-x86_64 | macos-arm64
+	Platform string `json:"platform"`
+	// Entrypoint - путь к исполняемому файлу относительно корня распакованного архива
+	Entrypoint string `json:"entrypoint"`
+	// zip | tar.gz
+	ArchiveFormat string `json:"archiveFormat"`
+	// SHA256 - hex-хеш архива, лаунчер сверяет его после скачивания
+	SHA256       string    `json:"sha256"`
+	Size         int64     `json:"size"`
+	ReleaseNotes string    `json:"releaseNotes"`
+	UploadedBy   uuid.UUID `json:"uploadedBy"`
+	IsCurrent    bool      `json:"isCurrent"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// UploadBuildDto - поля формы POST /games/:id/builds (кроме самого архива)
+type UploadBuildDto struct {
+	Version      string `form:"version"`
+	Platform     string `form:"platform"`
+	Entrypoint   string `form:"entrypoint"`
+	ReleaseNotes string `form:"releaseNotes"`
+	// Current - сразу сделать сборку текущей; без текущей сборки у платформы новая становится текущей всегда
+	Current bool `form:"current"`
+}
+
+// BuildListQueryDto - фильтр списка сборок игры
+type BuildListQueryDto struct {
+	// Platform - только сборки платформы; пустой - все
+	Platform string `form:"platform"`
+}
+
+// CurrentBuildQueryDto - параметры запроса текущей сборки
+type CurrentBuildQueryDto struct {
+	Platform string `form:"platform" binding:"required"`
+}
+
+// BuildDownloadURLDto - подписанная ссылка на архив сборки
+type BuildDownloadURLDto struct {
+	// URL - путь относительно адреса API; действует до ExpiresAt, в том числе для докачки
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// BuildDownloadQueryDto - параметры подписанной ссылки на архив
+type BuildDownloadQueryDto struct {
+	// Expires - срок действия ссылки, Unix-время в секундах
+	Expires   int64  `form:"expires" binding:"required"`
+	Signature string `form:"signature" binding:"required"`
+}
+
+// BuildManifestFileDto - файл распакованной сборки
+type BuildManifestFileDto struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// BuildManifestArchiveDto - архив, из которого распаковывается сборка
+type BuildManifestArchiveDto struct {
+	Format string `json:"format"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// BuildManifestDto - манифест сборки: по нему лаунчер проверяет архив и распакованные файлы
+type BuildManifestDto struct {
+	BuildID    uuid.UUID               `json:"buildId"`
+	GameID     uuid.UUID               `json:"gameId"`
+	Version    string                  `json:"version"`
+	Platform   string                  `json:"platform"`
+	Entrypoint string                  `json:"entrypoint"`
+	Archive    BuildManifestArchiveDto `json:"archive"`
+	// Files - обычные файлы архива по пути (каталоги и ссылки не входят)
+	Files []BuildManifestFileDto `json:"files"`
+}
+
+// SignedBuildManifest - манифест в том виде, в котором он подписан; Body отдается без изменений
+type SignedBuildManifest struct {
+	Body      []byte
+	Algorithm string
+	KeyID     string
+	// Signature - подпись Body в base64
+	Signature string
+}
+
+// BuildSigningKeyDto - открытый ключ для проверки подписи манифестов
+type BuildSigningKeyDto struct {
+	// ed25519
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"keyId"`
+	// PublicKey - открытый ключ в base64
+	PublicKey string `json:"publicKey"`
+}
+
+// BuildArchive - архив сборки для отдачи клиенту; Content закрывает вызывающий
+type BuildArchive struct {
+	Content  io.ReadSeekCloser
+	Filename string
+	SHA256   string
+	ModTime  time.Time
+}
@@ -0,0 +1,39 @@
+package dto
+
+// CatalogExportQueryDto - параметры GET /admin/catalog/export
+type CatalogExportQueryDto struct {
+	// csv | json | ndjson, по умолчанию json
+	Format string `form:"format"`
+}
+
+// CatalogImportQueryDto - параметры POST /admin/catalog/import
+type CatalogImportQueryDto struct {
+	// csv | json | ndjson, по умолчанию json
+	Format string `form:"format"`
+	// DryRun - только проверить файл и вернуть отчет, ничего не сохраняя
+	DryRun bool `form:"dryRun"`
+	// CreateGenres - создавать жанры, которых нет в базе и в файле; иначе это ошибка строки
+	CreateGenres bool `form:"createGenres"`
+}
+
+// CatalogRowErrorDto - ошибка проверки одной записи файла
+type CatalogRowErrorDto struct {
+	// Row - номер записи в файле с 1 (строка заголовка CSV не считается)
+	Row   int    `json:"row"`
+	Kind  string `json:"kind"`
+	ID    string `json:"id,omitempty"`
+	Title string `json:"title,omitempty"`
+	Error string `json:"error"`
+}
+
+// CatalogImportResultDto - отчет об импорте. Если есть ошибки, ничего не сохраняется
+type CatalogImportResultDto struct {
+	DryRun        bool                  `json:"dryRun"`
+	Committed     bool                  `json:"committed"`
+	Rows          int                   `json:"rows"`
+	GenresCreated int                   `json:"genresCreated"`
+	GenresUpdated int                   `json:"genresUpdated"`
+	GamesCreated  int                   `json:"gamesCreated"`
+	GamesUpdated  int                   `json:"gamesUpdated"`
+	Errors        []*CatalogRowErrorDto `json:"errors"`
+}
@@ -7,22 +7,38 @@ import (
 	"github.com/google/uuid"
 )
 
+// GameDto - игра; GenreID - основной жанр, GenreIDs - все жанры (основной первый)
 type GameDto struct {
-	ID          uuid.UUID `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	ReleaseDate time.Time `json:"releaseDate"`
-	GenreID     uuid.UUID `json:"genreId"`
+	ID          uuid.UUID   `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	ReleaseDate time.Time   `json:"releaseDate"`
+	GenreID     uuid.UUID   `json:"genreId"`
+	GenreIDs    []uuid.UUID `json:"genreIds"`
+	// draft | scheduled | published | archived
+	Status string `json:"status"`
+	// Момент запланированной публикации (только у статуса scheduled)
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+	// Версия записи; то же значение отдается в ETag и ожидается в If-Match
+	Version int `json:"version"`
 }
 
 type GameDtoWithStats struct {
-	ID            uuid.UUID `json:"id"`
-	Title         string    `json:"title"`
-	Description   string    `json:"description"`
-	ReleaseDate   time.Time `json:"releaseDate"`
-	GenreID       uuid.UUID `json:"genreId"`
-	AverageRating float64   `json:"averageRating"`
-	RatingCount   int       `json:"ratingCount"`
+	ID            uuid.UUID   `json:"id"`
+	Title         string      `json:"title"`
+	Description   string      `json:"description"`
+	ReleaseDate   time.Time   `json:"releaseDate"`
+	GenreID       uuid.UUID   `json:"genreId"`
+	GenreIDs      []uuid.UUID `json:"genreIds"`
+	Status        string      `json:"status"`
+	PublishAt     *time.Time  `json:"publishAt,omitempty"`
+	Version       int         `json:"version"`
+	Genre         *GenreDto   `json:"genre"`
+	Genres        []*GenreDto `json:"genres"`
+	AverageRating float64     `json:"averageRating"`
+	RatingCount   int         `json:"ratingCount"`
+	// Оценка текущего пользователя; null, если он не оценивал игру или не авторизован
+	UserRating *int `json:"userRating"`
 }
 
 type CreateGameDto struct {
@@ -30,6 +46,10 @@ type CreateGameDto struct {
 	Description string    `json:"description" validate:"max=2000"`
 	ReleaseDate time.Time `json:"releaseDate" validate:"required"`
 	GenreID     uuid.UUID `json:"genreId" validate:"required"`
+	// Дополнительные жанры (основной можно не повторять)
+	GenreIDs []uuid.UUID `json:"genreIds"`
+	// Момент публикации; без него игра создается черновиком
+	PublishAt *time.Time `json:"publishAt"`
 }
 
 type UpdateGameDto struct {
@@ -38,6 +58,18 @@ type UpdateGameDto struct {
 	Description string    `json:"description" validate:"max=2000"`
 	ReleaseDate time.Time `json:"releaseDate" validate:"required"`
 	GenreID     uuid.UUID `json:"genreId" validate:"required"`
+	// Дополнительные жанры; если поле не передано, прежние дополнительные жанры сохраняются
+	GenreIDs []uuid.UUID `json:"genreIds"`
+}
+
+// PatchGameDto - JSON Merge Patch для PATCH /games/{id}: отсутствующие поля не меняются,
+// null в genreIds оставляет игре только основной жанр
+type PatchGameDto struct {
+	Title       PatchField[string]      `json:"title" swaggertype:"string"`
+	Description PatchField[string]      `json:"description" swaggertype:"string"`
+	ReleaseDate PatchField[time.Time]   `json:"releaseDate" swaggertype:"string" format:"date-time"`
+	GenreID     PatchField[uuid.UUID]   `json:"genreId" swaggertype:"string" format:"uuid"`
+	GenreIDs    PatchField[[]uuid.UUID] `json:"genreIds" swaggertype:"array,string"`
 }
 
 // Response DTO для API
@@ -48,10 +80,57 @@ type GameResponse struct {
 	Error   string      `json:"error,omitempty"`
 }
 
-type PaginatedResponse struct {
-	Items      []*GameDto `json:"items"`
-	Total      int        `json:"total"`
-	Page       int        `json:"page"`
-	PageSize   int        `json:"pageSize"`
-	TotalPages int        `json:"totalPages"`
+// GameListQueryDto - параметры GET /games
+type GameListQueryDto struct {
+	PageQueryDto
+	GenreID      string `form:"genreId"`
+	ReleasedFrom string `form:"releasedFrom"`
+	ReleasedTo   string `form:"releasedTo"`
+	Q            string `form:"q"`
+	// Несколько жанров: повтор параметра или через запятую
+	GenreIDs []string `form:"genreIds"`
+	// any (по умолчанию) - хотя бы один из жанров, all - все сразу
+	GenreMatch string `form:"genreMatch"`
+	// title | releaseDate | rating, префикс "-" - по убыванию
+	Sort      string `form:"sort"`
+	WithStats bool   `form:"withStats"`
+	// Статус игр; фильтр доступен только администраторам, остальные видят опубликованные
+	Status string `form:"status"`
+}
+
+// GameSearchQueryDto - параметры GET /games/search (курсоры не поддерживаются)
+type GameSearchQueryDto struct {
+	PageQueryDto
+	Q string `form:"q"`
+}
+
+// GameSearchHitDto - найденная игра с подсвеченными (<mark>) фрагментами; фрагменты - экранированный HTML
+type GameSearchHitDto struct {
+	GameDto
+	// Релевантность bm25: чем меньше, тем выше в выдаче
+	Rank               float64 `json:"rank"`
+	TitleHighlight     string  `json:"titleHighlight"`
+	DescriptionSnippet string  `json:"descriptionSnippet"`
+}
+
+// ScheduleGameDto - тело POST /games/{id}/schedule
+type ScheduleGameDto struct {
+	PublishAt time.Time `json:"publishAt" validate:"required"`
+}
+
+// SimilarTitlesQueryDto - параметры GET /games/similar-titles
+type SimilarTitlesQueryDto struct {
+	Title string `form:"title"`
+	// Сколько игр вернуть, по умолчанию 10
+	Limit int `form:"limit"`
+}
+
+// SimilarTitleDto - активная игра с похожим названием
+type SimilarTitleDto struct {
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+	// Похожесть от 0 до 1
+	Score float64 `json:"score"`
+	// Exact - названия совпадают после нормализации, создать игру с таким названием нельзя
+	Exact bool `json:"exact"`
 }
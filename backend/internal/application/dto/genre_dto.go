@@ -3,8 +3,9 @@ package dto
 import "github.com/google/uuid"
 
 type GenreDto struct {
-	ID    uuid.UUID `json:"id"`
-	Title string    `json:"title"`
+	ID      uuid.UUID `json:"id"`
+	Title   string    `json:"title"`
+	Version int       `json:"version"`
 }
 
 type CreateGenreDto struct {
@@ -16,3 +17,16 @@ type UpdateGenreDto struct {
 	Title string    `json:"title" validate:"required,min=1,max=200"`
 }
 
+// PatchGenreDto - JSON Merge Patch для PATCH /genres/{id}
+type PatchGenreDto struct {
+	Title PatchField[string] `json:"title" swaggertype:"string"`
+}
+
+
+// GenreListQueryDto - параметры GET /genres
+type GenreListQueryDto struct {
+	PageQueryDto
+	Q string `form:"q"`
+	// title | -title
+	Sort string `form:"sort"`
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardQueryDto - параметры GET /games/:id/leaderboard
+type LeaderboardQueryDto struct {
+	// all (по умолчанию), month или week
+	Window string `form:"window"`
+	// Сколько первых мест вернуть, по умолчанию 10
+	Limit int `form:"limit"`
+	// Только результаты задачи игры
+	TaskID string `form:"taskId"`
+	// Не показывать администраторов
+	ExcludeAdmins bool `form:"excludeAdmins"`
+}
+
+// LeaderboardDto - таблица рекордов игры
+type LeaderboardDto struct {
+	GameID uuid.UUID  `json:"gameId"`
+	TaskID *uuid.UUID `json:"taskId,omitempty"`
+	Window string     `json:"window"`
+	// Since - с какого момента учтены результаты; нет для window=all
+	Since *time.Time `json:"since,omitempty"`
+	// Participants - сколько пользователей в таблице
+	Participants int                    `json:"participants"`
+	Entries      []*LeaderboardEntryDto `json:"entries"`
+	// Me - место запросившего пользователя, даже ниже первых limit; нет без токена или без результатов
+	Me *LeaderboardEntryDto `json:"me,omitempty"`
+}
+
+// LeaderboardEntryDto - лучший результат пользователя
+type LeaderboardEntryDto struct {
+	Rank       int       `json:"rank"`
+	UserID     uuid.UUID `json:"userId"`
+	Username   string    `json:"username"`
+	Score      int64     `json:"score"`
+	DurationMs int64     `json:"durationMs"`
+	AchievedAt time.Time `json:"achievedAt"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MediaThumbnailDto - уменьшенная копия изображения
+type MediaThumbnailDto struct {
+	// small | medium | large
+	Size        string `json:"size"`
+	URL         string `json:"url"`
+	ContentType string `json:"contentType"`
+	Bytes       int64  `json:"bytes"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+type GameMediaDto struct {
+	ID     uuid.UUID `json:"id"`
+	GameID uuid.UUID `json:"gameId"`
+	// cover | screenshot
+	Kind string `json:"kind"`
+	// Position - порядок скриншота (с 1), у обложки 0
+	Position    int                 `json:"position"`
+	URL         string              `json:"url"`
+	ContentType string              `json:"contentType"`
+	Bytes       int64               `json:"bytes"`
+	Width       int                 `json:"width"`
+	Height      int                 `json:"height"`
+	Thumbnails  []MediaThumbnailDto `json:"thumbnails"`
+	CreatedAt   time.Time           `json:"createdAt"`
+}
+
+// UploadMediaDto - поля формы POST /games/:id/media (кроме самого файла)
+type UploadMediaDto struct {
+	// cover | screenshot, по умолчанию screenshot
+	Kind string `form:"kind"`
+}
+
+// ReorderMediaDto - новый порядок скриншотов игры: все их ID по одному разу
+type ReorderMediaDto struct {
+	IDs []uuid.UUID `json:"ids" binding:"required"`
+}
+
+// MediaFileQueryDto - параметры выдачи изображения
+type MediaFileQueryDto struct {
+	// Size - миниатюра small | medium | large; без параметра отдается оригинал
+	Size string `form:"size"`
+}
+
+// MediaContent - содержимое изображения для отдачи клиенту; Content закрывает вызывающий
+type MediaContent struct {
+	Content     io.ReadSeekCloser
+	ContentType string
+	// Key - ключ содержимого в хранилище, годится как ETag
+	Key     string
+	ModTime time.Time
+}
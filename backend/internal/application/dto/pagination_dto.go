@@ -0,0 +1,19 @@
+package dto
+
+// PageQueryDto - общие параметры постраничного вывода (page начинается с 1).
+// After - курсор из nextCursor предыдущего ответа; если задан, page игнорируется.
+type PageQueryDto struct {
+	Page     int    `form:"page"`
+	PageSize int    `form:"pageSize"`
+	After    string `form:"after"`
+}
+
+type PaginatedResponse struct {
+	Items      interface{} `json:"items"`
+	Total      int         `json:"total"`
+	Page       int         `json:"page"`
+	PageSize   int         `json:"pageSize"`
+	TotalPages int         `json:"totalPages"`
+	// Курсор следующей страницы (передается в after); пустой, если страница последняя
+	NextCursor string `json:"nextCursor,omitempty"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// PatchField - поле документа JSON Merge Patch (RFC 7396).
+// Set - поле есть в патче; Null - передан null, то есть значение нужно удалить.
+type PatchField[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (f *PatchField[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResultSignatureDto - заголовки подписанного запроса POST /games/:id/results
+type ResultSignatureDto struct {
+	// Token - токен результата (X-Result-Token)
+	Token string
+	// Nonce - случайная строка, уникальная для каждого запроса попытки (X-Result-Nonce)
+	Nonce string
+	// Timestamp - время отправки в секундах Unix (X-Result-Timestamp)
+	Timestamp string
+	// Signature - HMAC-SHA256 в hex (X-Result-Signature)
+	Signature string
+}
+
+// SubmitResultDto - тело POST /games/:id/results
+type SubmitResultDto struct {
+	Score      *int64 `json:"score"`
+	DurationMs *int64 `json:"durationMs"`
+	Success    *bool  `json:"success"`
+	// Details - произвольный JSON-объект с подробностями прохождения
+	Details json.RawMessage `json:"details" swaggertype:"object"`
+}
+
+type PlayResultDto struct {
+	ID        uuid.UUID  `json:"id"`
+	AttemptID uuid.UUID  `json:"attemptId"`
+	UserID    uuid.UUID  `json:"userId"`
+	GameID    uuid.UUID  `json:"gameId"`
+	TaskID    *uuid.UUID `json:"taskId,omitempty"`
+	Score     int64      `json:"score"`
+	// DurationMs - время прохождения в миллисекундах
+	DurationMs int64 `json:"durationMs"`
+	Success    bool  `json:"success"`
+	// Passed - попытка засчитана: выполнены условия задачи, в свободной игре - success
+	Passed      bool            `json:"passed"`
+	Details     json.RawMessage `json:"details" swaggertype:"object"`
+	SubmittedAt time.Time       `json:"submittedAt"`
+}
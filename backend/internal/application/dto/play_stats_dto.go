@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserStatsDto - ответ GET /me/stats и GET /users/:id/stats
+type UserStatsDto struct {
+	UserID uuid.UUID        `json:"userId"`
+	Totals PlayTotalsDto    `json:"totals"`
+	Games  []*GameStatsDto  `json:"games"`
+	Genres []*GenreStatsDto `json:"genres"`
+	// GeneratedAt - когда статистика посчитана; ответ берется из кэша и может отставать на STATS_CACHE_TTL
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+type PlayTotalsDto struct {
+	GamesPlayed int `json:"gamesPlayed"`
+	Plays       int `json:"plays"`
+	Completions int `json:"completions"`
+}
+
+// GameStatsDto - статистика по игре; лучшее и медианное время - по засчитанным попыткам
+type GameStatsDto struct {
+	GameID       uuid.UUID `json:"gameId"`
+	Title        string    `json:"title"`
+	Plays        int       `json:"plays"`
+	Completions  int       `json:"completions"`
+	BestScore    int64     `json:"bestScore"`
+	BestTimeMs   *int64    `json:"bestTimeMs,omitempty"`
+	MedianTimeMs *int64    `json:"medianTimeMs,omitempty"`
+	LastPlayedAt time.Time `json:"lastPlayedAt"`
+}
+
+// GenreStatsDto - итоги по жанру; игра учитывается во всех своих жанрах
+type GenreStatsDto struct {
+	GenreID     uuid.UUID `json:"genreId"`
+	Title       string    `json:"title"`
+	Games       int       `json:"games"`
+	Plays       int       `json:"plays"`
+	Completions int       `json:"completions"`
+}
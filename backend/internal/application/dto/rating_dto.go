@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RatingDto struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	GameID    uuid.UUID `json:"gameId"`
+	Rating    int       `json:"rating"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type CreateRatingDto struct {
+	Rating int `json:"rating" validate:"required,min=1,max=5"`
+}
+
+type UpdateRatingDto struct {
+	Rating int `json:"rating" validate:"required,min=1,max=5"`
+}
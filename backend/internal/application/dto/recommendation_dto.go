@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/google/uuid"
+
+// RecommendationQueryDto - параметры GET /games/:id/similar и GET /me/recommendations
+type RecommendationQueryDto struct {
+	// Сколько игр вернуть, по умолчанию 10
+	Limit int `form:"limit"`
+}
+
+// SimilarGameDto - игра, похожая на запрошенную
+type SimilarGameDto struct {
+	Game *GameDto `json:"game"`
+	// Score - похожесть от 0 до 1: взвешенная сумма genreScore и ratingScore
+	Score float64 `json:"score"`
+	// GenreScore - доля общих жанров (коэффициент Жаккара)
+	GenreScore float64 `json:"genreScore"`
+	// RatingScore - похожесть оценок пользователей, оценивших обе игры
+	RatingScore float64 `json:"ratingScore"`
+}
+
+// RecommendedGameDto - игра, рекомендованная пользователю
+type RecommendedGameDto struct {
+	Game *GameDto `json:"game"`
+	// Score - вес рекомендации (чем больше, тем выше); 0 у источника popular
+	Score float64 `json:"score"`
+	// similar - похожа на игры, которые пользователь оценил высоко; popular - одна из самых высоко оцененных
+	Source string `json:"source"`
+	// BasedOn - оцененные пользователем игры, сильнее всего повлиявшие на рекомендацию
+	BasedOn []uuid.UUID `json:"basedOn,omitempty"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SetGameRelationDto - тело PUT /games/:id/relations/:type
+type SetGameRelationDto struct {
+	RelatedGameID uuid.UUID `json:"relatedGameId" binding:"required"`
+}
+
+type GameRelationDto struct {
+	GameID        uuid.UUID `json:"gameId"`
+	RelatedGameID uuid.UUID `json:"relatedGameId"`
+	// sequel | dlc | remaster: gameId - продолжение, дополнение или переиздание relatedGameId
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RelatedGameDto - игра, связанная с запрошенной
+type RelatedGameDto struct {
+	// Relation - кем игра приходится запрошенной: prequel | sequel | base | dlc | original | remaster
+	Relation string   `json:"relation"`
+	Game     *GameDto `json:"game"`
+}
+
+// RelatedGamesDto - ответ GET /games/:id/related
+type RelatedGamesDto struct {
+	// Series - серия, в которую входит игра (игры по порядку); нет, если игра не в серии
+	Series    *SeriesDto        `json:"series,omitempty"`
+	Relations []*RelatedGameDto `json:"relations"`
+}
+
+type SeriesDto struct {
+	ID    uuid.UUID `json:"id"`
+	Title string    `json:"title"`
+	// Games - игры серии по порядку
+	Games     []*GameDto `json:"games"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// SaveSeriesDto - тело POST /series и PUT /series/:id: название и игры по порядку
+type SaveSeriesDto struct {
+	Title   string      `json:"title" binding:"required"`
+	GameIDs []uuid.UUID `json:"gameIds"`
+}
+
+// SeriesListQueryDto - параметры GET /series (серии сортируются по названию)
+type SeriesListQueryDto struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
+}
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevisionDto - запись истории изменений игры или жанра
+type RevisionDto struct {
+	Number int `json:"number"`
+	// create | update | delete | restore | revert
+	Action string `json:"action"`
+	// Кто внес изменение; отсутствует, если пользователь неизвестен
+	ActorID *uuid.UUID `json:"actorId,omitempty"`
+	// Номер ревизии, к которой выполнен откат (только для revert)
+	RevertedFrom int       `json:"revertedFrom,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	// Состояние сущности после изменения
+	Snapshot json.RawMessage `json:"snapshot" swaggertype:"object"`
+}
+
+// RevisionListQueryDto - параметры списка ревизий (курсоры не поддерживаются)
+type RevisionListQueryDto struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
+}
+
+// RevisionDiffQueryDto - параметры сравнения ревизий.
+// По умолчанию to - последняя ревизия, from - предыдущая перед to.
+type RevisionDiffQueryDto struct {
+	From int `form:"from"`
+	To   int `form:"to"`
+}
+
+// FieldChangeDto - изменение одного поля; null - поля в ревизии не было
+type FieldChangeDto struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type RevisionDiffDto struct {
+	From    int               `json:"from"`
+	To      int               `json:"to"`
+	Changes []*FieldChangeDto `json:"changes"`
+}
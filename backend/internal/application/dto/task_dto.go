@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskPassCriteriaDto - условия прохождения задачи; пустые условия засчитывают любой завершенный запуск
+type TaskPassCriteriaDto struct {
+	// Минимальный счет; null - счет не важен
+	MinScore *int64 `json:"minScore"`
+	// Наибольшее время прохождения в миллисекундах; 0 - без ограничения
+	MaxDurationMs int64 `json:"maxDurationMs"`
+	// Игра должна сообщить об успешном завершении
+	RequireSuccess bool `json:"requireSuccess"`
+}
+
+type TaskDto struct {
+	ID           uuid.UUID `json:"id"`
+	GameID       uuid.UUID `json:"gameId"`
+	Title        string    `json:"title"`
+	Instructions string    `json:"instructions"`
+	// easy | medium | hard
+	Difficulty string `json:"difficulty"`
+	// Параметры, с которыми игра запускается для задачи
+	LaunchParams map[string]string   `json:"launchParams"`
+	PassCriteria TaskPassCriteriaDto `json:"passCriteria"`
+	// Порядковый номер задачи в игре
+	Order     int       `json:"order"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SaveTaskDto - тело POST /games/:id/tasks и PUT /tasks/:id
+type SaveTaskDto struct {
+	Title        string `json:"title" binding:"required"`
+	Instructions string `json:"instructions"`
+	// easy | medium | hard; по умолчанию medium
+	Difficulty   string               `json:"difficulty"`
+	LaunchParams map[string]string    `json:"launchParams"`
+	PassCriteria *TaskPassCriteriaDto `json:"passCriteria"`
+	// Порядковый номер; без него новая задача встает в конец списка, а при обновлении номер не меняется
+	Order *int `json:"order"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashQueryDto - параметры GET /admin/trash
+type TrashQueryDto struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
+	// game | genre | user; пусто - все типы
+	Kind string `form:"kind"`
+}
+
+// TrashItemDto - запись в корзине; Title - название игры/жанра или логин пользователя
+type TrashItemDto struct {
+	Kind      string    `json:"kind"`
+	ID        uuid.UUID `json:"id"`
+	Title     string    `json:"title"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+// PurgeTrashDto - параметры POST /admin/trash/purge
+type PurgeTrashDto struct {
+	// Удалить записи, лежащие в корзине дольше указанного числа дней; 0 - настройка сервера
+	OlderThanDays int `form:"olderThanDays"`
+}
+
+// PurgeResultDto - сколько записей окончательно удалено
+type PurgeResultDto struct {
+	Before time.Time `json:"before"`
+	Games  int       `json:"games"`
+	Genres int       `json:"genres"`
+	Users  int       `json:"users"`
+}
@@ -10,6 +10,7 @@ type UserDto struct {
 	ID       uuid.UUID             `json:"id"`
 	Username string                `json:"username"`
 	UserRole specifictype.UserRole `json:"userRole"`
+	Version  int                   `json:"version"`
 }
 
 type CreateUserDto struct {
@@ -25,6 +26,22 @@ type UpdateUserDto struct {
 	UserRole specifictype.UserRole `json:"userRole" validate:"required"`
 }
 
+// PatchUserDto - JSON Merge Patch для PATCH /users/{id}; пароль передается, только если его меняют
+type PatchUserDto struct {
+	Username PatchField[string]                `json:"username" swaggertype:"string"`
+	Password PatchField[string]                `json:"password" swaggertype:"string"`
+	UserRole PatchField[specifictype.UserRole] `json:"userRole" swaggertype:"string"`
+}
+
+// UserListQueryDto - параметры GET /users
+type UserListQueryDto struct {
+	PageQueryDto
+	Q        string `form:"q"`
+	UserRole string `form:"userRole"`
+	// username | -username
+	Sort string `form:"sort"`
+}
+
 type LoginDto struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
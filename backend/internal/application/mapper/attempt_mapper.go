@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type AttemptMapper struct{}
+
+func NewAttemptMapper() *AttemptMapper {
+	return &AttemptMapper{}
+}
+
+func (m *AttemptMapper) ToAttemptDto(attempt *model.Attempt) *dto.AttemptDto {
+	if attempt == nil {
+		return nil
+	}
+
+	return &dto.AttemptDto{
+		ID:         attempt.ID,
+		UserID:     attempt.UserID,
+		GameID:     attempt.GameID,
+		TaskID:     optionalID(attempt.TaskID),
+		Mode:       attempt.Mode,
+		Seed:       attempt.Seed,
+		Status:     attempt.Status,
+		StartedAt:  attempt.StartedAt,
+		RunningAt:  attempt.RunningAt,
+		FinishedAt: attempt.FinishedAt,
+	}
+}
+
+// optionalID - nil для uuid.Nil, чтобы поле не попадало в ответ
+func optionalID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
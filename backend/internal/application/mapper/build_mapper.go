@@ -0,0 +1,65 @@
+package mapper
+
+import (
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+)
+
+type BuildMapper struct{}
+
+func NewBuildMapper() *BuildMapper {
+	return &BuildMapper{}
+}
+
+func (m *BuildMapper) ToGameBuildDto(build *model.GameBuild) *dto.GameBuildDto {
+	if build == nil {
+		return nil
+	}
+
+	return &dto.GameBuildDto{
+		ID:            build.ID,
+		GameID:        build.GameID,
+		Version:       build.Version,
+		Platform:      build.Platform,
+		Entrypoint:    build.Entrypoint,
+		ArchiveFormat: build.ArchiveFormat,
+		SHA256:        build.SHA256,
+		Size:          build.Size,
+		ReleaseNotes:  build.ReleaseNotes,
+		UploadedBy:    build.UploadedBy,
+		IsCurrent:     build.IsCurrent,
+		CreatedAt:     build.CreatedAt,
+	}
+}
+
+func (m *BuildMapper) ToGameBuildDtoSlice(items []*model.GameBuild) []*dto.GameBuildDto {
+	if items == nil {
+		return []*dto.GameBuildDto{}
+	}
+	res := make([]*dto.GameBuildDto, len(items))
+	for i, item := range items {
+		res[i] = m.ToGameBuildDto(item)
+	}
+	return res
+}
+
+func (m *BuildMapper) ToBuildManifestDto(build *model.GameBuild, files []model.BuildFile) *dto.BuildManifestDto {
+	manifestFiles := make([]dto.BuildManifestFileDto, len(files))
+	for i, f := range files {
+		manifestFiles[i] = dto.BuildManifestFileDto{Path: f.Path, Size: f.Size, SHA256: f.SHA256}
+	}
+
+	return &dto.BuildManifestDto{
+		BuildID:    build.ID,
+		GameID:     build.GameID,
+		Version:    build.Version,
+		Platform:   build.Platform,
+		Entrypoint: build.Entrypoint,
+		Archive: dto.BuildManifestArchiveDto{
+			Format: build.ArchiveFormat,
+			SHA256: build.SHA256,
+			Size:   build.Size,
+		},
+		Files: manifestFiles,
+	}
+}
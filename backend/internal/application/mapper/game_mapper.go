@@ -3,16 +3,24 @@ package mapper
 
 import (
 	"errors"
+	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/application/dto"
 	"example/web-service-gin/internal/constants"
 	"example/web-service-gin/internal/domain/aggregate"
 	"example/web-service-gin/internal/domain/model"
+	"time"
+
+	"github.com/google/uuid"
 )
 
-type GameMapper struct{}
+type GameMapper struct {
+	genreMapper *GenreMapper
+}
 
 func NewGameMapper() *GameMapper {
-	return &GameMapper{}
+	return &GameMapper{
+		genreMapper: NewGenreMapper(),
+	}
 }
 
 func (m *GameMapper) ToGameDto(game *model.Game) *dto.GameDto {
@@ -26,23 +34,71 @@ func (m *GameMapper) ToGameDto(game *model.Game) *dto.GameDto {
 		Description: game.Description,
 		ReleaseDate: game.ReleaseDate,
 		GenreID:     game.GenreID,
+		GenreIDs:    genreIDs(game),
+		Status:      game.Status,
+		PublishAt:   game.PublishAt,
+		Version:     game.Version,
+	}
+}
+
+// genreIDs возвращает жанры игры; для игр, собранных без GenreIDs, - только основной.
+func genreIDs(game *model.Game) []uuid.UUID {
+	if len(game.GenreIDs) == 0 {
+		return []uuid.UUID{game.GenreID}
 	}
+	return append([]uuid.UUID(nil), game.GenreIDs...)
 }
 
-func (m *GameMapper) ToGameDtoWithStats(agg *aggregate.GameDetailsAggregate) *dto.GameDtoWithStats {
+func (m *GameMapper) ToGameSearchHitDto(hit *repository.GameSearchHit) *dto.GameSearchHitDto {
+	if hit == nil || hit.Game == nil {
+		return nil
+	}
+
+	return &dto.GameSearchHitDto{
+		GameDto:            *m.ToGameDto(hit.Game),
+		Rank:               hit.Rank,
+		TitleHighlight:     hit.TitleHighlight,
+		DescriptionSnippet: hit.DescriptionSnippet,
+	}
+}
+
+func (m *GameMapper) ToGameSearchHitDtoSlice(hits []*repository.GameSearchHit) []*dto.GameSearchHitDto {
+	res := make([]*dto.GameSearchHitDto, len(hits))
+	for i, hit := range hits {
+		res[i] = m.ToGameSearchHitDto(hit)
+	}
+	return res
+}
+
+func (m *GameMapper) ToGameDtoWithStats(agg *aggregate.GameDetailsAggregate, userID uuid.UUID) *dto.GameDtoWithStats {
 	if agg == nil || agg.Game == nil {
 		return nil
 	}
 
-	return &dto.GameDtoWithStats{
+	res := &dto.GameDtoWithStats{
 		ID:            agg.Game.ID,
 		Title:         agg.Game.Title,
 		Description:   agg.Game.Description,
 		ReleaseDate:   agg.Game.ReleaseDate,
 		GenreID:       agg.Game.GenreID,
-		AverageRating: agg.CalculateAverageRating(),
-		RatingCount:   len(agg.Ratings),
+		GenreIDs:      genreIDs(agg.Game),
+		Status:        agg.Game.Status,
+		PublishAt:     agg.Game.PublishAt,
+		Version:       agg.Game.Version,
+		Genre:         m.genreMapper.ToGenreDto(agg.Genre),
+		Genres:        m.genreMapper.ToGenreDtoSlice(agg.Genres),
+		AverageRating: agg.GetAverageRating(),
+		RatingCount:   agg.GetRatingCount(),
+	}
+
+	if userID != uuid.Nil {
+		if own := agg.FindRatingByUser(userID); own != nil {
+			value := own.Rating
+			res.UserRating = &value
+		}
 	}
+
+	return res
 }
 
 func (m *GameMapper) ToGameDtoSlice(games []*model.Game) []*dto.GameDto {
@@ -57,20 +113,36 @@ func (m *GameMapper) ToGameDtoSlice(games []*model.Game) []*dto.GameDto {
 	return result
 }
 
-func (m *GameMapper) FromCreateGameDto(dto *dto.CreateGameDto) (*model.Game, error) {
+// FromCreateGameDto создает игру из DTO по правилам policy: черновик или,
+// если задан publishAt, игру с запланированной публикацией.
+func (m *GameMapper) FromCreateGameDto(policy model.GamePolicy, dto *dto.CreateGameDto) (*model.Game, error) {
 	if dto == nil {
 		return nil, errors.New(constants.ErrInvalidData)
 	}
 
-	return model.NewGameWithValidate(
+	game, err := model.NewGameWithValidate(
+		policy,
 		dto.Title,
 		dto.Description,
 		dto.ReleaseDate,
 		dto.GenreID,
+		dto.GenreIDs,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.PublishAt != nil {
+		if err := game.Schedule(*dto.PublishAt, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	return game, nil
 }
 
-func (m *GameMapper) FromUpdateGameDto(game *model.Game, dto *dto.UpdateGameDto) error {
+// FromUpdateGameDto применяет DTO к игре по правилам policy.
+func (m *GameMapper) FromUpdateGameDto(policy model.GamePolicy, game *model.Game, dto *dto.UpdateGameDto) error {
 	if game == nil {
 		return errors.New(constants.ErrInvalidData)
 	}
@@ -83,10 +155,68 @@ func (m *GameMapper) FromUpdateGameDto(game *model.Game, dto *dto.UpdateGameDto)
 		return errors.New(constants.ErrIDMismatch)
 	}
 
+	// Клиенты, не знающие о genreIds, не должны терять дополнительные жанры:
+	// если поле не передано, сохраняем прежние дополнительные жанры
+	genreIds := dto.GenreIDs
+	if genreIds == nil {
+		for _, id := range game.GenreIDs {
+			if id != game.GenreID {
+				genreIds = append(genreIds, id)
+			}
+		}
+	}
+
 	return game.UpdateGameWithValidate(
+		policy,
 		dto.Title,
 		dto.Description,
 		dto.ReleaseDate,
 		dto.GenreID,
+		genreIds,
 	)
 }
+
+// MergeGamePatch накладывает merge patch на текущее состояние игры и возвращает
+// полный набор полей для обновления; сама игра не меняется.
+func (m *GameMapper) MergeGamePatch(game *model.Game, patch *dto.PatchGameDto) (dto.UpdateGameDto, error) {
+	if game == nil || patch == nil {
+		return dto.UpdateGameDto{}, errors.New(constants.ErrInvalidData)
+	}
+
+	res := dto.UpdateGameDto{
+		ID:          game.ID,
+		Title:       game.Title,
+		Description: game.Description,
+		ReleaseDate: game.ReleaseDate,
+		GenreID:     game.GenreID,
+		GenreIDs:    genreIDs(game),
+	}
+
+	if patch.Title.Set {
+		if patch.Title.Null {
+			return res, patchNullError("title")
+		}
+		res.Title = patch.Title.Value
+	}
+	if patch.Description.Set {
+		res.Description = patch.Description.Value
+	}
+	if patch.ReleaseDate.Set {
+		if patch.ReleaseDate.Null {
+			return res, patchNullError("releaseDate")
+		}
+		res.ReleaseDate = patch.ReleaseDate.Value
+	}
+	if patch.GenreID.Set {
+		if patch.GenreID.Null {
+			return res, patchNullError("genreId")
+		}
+		res.GenreID = patch.GenreID.Value
+	}
+	if patch.GenreIDs.Set {
+		// null и [] - только основной жанр; пустой, но не nil срез отличается от "поле не передано"
+		res.GenreIDs = append([]uuid.UUID{}, patch.GenreIDs.Value...)
+	}
+
+	return res, nil
+}
@@ -19,8 +19,9 @@ func (m *GenreMapper) ToGenreDto(genre *model.Genre) *dto.GenreDto {
 		return nil
 	}
 	return &dto.GenreDto{
-		ID:    genre.ID,
-		Title: genre.Title,
+		ID:      genre.ID,
+		Title:   genre.Title,
+		Version: genre.Version,
 	}
 }
 
@@ -53,3 +54,18 @@ func (m *GenreMapper) FromUpdateGenreDto(genre *model.Genre, in *dto.UpdateGenre
 	return genre.UpdateTitleWithValidate(in.Title)
 }
 
+// MergeGenrePatch накладывает merge patch на текущее состояние жанра.
+func (m *GenreMapper) MergeGenrePatch(genre *model.Genre, patch *dto.PatchGenreDto) (dto.UpdateGenreDto, error) {
+	if genre == nil || patch == nil {
+		return dto.UpdateGenreDto{}, errors.New(constants.ErrInvalidData)
+	}
+
+	res := dto.UpdateGenreDto{ID: genre.ID, Title: genre.Title}
+	if patch.Title.Set {
+		if patch.Title.Null {
+			return res, patchNullError("title")
+		}
+		res.Title = patch.Title.Value
+	}
+	return res, nil
+}
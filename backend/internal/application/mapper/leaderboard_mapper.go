@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"time"
+
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type LeaderboardMapper struct{}
+
+func NewLeaderboardMapper() *LeaderboardMapper {
+	return &LeaderboardMapper{}
+}
+
+// ToLeaderboardDto - таблица рекордов игры gameID; taskID uuid.Nil и zero since не попадают в ответ.
+func (m *LeaderboardMapper) ToLeaderboardDto(board *model.Leaderboard,
+	gameID, taskID uuid.UUID, window string, since time.Time) *dto.LeaderboardDto {
+
+	res := &dto.LeaderboardDto{
+		GameID:       gameID,
+		TaskID:       optionalID(taskID),
+		Window:       window,
+		Participants: board.Participants,
+		Entries:      make([]*dto.LeaderboardEntryDto, 0, len(board.Entries)),
+		Me:           m.ToLeaderboardEntryDto(board.Caller),
+	}
+	if !since.IsZero() {
+		res.Since = &since
+	}
+	for _, entry := range board.Entries {
+		res.Entries = append(res.Entries, m.ToLeaderboardEntryDto(entry))
+	}
+	return res
+}
+
+func (m *LeaderboardMapper) ToLeaderboardEntryDto(entry *model.LeaderboardEntry) *dto.LeaderboardEntryDto {
+	if entry == nil {
+		return nil
+	}
+
+	return &dto.LeaderboardEntryDto{
+		Rank:       entry.Rank,
+		UserID:     entry.UserID,
+		Username:   entry.Username,
+		Score:      entry.Score,
+		DurationMs: entry.Duration.Milliseconds(),
+		AchievedAt: entry.AchievedAt,
+	}
+}
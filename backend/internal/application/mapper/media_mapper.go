@@ -0,0 +1,64 @@
+package mapper
+
+import (
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+)
+
+type MediaMapper struct{}
+
+func NewMediaMapper() *MediaMapper {
+	return &MediaMapper{}
+}
+
+// mediaURL - путь, по которому сервер отдает изображение (size пустой для оригинала).
+func mediaURL(media *model.GameMedia, size string) string {
+	url := "/media/" + media.ID.String()
+	if size != "" {
+		url += "?size=" + size
+	}
+	return url
+}
+
+func (m *MediaMapper) ToGameMediaDto(media *model.GameMedia) *dto.GameMediaDto {
+	if media == nil {
+		return nil
+	}
+
+	thumbnails := make([]dto.MediaThumbnailDto, len(media.Thumbnails))
+	for i, t := range media.Thumbnails {
+		thumbnails[i] = dto.MediaThumbnailDto{
+			Size:        t.Size,
+			URL:         mediaURL(media, t.Size),
+			ContentType: t.ContentType,
+			Bytes:       t.Bytes,
+			Width:       t.Width,
+			Height:      t.Height,
+		}
+	}
+
+	return &dto.GameMediaDto{
+		ID:          media.ID,
+		GameID:      media.GameID,
+		Kind:        media.Kind,
+		Position:    media.Position,
+		URL:         mediaURL(media, ""),
+		ContentType: media.ContentType,
+		Bytes:       media.Bytes,
+		Width:       media.Width,
+		Height:      media.Height,
+		Thumbnails:  thumbnails,
+		CreatedAt:   media.CreatedAt,
+	}
+}
+
+func (m *MediaMapper) ToGameMediaDtoSlice(items []*model.GameMedia) []*dto.GameMediaDto {
+	if items == nil {
+		return []*dto.GameMediaDto{}
+	}
+	res := make([]*dto.GameMediaDto, len(items))
+	for i, item := range items {
+		res[i] = m.ToGameMediaDto(item)
+	}
+	return res
+}
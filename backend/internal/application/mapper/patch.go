@@ -0,0 +1,12 @@
+package mapper
+
+import (
+	"fmt"
+
+	"example/web-service-gin/internal/constants"
+)
+
+// patchNullError - null в merge patch для поля, без которого запись не существует.
+func patchNullError(field string) error {
+	return fmt.Errorf("%s: %s", constants.ErrValidationPatchNull, field)
+}
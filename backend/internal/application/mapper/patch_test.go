@@ -0,0 +1,145 @@
+package mapper
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+
+	"github.com/google/uuid"
+)
+
+// decodePatch разбирает тело запроса так же, как обработчик merge patch
+func decodePatch[T any](t *testing.T, body string) *T {
+	t.Helper()
+	var patch T
+	if err := json.Unmarshal([]byte(body), &patch); err != nil {
+		t.Fatalf("Unmarshal %s: %v", body, err)
+	}
+	return &patch
+}
+
+func testGame() *model.Game {
+	primary, extra := uuid.New(), uuid.New()
+	return &model.Game{
+		ID:          uuid.New(),
+		Title:       "Tetris",
+		Description: "desc",
+		ReleaseDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		GenreID:     primary,
+		GenreIDs:    []uuid.UUID{primary, extra},
+	}
+}
+
+func TestMergeGamePatch_AbsentFieldsKept(t *testing.T) {
+	game := testGame()
+
+	merged, err := NewGameMapper().MergeGamePatch(game, decodePatch[dto.PatchGameDto](t, `{"title":"Tetris 2"}`))
+	if err != nil {
+		t.Fatalf("MergeGamePatch: %v", err)
+	}
+	if merged.Title != "Tetris 2" {
+		t.Fatalf("expected patched title, got %q", merged.Title)
+	}
+	// Поля, которых нет в патче, берутся из текущего состояния
+	if merged.ID != game.ID || merged.Description != game.Description ||
+		!merged.ReleaseDate.Equal(game.ReleaseDate) || merged.GenreID != game.GenreID {
+		t.Fatalf("absent fields changed: %+v", merged)
+	}
+	if len(merged.GenreIDs) != 2 || merged.GenreIDs[1] != game.GenreIDs[1] {
+		t.Fatalf("expected genreIds to be kept, got %v", merged.GenreIDs)
+	}
+}
+
+func TestMergeGamePatch_NullClearsOptionalFields(t *testing.T) {
+	game := testGame()
+
+	merged, err := NewGameMapper().MergeGamePatch(game, decodePatch[dto.PatchGameDto](t, `{"description":null,"genreIds":null}`))
+	if err != nil {
+		t.Fatalf("MergeGamePatch: %v", err)
+	}
+	if merged.Description != "" {
+		t.Fatalf("expected description to be cleared, got %q", merged.Description)
+	}
+	// null в genreIds - только основной жанр, но не "поле не передано"
+	if merged.GenreIDs == nil || len(merged.GenreIDs) != 0 {
+		t.Fatalf("expected empty non-nil genreIds, got %#v", merged.GenreIDs)
+	}
+	if merged.Title != game.Title || merged.GenreID != game.GenreID {
+		t.Fatalf("absent fields changed: %+v", merged)
+	}
+}
+
+func TestMergeGamePatch_NullOnRequiredFieldRejected(t *testing.T) {
+	for _, field := range []string{"title", "releaseDate", "genreId"} {
+		patch := decodePatch[dto.PatchGameDto](t, `{"`+field+`":null}`)
+		_, err := NewGameMapper().MergeGamePatch(testGame(), patch)
+		if err == nil || !strings.HasPrefix(err.Error(), constants.ErrValidationPatchNull) || !strings.HasSuffix(err.Error(), field) {
+			t.Fatalf("%s: expected patch null error, got %v", field, err)
+		}
+	}
+}
+
+func TestMergeGenrePatch(t *testing.T) {
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	m := NewGenreMapper()
+
+	merged, err := m.MergeGenrePatch(genre, decodePatch[dto.PatchGenreDto](t, `{}`))
+	if err != nil {
+		t.Fatalf("MergeGenrePatch empty: %v", err)
+	}
+	if merged.ID != genre.ID || merged.Title != genre.Title {
+		t.Fatalf("empty patch changed genre: %+v", merged)
+	}
+
+	merged, err = m.MergeGenrePatch(genre, decodePatch[dto.PatchGenreDto](t, `{"title":"Arcade"}`))
+	if err != nil || merged.Title != "Arcade" {
+		t.Fatalf("expected patched title, got %+v, %v", merged, err)
+	}
+
+	if _, err := m.MergeGenrePatch(genre, decodePatch[dto.PatchGenreDto](t, `{"title":null}`)); err == nil {
+		t.Fatalf("expected error for null title")
+	}
+}
+
+func TestMergeUserPatch_PasswordKeptUnlessPatched(t *testing.T) {
+	user := &model.User{ID: uuid.New(), Username: "bob", Password: "secret", UserRole: specifictype.RoleUser}
+	m := NewUserMapper()
+
+	// Смена роли не требует пароля
+	merged, err := m.MergeUserPatch(user, decodePatch[dto.PatchUserDto](t, `{"userRole":"admin"}`))
+	if err != nil {
+		t.Fatalf("MergeUserPatch role: %v", err)
+	}
+	if merged.UserRole != specifictype.RoleAdmin {
+		t.Fatalf("expected admin role, got %q", merged.UserRole)
+	}
+	if merged.ID != user.ID || merged.Username != user.Username || merged.Password != user.Password {
+		t.Fatalf("absent fields changed: %+v", merged)
+	}
+
+	merged, err = m.MergeUserPatch(user, decodePatch[dto.PatchUserDto](t, `{"password":"new-secret"}`))
+	if err != nil || merged.Password != "new-secret" || merged.UserRole != user.UserRole {
+		t.Fatalf("expected only password to change, got %+v, %v", merged, err)
+	}
+}
+
+func TestMergeUserPatch_NullOnRequiredFieldRejected(t *testing.T) {
+	user := &model.User{ID: uuid.New(), Username: "bob", Password: "secret", UserRole: specifictype.RoleUser}
+
+	for _, field := range []string{"username", "password", "userRole"} {
+		patch := decodePatch[dto.PatchUserDto](t, `{"`+field+`":null}`)
+		if _, err := NewUserMapper().MergeUserPatch(user, patch); err == nil || !strings.HasSuffix(err.Error(), field) {
+			t.Fatalf("%s: expected patch null error, got %v", field, err)
+		}
+	}
+	// Патч не меняет исходную запись
+	if user.Username != "bob" || user.Password != "secret" || user.UserRole != specifictype.RoleUser {
+		t.Fatalf("user mutated by rejected patch: %+v", user)
+	}
+}
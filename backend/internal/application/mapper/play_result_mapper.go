@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+)
+
+type PlayResultMapper struct{}
+
+func NewPlayResultMapper() *PlayResultMapper {
+	return &PlayResultMapper{}
+}
+
+func (m *PlayResultMapper) ToPlayResultDto(result *model.PlayResult) *dto.PlayResultDto {
+	if result == nil {
+		return nil
+	}
+
+	return &dto.PlayResultDto{
+		ID:          result.ID,
+		AttemptID:   result.AttemptID,
+		UserID:      result.UserID,
+		GameID:      result.GameID,
+		TaskID:      optionalID(result.TaskID),
+		Score:       result.Score,
+		DurationMs:  result.Duration.Milliseconds(),
+		Success:     result.Success,
+		Passed:      result.Passed,
+		Details:     result.Details,
+		SubmittedAt: result.SubmittedAt,
+	}
+}
@@ -0,0 +1,54 @@
+package mapper
+
+import (
+	"time"
+
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+)
+
+type PlayStatsMapper struct{}
+
+func NewPlayStatsMapper() *PlayStatsMapper {
+	return &PlayStatsMapper{}
+}
+
+func (m *PlayStatsMapper) ToGameStatsDto(stats *model.GamePlayStats) *dto.GameStatsDto {
+	if stats == nil {
+		return nil
+	}
+
+	return &dto.GameStatsDto{
+		GameID:       stats.GameID,
+		Title:        stats.GameTitle,
+		Plays:        stats.Plays,
+		Completions:  stats.Completions,
+		BestScore:    stats.BestScore,
+		BestTimeMs:   optionalMilliseconds(stats.BestTime),
+		MedianTimeMs: optionalMilliseconds(stats.MedianTime),
+		LastPlayedAt: stats.LastPlayedAt,
+	}
+}
+
+func (m *PlayStatsMapper) ToGenreStatsDto(stats *model.GenrePlayStats) *dto.GenreStatsDto {
+	if stats == nil {
+		return nil
+	}
+
+	return &dto.GenreStatsDto{
+		GenreID:     stats.GenreID,
+		Title:       stats.GenreTitle,
+		Games:       stats.Games,
+		Plays:       stats.Plays,
+		Completions: stats.Completions,
+	}
+}
+
+// optionalMilliseconds - длительность в миллисекундах; nil, если ее нет
+func optionalMilliseconds(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
+	}
+	ms := d.Milliseconds()
+	return &ms
+}
@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+)
+
+type RatingMapper struct{}
+
+func NewRatingMapper() *RatingMapper {
+	return &RatingMapper{}
+}
+
+func (m *RatingMapper) ToRatingDto(rating *model.UserRating) *dto.RatingDto {
+	if rating == nil {
+		return nil
+	}
+	return &dto.RatingDto{
+		ID:        rating.ID,
+		UserID:    rating.UserID,
+		GameID:    rating.GameID,
+		Rating:    rating.Rating,
+		CreatedAt: rating.CreatedAt,
+	}
+}
+
+func (m *RatingMapper) ToRatingDtoSlice(ratings []*model.UserRating) []*dto.RatingDto {
+	if ratings == nil {
+		return []*dto.RatingDto{}
+	}
+	res := make([]*dto.RatingDto, len(ratings))
+	for i, r := range ratings {
+		res[i] = m.ToRatingDto(r)
+	}
+	return res
+}
@@ -0,0 +1,39 @@
+package mapper
+
+import (
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+)
+
+type RelationMapper struct {
+	gameMapper *GameMapper
+}
+
+func NewRelationMapper() *RelationMapper {
+	return &RelationMapper{gameMapper: NewGameMapper()}
+}
+
+func (m *RelationMapper) ToGameRelationDto(relation *model.GameRelation) *dto.GameRelationDto {
+	if relation == nil {
+		return nil
+	}
+	return &dto.GameRelationDto{
+		GameID:        relation.GameID,
+		RelatedGameID: relation.RelatedID,
+		Type:          relation.Type,
+		CreatedAt:     relation.CreatedAt,
+	}
+}
+
+// ToSeriesDto собирает серию с играми games, уже упорядоченными и отфильтрованными вызывающим.
+func (m *RelationMapper) ToSeriesDto(series *model.Series, games []*model.Game) *dto.SeriesDto {
+	if series == nil {
+		return nil
+	}
+	return &dto.SeriesDto{
+		ID:        series.ID,
+		Title:     series.Title,
+		Games:     m.gameMapper.ToGameDtoSlice(games),
+		CreatedAt: series.CreatedAt,
+	}
+}
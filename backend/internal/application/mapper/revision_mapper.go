@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type RevisionMapper struct{}
+
+func NewRevisionMapper() *RevisionMapper {
+	return &RevisionMapper{}
+}
+
+func (m *RevisionMapper) ToRevisionDto(revision *model.Revision) *dto.RevisionDto {
+	if revision == nil {
+		return nil
+	}
+
+	res := &dto.RevisionDto{
+		Number:       revision.Number,
+		Action:       revision.Action,
+		RevertedFrom: revision.RevertedFrom,
+		CreatedAt:    revision.CreatedAt,
+		Snapshot:     revision.Snapshot,
+	}
+	if revision.ActorID != uuid.Nil {
+		actorID := revision.ActorID
+		res.ActorID = &actorID
+	}
+	return res
+}
+
+func (m *RevisionMapper) ToRevisionDtoSlice(revisions []*model.Revision) []*dto.RevisionDto {
+	result := make([]*dto.RevisionDto, len(revisions))
+	for i, r := range revisions {
+		result[i] = m.ToRevisionDto(r)
+	}
+	return result
+}
+
+func (m *RevisionMapper) ToRevisionDiffDto(from, to int, changes []model.FieldChange) *dto.RevisionDiffDto {
+	res := &dto.RevisionDiffDto{
+		From:    from,
+		To:      to,
+		Changes: make([]*dto.FieldChangeDto, len(changes)),
+	}
+	for i, c := range changes {
+		res.Changes[i] = &dto.FieldChangeDto{Field: c.Field, From: c.From, To: c.To}
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"maps"
+	"time"
+
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/model"
+)
+
+type TaskMapper struct{}
+
+func NewTaskMapper() *TaskMapper {
+	return &TaskMapper{}
+}
+
+func (m *TaskMapper) ToTaskDto(task *model.Task) *dto.TaskDto {
+	if task == nil {
+		return nil
+	}
+
+	params := maps.Clone(task.LaunchParams)
+	if params == nil {
+		params = map[string]string{}
+	}
+	return &dto.TaskDto{
+		ID:           task.ID,
+		GameID:       task.GameID,
+		Title:        task.Title,
+		Instructions: task.Instructions,
+		Difficulty:   task.Difficulty,
+		LaunchParams: params,
+		PassCriteria: dto.TaskPassCriteriaDto{
+			MinScore:       task.PassCriteria.MinScore,
+			MaxDurationMs:  task.PassCriteria.MaxDuration.Milliseconds(),
+			RequireSuccess: task.PassCriteria.RequireSuccess,
+		},
+		Order:     task.Order,
+		CreatedAt: task.CreatedAt,
+		UpdatedAt: task.UpdatedAt,
+	}
+}
+
+func (m *TaskMapper) ToTaskDtoSlice(items []*model.Task) []*dto.TaskDto {
+	res := make([]*dto.TaskDto, len(items))
+	for i, item := range items {
+		res[i] = m.ToTaskDto(item)
+	}
+	return res
+}
+
+// ToPassCriteria переводит условия прохождения из DTO; nil - без условий.
+func (m *TaskMapper) ToPassCriteria(in *dto.TaskPassCriteriaDto) model.TaskPassCriteria {
+	if in == nil {
+		return model.TaskPassCriteria{}
+	}
+	return model.TaskPassCriteria{
+		MinScore:       in.MinScore,
+		MaxDuration:    time.Duration(in.MaxDurationMs) * time.Millisecond,
+		RequireSuccess: in.RequireSuccess,
+	}
+}
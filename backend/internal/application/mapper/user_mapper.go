@@ -24,6 +24,7 @@ func (m *UserMapper) ToUserDto(user *model.User) *dto.UserDto {
 		ID:       user.ID,
 		Username: user.Username,
 		UserRole: user.UserRole,
+		Version:  user.Version,
 	}
 }
 
@@ -63,3 +64,38 @@ func (m *UserMapper) FromUpdateUserDto(user *model.User, in *dto.UpdateUserDto)
 	return nil
 }
 
+// MergeUserPatch накладывает merge patch на текущее состояние пользователя;
+// без password в патче сохраняется прежний пароль.
+func (m *UserMapper) MergeUserPatch(user *model.User, patch *dto.PatchUserDto) (dto.UpdateUserDto, error) {
+	if user == nil || patch == nil {
+		return dto.UpdateUserDto{}, errors.New(constants.ErrInvalidData)
+	}
+
+	res := dto.UpdateUserDto{
+		ID:       user.ID,
+		Username: user.Username,
+		Password: user.Password,
+		UserRole: user.UserRole,
+	}
+
+	if patch.Username.Set {
+		if patch.Username.Null {
+			return res, patchNullError("username")
+		}
+		res.Username = patch.Username.Value
+	}
+	if patch.Password.Set {
+		if patch.Password.Null {
+			return res, patchNullError("password")
+		}
+		res.Password = patch.Password.Value
+	}
+	if patch.UserRole.Set {
+		if patch.UserRole.Null {
+			return res, patchNullError("userRole")
+		}
+		res.UserRole = patch.UserRole.Value
+	}
+
+	return res, nil
+}
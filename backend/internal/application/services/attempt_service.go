@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/abstraction/signing"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// tokenBytes - длина токенов запуска и результата до кодирования в base64url
+const tokenBytes = 32
+
+// AttemptService - попытки пройти игру или задачу. Приложение запускает игру с одноразовым
+// токеном запуска; игра предъявляет его по callbackUrl, попытка переходит в running,
+// а игра получает токен и ключ для подписанной отправки результата.
+type AttemptService struct {
+	games         repository.GameRepository
+	tasks         repository.TaskRepository
+	attempts      repository.AttemptRepository
+	resultSigner  signing.RequestSigner
+	launchTTL     time.Duration
+	resultTTL     time.Duration
+	baseURL       string
+	attemptMapper *mapper.AttemptMapper
+}
+
+// NewAttemptService создает сервис; launchTTL - срок действия токена запуска, resultTTL -
+// сколько после запуска принимается результат, baseURL - внешний адрес API для ссылок,
+// которые получает игра (пустой - ссылки относительные).
+func NewAttemptService(games repository.GameRepository,
+	tasks repository.TaskRepository,
+	attempts repository.AttemptRepository,
+	resultSigner signing.RequestSigner,
+	launchTTL, resultTTL time.Duration, baseURL string) *AttemptService {
+
+	return &AttemptService{
+		games:         games,
+		tasks:         tasks,
+		attempts:      attempts,
+		resultSigner:  resultSigner,
+		launchTTL:     launchTTL,
+		resultTTL:     resultTTL,
+		baseURL:       baseURL,
+		attemptMapper: mapper.NewAttemptMapper(),
+	}
+}
+
+// StartAttempt начинает попытку пользователя userID в игре gameID и выдает данные для запуска игры.
+// Прежние незавершенные попытки пользователя в этой игре бросаются (abandoned).
+func (s *AttemptService) StartAttempt(ctx context.Context,
+	userID, gameID uuid.UUID, in dto.StartAttemptDto, withUnpublished bool) (*dto.AttemptLaunchDto, error) {
+
+	game, err := s.games.FindByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !withUnpublished && !game.IsPublished() {
+		return nil, repository.ErrNotFound
+	}
+
+	var task *model.Task
+	if in.TaskID != nil && *in.TaskID != uuid.Nil {
+		task, err = s.tasks.FindByID(ctx, *in.TaskID)
+		if errors.Is(err, repository.ErrTaskNotFound) {
+			return nil, newQueryError(constants.ErrValidationAttemptTask)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if task.GameID != gameID {
+			return nil, newQueryError(constants.ErrValidationAttemptTask)
+		}
+	}
+
+	if err := s.abandonOpen(ctx, userID, gameID); err != nil {
+		return nil, err
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	seed, err := randomSeed()
+	if err != nil {
+		return nil, err
+	}
+	taskID := uuid.Nil
+	if task != nil {
+		taskID = task.ID
+	}
+	attempt, err := model.NewAttempt(userID, gameID, taskID, seed, hashToken(token), time.Now().Add(s.launchTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.attempts.Create(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AttemptLaunchDto{
+		Attempt: s.attemptMapper.ToAttemptDto(created),
+		Launch:  s.launchPayload(created, task, token),
+	}, nil
+}
+
+// LaunchAttempt принимает токен запуска от игры, переводит попытку в running и выдает
+// токен и ключ для отправки результата. Токен запуска действует один раз: повторное
+// предъявление дает repository.ErrAttemptNotFound.
+func (s *AttemptService) LaunchAttempt(ctx context.Context, in dto.LaunchAttemptDto) (*dto.AttemptSessionDto, error) {
+	attempt, err := s.attempts.FindByLaunchTokenHash(ctx, hashToken(in.LaunchToken))
+	if err != nil {
+		return nil, err
+	}
+
+	resultToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := attempt.Run(now, hashToken(resultToken), now.Add(s.resultTTL)); err != nil {
+		if errors.Is(err, model.ErrLaunchTokenExpired) {
+			// Игра так и не запустилась вовремя: попытка брошена
+			if err := attempt.Finish(model.AttemptAbandoned, now); err != nil {
+				return nil, err
+			}
+			if _, err := s.attempts.Update(ctx, attempt, model.AttemptStarted); err != nil &&
+				!errors.Is(err, repository.ErrAttemptStatusChanged) {
+				return nil, err
+			}
+			return nil, model.ErrLaunchTokenExpired
+		}
+		return nil, repository.ErrAttemptNotFound
+	}
+
+	updated, err := s.attempts.Update(ctx, attempt, model.AttemptStarted)
+	if errors.Is(err, repository.ErrAttemptStatusChanged) {
+		// Токен успели предъявить параллельным запросом
+		return nil, repository.ErrAttemptNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &dto.AttemptSessionDto{
+		Attempt: s.attemptMapper.ToAttemptDto(updated),
+		Result: &dto.ResultCredentialsDto{
+			ResultURL:   s.baseURL + "/games/" + updated.GameID.String() + "/results",
+			ResultToken: resultToken,
+			ResultKey:   s.resultSigner.ClientKey(resultToken),
+			ExpiresAt:   *updated.ResultExpiresAt,
+		},
+	}, nil
+}
+
+// GetAttempt возвращает попытку ее владельцу; остальным - только при withAnyUser (администратор).
+func (s *AttemptService) GetAttempt(ctx context.Context,
+	attemptID, userID uuid.UUID, withAnyUser bool) (*dto.AttemptDto, error) {
+
+	attempt, err := s.attempts.FindByID(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if !withAnyUser && attempt.UserID != userID {
+		return nil, repository.ErrAttemptNotFound
+	}
+	return s.attemptMapper.ToAttemptDto(attempt), nil
+}
+
+// AbandonAttempt бросает незавершенную попытку пользователя userID.
+func (s *AttemptService) AbandonAttempt(ctx context.Context, attemptID, userID uuid.UUID) (*dto.AttemptDto, error) {
+	attempt, err := s.attempts.FindByID(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt.UserID != userID {
+		return nil, repository.ErrAttemptNotFound
+	}
+
+	from := attempt.Status
+	if err := attempt.Finish(model.AttemptAbandoned, time.Now()); err != nil {
+		return nil, err
+	}
+	updated, err := s.attempts.Update(ctx, attempt, from)
+	if errors.Is(err, repository.ErrAttemptStatusChanged) {
+		return nil, model.ErrAttemptTransition
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.attemptMapper.ToAttemptDto(updated), nil
+}
+
+// abandonOpen бросает незавершенные попытки пользователя в игре; попытки, которые
+// успели завершиться параллельно, пропускаются.
+func (s *AttemptService) abandonOpen(ctx context.Context, userID, gameID uuid.UUID) error {
+	open, err := s.attempts.FindOpen(ctx, userID, gameID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, attempt := range open {
+		from := attempt.Status
+		if err := attempt.Finish(model.AttemptAbandoned, now); err != nil {
+			continue
+		}
+		if _, err := s.attempts.Update(ctx, attempt, from); err != nil && !errors.Is(err, repository.ErrAttemptStatusChanged) {
+			return err
+		}
+	}
+	return nil
+}
+
+// launchPayload собирает данные для запуска игры и те же данные в виде аргументов командной строки.
+func (s *AttemptService) launchPayload(attempt *model.Attempt, task *model.Task, token string) *dto.LaunchPayloadDto {
+	payload := &dto.LaunchPayloadDto{
+		AttemptID:       attempt.ID,
+		GameID:          attempt.GameID,
+		UserID:          attempt.UserID,
+		Mode:            attempt.Mode,
+		Seed:            attempt.Seed,
+		Params:          map[string]string{},
+		CallbackURL:     s.baseURL + "/attempts/launch",
+		LaunchToken:     token,
+		LaunchExpiresAt: attempt.LaunchExpiresAt,
+	}
+	if task != nil {
+		payload.TaskID = &task.ID
+		// Задачи, сохраненные до проверки имен, могут содержать параметры, подменяющие данные попытки
+		for key, value := range task.LaunchParams {
+			if model.IsLaunchParamName(key) {
+				payload.Params[key] = value
+			}
+		}
+	}
+
+	payload.Args = []string{
+		"-attemptId", payload.AttemptID.String(),
+		"-gameId", payload.GameID.String(),
+		"-userId", payload.UserID.String(),
+		"-mode", payload.Mode,
+		"-seed", strconv.FormatInt(payload.Seed, 10),
+		"-callbackUrl", payload.CallbackURL,
+		"-launchToken", payload.LaunchToken,
+	}
+	if payload.TaskID != nil {
+		payload.Args = append(payload.Args, "-taskId", payload.TaskID.String())
+	}
+	// Параметры задачи - по имени, чтобы порядок аргументов не менялся от запуска к запуску
+	for _, key := range slices.Sorted(maps.Keys(payload.Params)) {
+		payload.Args = append(payload.Args, "-"+key, payload.Params[key])
+	}
+	return payload
+}
+
+// randomToken - случайный токен в base64url без паддинга.
+func randomToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// randomSeed - случайное неотрицательное зерно в пределах int32, как ждет UnityEngine.Random.InitState.
+func randomSeed() (int64, error) {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, fmt.Errorf("generate seed: %w", err)
+	}
+	return int64(binary.BigEndian.Uint32(b[:]) >> 1), nil
+}
+
+// hashToken - хэш, под которым токены запуска и результата хранятся в репозитории.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"example/web-service-gin/internal/application/abstraction/blob"
+)
+
+// releaseUnusedBlobs удаляет из хранилища store содержимое, на которое больше не ссылается ни одна
+// запись (inUse); kind - вид файлов для журнала. Ошибки только логируются: лишний файл
+// в хранилище не мешает работе.
+func releaseUnusedBlobs(ctx context.Context, store blob.Store,
+	inUse func(ctx context.Context, key string) (bool, error), kind string, keys []string) {
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		used, err := inUse(ctx, key)
+		if err == nil && !used {
+			err = store.Delete(ctx, key)
+		}
+		if err != nil {
+			log.Printf("release %s blob %s: %v", kind, key, err)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/archive"
+	"example/web-service-gin/internal/application/abstraction/blob"
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/abstraction/signing"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// BuildService - сборки Unity для лаунчера. Архивы лежат в хранилище blob.Store
+// под ключом SHA-256, записи о них - в GameBuildRepository.
+// Архив скачивается по короткоживущей подписанной ссылке, целостность проверяется по подписанному манифесту.
+type BuildService struct {
+	games       repository.GameRepository
+	builds      repository.GameBuildRepository
+	blobs       blob.Store
+	archives    archive.Inspector
+	urls        signing.URLSigner
+	manifests   signing.ContentSigner
+	maxBytes    int64
+	urlTTL      time.Duration
+	buildMapper *mapper.BuildMapper
+}
+
+func NewBuildService(games repository.GameRepository,
+	builds repository.GameBuildRepository,
+	blobs blob.Store,
+	archives archive.Inspector,
+	urls signing.URLSigner,
+	manifests signing.ContentSigner,
+	maxBytes int64,
+	urlTTL time.Duration) *BuildService {
+
+	return &BuildService{
+		games:       games,
+		builds:      builds,
+		blobs:       blobs,
+		archives:    archives,
+		urls:        urls,
+		manifests:   manifests,
+		maxBytes:    maxBytes,
+		urlTTL:      urlTTL,
+		buildMapper: mapper.NewBuildMapper(),
+	}
+}
+
+// UploadBuild проверяет архив сборки и сохраняет его. Если у платформы нет текущей сборки,
+// новая становится текущей сама, иначе - только с in.Current.
+func (s *BuildService) UploadBuild(ctx context.Context,
+	gameID uuid.UUID, in dto.UploadBuildDto, r io.ReaderAt, size int64, actorID uuid.UUID) (*dto.GameBuildDto, error) {
+
+	build, err := newBuildFromDto(gameID, in)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureGame(ctx, gameID, true); err != nil {
+		return nil, err
+	}
+	if size > s.maxBytes {
+		return nil, archive.ErrTooLarge
+	}
+
+	existing, err := s.builds.FindByGameID(ctx, gameID, build.Platform)
+	if err != nil {
+		return nil, err
+	}
+	hasCurrent := false
+	for _, b := range existing {
+		// Проверяем до сохранения архива, чтобы не писать гигабайты впустую
+		if b.Version == build.Version {
+			return nil, repository.ErrBuildAlreadyExists
+		}
+		hasCurrent = hasCurrent || b.IsCurrent
+	}
+	build.IsCurrent = in.Current || !hasCurrent
+
+	format, entries, err := s.archives.Inspect(r, size)
+	if err != nil {
+		if errors.Is(err, archive.ErrUnsafePath) {
+			return nil, newQueryError(constants.ErrValidationBuildUnsafePath)
+		}
+		return nil, err
+	}
+	if msg := checkUnityLayout(build.Platform, build.Entrypoint, entries); msg != "" {
+		return nil, newQueryError(msg)
+	}
+
+	obj, err := s.blobs.Put(ctx, io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, err
+	}
+	build.ArchiveFormat = format
+	build.SHA256 = obj.Key
+	build.Size = obj.Size
+	build.UploadedBy = actorID
+	for _, e := range entries {
+		if e.SHA256 != "" {
+			build.Files = append(build.Files, model.BuildFile{Path: e.Path, Size: e.Size, SHA256: e.SHA256})
+		}
+	}
+
+	created, err := s.builds.Create(ctx, build)
+	if err != nil {
+		s.releaseBlob(ctx, obj.Key)
+		return nil, err
+	}
+	return s.buildMapper.ToGameBuildDto(created), nil
+}
+
+// ListBuilds возвращает сборки игры, новые первыми.
+func (s *BuildService) ListBuilds(ctx context.Context,
+	gameID uuid.UUID, query dto.BuildListQueryDto, withUnpublished bool) ([]*dto.GameBuildDto, error) {
+
+	platform := strings.TrimSpace(query.Platform)
+	if platform != "" && !model.IsBuildPlatform(platform) {
+		return nil, newQueryError(constants.ErrValidationBuildPlatform)
+	}
+	if err := s.ensureGame(ctx, gameID, withUnpublished); err != nil {
+		return nil, err
+	}
+
+	items, err := s.builds.FindByGameID(ctx, gameID, platform)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildMapper.ToGameBuildDtoSlice(items), nil
+}
+
+// GetBuild возвращает сборку игры.
+func (s *BuildService) GetBuild(ctx context.Context, gameID, buildID uuid.UUID, withUnpublished bool) (*dto.GameBuildDto, error) {
+	build, err := s.findBuild(ctx, gameID, buildID, withUnpublished)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildMapper.ToGameBuildDto(build), nil
+}
+
+// GetCurrentBuild возвращает сборку, которую лаунчер должен запускать на платформе.
+func (s *BuildService) GetCurrentBuild(ctx context.Context,
+	gameID uuid.UUID, platform string, withUnpublished bool) (*dto.GameBuildDto, error) {
+
+	platform = strings.TrimSpace(platform)
+	if !model.IsBuildPlatform(platform) {
+		return nil, newQueryError(constants.ErrValidationBuildPlatform)
+	}
+	if err := s.ensureGame(ctx, gameID, withUnpublished); err != nil {
+		return nil, err
+	}
+
+	build, err := s.builds.FindCurrent(ctx, gameID, platform)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildMapper.ToGameBuildDto(build), nil
+}
+
+// SetCurrentBuild делает сборку текущей для ее платформы (например, для отката на прежнюю версию).
+func (s *BuildService) SetCurrentBuild(ctx context.Context, gameID, buildID uuid.UUID) (*dto.GameBuildDto, error) {
+	if _, err := s.findBuild(ctx, gameID, buildID, true); err != nil {
+		return nil, err
+	}
+
+	build, err := s.builds.SetCurrent(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildMapper.ToGameBuildDto(build), nil
+}
+
+// DeleteBuild удаляет сборку; архив удаляется из хранилища, если больше нигде не используется.
+// После удаления текущей сборки у платформы нет текущей, пока ее не назначат.
+func (s *BuildService) DeleteBuild(ctx context.Context, gameID, buildID uuid.UUID) error {
+	build, err := s.findBuild(ctx, gameID, buildID, true)
+	if err != nil {
+		return err
+	}
+
+	if err := s.builds.Delete(ctx, buildID); err != nil {
+		return err
+	}
+	s.releaseBlob(ctx, build.SHA256)
+	return nil
+}
+
+// CreateDownloadURL выдает ссылку на архив сборки, действующую urlTTL.
+// По ссылке архив скачивается без токена, поэтому ее можно отдать менеджеру загрузок.
+func (s *BuildService) CreateDownloadURL(ctx context.Context,
+	gameID, buildID uuid.UUID, withUnpublished bool) (*dto.BuildDownloadURLDto, error) {
+
+	build, err := s.findBuild(ctx, gameID, buildID, withUnpublished)
+	if err != nil {
+		return nil, err
+	}
+
+	// Секунды: срок в ссылке хранится как Unix-время
+	expires := time.Now().Add(s.urlTTL).UTC().Truncate(time.Second)
+	signature := s.urls.Sign(downloadResource(build.ID), expires)
+	return &dto.BuildDownloadURLDto{
+		URL: fmt.Sprintf("/games/%s/builds/%s/download?expires=%d&signature=%s",
+			build.GameID, build.ID, expires.Unix(), signature),
+		ExpiresAt: expires,
+	}, nil
+}
+
+// OpenDownload проверяет подписанную ссылку и открывает архив сборки.
+func (s *BuildService) OpenDownload(ctx context.Context,
+	gameID, buildID uuid.UUID, query dto.BuildDownloadQueryDto) (*dto.BuildArchive, error) {
+
+	// Подпись проверяется до обращения к базе: без нее нельзя узнать даже, есть ли такая сборка
+	if err := s.urls.Verify(downloadResource(buildID), time.Unix(query.Expires, 0), query.Signature); err != nil {
+		return nil, err
+	}
+
+	// Ссылку выдают только тем, кому видна игра, поэтому статус игры здесь не проверяется:
+	// подписанная ссылка доживает свой короткий срок, даже если игру сняли с публикации
+	build, err := s.findBuild(ctx, gameID, buildID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.blobs.Open(ctx, build.SHA256)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, repository.ErrBuildNotFound
+		}
+		return nil, err
+	}
+
+	return &dto.BuildArchive{
+		Content:  content,
+		Filename: fmt.Sprintf("%s-%s.%s", build.Version, build.Platform, build.ArchiveFormat),
+		SHA256:   build.SHA256,
+		ModTime:  build.CreatedAt,
+	}, nil
+}
+
+// GetManifest возвращает подписанный манифест сборки: хеш архива и хеши всех его файлов.
+// Манифест сборки не меняется, поэтому подпись одинакова при каждом запросе.
+func (s *BuildService) GetManifest(ctx context.Context,
+	gameID, buildID uuid.UUID, withUnpublished bool) (*dto.SignedBuildManifest, error) {
+
+	build, err := s.findBuild(ctx, gameID, buildID, withUnpublished)
+	if err != nil {
+		return nil, err
+	}
+	files, err := s.builds.FindFiles(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(s.buildMapper.ToBuildManifestDto(build, files))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SignedBuildManifest{
+		Body:      body,
+		Algorithm: s.manifests.Algorithm(),
+		KeyID:     s.manifests.KeyID(),
+		Signature: base64.StdEncoding.EncodeToString(s.manifests.Sign(body)),
+	}, nil
+}
+
+// SigningKey возвращает открытый ключ, которым проверяются подписи манифестов.
+func (s *BuildService) SigningKey() *dto.BuildSigningKeyDto {
+	return &dto.BuildSigningKeyDto{
+		Algorithm: s.manifests.Algorithm(),
+		KeyID:     s.manifests.KeyID(),
+		PublicKey: base64.StdEncoding.EncodeToString(s.manifests.PublicKey()),
+	}
+}
+
+// findBuild возвращает сборку активной игры; сборка другой игры считается ненайденной.
+func (s *BuildService) findBuild(ctx context.Context,
+	gameID, buildID uuid.UUID, withUnpublished bool) (*model.GameBuild, error) {
+
+	if err := s.ensureGame(ctx, gameID, withUnpublished); err != nil {
+		return nil, err
+	}
+
+	build, err := s.builds.FindByID(ctx, buildID)
+	if err != nil {
+		return nil, err
+	}
+	if build.GameID != gameID {
+		return nil, repository.ErrBuildNotFound
+	}
+	return build, nil
+}
+
+// ensureGame проверяет, что игра есть и не в корзине; без withUnpublished неопубликованная
+// игра считается ненайденной.
+func (s *BuildService) ensureGame(ctx context.Context, gameID uuid.UUID, withUnpublished bool) error {
+	game, err := s.games.FindByID(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if !withUnpublished && !game.IsPublished() {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// releaseBlob удаляет архив из хранилища, если на него не ссылается ни одна сборка.
+func (s *BuildService) releaseBlob(ctx context.Context, key string) {
+	releaseUnusedBlobs(ctx, s.blobs, s.builds.BlobInUse, "build", []string{key})
+}
+
+// downloadResource - подписываемая часть ссылки на архив; ссылка одной сборки не подходит к другой.
+func downloadResource(buildID uuid.UUID) string {
+	return "build-download:" + buildID.String()
+}
+
+// newBuildFromDto проверяет поля формы загрузки и нормализует путь к исполняемому файлу.
+func newBuildFromDto(gameID uuid.UUID, in dto.UploadBuildDto) (*model.GameBuild, error) {
+	version := strings.TrimSpace(in.Version)
+	if !model.IsBuildVersion(version) {
+		return nil, newQueryError(constants.ErrValidationBuildVersion)
+	}
+	platform := strings.ToLower(strings.TrimSpace(in.Platform))
+	if !model.IsBuildPlatform(platform) {
+		return nil, newQueryError(constants.ErrValidationBuildPlatform)
+	}
+	if len([]rune(in.ReleaseNotes)) > model.MaxBuildReleaseNotes {
+		return nil, newQueryError(constants.ErrValidationBuildNotes)
+	}
+
+	// Пути из Проводника приходят с обратными слешами
+	entrypoint := strings.ReplaceAll(strings.TrimSpace(in.Entrypoint), `\`, "/")
+	if entrypoint == "" || strings.HasPrefix(entrypoint, "/") || strings.Contains(entrypoint, ":") {
+		return nil, newQueryError(constants.ErrValidationBuildEntrypoint)
+	}
+	entrypoint = path.Clean(entrypoint)
+	if entrypoint == "." || entrypoint == ".." || strings.HasPrefix(entrypoint, "../") {
+		return nil, newQueryError(constants.ErrValidationBuildEntrypoint)
+	}
+
+	return model.NewGameBuildWithValidate(gameID, version, platform, entrypoint, in.ReleaseNotes)
+}
+
+// checkUnityLayout проверяет, что архив похож на сборку Unity для платформы:
+// Windows и Linux - исполняемый файл и папка <имя>_Data рядом, macOS - бандл .app.
+// Возвращает текст ошибки или пустую строку.
+func checkUnityLayout(platform, entrypoint string, entries []archive.Entry) string {
+	files := make(map[string]bool, len(entries))
+	dirs := make(map[string]bool)
+	for _, e := range entries {
+		if e.IsDir {
+			dirs[e.Path] = true
+		} else {
+			files[e.Path] = true
+		}
+		// В zip каталоги часто не записываются отдельными элементами
+		for dir := path.Dir(e.Path); dir != "."; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	if !files[entrypoint] {
+		return constants.ErrValidationBuildNoEntry
+	}
+
+	switch platform {
+	case model.BuildPlatformWindows:
+		ext := path.Ext(entrypoint)
+		if !strings.EqualFold(ext, ".exe") {
+			return constants.ErrValidationBuildWindowsExe
+		}
+		if !dirs[strings.TrimSuffix(entrypoint, ext)+"_Data"] {
+			return constants.ErrValidationBuildDataDir
+		}
+	case model.BuildPlatformLinux:
+		if !dirs[strings.TrimSuffix(entrypoint, path.Ext(entrypoint))+"_Data"] {
+			return constants.ErrValidationBuildDataDir
+		}
+	case model.BuildPlatformMacOS, model.BuildPlatformMacOSArm:
+		contents, ok := strings.CutSuffix(path.Dir(entrypoint), "/MacOS")
+		if !ok || !strings.HasSuffix(contents, ".app/Contents") || !files[contents+"/Info.plist"] {
+			return constants.ErrValidationBuildMacBundle
+		}
+	}
+	return ""
+}
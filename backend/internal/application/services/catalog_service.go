@@ -0,0 +1,594 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/catalog"
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// defaultCatalogFormat - формат выгрузки и импорта, если он не указан
+const defaultCatalogFormat = "json"
+
+// CatalogService выгружает и загружает каталог (жанры и игры) целиком.
+type CatalogService struct {
+	games        repository.GameRepository
+	genres       repository.GenreRepository
+	catalog      repository.CatalogRepository
+	tx           repository.Transactor
+	gameHistory  *revisionLog
+	genreHistory *revisionLog
+	policy       model.GamePolicy
+	codecs       map[string]catalog.Codec
+}
+
+func NewCatalogService(games repository.GameRepository,
+	genres repository.GenreRepository,
+	catalogRepo repository.CatalogRepository,
+	revisions repository.RevisionRepository,
+	tx repository.Transactor,
+	policy model.GamePolicy,
+	codecs ...catalog.Codec) *CatalogService {
+
+	byFormat := make(map[string]catalog.Codec, len(codecs))
+	for _, codec := range codecs {
+		byFormat[codec.Format()] = codec
+	}
+
+	return &CatalogService{
+		games:        games,
+		genres:       genres,
+		catalog:      catalogRepo,
+		tx:           tx,
+		gameHistory:  newRevisionLog(revisions, model.RevisionEntityGame),
+		genreHistory: newRevisionLog(revisions, model.RevisionEntityGenre),
+		policy:       policy,
+		codecs:       byFormat,
+	}
+}
+
+// ContentType возвращает MIME-тип выгрузки в формате format.
+func (s *CatalogService) ContentType(format string) (string, error) {
+	codec, err := s.codec(format)
+	if err != nil {
+		return "", err
+	}
+	return codec.ContentType(), nil
+}
+
+// ExportCatalog пишет в w все активные жанры, затем все активные игры.
+// Игры ссылаются на жанры по названию, поэтому файл можно загрузить в другую базу.
+func (s *CatalogService) ExportCatalog(ctx context.Context, format string, w io.Writer) error {
+	codec, err := s.codec(format)
+	if err != nil {
+		return err
+	}
+
+	genres, err := s.genres.FindAll(ctx, 0, 0)
+	if err != nil {
+		return err
+	}
+	games, err := s.games.FindAll(ctx, 0, 0)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(genres, func(i, j int) bool {
+		if genres[i].Title != genres[j].Title {
+			return genres[i].Title < genres[j].Title
+		}
+		return genres[i].ID.String() < genres[j].ID.String()
+	})
+	sort.Slice(games, func(i, j int) bool {
+		if games[i].Title != games[j].Title {
+			return games[i].Title < games[j].Title
+		}
+		return games[i].ID.String() < games[j].ID.String()
+	})
+
+	titles := make(map[uuid.UUID]string, len(genres))
+	records := make([]catalog.Record, 0, len(genres)+len(games))
+	for _, genre := range genres {
+		titles[genre.ID] = genre.Title
+		records = append(records, catalog.Record{
+			Kind:  catalog.KindGenre,
+			ID:    genre.ID.String(),
+			Title: genre.Title,
+		})
+	}
+	for _, game := range games {
+		genreIDs := game.GenreIDs
+		if len(genreIDs) == 0 {
+			genreIDs = []uuid.UUID{game.GenreID}
+		}
+		genreTitles := make([]string, 0, len(genreIDs))
+		for _, id := range genreIDs {
+			genreTitles = append(genreTitles, titles[id])
+		}
+		rec := catalog.Record{
+			Kind:        catalog.KindGame,
+			ID:          game.ID.String(),
+			Title:       game.Title,
+			Description: game.Description,
+			ReleaseDate: game.ReleaseDate.UTC().Format(time.RFC3339Nano),
+			Genres:      genreTitles,
+			Status:      game.Status,
+		}
+		if game.PublishAt != nil {
+			rec.PublishAt = game.PublishAt.UTC().Format(time.RFC3339Nano)
+		}
+		records = append(records, rec)
+	}
+
+	return codec.Encode(w, records)
+}
+
+// ImportCatalog проверяет все записи файла и, если ошибок нет и это не dryRun, сохраняет их вместе с ревизиями
+// одной транзакцией. Записи с id обновляют существующие жанры и игры (upsert), без id - создают.
+// Игры ссылаются на жанры по названию. При ошибках в записях отчет возвращается без ошибки
+// сервиса, а в базе ничего не меняется.
+func (s *CatalogService) ImportCatalog(ctx context.Context,
+	in dto.CatalogImportQueryDto, r io.Reader, actorID uuid.UUID) (*dto.CatalogImportResultDto, error) {
+
+	codec, err := s.codec(in.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := codec.Decode(r)
+	if err != nil {
+		return nil, newQueryError(fmt.Sprintf("%s: %v", constants.ErrValidationCatalogFile, err))
+	}
+
+	plan, err := s.newCatalogPlan(ctx, in.CreateGenres, actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сначала жанры: игры могут ссылаться на жанры, объявленные ниже в файле
+	for i, rec := range records {
+		switch strings.ToLower(strings.TrimSpace(rec.Kind)) {
+		case catalog.KindGenre:
+			plan.addGenre(i+1, rec)
+		case catalog.KindGame:
+		default:
+			plan.fail(i+1, rec, errors.New(constants.ErrCatalogKind))
+		}
+	}
+	for i, rec := range records {
+		if strings.ToLower(strings.TrimSpace(rec.Kind)) == catalog.KindGame {
+			if err := plan.addGame(ctx, i+1, rec); err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.SliceStable(plan.errors, func(i, j int) bool { return plan.errors[i].Row < plan.errors[j].Row })
+
+	result := &dto.CatalogImportResultDto{
+		DryRun:        in.DryRun,
+		Rows:          len(records),
+		GenresCreated: len(plan.changes.CreateGenres),
+		GenresUpdated: len(plan.changes.UpdateGenres),
+		GamesCreated:  len(plan.changes.CreateGames),
+		GamesUpdated:  len(plan.changes.UpdateGames),
+		Errors:        plan.errors,
+	}
+	if in.DryRun || len(plan.errors) > 0 {
+		return result, nil
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// План проверял квоту по данным до транзакции; перед записью она пересчитывается,
+		// чтобы параллельные создания не превысили ее вместе
+		if len(plan.changes.CreateGames) > 0 {
+			if err := checkCreateQuota(ctx, s.gameHistory, s.policy, actorID, len(plan.changes.CreateGames)); err != nil {
+				return err
+			}
+		}
+		if err := s.catalog.Apply(ctx, plan.changes); err != nil {
+			return err
+		}
+		return s.recordImport(ctx, plan.changes, actorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Committed = true
+	return result, nil
+}
+
+// recordImport сохраняет ревизии для всех записей, измененных импортом.
+func (s *CatalogService) recordImport(ctx context.Context, changes repository.CatalogImport, actorID uuid.UUID) error {
+	for _, genre := range changes.CreateGenres {
+		if err := s.genreHistory.record(ctx, genre.ID, model.RevisionActionCreate,
+			model.NewGenreSnapshot(genre), actorID, 0); err != nil {
+			return err
+		}
+	}
+	for _, genre := range changes.UpdateGenres {
+		if err := s.genreHistory.record(ctx, genre.ID, model.RevisionActionUpdate,
+			model.NewGenreSnapshot(genre), actorID, 0); err != nil {
+			return err
+		}
+	}
+	for _, game := range changes.CreateGames {
+		if err := s.gameHistory.record(ctx, game.ID, model.RevisionActionCreate,
+			model.NewGameSnapshot(game), actorID, 0); err != nil {
+			return err
+		}
+	}
+	for _, game := range changes.UpdateGames {
+		if err := s.gameHistory.record(ctx, game.ID, model.RevisionActionUpdate,
+			model.NewGameSnapshot(game), actorID, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CatalogService) codec(format string) (catalog.Codec, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = defaultCatalogFormat
+	}
+	codec, ok := s.codecs[format]
+	if !ok {
+		return nil, newQueryError(constants.ErrValidationCatalogType)
+	}
+	return codec, nil
+}
+
+// catalogPlan - изменения, собранные при проверке файла импорта, и ошибки записей
+type catalogPlan struct {
+	games        repository.GameRepository
+	policy       model.GamePolicy
+	createGenres bool
+
+	changes repository.CatalogImport
+	errors  []*dto.CatalogRowErrorDto
+
+	// Жанры из базы и из файла по id и по названию (без учета регистра)
+	genresByID    map[uuid.UUID]*model.Genre
+	genresByTitle map[string][]*model.Genre
+	deletedGenres map[uuid.UUID]bool
+	deletedGames  map[uuid.UUID]bool
+
+	seenGenreIDs map[uuid.UUID]bool
+	seenGameIDs  map[uuid.UUID]bool
+
+	// Владельцы нормализованных названий среди активных игр и игр из файла
+	gameTitles map[string]uuid.UUID
+
+	// Дневная квота создания действует, если известен администратор; createdToday - уже созданные им сегодня игры
+	checkQuota   bool
+	createdToday int
+}
+
+func (s *CatalogService) newCatalogPlan(ctx context.Context, createGenres bool, actorID uuid.UUID) (*catalogPlan, error) {
+	genres, err := s.genres.FindAll(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	deletedGenres, err := s.genres.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deletedGames, err := s.games.FindDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	titles, err := s.games.FindTitles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &catalogPlan{
+		games:         s.games,
+		policy:        s.policy,
+		createGenres:  createGenres,
+		errors:        []*dto.CatalogRowErrorDto{},
+		genresByID:    make(map[uuid.UUID]*model.Genre, len(genres)),
+		genresByTitle: make(map[string][]*model.Genre, len(genres)),
+		deletedGenres: make(map[uuid.UUID]bool, len(deletedGenres)),
+		deletedGames:  make(map[uuid.UUID]bool, len(deletedGames)),
+		seenGenreIDs:  make(map[uuid.UUID]bool),
+		seenGameIDs:   make(map[uuid.UUID]bool),
+		gameTitles:    make(map[string]uuid.UUID, len(titles)),
+	}
+	for _, genre := range genres {
+		plan.indexGenre(genre)
+	}
+	for _, genre := range deletedGenres {
+		plan.deletedGenres[genre.ID] = true
+	}
+	for _, game := range deletedGames {
+		plan.deletedGames[game.ID] = true
+	}
+	for _, t := range titles {
+		if _, taken := plan.gameTitles[model.NormalizeTitle(t.Title)]; !taken {
+			plan.gameTitles[model.NormalizeTitle(t.Title)] = t.ID
+		}
+	}
+	if s.policy.DailyCreateQuota > 0 && actorID != uuid.Nil {
+		plan.checkQuota = true
+		plan.createdToday, err = s.gameHistory.countCreated(ctx, actorID, model.QuotaDayStart(time.Now()))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+func catalogTitleKey(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
+
+func (p *catalogPlan) indexGenre(genre *model.Genre) {
+	p.genresByID[genre.ID] = genre
+	key := catalogTitleKey(genre.Title)
+	p.genresByTitle[key] = append(p.genresByTitle[key], genre)
+}
+
+func (p *catalogPlan) unindexGenreTitle(genre *model.Genre) {
+	key := catalogTitleKey(genre.Title)
+	list := p.genresByTitle[key]
+	for i, g := range list {
+		if g == genre {
+			p.genresByTitle[key] = append(list[:i:i], list[i+1:]...)
+			return
+		}
+	}
+}
+
+func (p *catalogPlan) fail(row int, rec catalog.Record, err error) {
+	p.errors = append(p.errors, &dto.CatalogRowErrorDto{
+		Row:   row,
+		Kind:  rec.Kind,
+		ID:    rec.ID,
+		Title: rec.Title,
+		Error: err.Error(),
+	})
+}
+
+// parseRecordID разбирает необязательный id записи и проверяет, что он не повторяется в файле.
+func parseRecordID(raw string, seen map[uuid.UUID]bool) (uuid.UUID, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return uuid.Nil, nil
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, errors.New(constants.ErrCatalogID)
+	}
+	if seen[id] {
+		return uuid.Nil, errors.New(constants.ErrCatalogDuplicateID)
+	}
+	seen[id] = true
+	return id, nil
+}
+
+// addGenre: жанр с известным id обновляется, жанр с уже существующим названием
+// переиспользуется, остальные создаются (с id из файла, если он указан).
+func (p *catalogPlan) addGenre(row int, rec catalog.Record) {
+	id, err := parseRecordID(rec.ID, p.seenGenreIDs)
+	if err != nil {
+		p.fail(row, rec, err)
+		return
+	}
+	if err := validateGenreTitle(rec.Title); err != nil {
+		p.fail(row, rec, err)
+		return
+	}
+	if p.deletedGenres[id] {
+		p.fail(row, rec, errors.New(constants.ErrCatalogGenreDeleted))
+		return
+	}
+
+	if existing, ok := p.genresByID[id]; ok && id != uuid.Nil {
+		if existing.Title == strings.TrimSpace(rec.Title) {
+			return
+		}
+		// Копия: до фиксации импорта жанр из хранилища не меняется
+		updated := *existing
+		if err := updated.UpdateTitleWithValidate(rec.Title); err != nil {
+			p.fail(row, rec, err)
+			return
+		}
+		p.unindexGenreTitle(existing)
+		p.indexGenre(&updated)
+		p.changes.UpdateGenres = append(p.changes.UpdateGenres, &updated)
+		return
+	}
+
+	if len(p.genresByTitle[catalogTitleKey(rec.Title)]) > 0 {
+		return
+	}
+
+	genre, err := model.NewGenreWithValidate(rec.Title)
+	if err != nil {
+		p.fail(row, rec, err)
+		return
+	}
+	if id != uuid.Nil {
+		genre.ID = id
+	}
+	p.indexGenre(genre)
+	p.changes.CreateGenres = append(p.changes.CreateGenres, genre)
+}
+
+// addGame проверяет игру доменными правилами и планирует создание или обновление по id.
+// Ошибкой сервиса считается только сбой хранилища.
+func (p *catalogPlan) addGame(ctx context.Context, row int, rec catalog.Record) error {
+	id, err := parseRecordID(rec.ID, p.seenGameIDs)
+	if err != nil {
+		p.fail(row, rec, err)
+		return nil
+	}
+	if p.deletedGames[id] {
+		p.fail(row, rec, errors.New(constants.ErrCatalogGameDeleted))
+		return nil
+	}
+
+	title := strings.TrimSpace(rec.Title)
+	releaseDate, err := parseCatalogDate(rec.ReleaseDate)
+	if err != nil {
+		p.fail(row, rec, err)
+		return nil
+	}
+	if err := p.policy.CheckFields(title, rec.Description); err != nil {
+		p.fail(row, rec, policyError(err))
+		return nil
+	}
+	genreIDs, err := p.resolveGenres(rec.Genres)
+	if err != nil {
+		p.fail(row, rec, err)
+		return nil
+	}
+	status, publishAt, err := parseCatalogStatus(rec)
+	if err != nil {
+		p.fail(row, rec, err)
+		return nil
+	}
+
+	var existing *model.Game
+	if id != uuid.Nil {
+		existing, err = p.games.FindByID(ctx, id)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+	}
+
+	if p.titleTaken(title, id) {
+		p.fail(row, rec, errors.New(constants.ErrBusinessDuplicateTitle))
+		return nil
+	}
+
+	if existing != nil {
+		if err := existing.UpdateGameWithValidate(p.policy, title, rec.Description, releaseDate, genreIDs[0], genreIDs[1:]); err != nil {
+			p.fail(row, rec, policyError(err))
+			return nil
+		}
+		// Без статуса в файле статус игры не меняется
+		if status != "" {
+			if err := existing.RestoreStatus(status, publishAt); err != nil {
+				p.fail(row, rec, catalogStatusError(err))
+				return nil
+			}
+		}
+		p.gameTitles[model.NormalizeTitle(title)] = existing.ID
+		p.changes.UpdateGames = append(p.changes.UpdateGames, existing)
+		return nil
+	}
+
+	game, err := model.NewGameWithValidate(p.policy, title, rec.Description, releaseDate, genreIDs[0], genreIDs[1:])
+	if err != nil {
+		p.fail(row, rec, policyError(err))
+		return nil
+	}
+	if p.checkQuota {
+		if err := p.policy.CheckDailyQuota(p.createdToday, len(p.changes.CreateGames)+1); err != nil {
+			p.fail(row, rec, policyError(err))
+			return nil
+		}
+	}
+	if id != uuid.Nil {
+		game.ID = id
+	}
+	// Статус переносится из выгрузки как есть; игра без статуса остается черновиком
+	if status != "" {
+		if err := game.RestoreStatus(status, publishAt); err != nil {
+			p.fail(row, rec, catalogStatusError(err))
+			return nil
+		}
+	}
+	p.gameTitles[model.NormalizeTitle(title)] = game.ID
+	p.changes.CreateGames = append(p.changes.CreateGames, game)
+	return nil
+}
+
+// titleTaken проверяет, занято ли нормализованное название другой игрой, чем id (uuid.Nil - новая игра).
+// Старое название переименованной игры не освобождается: новые игры из файла сохраняются раньше обновлений.
+func (p *catalogPlan) titleTaken(title string, id uuid.UUID) bool {
+	owner, taken := p.gameTitles[model.NormalizeTitle(title)]
+	return taken && (owner != id || id == uuid.Nil)
+}
+
+// resolveGenres превращает названия жанров в id; недостающие жанры создаются, если это разрешено.
+func (p *catalogPlan) resolveGenres(titles []string) ([]uuid.UUID, error) {
+	res := make([]uuid.UUID, 0, len(titles))
+	for _, title := range titles {
+		if strings.TrimSpace(title) == "" {
+			continue
+		}
+		matches := p.genresByTitle[catalogTitleKey(title)]
+		switch {
+		case len(matches) == 1:
+			res = append(res, matches[0].ID)
+		case len(matches) > 1:
+			return nil, fmt.Errorf("%s: %s", constants.ErrCatalogGenreAmbiguous, title)
+		case !p.createGenres:
+			return nil, fmt.Errorf("%s: %s", constants.ErrCatalogGenreUnknown, title)
+		default:
+			genre, err := model.NewGenreWithValidate(title)
+			if err != nil {
+				return nil, err
+			}
+			p.indexGenre(genre)
+			p.changes.CreateGenres = append(p.changes.CreateGenres, genre)
+			res = append(res, genre.ID)
+		}
+	}
+	if len(res) == 0 {
+		return nil, errors.New(constants.ErrCatalogNoGenres)
+	}
+	return res, nil
+}
+
+// parseCatalogStatus разбирает статус и момент публикации игры; пустой статус - не задан.
+func parseCatalogStatus(rec catalog.Record) (string, *time.Time, error) {
+	status := strings.ToLower(strings.TrimSpace(rec.Status))
+	raw := strings.TrimSpace(rec.PublishAt)
+	if raw == "" {
+		return status, nil, nil
+	}
+	publishAt, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return "", nil, errors.New(constants.ErrCatalogPublishAt)
+	}
+	publishAt = publishAt.UTC()
+	return status, &publishAt, nil
+}
+
+func catalogStatusError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrUnknownGameStatus):
+		return errors.New(constants.ErrValidationGameStatus)
+	case errors.Is(err, model.ErrPublishAtMismatch):
+		return errors.New(constants.ErrCatalogPublishAt)
+	default:
+		return err
+	}
+}
+
+// parseCatalogDate принимает RFC3339 или YYYY-MM-DD (полночь UTC).
+func parseCatalogDate(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", raw); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.New(constants.ErrCatalogReleaseDate)
+}
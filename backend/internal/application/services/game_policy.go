@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// PolicyError - нарушено правило политики игр. Обработчики отдают текст вместе с именем
+// правила и пределом: 429 для квоты создания, 400 для остальных правил.
+type PolicyError struct {
+	Rule    string
+	Limit   int
+	Message string
+}
+
+func (e *PolicyError) Error() string {
+	return e.Message
+}
+
+// QuotaExceeded сообщает, что исчерпана дневная квота создания, а не нарушены правила полей.
+func (e *PolicyError) QuotaExceeded() bool {
+	return e.Rule == model.RuleDailyCreateQuota
+}
+
+// policyError заменяет model.PolicyViolation на PolicyError с текстом для клиента;
+// остальные ошибки возвращает без изменений.
+func policyError(err error) error {
+	var violation *model.PolicyViolation
+	if !errors.As(err, &violation) {
+		return err
+	}
+
+	var message string
+	switch violation.Rule {
+	case model.RuleTitleLength:
+		message = fmt.Sprintf(constants.ErrPolicyTitleLength, violation.Limit)
+	case model.RuleDescriptionLength:
+		message = fmt.Sprintf(constants.ErrPolicyDescriptionLength, violation.Limit)
+	case model.RuleReleaseHorizon:
+		message = fmt.Sprintf(constants.ErrPolicyReleaseHorizon, violation.Limit)
+	case model.RuleWeekendRelease:
+		message = constants.ErrBusinessCannotReleaseOnWeekend
+	case model.RuleDailyCreateQuota:
+		message = fmt.Sprintf(constants.ErrBusinessDailyLimitExceeded, violation.Limit)
+	default:
+		message = violation.Error()
+	}
+	return &PolicyError{Rule: violation.Rule, Limit: violation.Limit, Message: message}
+}
+
+// checkCreateQuota проверяет, может ли actorID создать еще count игр сегодня. Создания
+// считаются по ревизиям, поэтому квоту расходуют и игры, позже удаленные в корзину.
+// Без пользователя (авторизация отключена) квота не действует.
+func checkCreateQuota(ctx context.Context, history *revisionLog, policy model.GamePolicy,
+	actorID uuid.UUID, count int) error {
+
+	if policy.DailyCreateQuota == 0 || actorID == uuid.Nil {
+		return nil
+	}
+	created, err := history.countCreated(ctx, actorID, model.QuotaDayStart(time.Now()))
+	if err != nil {
+		return err
+	}
+	return policyError(policy.CheckDailyQuota(created, count))
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// publishSchedulerMinWait - минимальная пауза планировщика, чтобы игра, которую по какой-то
+// причине не удалось опубликовать вовремя, не заставила его крутиться вхолостую
+const publishSchedulerMinWait = 50 * time.Millisecond
+
+// StatusTransitionError - игру нельзя перевести из статуса From в To. Обработчики отдают 409.
+type StatusTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf(constants.ErrBusinessStatusTransition, e.From, e.To)
+}
+
+// statusError заменяет ошибки переходов из model на ошибки с текстом для клиента;
+// остальные ошибки возвращает без изменений.
+func statusError(err error, from, to string) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidStatusTransition):
+		return &StatusTransitionError{From: from, To: to}
+	case errors.Is(err, model.ErrPublishAtNotInFuture):
+		return errors.New(constants.ErrValidationPublishAt)
+	}
+	return err
+}
+
+// PublishGame публикует игру сразу, если ее версия равна version (0 - любая версия).
+func (s *GameService) PublishGame(ctx context.Context,
+	gameID uuid.UUID, version int, actorID uuid.UUID) (*dto.GameDto, error) {
+
+	return s.changeStatus(ctx, gameID, version, actorID, model.GameStatusPublished, model.RevisionActionPublish,
+		func(game *model.Game) error { return game.Publish() })
+}
+
+// ScheduleGame назначает публикацию игры на момент publishAt.
+func (s *GameService) ScheduleGame(ctx context.Context,
+	gameID uuid.UUID, publishAt time.Time, version int, actorID uuid.UUID) (*dto.GameDto, error) {
+
+	return s.changeStatus(ctx, gameID, version, actorID, model.GameStatusScheduled, model.RevisionActionSchedule,
+		func(game *model.Game) error { return game.Schedule(publishAt, time.Now()) })
+}
+
+// UnpublishGame возвращает игру в черновики и отменяет запланированную публикацию.
+func (s *GameService) UnpublishGame(ctx context.Context,
+	gameID uuid.UUID, version int, actorID uuid.UUID) (*dto.GameDto, error) {
+
+	return s.changeStatus(ctx, gameID, version, actorID, model.GameStatusDraft, model.RevisionActionUnpublish,
+		func(game *model.Game) error { return game.Unpublish() })
+}
+
+// ArchiveGame снимает опубликованную игру с витрины.
+func (s *GameService) ArchiveGame(ctx context.Context,
+	gameID uuid.UUID, version int, actorID uuid.UUID) (*dto.GameDto, error) {
+
+	return s.changeStatus(ctx, gameID, version, actorID, model.GameStatusArchived, model.RevisionActionArchive,
+		func(game *model.Game) error { return game.Archive() })
+}
+
+// changeStatus применяет переход transition к игре с версией version, сохраняет новый статус
+// и пишет ревизию action.
+func (s *GameService) changeStatus(ctx context.Context,
+	gameID uuid.UUID, version int, actorID uuid.UUID,
+	to, action string, transition func(game *model.Game) error) (*dto.GameDto, error) {
+
+	if err := s.validateGameID(gameID); err != nil {
+		return nil, err
+	}
+
+	game, repoError := s.repo.FindByID(ctx, gameID)
+
+	if repoError != nil {
+		return nil, repoError
+	}
+
+	if err := checkVersion(version, game.Version); err != nil {
+		return nil, err
+	}
+
+	from := game.Status
+	if err := transition(game); err != nil {
+		return nil, statusError(err, from, to)
+	}
+
+	var updatedGame *model.Game
+	repoError = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if updatedGame, err = s.repo.UpdateStatus(ctx, game); err != nil {
+			return err
+		}
+		return s.history.record(ctx, gameID, action,
+			model.NewGameSnapshot(updatedGame), actorID, 0)
+	})
+
+	if repoError != nil {
+		return nil, repoError
+	}
+
+	// Расписание могло появиться, сдвинуться или исчезнуть
+	s.wakeScheduler()
+
+	return s.gameMapper.ToGameDto(updatedGame), nil
+}
+
+// PublishDueGames публикует игры, время публикации которых наступило, и возвращает их количество.
+// Ревизии таких публикаций пишутся без автора.
+func (s *GameService) PublishDueGames(ctx context.Context) (int, error) {
+	var games []*model.Game
+	repoError := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if games, err = s.repo.PublishDue(ctx, time.Now()); err != nil {
+			return err
+		}
+		for _, game := range games {
+			if err := s.history.record(ctx, game.ID, model.RevisionActionPublish,
+				model.NewGameSnapshot(game), uuid.Nil, 0); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if repoError != nil {
+		return 0, repoError
+	}
+
+	return len(games), nil
+}
+
+// RunPublishScheduler публикует запланированные игры, пока не отменен ctx. Сначала публикуются
+// все просроченные, в том числе пропущенные, пока сервис был остановлен; затем планировщик спит
+// до ближайшей публикации, но не дольше maxWait, чтобы заметить игры, запланированные другим
+// процессом с той же базой. Изменения статуса через этот сервис будят его сразу.
+func (s *GameService) RunPublishScheduler(ctx context.Context, maxWait time.Duration) {
+	if maxWait <= 0 {
+		return
+	}
+
+	for {
+		wait := maxWait
+		if published, err := s.PublishDueGames(ctx); err != nil {
+			log.Printf("publish scheduler failed: %v", err)
+		} else {
+			if published > 0 {
+				log.Printf("publish scheduler: published %d games", published)
+			}
+			wait = s.nextPublishWait(ctx, maxWait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.schedulerWake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// nextPublishWait - сколько ждать до ближайшей публикации, не больше maxWait.
+func (s *GameService) nextPublishWait(ctx context.Context, maxWait time.Duration) time.Duration {
+	next, err := s.repo.NextPublishAt(ctx)
+	if err != nil {
+		log.Printf("publish scheduler failed: %v", err)
+		return maxWait
+	}
+	if next == nil {
+		return maxWait
+	}
+	return min(max(time.Until(*next), publishSchedulerMinWait), maxWait)
+}
+
+// wakeScheduler будит RunPublishScheduler, не дожидаясь конца паузы; если он уже разбужен
+// или не запущен, ничего не делает.
+func (s *GameService) wakeScheduler() {
+	select {
+	case s.schedulerWake <- struct{}{}:
+	default:
+	}
+}
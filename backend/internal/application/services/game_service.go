@@ -3,52 +3,117 @@ package services
 import (
 	"context"
 	"errors"
+	"example/web-service-gin/internal/application/abstraction/pagination"
 	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/application/dto"
 	"example/web-service-gin/internal/application/mapper"
 	"example/web-service-gin/internal/constants"
-	"math"
+	"example/web-service-gin/internal/domain/aggregate"
+	"example/web-service-gin/internal/domain/model"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
 
 type GameService struct {
 	repo       repository.GameRepository
+	genres     repository.GenreRepository
+	ratings    repository.UserRatingRepository
+	cursors    pagination.CursorCodec
+	tx         repository.Transactor
+	history    *revisionLog
+	policy     model.GamePolicy
 	gameMapper *mapper.GameMapper
+	// schedulerWake будит планировщик публикаций после смены статуса
+	schedulerWake chan struct{}
 }
 
-func NewGameService(repo repository.GameRepository) *GameService {
+// gamesPage - страница игр до преобразования в DTO
+type gamesPage struct {
+	games      []*model.Game
+	total      int
+	page       int
+	pageSize   int
+	nextCursor string
+}
+
+func NewGameService(repo repository.GameRepository,
+	genres repository.GenreRepository,
+	ratings repository.UserRatingRepository,
+	revisions repository.RevisionRepository,
+	tx repository.Transactor,
+	cursors pagination.CursorCodec,
+	policy model.GamePolicy) *GameService {
 	return &GameService{
 		repo:       repo,
+		genres:     genres,
+		ratings:    ratings,
+		cursors:    cursors,
+		tx:         tx,
+		history:    newRevisionLog(revisions, model.RevisionEntityGame),
+		policy:     policy,
 		gameMapper: mapper.NewGameMapper(),
+
+		schedulerWake: make(chan struct{}, 1),
 	}
 }
 
+// CreateGame создает игру; actorID - автор изменения для истории ревизий,
+// на него же расходуется дневная квота создания.
 func (s *GameService) CreateGame(ctx context.Context,
-	gameCreateDto dto.CreateGameDto) (*dto.GameDto, error) {
+	gameCreateDto dto.CreateGameDto, actorID uuid.UUID) (*dto.GameDto, error) {
 
-	if err := s.validateGameData(gameCreateDto); err != nil {
-		return nil, err
-	}
-
-	game, mapperError := s.gameMapper.FromCreateGameDto(&gameCreateDto)
+	game, mapperError := s.gameMapper.FromCreateGameDto(s.policy, &gameCreateDto)
 
 	if mapperError != nil {
-		return nil, mapperError
+		return nil, statusError(policyError(mapperError), "", model.GameStatusScheduled)
 	}
 
-	createdGame, repoError := s.repo.Create(ctx, game)
+	var createdGame *model.Game
+	repoError := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Квота считается в той же транзакции, что и вставка: иначе параллельные
+		// создания одного администратора прошли бы проверку вместе
+		if err := checkCreateQuota(ctx, s.history, s.policy, actorID, 1); err != nil {
+			return err
+		}
+		var err error
+		if createdGame, err = s.repo.Create(ctx, game); err != nil {
+			return err
+		}
+		return s.history.record(ctx, createdGame.ID, model.RevisionActionCreate,
+			model.NewGameSnapshot(createdGame), actorID, 0)
+	})
 
 	if repoError != nil {
-		return nil, repoError
+		return nil, s.duplicateTitle(ctx, game.Title, repoError)
+	}
+
+	if createdGame.Status == model.GameStatusScheduled {
+		s.wakeScheduler()
 	}
 
 	return s.gameMapper.ToGameDto(createdGame), nil
 }
 
+// GetGameByID возвращает игру; без withUnpublished неопубликованная игра считается ненайденной.
 func (s *GameService) GetGameByID(ctx context.Context,
-	gameID uuid.UUID) (*dto.GameDto, error) {
+	gameID uuid.UUID, withUnpublished bool) (*dto.GameDto, error) {
+
+	game, err := s.findVisibleGame(ctx, gameID, withUnpublished)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.gameMapper.ToGameDto(game), nil
+}
+
+// findVisibleGame ищет активную игру; неопубликованную отдает только при withUnpublished.
+func (s *GameService) findVisibleGame(ctx context.Context,
+	gameID uuid.UUID, withUnpublished bool) (*model.Game, error) {
 
 	if err := s.validateGameID(gameID); err != nil {
 		return nil, err
@@ -60,22 +125,369 @@ func (s *GameService) GetGameByID(ctx context.Context,
 		return nil, repoError
 	}
 
-	return s.gameMapper.ToGameDto(game), nil
+	if !withUnpublished && !game.IsPublished() {
+		return nil, repository.ErrNotFound
+	}
+
+	return game, nil
+}
+
+// GetAllGames возвращает страницу игр с учетом фильтров и сортировки;
+// без withUnpublished - только опубликованные игры.
+func (s *GameService) GetAllGames(ctx context.Context,
+	in dto.GameListQueryDto, withUnpublished bool) (*dto.PaginatedResponse, error) {
+
+	p, err := s.findGamesPage(ctx, in, withUnpublished)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPaginatedResponse(s.gameMapper.ToGameDtoSlice(p.games), p.total, p.page, p.pageSize, p.nextCursor), nil
+}
+
+// SearchGames - полнотекстовый поиск по названию и описанию, результаты упорядочены по релевантности.
+// Без withUnpublished ищутся только опубликованные игры.
+func (s *GameService) SearchGames(ctx context.Context,
+	in dto.GameSearchQueryDto, withUnpublished bool) (*dto.PaginatedResponse, error) {
+
+	page, pageSize, err := resolvePage(in.PageQueryDto)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(in.After) != "" {
+		return nil, newQueryError(constants.ErrValidationSearchAfter)
+	}
+
+	if strings.IndexFunc(in.Q, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+		return nil, newQueryError(constants.ErrValidationSearchQuery)
+	}
+
+	query := repository.GameSearchQuery{
+		Text:   in.Q,
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	}
+	if !withUnpublished {
+		query.Status = model.GameStatusPublished
+	}
+
+	hits, total, repoError := s.repo.Search(ctx, query)
+
+	if repoError != nil {
+		return nil, repoError
+	}
+
+	return newPaginatedResponse(s.gameMapper.ToGameSearchHitDtoSlice(hits), total, page, pageSize, ""), nil
+}
+
+// GetGameDetails возвращает игру вместе с жанром, статистикой оценок
+// и оценкой текущего пользователя (userID может быть uuid.Nil). Видимость - как у GetGameByID.
+func (s *GameService) GetGameDetails(ctx context.Context,
+	gameID uuid.UUID, userID uuid.UUID, withUnpublished bool) (*dto.GameDtoWithStats, error) {
+
+	game, err := s.findVisibleGame(ctx, gameID, withUnpublished)
+	if err != nil {
+		return nil, err
+	}
+
+	genres := make([]*model.Genre, 0, len(game.GenreIDs))
+	for _, genreID := range game.GenreIDs {
+		genre, repoError := s.genres.FindByID(ctx, genreID)
+		if repoError != nil {
+			return nil, repoError
+		}
+		genres = append(genres, genre)
+	}
+
+	stats, repoError := s.ratings.FindStatsByGameIDs(ctx, []uuid.UUID{game.ID})
+
+	if repoError != nil {
+		return nil, repoError
+	}
+
+	var own *model.UserRating
+	if userID != uuid.Nil {
+		own, repoError = s.ratings.FindByUserAndGame(ctx, userID, game.ID)
+		if repoError != nil && !errors.Is(repoError, repository.ErrUserRatingNotFound) {
+			return nil, repoError
+		}
+	}
+
+	agg := aggregate.NewGameDetailsAggregateFromStats(game, genres, stats[game.ID], own)
+
+	return s.gameMapper.ToGameDtoWithStats(agg, userID), nil
+}
+
+// GetAllGamesWithStats - то же, что GetAllGames, но с жанром и статистикой оценок для каждой игры.
+func (s *GameService) GetAllGamesWithStats(ctx context.Context,
+	in dto.GameListQueryDto, userID uuid.UUID, withUnpublished bool) (*dto.PaginatedResponse, error) {
+
+	p, err := s.findGamesPage(ctx, in, withUnpublished)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.withStats(ctx, p.games, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPaginatedResponse(items, p.total, p.page, p.pageSize, p.nextCursor), nil
+}
+
+// findGamesPage выбирает страницу игр (по номеру или после курсора) и готовит курсор следующей.
+func (s *GameService) findGamesPage(ctx context.Context,
+	in dto.GameListQueryDto, withUnpublished bool) (*gamesPage, error) {
+
+	query, page, pageSize, err := s.buildGameQuery(in, withUnpublished)
+	if err != nil {
+		return nil, err
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	query.Limit = pageSize + 1
+
+	games, total, repoError := s.repo.FindByQuery(ctx, query)
+
+	if repoError != nil {
+		return nil, repoError
+	}
+
+	games, hasMore := trimPage(games, pageSize)
+	res := &gamesPage{games: games, total: total, page: page, pageSize: pageSize}
+
+	if hasMore {
+		last := games[len(games)-1]
+		key, err := s.gameCursorKey(ctx, last, query.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		res.nextCursor, err = s.cursors.Encode(repository.Cursor{
+			Sort:   sortToken(string(query.SortBy), query.SortDesc),
+			Filter: gameFilterHash(query),
+			Key:    key,
+			ID:     last.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// gameCursorKey - значение ключа сортировки игры в том виде, в котором его ожидает репозиторий.
+func (s *GameService) gameCursorKey(ctx context.Context,
+	game *model.Game, sortBy repository.GameSortField) (string, error) {
+
+	switch sortBy {
+	case repository.GameSortTitle:
+		return game.Title, nil
+	case repository.GameSortRating:
+		stats, repoError := s.ratings.FindStatsByGameIDs(ctx, []uuid.UUID{game.ID})
+		if repoError != nil {
+			return "", repoError
+		}
+		average := 0.0
+		if st, ok := stats[game.ID]; ok {
+			average = st.Average
+		}
+		return strconv.FormatFloat(average, 'g', -1, 64), nil
+	default:
+		return game.ReleaseDate.UTC().Format(time.RFC3339Nano), nil
+	}
+}
+
+func (s *GameService) buildGameQuery(in dto.GameListQueryDto,
+	withUnpublished bool) (repository.GameQuery, int, int, error) {
+
+	page, pageSize, err := resolvePage(in.PageQueryDto)
+	if err != nil {
+		return repository.GameQuery{}, 0, 0, err
+	}
+
+	sortBy, sortDesc, err := parseSort(in.Sort,
+		string(repository.GameSortReleaseDate), true,
+		string(repository.GameSortReleaseDate), string(repository.GameSortTitle), string(repository.GameSortRating))
+	if err != nil {
+		return repository.GameQuery{}, 0, 0, err
+	}
+
+	query := repository.GameQuery{
+		Limit:    pageSize,
+		Offset:   (page - 1) * pageSize,
+		Search:   in.Q,
+		SortBy:   repository.GameSortField(sortBy),
+		SortDesc: sortDesc,
+	}
+
+	if query.GenreIDs, err = parseGenreFilter(in.GenreID, in.GenreIDs); err != nil {
+		return repository.GameQuery{}, 0, 0, err
+	}
+	switch strings.TrimSpace(in.GenreMatch) {
+	case "", "any":
+	case "all":
+		query.MatchAllGenres = true
+	default:
+		return repository.GameQuery{}, 0, 0, newQueryError(constants.ErrValidationGenreMatch)
+	}
+
+	if query.ReleasedFrom, err = parseDateFilter(in.ReleasedFrom, false); err != nil {
+		return repository.GameQuery{}, 0, 0, err
+	}
+	if query.ReleasedTo, err = parseDateFilter(in.ReleasedTo, true); err != nil {
+		return repository.GameQuery{}, 0, 0, err
+	}
+	if query.ReleasedFrom != nil && query.ReleasedTo != nil && query.ReleasedFrom.After(*query.ReleasedTo) {
+		return repository.GameQuery{}, 0, 0, newQueryError(constants.ErrValidationDateRange)
+	}
+
+	if query.Status, err = parseStatusFilter(in.Status, withUnpublished); err != nil {
+		return repository.GameQuery{}, 0, 0, err
+	}
+
+	// Курсор проверяется после фильтров: он привязан к их разобранным значениям
+	if query.After, err = resolveCursor(s.cursors, in.After, sortToken(sortBy, sortDesc), gameFilterHash(query)); err != nil {
+		return repository.GameQuery{}, 0, 0, err
+	}
+
+	return query, page, pageSize, nil
+}
+
+// gameFilterHash - отпечаток фильтров выборки игр; жанры, указанные в другом порядке
+// или другим параметром, дают тот же отпечаток.
+func gameFilterHash(query repository.GameQuery) string {
+	genreIDs := make([]string, len(query.GenreIDs))
+	for i, id := range query.GenreIDs {
+		genreIDs[i] = id.String()
+	}
+	slices.Sort(genreIDs)
+
+	formatDate := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+
+	return filterHash(
+		strings.TrimSpace(query.Search),
+		strings.Join(genreIDs, ","),
+		strconv.FormatBool(query.MatchAllGenres),
+		formatDate(query.ReleasedFrom),
+		formatDate(query.ReleasedTo),
+		query.Status,
+	)
+}
+
+// parseStatusFilter проверяет фильтр по статусу. Без withUnpublished выбираются только
+// опубликованные игры, а фильтр по другому статусу - ошибка.
+func parseStatusFilter(status string, withUnpublished bool) (string, error) {
+	status = strings.TrimSpace(status)
+	if status != "" && !model.IsGameStatus(status) {
+		return "", newQueryError(constants.ErrValidationGameStatus)
+	}
+	if withUnpublished {
+		return status, nil
+	}
+	if status != "" && status != model.GameStatusPublished {
+		return "", newQueryError(constants.ErrValidationStatusFilter)
+	}
+	return model.GameStatusPublished, nil
 }
 
-func (s *GameService) GetAllGames(ctx context.Context) ([]*dto.GameDto, error) {
+// parseGenreFilter собирает жанры из genreId и genreIds (повтор параметра или список через запятую).
+func parseGenreFilter(genreID string, genreIDs []string) ([]uuid.UUID, error) {
+	raw := append([]string{genreID}, genreIDs...)
+
+	var res []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, item := range raw {
+		for _, part := range strings.Split(item, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			id, err := uuid.Parse(part)
+			if err != nil {
+				return nil, newQueryError(constants.ErrValidationGenreID)
+			}
+			if !seen[id] {
+				seen[id] = true
+				res = append(res, id)
+			}
+		}
+	}
+	return res, nil
+}
 
-	games, repoError := s.repo.FindAll(ctx, math.MaxInt, 0)
+func (s *GameService) withStats(ctx context.Context,
+	games []*model.Game, userID uuid.UUID) ([]*dto.GameDtoWithStats, error) {
+
+	gameIDs := make([]uuid.UUID, len(games))
+	for i, game := range games {
+		gameIDs[i] = game.ID
+	}
+
+	stats, repoError := s.ratings.FindStatsByGameIDs(ctx, gameIDs)
 
 	if repoError != nil {
 		return nil, repoError
 	}
 
-	return s.gameMapper.ToGameDtoSlice(games), nil
+	// Только жанры игр страницы, а не весь справочник
+	genreIDs := make([]uuid.UUID, 0, len(games))
+	seenGenres := make(map[uuid.UUID]bool, len(games))
+	for _, game := range games {
+		for _, genreID := range game.GenreIDs {
+			if !seenGenres[genreID] {
+				seenGenres[genreID] = true
+				genreIDs = append(genreIDs, genreID)
+			}
+		}
+	}
+
+	pageGenres, repoError := s.genres.FindByIDs(ctx, genreIDs)
+
+	if repoError != nil {
+		return nil, repoError
+	}
+
+	genres := make(map[uuid.UUID]*model.Genre, len(pageGenres))
+	for _, genre := range pageGenres {
+		genres[genre.ID] = genre
+	}
+
+	own := make(map[uuid.UUID]*model.UserRating)
+	if userID != uuid.Nil {
+		userRatings, repoError := s.ratings.FindByUserID(ctx, userID)
+		if repoError != nil {
+			return nil, repoError
+		}
+		for _, rating := range userRatings {
+			own[rating.GameID] = rating
+		}
+	}
+
+	result := make([]*dto.GameDtoWithStats, len(games))
+	for i, game := range games {
+		gameGenres := make([]*model.Genre, 0, len(game.GenreIDs))
+		for _, genreID := range game.GenreIDs {
+			if genre, ok := genres[genreID]; ok {
+				gameGenres = append(gameGenres, genre)
+			}
+		}
+		agg := aggregate.NewGameDetailsAggregateFromStats(game, gameGenres, stats[game.ID], own[game.ID])
+		result[i] = s.gameMapper.ToGameDtoWithStats(agg, userID)
+	}
+
+	return result, nil
 }
 
+// UpdateGame сохраняет игру, если ее версия равна version (0 - любая версия).
 func (s *GameService) UpdateGame(ctx context.Context,
-	updateGameDto dto.UpdateGameDto) (*dto.GameDto, error) {
+	updateGameDto dto.UpdateGameDto, version int, actorID uuid.UUID) (*dto.GameDto, error) {
 
 	if err := s.validateUpdateData(updateGameDto); err != nil {
 		return nil, err
@@ -87,86 +499,208 @@ func (s *GameService) UpdateGame(ctx context.Context,
 		return nil, repoError
 	}
 
-	mapperError := s.gameMapper.FromUpdateGameDto(existingGame, &updateGameDto)
+	if err := checkVersion(version, existingGame.Version); err != nil {
+		return nil, err
+	}
+
+	return s.applyGameUpdate(ctx, existingGame, updateGameDto, actorID)
+}
+
+// PatchGame применяет JSON Merge Patch: меняются только переданные поля, а итог
+// проходит те же проверки, что и полное обновление.
+func (s *GameService) PatchGame(ctx context.Context,
+	gameID uuid.UUID, patch dto.PatchGameDto, version int, actorID uuid.UUID) (*dto.GameDto, error) {
+
+	if err := s.validateGameID(gameID); err != nil {
+		return nil, err
+	}
+
+	existingGame, repoError := s.repo.FindByID(ctx, gameID)
+
+	if repoError != nil {
+		return nil, repoError
+	}
+
+	if err := checkVersion(version, existingGame.Version); err != nil {
+		return nil, err
+	}
+
+	merged, err := s.gameMapper.MergeGamePatch(existingGame, &patch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateUpdateData(merged); err != nil {
+		return nil, err
+	}
+
+	return s.applyGameUpdate(ctx, existingGame, merged, actorID)
+}
+
+// applyGameUpdate переносит проверенные данные в игру, сохраняет ее и пишет ревизию.
+func (s *GameService) applyGameUpdate(ctx context.Context,
+	existingGame *model.Game, updateGameDto dto.UpdateGameDto, actorID uuid.UUID) (*dto.GameDto, error) {
+
+	mapperError := s.gameMapper.FromUpdateGameDto(s.policy, existingGame, &updateGameDto)
 
 	if mapperError != nil {
-		return nil, mapperError
+		return nil, policyError(mapperError)
 	}
 
-	updatedGame, repoError := s.repo.Update(ctx, existingGame)
+	var updatedGame *model.Game
+	repoError := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if updatedGame, err = s.repo.Update(ctx, existingGame); err != nil {
+			return err
+		}
+		return s.history.record(ctx, updatedGame.ID, model.RevisionActionUpdate,
+			model.NewGameSnapshot(updatedGame), actorID, 0)
+	})
 
 	if repoError != nil {
-		return nil, repoError
+		return nil, s.duplicateTitle(ctx, existingGame.Title, repoError)
 	}
 
 	return s.gameMapper.ToGameDto(updatedGame), nil
 }
 
+// DeleteGame перемещает игру в корзину, если ее версия равна version (0 - любая версия).
 func (s *GameService) DeleteGame(ctx context.Context,
-	gameID uuid.UUID) error {
+	gameID uuid.UUID, version int, actorID uuid.UUID) error {
 
 	if err := s.validateGameID(gameID); err != nil {
 		return err
 	}
 
-	exists, repoError := s.repo.Exists(ctx, gameID)
+	game, repoError := s.repo.FindByID(ctx, gameID)
 
 	if repoError != nil {
+		if errors.Is(repoError, repository.ErrNotFound) {
+			return errors.New(constants.ErrGameNotFound)
+		}
 		return repoError
 	}
 
-	if !exists {
-		return errors.New(constants.ErrGameNotFound)
+	if err := checkVersion(version, game.Version); err != nil {
+		return err
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Delete(ctx, gameID, game.Version); err != nil {
+			return err
+		}
+		return s.history.record(ctx, gameID, model.RevisionActionDelete,
+			model.NewGameSnapshot(game), actorID, 0)
+	})
+}
+
+// RestoreGame возвращает игру из корзины; жанры игры должны быть активны.
+func (s *GameService) RestoreGame(ctx context.Context,
+	gameID uuid.UUID, actorID uuid.UUID) (*dto.GameDto, error) {
+
+	if err := s.validateGameID(gameID); err != nil {
+		return nil, err
 	}
 
-	repoError = s.repo.Delete(ctx, gameID)
+	var game *model.Game
+	repoError := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Restore(ctx, gameID); err != nil {
+			return err
+		}
+		var err error
+		if game, err = s.repo.FindByID(ctx, gameID); err != nil {
+			return err
+		}
+		return s.history.record(ctx, gameID, model.RevisionActionRestore,
+			model.NewGameSnapshot(game), actorID, 0)
+	})
 
 	if repoError != nil {
-		return repoError
+		if errors.Is(repoError, repository.ErrDuplicateTitle) {
+			return nil, s.restoreConflict(ctx, gameID, repoError)
+		}
+		return nil, repoError
 	}
 
-	return nil
+	return s.gameMapper.ToGameDto(game), nil
 }
 
-func (s *GameService) validateUpdateData(gameDto dto.UpdateGameDto) error {
-	if gameDto.ID == uuid.Nil {
-		return errors.New(constants.ErrGameIDRequired)
+// GetGameRevisions возвращает историю изменений игры, последние ревизии первыми.
+// История доступна и для игр в корзине.
+func (s *GameService) GetGameRevisions(ctx context.Context,
+	gameID uuid.UUID, in dto.RevisionListQueryDto) (*dto.PaginatedResponse, error) {
+
+	if err := s.validateGameID(gameID); err != nil {
+		return nil, err
+	}
+
+	return s.history.list(ctx, gameID, in)
+}
+
+// DiffGameRevisions сравнивает две ревизии игры по полям.
+func (s *GameService) DiffGameRevisions(ctx context.Context,
+	gameID uuid.UUID, in dto.RevisionDiffQueryDto) (*dto.RevisionDiffDto, error) {
+
+	if err := s.validateGameID(gameID); err != nil {
+		return nil, err
+	}
+
+	return s.history.diff(ctx, gameID, in)
+}
+
+// RevertGame возвращает игру к состоянию из ревизии number. Снимок проходит ту же
+// доменную валидацию, что и обычное обновление, а откат сохраняется новой ревизией.
+func (s *GameService) RevertGame(ctx context.Context,
+	gameID uuid.UUID, number int, actorID uuid.UUID) (*dto.GameDto, error) {
+
+	if err := s.validateGameID(gameID); err != nil {
+		return nil, err
 	}
 
-	if gameDto.Title == "" || len(gameDto.Title) > 200 {
-		return errors.New(constants.ErrValidationTitleLength)
+	var snapshot model.GameSnapshot
+	if err := s.history.snapshot(ctx, gameID, number, &snapshot); err != nil {
+		return nil, err
 	}
 
-	if len(gameDto.Description) > 2000 {
-		return errors.New(constants.ErrValidationDescription)
+	game, repoError := s.repo.FindByID(ctx, gameID)
+
+	if repoError != nil {
+		return nil, repoError
 	}
 
-	if gameDto.ReleaseDate.After(time.Now().AddDate(1, 0, 0)) {
-		return errors.New(constants.ErrValidationReleaseDate)
+	if err := game.UpdateGameWithValidate(s.policy, snapshot.Title, snapshot.Description,
+		snapshot.ReleaseDate, snapshot.GenreID, snapshot.GenreIDs); err != nil {
+		return nil, policyError(err)
 	}
 
-	return nil
-}
+	var revertedGame *model.Game
+	repoError = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if revertedGame, err = s.repo.Update(ctx, game); err != nil {
+			return err
+		}
+		return s.history.record(ctx, gameID, model.RevisionActionRevert,
+			model.NewGameSnapshot(revertedGame), actorID, number)
+	})
 
-func (s *GameService) validateGameID(gameID uuid.UUID) error {
-	if gameID == uuid.Nil {
-		return errors.New(constants.ErrGameIDRequired)
+	if repoError != nil {
+		return nil, s.duplicateTitle(ctx, game.Title, repoError)
 	}
 
-	return nil
+	return s.gameMapper.ToGameDto(revertedGame), nil
 }
 
-func (s *GameService) validateGameData(gameDto dto.CreateGameDto) error {
-	if gameDto.Title == "" || len(gameDto.Title) > 200 {
-		return errors.New(constants.ErrValidationTitleLength)
+func (s *GameService) validateUpdateData(gameDto dto.UpdateGameDto) error {
+	if gameDto.ID == uuid.Nil {
+		return errors.New(constants.ErrGameIDRequired)
 	}
 
-	if len(gameDto.Description) > 2000 {
-		return errors.New(constants.ErrValidationDescription)
-	}
+	return nil
+}
 
-	if gameDto.ReleaseDate.After(time.Now().AddDate(1, 0, 0)) {
-		return errors.New(constants.ErrValidationReleaseDate)
+func (s *GameService) validateGameID(gameID uuid.UUID) error {
+	if gameID == uuid.Nil {
+		return errors.New(constants.ErrGameIDRequired)
 	}
 
 	return nil
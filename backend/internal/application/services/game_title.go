@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSimilarTitlesLimit = 10
+	// similarTitleThreshold - минимальная похожесть, с которой название считается почти дублем
+	similarTitleThreshold = 0.4
+)
+
+// DuplicateTitleError - название занято другой активной игрой. Обработчики отдают его как 409
+// вместе с этой игрой.
+type DuplicateTitleError struct {
+	Existing *dto.GameDto
+}
+
+func (e *DuplicateTitleError) Error() string {
+	return constants.ErrBusinessDuplicateTitle
+}
+
+// duplicateTitle заменяет repository.ErrDuplicateTitle на DuplicateTitleError с игрой,
+// которая занимает название title; остальные ошибки возвращает без изменений.
+func (s *GameService) duplicateTitle(ctx context.Context, title string, err error) error {
+	if !errors.Is(err, repository.ErrDuplicateTitle) {
+		return err
+	}
+	existing, findErr := s.repo.FindByTitle(ctx, title)
+	if findErr != nil {
+		// Игра, занявшая название, уже успела его освободить
+		return &DuplicateTitleError{}
+	}
+	return &DuplicateTitleError{Existing: s.gameMapper.ToGameDto(existing)}
+}
+
+// restoreConflict находит в корзине восстанавливаемую игру и отдает DuplicateTitleError по ее названию.
+func (s *GameService) restoreConflict(ctx context.Context, gameID uuid.UUID, err error) error {
+	deleted, findErr := s.repo.FindDeleted(ctx)
+	if findErr != nil {
+		return findErr
+	}
+	for _, game := range deleted {
+		if game.ID == gameID {
+			return s.duplicateTitle(ctx, game.Title, err)
+		}
+	}
+	return err
+}
+
+// FindSimilarTitles возвращает активные игры с похожими названиями, самые похожие первыми,
+// чтобы предупредить о дубле до создания игры.
+func (s *GameService) FindSimilarTitles(ctx context.Context,
+	in dto.SimilarTitlesQueryDto) ([]*dto.SimilarTitleDto, error) {
+
+	if strings.TrimSpace(in.Title) == "" {
+		return nil, newQueryError(constants.ErrValidationSimilarTitle)
+	}
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultSimilarTitlesLimit
+	}
+	if limit < 0 {
+		return nil, newQueryError(constants.ErrValidationLimit)
+	}
+	if limit > maxPageSize {
+		return nil, newQueryError(constants.ErrValidationMaxLimit)
+	}
+
+	titles, err := s.repo.FindTitles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := model.NormalizeTitle(in.Title)
+	res := []*dto.SimilarTitleDto{}
+	for _, t := range titles {
+		score := math.Round(model.TitleSimilarity(in.Title, t.Title)*1000) / 1000
+		if score < similarTitleThreshold {
+			continue
+		}
+		res = append(res, &dto.SimilarTitleDto{
+			ID:    t.ID,
+			Title: t.Title,
+			Score: score,
+			Exact: model.NormalizeTitle(t.Title) == key,
+		})
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Score > res[j].Score })
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
@@ -3,31 +3,45 @@ package services
 import (
 	"context"
 	"errors"
-	"math"
 	"strings"
 
+	"example/web-service-gin/internal/application/abstraction/pagination"
 	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/application/dto"
 	"example/web-service-gin/internal/application/mapper"
 	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
 
 	"github.com/google/uuid"
 )
 
 type GenreService struct {
 	repo        repository.GenreRepository
+	games       repository.GameRepository
+	cursors     pagination.CursorCodec
+	tx          repository.Transactor
+	history     *revisionLog
 	genreMapper *mapper.GenreMapper
 }
 
-func NewGenreService(repo repository.GenreRepository) *GenreService {
+func NewGenreService(repo repository.GenreRepository,
+	games repository.GameRepository,
+	revisions repository.RevisionRepository,
+	tx repository.Transactor,
+	cursors pagination.CursorCodec) *GenreService {
 	return &GenreService{
 		repo:        repo,
+		games:       games,
+		cursors:     cursors,
+		tx:          tx,
+		history:     newRevisionLog(revisions, model.RevisionEntityGenre),
 		genreMapper: mapper.NewGenreMapper(),
 	}
 }
 
-func (s *GenreService) CreateGenre(ctx context.Context, in dto.CreateGenreDto) (*dto.GenreDto, error) {
-	if err := s.validateGenreTitle(in.Title); err != nil {
+// CreateGenre создает жанр; actorID - автор изменения для истории ревизий.
+func (s *GenreService) CreateGenre(ctx context.Context, in dto.CreateGenreDto, actorID uuid.UUID) (*dto.GenreDto, error) {
+	if err := validateGenreTitle(in.Title); err != nil {
 		return nil, err
 	}
 
@@ -36,7 +50,15 @@ func (s *GenreService) CreateGenre(ctx context.Context, in dto.CreateGenreDto) (
 		return nil, err
 	}
 
-	created, err := s.repo.Create(ctx, genre)
+	var created *model.Genre
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if created, err = s.repo.Create(ctx, genre); err != nil {
+			return err
+		}
+		return s.history.record(ctx, created.ID, model.RevisionActionCreate,
+			model.NewGenreSnapshot(created), actorID, 0)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -57,20 +79,54 @@ func (s *GenreService) GetGenreByID(ctx context.Context, id uuid.UUID) (*dto.Gen
 	return s.genreMapper.ToGenreDto(genre), nil
 }
 
-func (s *GenreService) GetAllGenres(ctx context.Context) ([]*dto.GenreDto, error) {
-	genres, err := s.repo.FindAll(ctx, math.MaxInt, 0)
+func (s *GenreService) GetAllGenres(ctx context.Context, in dto.GenreListQueryDto) (*dto.PaginatedResponse, error) {
+	page, pageSize, err := resolvePage(in.PageQueryDto)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.genreMapper.ToGenreDtoSlice(genres), nil
+	_, sortDesc, err := parseSort(in.Sort, "title", false, "title")
+	if err != nil {
+		return nil, err
+	}
+
+	sort := sortToken("title", sortDesc)
+	filter := filterHash(strings.TrimSpace(in.Q))
+	after, err := resolveCursor(s.cursors, in.After, sort, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	genres, total, err := s.repo.FindByQuery(ctx, repository.GenreQuery{
+		Limit:    pageSize + 1,
+		Offset:   (page - 1) * pageSize,
+		After:    after,
+		Search:   in.Q,
+		SortDesc: sortDesc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	genres, hasMore := trimPage(genres, pageSize)
+	nextCursor := ""
+	if hasMore {
+		last := genres[len(genres)-1]
+		nextCursor, err = s.cursors.Encode(repository.Cursor{Sort: sort, Filter: filter, Key: last.Title, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newPaginatedResponse(s.genreMapper.ToGenreDtoSlice(genres), total, page, pageSize, nextCursor), nil
 }
 
-func (s *GenreService) UpdateGenre(ctx context.Context, in dto.UpdateGenreDto) (*dto.GenreDto, error) {
+// UpdateGenre сохраняет жанр, если его версия равна version (0 - любая версия).
+func (s *GenreService) UpdateGenre(ctx context.Context, in dto.UpdateGenreDto, version int, actorID uuid.UUID) (*dto.GenreDto, error) {
 	if in.ID == uuid.Nil {
 		return nil, errors.New(constants.ErrGenreIDRequired)
 	}
-	if err := s.validateGenreTitle(in.Title); err != nil {
+	if err := validateGenreTitle(in.Title); err != nil {
 		return nil, err
 	}
 
@@ -79,11 +135,54 @@ func (s *GenreService) UpdateGenre(ctx context.Context, in dto.UpdateGenreDto) (
 		return nil, err
 	}
 
+	if err := checkVersion(version, existing.Version); err != nil {
+		return nil, err
+	}
+
+	return s.applyGenreUpdate(ctx, existing, in, actorID)
+}
+
+// PatchGenre применяет JSON Merge Patch к жанру.
+func (s *GenreService) PatchGenre(ctx context.Context, id uuid.UUID, patch dto.PatchGenreDto, version int, actorID uuid.UUID) (*dto.GenreDto, error) {
+	if id == uuid.Nil {
+		return nil, errors.New(constants.ErrGenreIDRequired)
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(version, existing.Version); err != nil {
+		return nil, err
+	}
+
+	merged, err := s.genreMapper.MergeGenrePatch(existing, &patch)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGenreTitle(merged.Title); err != nil {
+		return nil, err
+	}
+
+	return s.applyGenreUpdate(ctx, existing, merged, actorID)
+}
+
+// applyGenreUpdate переносит проверенные данные в жанр, сохраняет его и пишет ревизию.
+func (s *GenreService) applyGenreUpdate(ctx context.Context, existing *model.Genre, in dto.UpdateGenreDto, actorID uuid.UUID) (*dto.GenreDto, error) {
 	if err := s.genreMapper.FromUpdateGenreDto(existing, &in); err != nil {
 		return nil, err
 	}
 
-	updated, err := s.repo.Update(ctx, existing)
+	var updated *model.Genre
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if updated, err = s.repo.Update(ctx, existing); err != nil {
+			return err
+		}
+		return s.history.record(ctx, updated.ID, model.RevisionActionUpdate,
+			model.NewGenreSnapshot(updated), actorID, 0)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -91,23 +190,122 @@ func (s *GenreService) UpdateGenre(ctx context.Context, in dto.UpdateGenreDto) (
 	return s.genreMapper.ToGenreDto(updated), nil
 }
 
-func (s *GenreService) DeleteGenre(ctx context.Context, id uuid.UUID) error {
+// DeleteGenre перемещает жанр в корзину, если его версия равна version (0 - любая версия).
+func (s *GenreService) DeleteGenre(ctx context.Context, id uuid.UUID, version int, actorID uuid.UUID) error {
 	if id == uuid.Nil {
 		return errors.New(constants.ErrGenreIDRequired)
 	}
 
-	exists, err := s.repo.Exists(ctx, id)
+	genre, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return repository.ErrGenreNotFound
+
+	if err := checkVersion(version, genre.Version); err != nil {
+		return err
+	}
+
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Жанр с активными играми в корзину не отправляем, иначе игры останутся без жанра
+		_, used, err := s.games.FindByQuery(ctx, repository.GameQuery{Limit: 1, GenreIDs: []uuid.UUID{id}})
+		if err != nil {
+			return err
+		}
+		if used > 0 {
+			return repository.ErrGenreInUse
+		}
+
+		if err := s.repo.Delete(ctx, id, genre.Version); err != nil {
+			return err
+		}
+		return s.history.record(ctx, id, model.RevisionActionDelete,
+			model.NewGenreSnapshot(genre), actorID, 0)
+	})
+}
+
+// RestoreGenre возвращает жанр из корзины.
+func (s *GenreService) RestoreGenre(ctx context.Context, id uuid.UUID, actorID uuid.UUID) (*dto.GenreDto, error) {
+	if id == uuid.Nil {
+		return nil, errors.New(constants.ErrGenreIDRequired)
+	}
+
+	var genre *model.Genre
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Restore(ctx, id); err != nil {
+			return err
+		}
+		var err error
+		if genre, err = s.repo.FindByID(ctx, id); err != nil {
+			return err
+		}
+		return s.history.record(ctx, id, model.RevisionActionRestore,
+			model.NewGenreSnapshot(genre), actorID, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.genreMapper.ToGenreDto(genre), nil
+}
+
+// GetGenreRevisions возвращает историю изменений жанра, последние ревизии первыми.
+func (s *GenreService) GetGenreRevisions(ctx context.Context,
+	id uuid.UUID, in dto.RevisionListQueryDto) (*dto.PaginatedResponse, error) {
+	if id == uuid.Nil {
+		return nil, errors.New(constants.ErrGenreIDRequired)
+	}
+
+	return s.history.list(ctx, id, in)
+}
+
+// DiffGenreRevisions сравнивает две ревизии жанра по полям.
+func (s *GenreService) DiffGenreRevisions(ctx context.Context,
+	id uuid.UUID, in dto.RevisionDiffQueryDto) (*dto.RevisionDiffDto, error) {
+	if id == uuid.Nil {
+		return nil, errors.New(constants.ErrGenreIDRequired)
+	}
+
+	return s.history.diff(ctx, id, in)
+}
+
+// RevertGenre возвращает жанр к состоянию из ревизии number через обычную доменную валидацию.
+func (s *GenreService) RevertGenre(ctx context.Context,
+	id uuid.UUID, number int, actorID uuid.UUID) (*dto.GenreDto, error) {
+	if id == uuid.Nil {
+		return nil, errors.New(constants.ErrGenreIDRequired)
+	}
+
+	var snapshot model.GenreSnapshot
+	if err := s.history.snapshot(ctx, id, number, &snapshot); err != nil {
+		return nil, err
+	}
+
+	genre, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := genre.UpdateTitleWithValidate(snapshot.Title); err != nil {
+		return nil, err
+	}
+
+	var reverted *model.Genre
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if reverted, err = s.repo.Update(ctx, genre); err != nil {
+			return err
+		}
+		return s.history.record(ctx, id, model.RevisionActionRevert,
+			model.NewGenreSnapshot(reverted), actorID, number)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.repo.Delete(ctx, id)
+	return s.genreMapper.ToGenreDto(reverted), nil
 }
 
-func (s *GenreService) validateGenreTitle(title string) error {
+func validateGenreTitle(title string) error {
 	title = strings.TrimSpace(title)
 	if title == "" {
 		return errors.New(constants.ErrGenreTitleEmpty)
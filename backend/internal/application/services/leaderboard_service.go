@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+const defaultLeaderboardLimit = 10
+
+// LeaderboardService - таблицы рекордов игр по результатам попыток (PlayResultService).
+type LeaderboardService struct {
+	games       repository.GameRepository
+	tasks       repository.TaskRepository
+	results     repository.PlayResultRepository
+	boardMapper *mapper.LeaderboardMapper
+}
+
+func NewLeaderboardService(games repository.GameRepository,
+	tasks repository.TaskRepository,
+	results repository.PlayResultRepository) *LeaderboardService {
+
+	return &LeaderboardService{
+		games:       games,
+		tasks:       tasks,
+		results:     results,
+		boardMapper: mapper.NewLeaderboardMapper(),
+	}
+}
+
+// GetLeaderboard возвращает таблицу рекордов игры gameID за окно in.Window; callerID (uuid.Nil
+// без токена) получает свое место в Me. Неопубликованные игры видны только при withUnpublished.
+func (s *LeaderboardService) GetLeaderboard(ctx context.Context,
+	gameID uuid.UUID, in dto.LeaderboardQueryDto, callerID uuid.UUID, withUnpublished bool) (*dto.LeaderboardDto, error) {
+
+	limit, err := resolveLeaderboardLimit(in.Limit)
+	if err != nil {
+		return nil, err
+	}
+	window := in.Window
+	if window == "" {
+		window = model.LeaderboardWindowAll
+	}
+	since, err := model.LeaderboardSince(window, time.Now())
+	if err != nil {
+		return nil, newQueryError(constants.ErrValidationLeaderboardWindow)
+	}
+
+	game, err := s.games.FindByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !withUnpublished && !game.IsPublished() {
+		return nil, repository.ErrNotFound
+	}
+
+	taskID := uuid.Nil
+	if in.TaskID != "" {
+		if taskID, err = s.resolveTask(ctx, gameID, in.TaskID); err != nil {
+			return nil, err
+		}
+	}
+
+	board, err := s.results.FindLeaderboard(ctx, repository.LeaderboardQuery{
+		GameID:        gameID,
+		TaskID:        taskID,
+		Since:         since,
+		ExcludeAdmins: in.ExcludeAdmins,
+		Limit:         limit,
+		CallerID:      callerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.boardMapper.ToLeaderboardDto(board, gameID, taskID, window, since), nil
+}
+
+// resolveTask проверяет, что taskId - задача игры gameID.
+func (s *LeaderboardService) resolveTask(ctx context.Context, gameID uuid.UUID, raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, newQueryError(constants.ErrValidationLeaderboardTask)
+	}
+	task, err := s.tasks.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrTaskNotFound) || (err == nil && task.GameID != gameID) {
+		return uuid.Nil, newQueryError(constants.ErrValidationLeaderboardTask)
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func resolveLeaderboardLimit(limit int) (int, error) {
+	if limit == 0 {
+		return defaultLeaderboardLimit, nil
+	}
+	if limit < 0 {
+		return 0, newQueryError(constants.ErrValidationLimit)
+	}
+	if limit > maxPageSize {
+		return 0, newQueryError(constants.ErrValidationMaxLimit)
+	}
+	return limit, nil
+}
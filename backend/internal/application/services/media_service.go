@@ -0,0 +1,283 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"example/web-service-gin/internal/application/abstraction/blob"
+	"example/web-service-gin/internal/application/abstraction/media"
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// thumbnailSizes - миниатюры, которые строятся для каждого изображения: имя и наибольшая сторона
+var thumbnailSizes = []struct {
+	name    string
+	maxSide int
+}{
+	{name: "small", maxSide: 160},
+	{name: "medium", maxSide: 480},
+	{name: "large", maxSide: 1280},
+}
+
+// MediaService - обложки и скриншоты игр. Файлы лежат в хранилище blob.Store,
+// записи о них - в GameMediaRepository.
+type MediaService struct {
+	games       repository.GameRepository
+	media       repository.GameMediaRepository
+	blobs       blob.Store
+	images      media.ImageProcessor
+	maxBytes    int64
+	mediaMapper *mapper.MediaMapper
+}
+
+func NewMediaService(games repository.GameRepository,
+	mediaRepo repository.GameMediaRepository,
+	blobs blob.Store,
+	images media.ImageProcessor,
+	maxBytes int64) *MediaService {
+
+	return &MediaService{
+		games:       games,
+		media:       mediaRepo,
+		blobs:       blobs,
+		images:      images,
+		maxBytes:    maxBytes,
+		mediaMapper: mapper.NewMediaMapper(),
+	}
+}
+
+// UploadMedia сохраняет изображение игры и его миниатюры. Тип файла определяется по содержимому.
+// Новая обложка заменяет прежнюю, скриншот добавляется в конец.
+func (s *MediaService) UploadMedia(ctx context.Context,
+	gameID uuid.UUID, kind string, r io.Reader, actorID uuid.UUID) (*dto.GameMediaDto, error) {
+
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind = model.MediaKindScreenshot
+	}
+	item, err := model.NewGameMediaWithValidate(gameID, kind)
+	if err != nil {
+		return nil, newQueryError(constants.ErrValidationMediaKind)
+	}
+	if err := s.ensureGame(ctx, gameID, true); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.maxBytes {
+		return nil, media.ErrTooLarge
+	}
+
+	info, err := s.images.Inspect(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *model.GameMedia
+	if kind == model.MediaKindCover {
+		if previous, err = s.findCover(ctx, gameID); err != nil && !errors.Is(err, repository.ErrMediaNotFound) {
+			return nil, err
+		}
+	}
+
+	// Ключи сохраненного содержимого: при ошибке ниже их нужно освободить
+	var stored []string
+	fail := func(err error) (*dto.GameMediaDto, error) {
+		s.releaseBlobs(ctx, stored)
+		return nil, err
+	}
+
+	original, err := s.blobs.Put(ctx, bytes.NewReader(data))
+	if err != nil {
+		return fail(err)
+	}
+	stored = append(stored, original.Key)
+
+	item.BlobKey = original.Key
+	item.ContentType = info.ContentType
+	item.Bytes = original.Size
+	item.Width = info.Width
+	item.Height = info.Height
+	item.UploadedBy = actorID
+
+	for _, size := range thumbnailSizes {
+		thumb, thumbInfo, err := s.images.Thumbnail(data, size.maxSide)
+		if err != nil {
+			return fail(err)
+		}
+		obj, err := s.blobs.Put(ctx, bytes.NewReader(thumb))
+		if err != nil {
+			return fail(err)
+		}
+		stored = append(stored, obj.Key)
+		item.Thumbnails = append(item.Thumbnails, model.MediaThumbnail{
+			Size:        size.name,
+			BlobKey:     obj.Key,
+			ContentType: thumbInfo.ContentType,
+			Bytes:       obj.Size,
+			Width:       thumbInfo.Width,
+			Height:      thumbInfo.Height,
+		})
+	}
+
+	created, err := s.media.Create(ctx, item)
+	if err != nil {
+		return fail(err)
+	}
+
+	if previous != nil {
+		s.releaseBlobs(ctx, previous.BlobKeys())
+	}
+	return s.mediaMapper.ToGameMediaDto(created), nil
+}
+
+// ListMedia возвращает обложку и скриншоты игры по порядку.
+func (s *MediaService) ListMedia(ctx context.Context, gameID uuid.UUID, withUnpublished bool) ([]*dto.GameMediaDto, error) {
+	if err := s.ensureGame(ctx, gameID, withUnpublished); err != nil {
+		return nil, err
+	}
+
+	items, err := s.media.FindByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return s.mediaMapper.ToGameMediaDtoSlice(items), nil
+}
+
+// ReorderScreenshots задает порядок скриншотов; ids должны содержать все скриншоты игры.
+func (s *MediaService) ReorderScreenshots(ctx context.Context, gameID uuid.UUID, in dto.ReorderMediaDto) ([]*dto.GameMediaDto, error) {
+	if err := s.ensureGame(ctx, gameID, true); err != nil {
+		return nil, err
+	}
+
+	if err := s.media.Reorder(ctx, gameID, in.IDs); err != nil {
+		if errors.Is(err, repository.ErrMediaOrderMismatch) {
+			return nil, newQueryError(constants.ErrValidationMediaOrder)
+		}
+		return nil, err
+	}
+	return s.ListMedia(ctx, gameID, true)
+}
+
+// DeleteMedia удаляет изображение игры; содержимое удаляется из хранилища, если больше нигде не используется.
+func (s *MediaService) DeleteMedia(ctx context.Context, gameID, mediaID uuid.UUID) error {
+	if err := s.ensureGame(ctx, gameID, true); err != nil {
+		return err
+	}
+
+	item, err := s.media.FindByID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if item.GameID != gameID {
+		return repository.ErrMediaNotFound
+	}
+
+	if err := s.media.Delete(ctx, mediaID); err != nil {
+		return err
+	}
+	s.releaseBlobs(ctx, item.BlobKeys())
+	return nil
+}
+
+// OpenMedia открывает оригинал изображения или его миниатюру size.
+func (s *MediaService) OpenMedia(ctx context.Context,
+	mediaID uuid.UUID, size string, withUnpublished bool) (*dto.MediaContent, error) {
+
+	item, err := s.media.FindByID(ctx, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	// Изображения игр из корзины и скрытых игр не отдаются
+	if err := s.ensureGame(ctx, item.GameID, withUnpublished); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, repository.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return s.open(ctx, item, size)
+}
+
+// OpenCover открывает обложку игры или ее миниатюру size.
+func (s *MediaService) OpenCover(ctx context.Context,
+	gameID uuid.UUID, size string, withUnpublished bool) (*dto.MediaContent, error) {
+
+	if err := s.ensureGame(ctx, gameID, withUnpublished); err != nil {
+		return nil, err
+	}
+
+	cover, err := s.findCover(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return s.open(ctx, cover, size)
+}
+
+func (s *MediaService) open(ctx context.Context, item *model.GameMedia, size string) (*dto.MediaContent, error) {
+	key, contentType := item.BlobKey, item.ContentType
+	if size = strings.ToLower(strings.TrimSpace(size)); size != "" {
+		thumb := item.Thumbnail(size)
+		if thumb == nil {
+			return nil, newQueryError(constants.ErrValidationMediaSize)
+		}
+		key, contentType = thumb.BlobKey, thumb.ContentType
+	}
+
+	content, err := s.blobs.Open(ctx, key)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, repository.ErrMediaNotFound
+		}
+		return nil, err
+	}
+
+	return &dto.MediaContent{
+		Content:     content,
+		ContentType: contentType,
+		Key:         key,
+		ModTime:     item.CreatedAt,
+	}, nil
+}
+
+// ensureGame проверяет, что игра есть и не в корзине; без withUnpublished неопубликованная
+// игра считается ненайденной.
+func (s *MediaService) ensureGame(ctx context.Context, gameID uuid.UUID, withUnpublished bool) error {
+	game, err := s.games.FindByID(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if !withUnpublished && !game.IsPublished() {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (s *MediaService) findCover(ctx context.Context, gameID uuid.UUID) (*model.GameMedia, error) {
+	items, err := s.media.FindByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if item.Kind == model.MediaKindCover {
+			return item, nil
+		}
+	}
+	return nil, repository.ErrMediaNotFound
+}
+
+// releaseBlobs удаляет из хранилища содержимое, на которое больше не ссылается ни одна запись.
+func (s *MediaService) releaseBlobs(ctx context.Context, keys []string) {
+	releaseUnusedBlobs(ctx, s.blobs, s.media.BlobInUse, "media", keys)
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/pagination"
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/constants"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// QueryError - ошибка в параметрах списка (страница, фильтры, сортировка).
+// Обработчики отдают ее клиенту как 400.
+type QueryError struct {
+	Message string
+}
+
+func (e *QueryError) Error() string {
+	return e.Message
+}
+
+func newQueryError(message string) error {
+	return &QueryError{Message: message}
+}
+
+// resolvePage проверяет параметры страницы и подставляет значения по умолчанию.
+func resolvePage(in dto.PageQueryDto) (page, pageSize int, err error) {
+	page = in.Page
+	if page == 0 {
+		page = 1
+	}
+	if page < 0 {
+		return 0, 0, newQueryError(constants.ErrValidationPage)
+	}
+
+	pageSize = in.PageSize
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize < 0 {
+		return 0, 0, newQueryError(constants.ErrValidationLimit)
+	}
+	if pageSize > maxPageSize {
+		return 0, 0, newQueryError(constants.ErrValidationMaxLimit)
+	}
+
+	return page, pageSize, nil
+}
+
+func newPaginatedResponse(items interface{}, total, page, pageSize int, nextCursor string) *dto.PaginatedResponse {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+	return &dto.PaginatedResponse{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+		NextCursor: nextCursor,
+	}
+}
+
+// resolveCursor расшифровывает after и проверяет, что курсор выдан для той же сортировки
+// и тех же фильтров (filter - их отпечаток filterHash).
+func resolveCursor(codec pagination.CursorCodec, after, sort, filter string) (*repository.Cursor, error) {
+	after = strings.TrimSpace(after)
+	if after == "" {
+		return nil, nil
+	}
+
+	cursor, err := codec.Decode(after)
+	if err != nil || cursor.Sort != sort {
+		return nil, newQueryError(constants.ErrValidationCursor)
+	}
+	// С другими фильтрами позиция курсора указывала бы в другую выборку
+	if cursor.Filter != filter {
+		return nil, newQueryError(constants.ErrValidationCursorFilter)
+	}
+	return &cursor, nil
+}
+
+// filterHash - отпечаток фильтров списка для курсора; parts - нормализованные значения фильтров
+// в фиксированном порядке.
+func filterHash(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:12])
+}
+
+// trimPage отбрасывает дополнительно запрошенный элемент, по которому видно,
+// что за страницей есть еще записи.
+func trimPage[T any](items []T, pageSize int) ([]T, bool) {
+	if len(items) > pageSize {
+		return items[:pageSize], true
+	}
+	return items, false
+}
+
+// sortToken - каноническая запись сортировки ("field" / "-field"), она же хранится в курсоре.
+func sortToken(field string, desc bool) string {
+	if desc {
+		return "-" + field
+	}
+	return field
+}
+
+// parseSort разбирает "field" / "-field"; пустая строка дает значение по умолчанию.
+func parseSort(raw, defaultField string, defaultDesc bool, allowed ...string) (field string, desc bool, err error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return defaultField, defaultDesc, nil
+	}
+
+	desc = strings.HasPrefix(raw, "-")
+	field = strings.TrimPrefix(raw, "-")
+	for _, a := range allowed {
+		if field == a {
+			return field, desc, nil
+		}
+	}
+	return "", false, newQueryError(constants.ErrValidationSort)
+}
+
+// parseDateFilter принимает YYYY-MM-DD или RFC3339. Для верхней границы
+// дата без времени означает конец дня.
+func parseDateFilter(raw string, endOfDay bool) (*time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, newQueryError(constants.ErrValidationDateFilter)
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &t, nil
+}
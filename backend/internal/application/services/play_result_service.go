@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/abstraction/signing"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// resultClockSkew - насколько метка времени запроса с результатом может расходиться с часами
+// сервера; вместе с nonce не дает повторить перехваченный запрос позже
+const resultClockSkew = 5 * time.Minute
+
+var resultNoncePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{16,128}$`)
+
+// PlayResultService принимает результаты попыток от игр. Запрос подписывается ключом,
+// выданным игре при запуске (AttemptService.LaunchAttempt):
+//
+//	X-Result-Signature = hex(HMAC-SHA256(resultKey, timestamp + "\n" + nonce + "\n" + body))
+type PlayResultService struct {
+	attempts     repository.AttemptRepository
+	tasks        repository.TaskRepository
+	results      repository.PlayResultRepository
+	signer       signing.RequestSigner
+	statsCache   *UserStatsCache
+	resultMapper *mapper.PlayResultMapper
+}
+
+// NewPlayResultService создает сервис; statsCache - кэш PlayStatsService, из которого
+// устаревает статистика пользователя, приславшего результат.
+func NewPlayResultService(attempts repository.AttemptRepository,
+	tasks repository.TaskRepository,
+	results repository.PlayResultRepository,
+	signer signing.RequestSigner,
+	statsCache *UserStatsCache) *PlayResultService {
+
+	return &PlayResultService{
+		attempts:     attempts,
+		tasks:        tasks,
+		results:      results,
+		signer:       signer,
+		statsCache:   statsCache,
+		resultMapper: mapper.NewPlayResultMapper(),
+	}
+}
+
+// SubmitResult проверяет подпись запроса и сохраняет результат попытки в игре gameID;
+// попытка переходит в completed или failed. Ошибки:
+//   - repository.ErrAttemptNotFound - токен результата неизвестен или выдан для другой игры;
+//   - signing.ErrInvalidSignature, signing.ErrExpired - подпись не совпадает или метка времени устарела;
+//   - repository.ErrNonceUsed - повтор уже принятого запроса;
+//   - model.ErrResultTokenExpired - результат опоздал, попытка брошена;
+//   - repository.ErrResultAlreadySubmitted - у попытки уже есть результат.
+func (s *PlayResultService) SubmitResult(ctx context.Context,
+	gameID uuid.UUID, sig dto.ResultSignatureDto, body []byte) (*dto.PlayResultDto, error) {
+
+	timestamp, err := strconv.ParseInt(sig.Timestamp, 10, 64)
+	if err != nil || sig.Token == "" || sig.Signature == "" || !resultNoncePattern.MatchString(sig.Nonce) {
+		return nil, newQueryError(constants.ErrValidationResultHeaders)
+	}
+
+	attempt, err := s.attempts.FindByResultTokenHash(ctx, hashToken(sig.Token))
+	if err != nil {
+		return nil, err
+	}
+	if attempt.GameID != gameID {
+		return nil, repository.ErrAttemptNotFound
+	}
+
+	message := make([]byte, 0, len(sig.Timestamp)+len(sig.Nonce)+len(body)+2)
+	message = append(message, sig.Timestamp+"\n"+sig.Nonce+"\n"...)
+	message = append(message, body...)
+	if err := s.signer.Verify(sig.Token, message, sig.Signature); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if skew := now.Sub(time.Unix(timestamp, 0)); skew > resultClockSkew || skew < -resultClockSkew {
+		return nil, signing.ErrExpired
+	}
+	if err := s.results.UseNonce(ctx, attempt.ID, sig.Nonce, now); err != nil {
+		return nil, err
+	}
+
+	if err := attempt.CanSubmitResult(now); err != nil {
+		return nil, s.rejectAttempt(ctx, attempt, err)
+	}
+
+	var in dto.SubmitResultDto
+	if err := json.Unmarshal(body, &in); err != nil || in.Score == nil || in.DurationMs == nil || in.Success == nil {
+		return nil, newQueryError(constants.ErrValidationResultBody)
+	}
+
+	// Условия прохождения берутся у задачи; удаленная задача засчитывается как свободная игра
+	var task *model.Task
+	if attempt.TaskID != uuid.Nil {
+		task, err = s.tasks.FindByID(ctx, attempt.TaskID)
+		if err != nil && !errors.Is(err, repository.ErrTaskNotFound) {
+			return nil, err
+		}
+	}
+
+	result, err := model.NewPlayResultWithValidate(attempt, task,
+		*in.Score, time.Duration(*in.DurationMs)*time.Millisecond, *in.Success, in.Details)
+	if err != nil {
+		return nil, playResultValidationError(err)
+	}
+	if err := attempt.Finish(result.AttemptStatus(), now); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.results.Submit(ctx, result, attempt)
+	if errors.Is(err, repository.ErrAttemptStatusChanged) {
+		// Попытку успели бросить параллельным запросом
+		return nil, model.ErrAttemptTransition
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.statsCache.invalidate(saved.UserID)
+	return s.resultMapper.ToPlayResultDto(saved), nil
+}
+
+// rejectAttempt объясняет, почему попытка не принимает результат. Завершенная результатом
+// попытка дает repository.ErrResultAlreadySubmitted; опоздавший результат бросает попытку.
+func (s *PlayResultService) rejectAttempt(ctx context.Context, attempt *model.Attempt, reason error) error {
+	if attempt.Status == model.AttemptCompleted || attempt.Status == model.AttemptFailed {
+		return repository.ErrResultAlreadySubmitted
+	}
+	if !errors.Is(reason, model.ErrResultTokenExpired) {
+		return reason
+	}
+
+	if err := attempt.Finish(model.AttemptAbandoned, time.Now()); err != nil {
+		return err
+	}
+	if _, err := s.attempts.Update(ctx, attempt, model.AttemptRunning); err != nil &&
+		!errors.Is(err, repository.ErrAttemptStatusChanged) {
+		return err
+	}
+	return model.ErrResultTokenExpired
+}
+
+func playResultValidationError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrPlayResultDuration):
+		return newQueryError(constants.ErrValidationResultDuration)
+	case errors.Is(err, model.ErrPlayResultDetails):
+		return newQueryError(constants.ErrValidationResultDetails)
+	default:
+		return err
+	}
+}
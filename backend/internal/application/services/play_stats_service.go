@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+
+	"github.com/google/uuid"
+)
+
+// PlayStatsService - статистика результатов пользователя для экрана прогресса. Агрегаты
+// считает хранилище, готовый ответ кэшируется на cacheTTL; новый результат пользователя
+// сбрасывает его кэш (PlayResultService), остальные изменения видны по истечении срока.
+type PlayStatsService struct {
+	users       repository.UserRepository
+	results     repository.PlayResultRepository
+	cache       *UserStatsCache
+	cacheTTL    time.Duration
+	statsMapper *mapper.PlayStatsMapper
+}
+
+func NewPlayStatsService(users repository.UserRepository,
+	results repository.PlayResultRepository,
+	statsCache *UserStatsCache, cacheTTL time.Duration) *PlayStatsService {
+
+	return &PlayStatsService{
+		users:       users,
+		results:     results,
+		cache:       statsCache,
+		cacheTTL:    cacheTTL,
+		statsMapper: mapper.NewPlayStatsMapper(),
+	}
+}
+
+// GetUserStats возвращает статистику пользователя userID; repository.ErrUserNotFound, если его нет.
+func (s *PlayStatsService) GetUserStats(ctx context.Context, userID uuid.UUID) (*dto.UserStatsDto, error) {
+	// Поколение берется до чтения хранилища: если результат придет во время подсчета,
+	// посчитанное ляжет под устаревший ключ
+	generation := s.cache.generation(userID)
+	if cached, ok := s.cache.get(userID, generation); ok {
+		return cached, nil
+	}
+
+	if _, err := s.users.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	games, err := s.results.FindGameStatsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	genres, err := s.results.FindGenreStatsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.UserStatsDto{
+		UserID:      userID,
+		Games:       make([]*dto.GameStatsDto, 0, len(games)),
+		Genres:      make([]*dto.GenreStatsDto, 0, len(genres)),
+		GeneratedAt: time.Now().UTC(),
+	}
+	for _, stats := range games {
+		res.Totals.GamesPlayed++
+		res.Totals.Plays += stats.Plays
+		res.Totals.Completions += stats.Completions
+		res.Games = append(res.Games, s.statsMapper.ToGameStatsDto(stats))
+	}
+	for _, stats := range genres {
+		res.Genres = append(res.Genres, s.statsMapper.ToGenreStatsDto(stats))
+	}
+
+	s.cache.set(userID, generation, res, s.cacheTTL)
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/aggregate"
+
+	"github.com/google/uuid"
+)
+
+type RatingService struct {
+	games        repository.GameRepository
+	ratings      repository.UserRatingRepository
+	ratingMapper *mapper.RatingMapper
+}
+
+func NewRatingService(games repository.GameRepository, ratings repository.UserRatingRepository) *RatingService {
+	return &RatingService{
+		games:        games,
+		ratings:      ratings,
+		ratingMapper: mapper.NewRatingMapper(),
+	}
+}
+
+func (s *RatingService) RateGame(ctx context.Context, userID, gameID uuid.UUID, in dto.CreateRatingDto) (*dto.RatingDto, error) {
+	agg, err := s.loadAggregate(ctx, userID, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := agg.AddRating(userID, in.Rating); err != nil {
+		return nil, err
+	}
+
+	created, err := s.ratings.Create(ctx, agg.FindRatingByUser(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ratingMapper.ToRatingDto(created), nil
+}
+
+func (s *RatingService) UpdateRating(ctx context.Context, userID, gameID uuid.UUID, in dto.UpdateRatingDto) (*dto.RatingDto, error) {
+	agg, err := s.loadAggregate(ctx, userID, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := agg.UpdateRating(userID, in.Rating); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.ratings.Update(ctx, agg.FindRatingByUser(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ratingMapper.ToRatingDto(updated), nil
+}
+
+func (s *RatingService) DeleteRating(ctx context.Context, userID, gameID uuid.UUID) error {
+	agg, err := s.loadAggregate(ctx, userID, gameID)
+	if err != nil {
+		return err
+	}
+
+	removed, err := agg.RemoveRating(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.ratings.Delete(ctx, removed.ID)
+}
+
+func (s *RatingService) loadAggregate(ctx context.Context, userID, gameID uuid.UUID) (*aggregate.GameDetailsAggregate, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New(constants.ErrUnauthorized)
+	}
+	if gameID == uuid.Nil {
+		return nil, errors.New(constants.ErrGameIDRequired)
+	}
+
+	game, err := s.games.FindByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	// Неопубликованная игра для пользователей не существует
+	if !game.IsPublished() {
+		return nil, repository.ErrNotFound
+	}
+
+	ratings, err := s.ratings.FindByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return aggregate.NewGameDetailsAggregate(game, nil, ratings), nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/domain/aggregate"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+	"example/web-service-gin/internal/infrastructure/persistence/inmemory"
+
+	"github.com/google/uuid"
+)
+
+func newTestRatingService(t *testing.T, status string) (*RatingService, *model.Game) {
+	t.Helper()
+	store := data.New()
+	game := &model.Game{
+		ID:          uuid.New(),
+		Title:       "Tetris",
+		ReleaseDate: time.Now().UTC(),
+		GenreID:     uuid.New(),
+		Status:      status,
+	}
+	if _, err := inmemory.NewGameRepository(store).Create(context.Background(), game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+	return NewRatingService(inmemory.NewGameRepository(store), inmemory.NewUserRatingRepository(store)), game
+}
+
+func TestRatingService_RateUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	svc, game := newTestRatingService(t, model.GameStatusPublished)
+	userID := uuid.New()
+
+	created, err := svc.RateGame(ctx, userID, game.ID, dto.CreateRatingDto{Rating: 4})
+	if err != nil {
+		t.Fatalf("RateGame: %v", err)
+	}
+	if created.Rating != 4 {
+		t.Fatalf("expected rating 4, got %d", created.Rating)
+	}
+
+	// Одна оценка на пользователя
+	if _, err := svc.RateGame(ctx, userID, game.ID, dto.CreateRatingDto{Rating: 5}); !errors.Is(err, aggregate.ErrRatingAlreadyExists) {
+		t.Fatalf("expected ErrRatingAlreadyExists, got %v", err)
+	}
+
+	updated, err := svc.UpdateRating(ctx, userID, game.ID, dto.UpdateRatingDto{Rating: 2})
+	if err != nil {
+		t.Fatalf("UpdateRating: %v", err)
+	}
+	if updated.Rating != 2 {
+		t.Fatalf("expected rating 2, got %d", updated.Rating)
+	}
+
+	if err := svc.DeleteRating(ctx, userID, game.ID); err != nil {
+		t.Fatalf("DeleteRating: %v", err)
+	}
+	if err := svc.DeleteRating(ctx, userID, game.ID); !errors.Is(err, aggregate.ErrRatingNotFound) {
+		t.Fatalf("expected ErrRatingNotFound after delete, got %v", err)
+	}
+	if _, err := svc.UpdateRating(ctx, userID, game.ID, dto.UpdateRatingDto{Rating: 3}); !errors.Is(err, aggregate.ErrRatingNotFound) {
+		t.Fatalf("expected ErrRatingNotFound for update without rating, got %v", err)
+	}
+}
+
+func TestRatingService_RatingOutOfRange(t *testing.T) {
+	ctx := context.Background()
+	svc, game := newTestRatingService(t, model.GameStatusPublished)
+	userID := uuid.New()
+
+	for _, rating := range []int{0, 6} {
+		if _, err := svc.RateGame(ctx, userID, game.ID, dto.CreateRatingDto{Rating: rating}); !errors.Is(err, aggregate.ErrRatingOutOfRange) {
+			t.Fatalf("RateGame %d: expected ErrRatingOutOfRange, got %v", rating, err)
+		}
+	}
+
+	if _, err := svc.RateGame(ctx, userID, game.ID, dto.CreateRatingDto{Rating: 3}); err != nil {
+		t.Fatalf("RateGame: %v", err)
+	}
+	// UpdateRating проверяет границы так же, как AddRating
+	for _, rating := range []int{-1, 0, 6, 100} {
+		if _, err := svc.UpdateRating(ctx, userID, game.ID, dto.UpdateRatingDto{Rating: rating}); !errors.Is(err, aggregate.ErrRatingOutOfRange) {
+			t.Fatalf("UpdateRating %d: expected ErrRatingOutOfRange, got %v", rating, err)
+		}
+	}
+}
+
+func TestRatingService_UnpublishedGameNotFound(t *testing.T) {
+	svc, game := newTestRatingService(t, model.GameStatusDraft)
+
+	_, err := svc.RateGame(context.Background(), uuid.New(), game.ID, dto.CreateRatingDto{Rating: 4})
+	if !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for draft game, got %v", err)
+	}
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRecommendationsLimit = 10
+	// neutralRating - оценка, которая не тянет рекомендации ни к похожим играм, ни от них:
+	// похожие на игры с оценкой выше рекомендуются, с оценкой ниже - отодвигаются
+	neutralRating = 2.5
+	// maxBasedOn - сколько оцененных игр показывать как причину рекомендации
+	maxBasedOn = 3
+)
+
+// Источники рекомендаций
+const (
+	// RecommendationSourceSimilar - игра похожа на игры, которые пользователь оценил высоко
+	RecommendationSourceSimilar = "similar"
+	// RecommendationSourcePopular - игра из самых высоко оцененных; такими рекомендациями
+	// добирается список, когда похожих игр не хватает (например, пользователь еще ничего не оценил)
+	RecommendationSourcePopular = "popular"
+)
+
+// similarityParams - параметры пересчета похожести игр
+var similarityParams = model.SimilarityParams{
+	GenreWeight: 0.4,
+	Shrinkage:   5,
+	TopK:        50,
+}
+
+// RecommendationService - похожие игры и персональные рекомендации. Похожесть игр считается
+// фоновой задачей в таблицу GameSimilarityRepository, запросы только читают ее.
+type RecommendationService struct {
+	games        repository.GameRepository
+	ratings      repository.UserRatingRepository
+	similarities repository.GameSimilarityRepository
+	params       model.SimilarityParams
+	gameMapper   *mapper.GameMapper
+}
+
+func NewRecommendationService(games repository.GameRepository,
+	ratings repository.UserRatingRepository,
+	similarities repository.GameSimilarityRepository) *RecommendationService {
+
+	return &RecommendationService{
+		games:        games,
+		ratings:      ratings,
+		similarities: similarities,
+		params:       similarityParams,
+		gameMapper:   mapper.NewGameMapper(),
+	}
+}
+
+// RebuildSimilarities пересчитывает похожесть всех активных игр по жанрам и оценкам
+// и возвращает число сохраненных пар.
+func (s *RecommendationService) RebuildSimilarities(ctx context.Context) (int, error) {
+	games, _, err := s.games.FindByQuery(ctx, repository.GameQuery{})
+	if err != nil {
+		return 0, err
+	}
+	ratings, err := s.ratings.FindAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	items := model.ComputeSimilarities(games, ratings, s.params)
+	if err := s.similarities.Replace(ctx, items, time.Now()); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// RunSimilarityJob пересчитывает похожесть игр сразу и затем каждые interval, пока не отменен ctx.
+func (s *RecommendationService) RunSimilarityJob(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.RebuildSimilarities(ctx); err != nil {
+			log.Printf("similarity rebuild failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetSimilarGames возвращает игры, похожие на gameID, самые похожие первыми.
+// Игры, которые пользователь userID уже оценил, пропускаются (uuid.Nil - анонимный запрос).
+func (s *RecommendationService) GetSimilarGames(ctx context.Context,
+	gameID uuid.UUID, in dto.RecommendationQueryDto, userID uuid.UUID, withUnpublished bool) ([]*dto.SimilarGameDto, error) {
+
+	limit, err := resolveRecommendationsLimit(in.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	game, err := s.games.FindByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !withUnpublished && !game.IsPublished() {
+		return nil, repository.ErrNotFound
+	}
+
+	rated := map[uuid.UUID]bool{}
+	if userID != uuid.Nil {
+		ratings, err := s.ratings.FindByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rating := range ratings {
+			rated[rating.GameID] = true
+		}
+	}
+
+	neighbours, err := s.similarities.FindByGameIDs(ctx, []uuid.UUID{gameID})
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*dto.SimilarGameDto{}
+	for _, item := range neighbours[gameID] {
+		if len(res) == limit {
+			break
+		}
+		if rated[item.SimilarID] {
+			continue
+		}
+		similar, err := s.visibleGame(ctx, item.SimilarID, withUnpublished)
+		if err != nil {
+			return nil, err
+		}
+		if similar == nil {
+			continue
+		}
+		res = append(res, &dto.SimilarGameDto{
+			Game:        s.gameMapper.ToGameDto(similar),
+			Score:       item.Score,
+			GenreScore:  item.GenreScore,
+			RatingScore: item.RatingScore,
+		})
+	}
+	return res, nil
+}
+
+// recommendationCandidate - неоцененная игра и вклад оцененных игр в ее рекомендацию
+type recommendationCandidate struct {
+	gameID        uuid.UUID
+	score         float64
+	contributions map[uuid.UUID]float64
+}
+
+// GetRecommendations подбирает пользователю опубликованные игры, которые он еще не оценил:
+// похожие на высоко оцененные им игры, а если их не хватает - самые высоко оцененные.
+func (s *RecommendationService) GetRecommendations(ctx context.Context,
+	userID uuid.UUID, in dto.RecommendationQueryDto) ([]*dto.RecommendedGameDto, error) {
+
+	limit, err := resolveRecommendationsLimit(in.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, err := s.ratings.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rated := make(map[uuid.UUID]bool, len(ratings))
+	ratedIDs := make([]uuid.UUID, 0, len(ratings))
+	for _, rating := range ratings {
+		rated[rating.GameID] = true
+		ratedIDs = append(ratedIDs, rating.GameID)
+	}
+
+	neighbours, err := s.similarities.FindByGameIDs(ctx, ratedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := map[uuid.UUID]*recommendationCandidate{}
+	for _, rating := range ratings {
+		weight := float64(rating.Rating) - neutralRating
+		for _, item := range neighbours[rating.GameID] {
+			if rated[item.SimilarID] {
+				continue
+			}
+			c, ok := candidates[item.SimilarID]
+			if !ok {
+				c = &recommendationCandidate{gameID: item.SimilarID, contributions: map[uuid.UUID]float64{}}
+				candidates[item.SimilarID] = c
+			}
+			c.score += item.Score * weight
+			c.contributions[rating.GameID] = item.Score * weight
+		}
+	}
+
+	ranked := make([]*recommendationCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.score > 0 {
+			ranked = append(ranked, c)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].gameID.String() < ranked[j].gameID.String()
+	})
+
+	res := []*dto.RecommendedGameDto{}
+	included := map[uuid.UUID]bool{}
+	for _, c := range ranked {
+		if len(res) == limit {
+			break
+		}
+		game, err := s.visibleGame(ctx, c.gameID, false)
+		if err != nil {
+			return nil, err
+		}
+		if game == nil {
+			continue
+		}
+		included[game.ID] = true
+		res = append(res, &dto.RecommendedGameDto{
+			Game:    s.gameMapper.ToGameDto(game),
+			Score:   c.score,
+			Source:  RecommendationSourceSimilar,
+			BasedOn: basedOn(c.contributions),
+		})
+	}
+
+	if len(res) < limit {
+		popular, _, err := s.games.FindByQuery(ctx, repository.GameQuery{
+			Status:   model.GameStatusPublished,
+			SortBy:   repository.GameSortRating,
+			SortDesc: true,
+			Limit:    limit + len(rated) + len(res),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, game := range popular {
+			if len(res) == limit {
+				break
+			}
+			if rated[game.ID] || included[game.ID] {
+				continue
+			}
+			res = append(res, &dto.RecommendedGameDto{
+				Game:   s.gameMapper.ToGameDto(game),
+				Source: RecommendationSourcePopular,
+			})
+		}
+	}
+	return res, nil
+}
+
+// visibleGame загружает игру; nil, если ее нет, она в корзине или, без withUnpublished, не опубликована.
+func (s *RecommendationService) visibleGame(ctx context.Context,
+	gameID uuid.UUID, withUnpublished bool) (*model.Game, error) {
+
+	game, err := s.games.FindByID(ctx, gameID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !withUnpublished && !game.IsPublished() {
+		return nil, nil
+	}
+	return game, nil
+}
+
+// basedOn - оцененные игры с наибольшим положительным вкладом в рекомендацию.
+func basedOn(contributions map[uuid.UUID]float64) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(contributions))
+	for id, contribution := range contributions {
+		if contribution > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if contributions[ids[i]] != contributions[ids[j]] {
+			return contributions[ids[i]] > contributions[ids[j]]
+		}
+		return ids[i].String() < ids[j].String()
+	})
+	if len(ids) > maxBasedOn {
+		ids = ids[:maxBasedOn]
+	}
+	return ids
+}
+
+func resolveRecommendationsLimit(limit int) (int, error) {
+	if limit == 0 {
+		return defaultRecommendationsLimit, nil
+	}
+	if limit < 0 {
+		return 0, newQueryError(constants.ErrValidationLimit)
+	}
+	if limit > maxPageSize {
+		return 0, newQueryError(constants.ErrValidationMaxLimit)
+	}
+	return limit, nil
+}
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// relationRoles - кем приходится игре другая сторона связи: для исходящей связи
+// (игра - продолжение, дополнение, переиздание) и для входящей
+var relationRoles = map[string]struct{ outgoing, incoming string }{
+	model.RelationSequel:   {outgoing: "prequel", incoming: "sequel"},
+	model.RelationDLC:      {outgoing: "base", incoming: "dlc"},
+	model.RelationRemaster: {outgoing: "original", incoming: "remaster"},
+}
+
+// RelationService - связи между играми (продолжения, дополнения, переиздания) и серии игр.
+// Неопубликованные игры в ответах видны только при withUnpublished.
+type RelationService struct {
+	games          repository.GameRepository
+	relations      repository.GameRelationRepository
+	series         repository.SeriesRepository
+	tx             repository.Transactor
+	relationMapper *mapper.RelationMapper
+	gameMapper     *mapper.GameMapper
+}
+
+func NewRelationService(games repository.GameRepository,
+	relations repository.GameRelationRepository,
+	series repository.SeriesRepository,
+	tx repository.Transactor) *RelationService {
+
+	return &RelationService{
+		games:          games,
+		relations:      relations,
+		series:         series,
+		tx:             tx,
+		relationMapper: mapper.NewRelationMapper(),
+		gameMapper:     mapper.NewGameMapper(),
+	}
+}
+
+// SetRelation делает игру gameID продолжением, дополнением или переизданием (relationType)
+// игры из in, заменяя прежнюю связь того же типа. Связь, замыкающая цикл, отклоняется.
+func (s *RelationService) SetRelation(ctx context.Context,
+	gameID uuid.UUID, relationType string, in dto.SetGameRelationDto) (*dto.GameRelationDto, error) {
+
+	relationType, err := parseRelationType(relationType)
+	if err != nil {
+		return nil, err
+	}
+	relation, err := model.NewGameRelationWithValidate(gameID, in.RelatedGameID, relationType)
+	if err != nil {
+		if errors.Is(err, model.ErrSelfRelation) {
+			return nil, newQueryError(constants.ErrValidationSelfRelation)
+		}
+		return nil, newQueryError(constants.ErrInvalidData)
+	}
+
+	if err := s.ensureGame(ctx, gameID); err != nil {
+		return nil, err
+	}
+	exists, err := s.games.Exists(ctx, relation.RelatedID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, newQueryError(constants.ErrRelatedGameNotFound)
+	}
+
+	// Проверка цикла и запись в одной транзакции: иначе встречные связи A->B и B->A,
+	// сохраняемые параллельно, прошли бы проверку обе
+	var saved *model.GameRelation
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		// Цикл может замкнуть только путь от связанной игры, поэтому нужны лишь достижимые из нее связи
+		reachable, err := s.relations.FindReachable(ctx, relation.RelatedID)
+		if err != nil {
+			return err
+		}
+		if err := model.CheckRelationCycle(reachable, relation); err != nil {
+			return err
+		}
+		saved, err = s.relations.Set(ctx, relation)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.relationMapper.ToGameRelationDto(saved), nil
+}
+
+// DeleteRelation удаляет связь игры типа relationType.
+func (s *RelationService) DeleteRelation(ctx context.Context, gameID uuid.UUID, relationType string) error {
+	relationType, err := parseRelationType(relationType)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureGame(ctx, gameID); err != nil {
+		return err
+	}
+	return s.relations.Delete(ctx, gameID, relationType)
+}
+
+// GetRelatedGames возвращает серию игры и игры, связанные с ней в обе стороны.
+func (s *RelationService) GetRelatedGames(ctx context.Context,
+	gameID uuid.UUID, withUnpublished bool) (*dto.RelatedGamesDto, error) {
+
+	game, err := s.games.FindByID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	if !withUnpublished && !game.IsPublished() {
+		return nil, repository.ErrNotFound
+	}
+
+	relations, err := s.relations.FindByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.RelatedGamesDto{Relations: []*dto.RelatedGameDto{}}
+	for _, relation := range relations {
+		roles := relationRoles[relation.Type]
+		otherID, role := relation.RelatedID, roles.outgoing
+		if relation.RelatedID == gameID {
+			otherID, role = relation.GameID, roles.incoming
+		}
+
+		other, err := s.visibleGames(ctx, []uuid.UUID{otherID}, withUnpublished)
+		if err != nil {
+			return nil, err
+		}
+		if len(other) == 0 {
+			continue
+		}
+		res.Relations = append(res.Relations, &dto.RelatedGameDto{
+			Relation: role,
+			Game:     s.gameMapper.ToGameDto(other[0]),
+		})
+	}
+
+	series, err := s.series.FindByGameID(ctx, gameID)
+	if err != nil && !errors.Is(err, repository.ErrSeriesNotFound) {
+		return nil, err
+	}
+	if series != nil {
+		if res.Series, err = s.toSeriesDto(ctx, series, withUnpublished); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// ListSeries возвращает страницу серий по названию.
+func (s *RelationService) ListSeries(ctx context.Context,
+	in dto.SeriesListQueryDto, withUnpublished bool) (*dto.PaginatedResponse, error) {
+
+	page, pageSize, err := resolvePage(dto.PageQueryDto{Page: in.Page, PageSize: in.PageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.series.FindAll(ctx, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*dto.SeriesDto, len(items))
+	for i, series := range items {
+		if res[i], err = s.toSeriesDto(ctx, series, withUnpublished); err != nil {
+			return nil, err
+		}
+	}
+	return newPaginatedResponse(res, total, page, pageSize, ""), nil
+}
+
+// GetSeries возвращает серию с играми по порядку.
+func (s *RelationService) GetSeries(ctx context.Context,
+	seriesID uuid.UUID, withUnpublished bool) (*dto.SeriesDto, error) {
+
+	series, err := s.series.FindByID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toSeriesDto(ctx, series, withUnpublished)
+}
+
+// CreateSeries создает серию; игры из in.GameIDs идут в ней в указанном порядке.
+func (s *RelationService) CreateSeries(ctx context.Context, in dto.SaveSeriesDto) (*dto.SeriesDto, error) {
+	series, err := model.NewSeriesWithValidate(in.Title, in.GameIDs)
+	if err != nil {
+		return nil, seriesValidationError(err)
+	}
+
+	created, err := s.series.Create(ctx, series)
+	if err != nil {
+		return nil, seriesSaveError(err)
+	}
+	return s.toSeriesDto(ctx, created, true)
+}
+
+// UpdateSeries заменяет название и состав серии.
+func (s *RelationService) UpdateSeries(ctx context.Context,
+	seriesID uuid.UUID, in dto.SaveSeriesDto) (*dto.SeriesDto, error) {
+
+	series, err := s.series.FindByID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if err := series.UpdateWithValidate(in.Title, in.GameIDs); err != nil {
+		return nil, seriesValidationError(err)
+	}
+
+	updated, err := s.series.Update(ctx, series)
+	if err != nil {
+		return nil, seriesSaveError(err)
+	}
+	return s.toSeriesDto(ctx, updated, true)
+}
+
+// DeleteSeries удаляет серию, игры остаются в каталоге.
+func (s *RelationService) DeleteSeries(ctx context.Context, seriesID uuid.UUID) error {
+	return s.series.Delete(ctx, seriesID)
+}
+
+// toSeriesDto собирает серию с видимыми играми в порядке серии.
+func (s *RelationService) toSeriesDto(ctx context.Context,
+	series *model.Series, withUnpublished bool) (*dto.SeriesDto, error) {
+
+	games, err := s.visibleGames(ctx, series.GameIDs, withUnpublished)
+	if err != nil {
+		return nil, err
+	}
+	return s.relationMapper.ToSeriesDto(series, games), nil
+}
+
+// visibleGames загружает игры ids в том же порядке, пропуская игры из корзины
+// и, без withUnpublished, неопубликованные.
+func (s *RelationService) visibleGames(ctx context.Context,
+	ids []uuid.UUID, withUnpublished bool) ([]*model.Game, error) {
+
+	games := make([]*model.Game, 0, len(ids))
+	for _, id := range ids {
+		game, err := s.games.FindByID(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if withUnpublished || game.IsPublished() {
+			games = append(games, game)
+		}
+	}
+	return games, nil
+}
+
+func (s *RelationService) ensureGame(ctx context.Context, gameID uuid.UUID) error {
+	exists, err := s.games.Exists(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func parseRelationType(relationType string) (string, error) {
+	relationType = strings.ToLower(strings.TrimSpace(relationType))
+	if !model.IsRelationType(relationType) {
+		return "", newQueryError(constants.ErrValidationRelationType)
+	}
+	return relationType, nil
+}
+
+// seriesValidationError заменяет ошибку проверки серии из model на ошибку с текстом для клиента.
+func seriesValidationError(err error) error {
+	if errors.Is(err, model.ErrSeriesDuplicateGame) {
+		return newQueryError(constants.ErrValidationSeriesGames)
+	}
+	return newQueryError(constants.ErrValidationSeriesTitle)
+}
+
+// seriesSaveError - ошибка сохранения серии: ErrNotFound репозитория означает,
+// что какой-то игры из состава нет; остальные ошибки возвращаются без изменений.
+func seriesSaveError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return newQueryError(constants.ErrSeriesGameNotFound)
+	}
+	return err
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// revisionLog - история изменений сущностей одного типа; общая для сервисов игр и жанров.
+type revisionLog struct {
+	repo           repository.RevisionRepository
+	entityType     string
+	revisionMapper *mapper.RevisionMapper
+}
+
+func newRevisionLog(repo repository.RevisionRepository, entityType string) *revisionLog {
+	return &revisionLog{
+		repo:           repo,
+		entityType:     entityType,
+		revisionMapper: mapper.NewRevisionMapper(),
+	}
+}
+
+// record сохраняет состояние сущности после действия пользователя actorID.
+// revertedFrom - номер ревизии, к которой откатились (0 для остальных действий).
+func (l *revisionLog) record(ctx context.Context,
+	entityID uuid.UUID, action string, snapshot any, actorID uuid.UUID, revertedFrom int) error {
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("encode revision snapshot: %w", err)
+	}
+
+	_, err = l.repo.Append(ctx, &model.Revision{
+		EntityType:   l.entityType,
+		EntityID:     entityID,
+		Action:       action,
+		Snapshot:     data,
+		ActorID:      actorID,
+		RevertedFrom: revertedFrom,
+	})
+	if err != nil {
+		return fmt.Errorf("record revision: %w", err)
+	}
+	return nil
+}
+
+// countCreated считает сущности, созданные actorID начиная с since.
+func (l *revisionLog) countCreated(ctx context.Context, actorID uuid.UUID, since time.Time) (int, error) {
+	return l.repo.CountByActor(ctx, l.entityType, model.RevisionActionCreate, actorID, since)
+}
+
+// list возвращает страницу истории, последние ревизии первыми.
+func (l *revisionLog) list(ctx context.Context,
+	entityID uuid.UUID, in dto.RevisionListQueryDto) (*dto.PaginatedResponse, error) {
+
+	page, pageSize, err := resolvePage(dto.PageQueryDto{Page: in.Page, PageSize: in.PageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, total, err := l.repo.FindByEntity(ctx, l.entityType, entityID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPaginatedResponse(l.revisionMapper.ToRevisionDtoSlice(revisions), total, page, pageSize, ""), nil
+}
+
+// diff сравнивает две ревизии по полям. Без to берется последняя ревизия, без from - предыдущая перед to.
+func (l *revisionLog) diff(ctx context.Context,
+	entityID uuid.UUID, in dto.RevisionDiffQueryDto) (*dto.RevisionDiffDto, error) {
+
+	if in.From < 0 || in.To < 0 {
+		return nil, newQueryError(constants.ErrValidationRevision)
+	}
+
+	to := in.To
+	if to == 0 {
+		latest, _, err := l.repo.FindByEntity(ctx, l.entityType, entityID, 1, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(latest) == 0 {
+			return nil, repository.ErrRevisionNotFound
+		}
+		to = latest[0].Number
+	}
+
+	from := in.From
+	if from == 0 {
+		from = to - 1
+	}
+	if from < 1 || from == to {
+		return nil, newQueryError(constants.ErrValidationDiffRange)
+	}
+
+	fromRevision, err := l.repo.FindByNumber(ctx, l.entityType, entityID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := l.repo.FindByNumber(ctx, l.entityType, entityID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := model.DiffSnapshots(fromRevision.Snapshot, toRevision.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.revisionMapper.ToRevisionDiffDto(from, to, changes), nil
+}
+
+// snapshot декодирует состояние сущности из ревизии number в dst.
+func (l *revisionLog) snapshot(ctx context.Context, entityID uuid.UUID, number int, dst any) error {
+	if number < 1 {
+		return newQueryError(constants.ErrValidationRevision)
+	}
+
+	revision, err := l.repo.FindByNumber(ctx, l.entityType, entityID, number)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(revision.Snapshot, dst); err != nil {
+		return fmt.Errorf("decode revision snapshot: %w", err)
+	}
+	return nil
+}
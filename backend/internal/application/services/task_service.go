@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/mapper"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// TaskService - задачи в играх. Задачи неопубликованных игр видны только при withUnpublished.
+type TaskService struct {
+	games      repository.GameRepository
+	tasks      repository.TaskRepository
+	taskMapper *mapper.TaskMapper
+}
+
+func NewTaskService(games repository.GameRepository, tasks repository.TaskRepository) *TaskService {
+	return &TaskService{
+		games:      games,
+		tasks:      tasks,
+		taskMapper: mapper.NewTaskMapper(),
+	}
+}
+
+// ListTasks возвращает задачи игры по порядку.
+func (s *TaskService) ListTasks(ctx context.Context, gameID uuid.UUID, withUnpublished bool) ([]*dto.TaskDto, error) {
+	if err := s.ensureVisibleGame(ctx, gameID, withUnpublished); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.tasks.FindByGameID(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return s.taskMapper.ToTaskDtoSlice(tasks), nil
+}
+
+// GetTask возвращает задачу; задача неопубликованной игры без withUnpublished не находится.
+func (s *TaskService) GetTask(ctx context.Context, taskID uuid.UUID, withUnpublished bool) (*dto.TaskDto, error) {
+	task, err := s.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureVisibleGame(ctx, task.GameID, withUnpublished); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, repository.ErrTaskNotFound
+		}
+		return nil, err
+	}
+	return s.taskMapper.ToTaskDto(task), nil
+}
+
+// CreateTask добавляет задачу в игру gameID; без номера задача встает в конец списка.
+func (s *TaskService) CreateTask(ctx context.Context, gameID uuid.UUID, in dto.SaveTaskDto) (*dto.TaskDto, error) {
+	order, err := resolveTaskOrder(in.Order, 0)
+	if err != nil {
+		return nil, err
+	}
+	task, err := model.NewTaskWithValidate(gameID, in.Title, in.Instructions, in.Difficulty,
+		in.LaunchParams, s.taskMapper.ToPassCriteria(in.PassCriteria), order)
+	if err != nil {
+		return nil, taskValidationError(err)
+	}
+
+	created, err := s.tasks.Create(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	return s.taskMapper.ToTaskDto(created), nil
+}
+
+// UpdateTask заменяет содержимое задачи; без номера задача остается на своем месте.
+func (s *TaskService) UpdateTask(ctx context.Context, taskID uuid.UUID, in dto.SaveTaskDto) (*dto.TaskDto, error) {
+	task, err := s.tasks.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := resolveTaskOrder(in.Order, task.Order)
+	if err != nil {
+		return nil, err
+	}
+	err = task.UpdateWithValidate(in.Title, in.Instructions, in.Difficulty,
+		in.LaunchParams, s.taskMapper.ToPassCriteria(in.PassCriteria), order)
+	if err != nil {
+		return nil, taskValidationError(err)
+	}
+
+	updated, err := s.tasks.Update(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	return s.taskMapper.ToTaskDto(updated), nil
+}
+
+// DeleteTask удаляет задачу.
+func (s *TaskService) DeleteTask(ctx context.Context, taskID uuid.UUID) error {
+	return s.tasks.Delete(ctx, taskID)
+}
+
+func (s *TaskService) ensureVisibleGame(ctx context.Context, gameID uuid.UUID, withUnpublished bool) error {
+	game, err := s.games.FindByID(ctx, gameID)
+	if err != nil {
+		return err
+	}
+	if !withUnpublished && !game.IsPublished() {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// resolveTaskOrder - номер задачи из запроса или current, если номер не передан.
+func resolveTaskOrder(order *int, current int) (int, error) {
+	if order == nil {
+		return current, nil
+	}
+	if *order <= 0 {
+		return 0, newQueryError(constants.ErrValidationTaskOrder)
+	}
+	return *order, nil
+}
+
+// taskValidationError заменяет ошибку проверки задачи из model на ошибку с текстом для клиента.
+func taskValidationError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrTaskDifficulty):
+		return newQueryError(constants.ErrValidationTaskDifficulty)
+	case errors.Is(err, model.ErrTaskLaunchParams):
+		return newQueryError(constants.ErrValidationTaskLaunchParams)
+	case errors.Is(err, model.ErrTaskLaunchParamsReserved):
+		return newQueryError(constants.ErrValidationTaskParamNames)
+	case errors.Is(err, model.ErrTaskPassCriteria):
+		return newQueryError(constants.ErrValidationTaskCriteria)
+	case errors.Is(err, model.ErrTaskOrder):
+		return newQueryError(constants.ErrValidationTaskOrder)
+	default:
+		return newQueryError(constants.ErrValidationTaskTitle)
+	}
+}
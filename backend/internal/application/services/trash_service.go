@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/blob"
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/constants"
+)
+
+// Типы записей в корзине
+const (
+	TrashKindGame  = "game"
+	TrashKindGenre = "genre"
+	TrashKindUser  = "user"
+)
+
+// TrashService - корзина: просмотр мягко удаленных записей и их окончательное удаление.
+// Вместе с играми из хранилищ удаляются их изображения и архивы сборок.
+type TrashService struct {
+	games      repository.GameRepository
+	genres     repository.GenreRepository
+	users      repository.UserRepository
+	media      repository.GameMediaRepository
+	mediaBlobs blob.Store
+	builds     repository.GameBuildRepository
+	buildBlobs blob.Store
+	retention  time.Duration
+}
+
+// NewTrashService создает сервис; retention - сколько записи хранятся в корзине
+// до окончательного удаления (0 - автоматическая очистка выключена).
+func NewTrashService(games repository.GameRepository,
+	genres repository.GenreRepository,
+	users repository.UserRepository,
+	media repository.GameMediaRepository,
+	mediaBlobs blob.Store,
+	builds repository.GameBuildRepository,
+	buildBlobs blob.Store,
+	retention time.Duration) *TrashService {
+	return &TrashService{
+		games:      games,
+		genres:     genres,
+		users:      users,
+		media:      media,
+		mediaBlobs: mediaBlobs,
+		builds:     builds,
+		buildBlobs: buildBlobs,
+		retention:  retention,
+	}
+}
+
+// GetTrash возвращает страницу корзины, недавно удаленные записи первыми.
+func (s *TrashService) GetTrash(ctx context.Context, in dto.TrashQueryDto) (*dto.PaginatedResponse, error) {
+	page, pageSize, err := resolvePage(dto.PageQueryDto{Page: in.Page, PageSize: in.PageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	kind := strings.TrimSpace(in.Kind)
+	switch kind {
+	case "", TrashKindGame, TrashKindGenre, TrashKindUser:
+	default:
+		return nil, newQueryError(constants.ErrValidationTrashKind)
+	}
+
+	items := []*dto.TrashItemDto{}
+
+	if kind == "" || kind == TrashKindGame {
+		games, err := s.games.FindDeleted(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, game := range games {
+			items = append(items, &dto.TrashItemDto{Kind: TrashKindGame, ID: game.ID, Title: game.Title, DeletedAt: *game.DeletedAt})
+		}
+	}
+	if kind == "" || kind == TrashKindGenre {
+		genres, err := s.genres.FindDeleted(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, genre := range genres {
+			items = append(items, &dto.TrashItemDto{Kind: TrashKindGenre, ID: genre.ID, Title: genre.Title, DeletedAt: *genre.DeletedAt})
+		}
+	}
+	if kind == "" || kind == TrashKindUser {
+		users, err := s.users.FindDeleted(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			items = append(items, &dto.TrashItemDto{Kind: TrashKindUser, ID: user.ID, Title: user.Username, DeletedAt: *user.DeletedAt})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+
+	total := len(items)
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	return newPaginatedResponse(items[start:end], total, page, pageSize, ""), nil
+}
+
+// Purge окончательно удаляет записи, лежащие в корзине дольше olderThan
+// (0 - срок из настроек сервиса). Игры удаляются первыми, чтобы освободить их жанры; файлы игр,
+// на которые больше ничто не ссылается, удаляются из хранилищ.
+func (s *TrashService) Purge(ctx context.Context, olderThan time.Duration) (*dto.PurgeResultDto, error) {
+	if olderThan == 0 {
+		olderThan = s.retention
+	}
+	if olderThan <= 0 {
+		return nil, newQueryError(constants.ErrValidationRetention)
+	}
+
+	res := &dto.PurgeResultDto{Before: time.Now().UTC().Add(-olderThan)}
+
+	games, err := s.games.Purge(ctx, res.Before)
+	if err != nil {
+		return nil, err
+	}
+	res.Games = games.Count
+	releaseUnusedBlobs(ctx, s.mediaBlobs, s.media.BlobInUse, "media", games.MediaKeys)
+	releaseUnusedBlobs(ctx, s.buildBlobs, s.builds.BlobInUse, "build", games.BuildKeys)
+	if res.Genres, err = s.genres.Purge(ctx, res.Before); err != nil {
+		return nil, err
+	}
+	if res.Users, err = s.users.Purge(ctx, res.Before); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RunPurgeJob очищает корзину сразу и затем каждые interval, пока не отменен ctx.
+// Если срок хранения не задан, задача не запускается.
+func (s *TrashService) RunPurgeJob(ctx context.Context, interval time.Duration) {
+	if s.retention <= 0 || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := s.Purge(ctx, 0)
+		if err != nil {
+			log.Printf("trash purge failed: %v", err)
+		} else if res.Games+res.Genres+res.Users > 0 {
+			log.Printf("trash purge: games=%d genres=%d users=%d (deleted before %s)",
+				res.Games, res.Genres, res.Users, res.Before.Format(time.RFC3339))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,13 +3,14 @@ package services
 import (
 	"context"
 	"errors"
-	"math"
 	"strings"
 
+	"example/web-service-gin/internal/application/abstraction/pagination"
 	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/application/dto"
 	"example/web-service-gin/internal/application/mapper"
 	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
 	specifictype "example/web-service-gin/internal/domain/specific_type"
 
 	"github.com/google/uuid"
@@ -17,12 +18,14 @@ import (
 
 type UserService struct {
 	repo       repository.UserRepository
+	cursors    pagination.CursorCodec
 	userMapper *mapper.UserMapper
 }
 
-func NewUserService(repo repository.UserRepository) *UserService {
+func NewUserService(repo repository.UserRepository, cursors pagination.CursorCodec) *UserService {
 	return &UserService{
 		repo:       repo,
+		cursors:    cursors,
 		userMapper: mapper.NewUserMapper(),
 	}
 }
@@ -56,15 +59,56 @@ func (s *UserService) GetUserByID(ctx context.Context, id uuid.UUID) (*dto.UserD
 	return s.userMapper.ToUserDto(u), nil
 }
 
-func (s *UserService) GetAllUsers(ctx context.Context) ([]*dto.UserDto, error) {
-	users, err := s.repo.FindAll(ctx, math.MaxInt, 0)
+func (s *UserService) GetAllUsers(ctx context.Context, in dto.UserListQueryDto) (*dto.PaginatedResponse, error) {
+	page, pageSize, err := resolvePage(in.PageQueryDto)
 	if err != nil {
 		return nil, err
 	}
-	return s.userMapper.ToUserDtoSlice(users), nil
+
+	_, sortDesc, err := parseSort(in.Sort, "username", false, "username")
+	if err != nil {
+		return nil, err
+	}
+
+	role := specifictype.UserRole(in.UserRole)
+	if role != "" && role != specifictype.RoleUser && role != specifictype.RoleAdmin {
+		return nil, newQueryError(constants.ErrUserRoleInvalid)
+	}
+
+	sort := sortToken("username", sortDesc)
+	filter := filterHash(strings.TrimSpace(in.Q), string(role))
+	after, err := resolveCursor(s.cursors, in.After, sort, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	users, total, err := s.repo.FindByQuery(ctx, repository.UserQuery{
+		Limit:    pageSize + 1,
+		Offset:   (page - 1) * pageSize,
+		After:    after,
+		Search:   in.Q,
+		Role:     role,
+		SortDesc: sortDesc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users, hasMore := trimPage(users, pageSize)
+	nextCursor := ""
+	if hasMore {
+		last := users[len(users)-1]
+		nextCursor, err = s.cursors.Encode(repository.Cursor{Sort: sort, Filter: filter, Key: last.Username, ID: last.ID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newPaginatedResponse(s.userMapper.ToUserDtoSlice(users), total, page, pageSize, nextCursor), nil
 }
 
-func (s *UserService) UpdateUser(ctx context.Context, in dto.UpdateUserDto) (*dto.UserDto, error) {
+// UpdateUser сохраняет пользователя, если его версия равна version (0 - любая версия).
+func (s *UserService) UpdateUser(ctx context.Context, in dto.UpdateUserDto, version int) (*dto.UserDto, error) {
 	if in.ID == uuid.Nil {
 		return nil, errors.New(constants.ErrUserIDRequired)
 	}
@@ -77,6 +121,40 @@ func (s *UserService) UpdateUser(ctx context.Context, in dto.UpdateUserDto) (*dt
 		return nil, err
 	}
 
+	if err := checkVersion(version, existing.Version); err != nil {
+		return nil, err
+	}
+
+	return s.applyUserUpdate(ctx, existing, in)
+}
+
+// PatchUser применяет JSON Merge Patch к пользователю; пароль меняется, только если он есть в патче.
+func (s *UserService) PatchUser(ctx context.Context, id uuid.UUID, patch dto.PatchUserDto, version int) (*dto.UserDto, error) {
+	if id == uuid.Nil {
+		return nil, errors.New(constants.ErrUserIDRequired)
+	}
+
+	existing, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(version, existing.Version); err != nil {
+		return nil, err
+	}
+
+	merged, err := s.userMapper.MergeUserPatch(existing, &patch)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateUserData(merged.Username, merged.Password, merged.UserRole); err != nil {
+		return nil, err
+	}
+
+	return s.applyUserUpdate(ctx, existing, merged)
+}
+
+func (s *UserService) applyUserUpdate(ctx context.Context, existing *model.User, in dto.UpdateUserDto) (*dto.UserDto, error) {
 	if err := s.userMapper.FromUpdateUserDto(existing, &in); err != nil {
 		return nil, err
 	}
@@ -88,18 +166,30 @@ func (s *UserService) UpdateUser(ctx context.Context, in dto.UpdateUserDto) (*dt
 	return s.userMapper.ToUserDto(updated), nil
 }
 
-func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+// DeleteUser перемещает пользователя в корзину, если его версия равна version (0 - любая версия).
+func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID, version int) error {
 	if id == uuid.Nil {
 		return errors.New(constants.ErrUserIDRequired)
 	}
-	exists, err := s.repo.Exists(ctx, id)
+	existing, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if !exists {
-		return repository.ErrUserNotFound
+	if err := checkVersion(version, existing.Version); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id, existing.Version)
+}
+
+// RestoreUser возвращает пользователя из корзины.
+func (s *UserService) RestoreUser(ctx context.Context, id uuid.UUID) (*dto.UserDto, error) {
+	if id == uuid.Nil {
+		return nil, errors.New(constants.ErrUserIDRequired)
+	}
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
 	}
-	return s.repo.Delete(ctx, id)
+	return s.GetUserByID(ctx, id)
 }
 
 func (s *UserService) Authenticate(ctx context.Context, username, password string) (bool, error) {
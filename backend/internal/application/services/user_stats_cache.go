@@ -0,0 +1,60 @@
+package services
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/cache"
+	"example/web-service-gin/internal/application/dto"
+
+	"github.com/google/uuid"
+)
+
+// UserStatsCache - кэш статистики пользователей, общий для PlayStatsService и PlayResultService.
+// Ключ включает поколение пользователя, а новый результат увеличивает его: статистика, которую
+// начали считать до результата, ложится под устаревший ключ и больше не читается.
+type UserStatsCache struct {
+	cache cache.Cache
+
+	mu          sync.Mutex
+	generations map[uuid.UUID]uint64
+}
+
+func NewUserStatsCache(c cache.Cache) *UserStatsCache {
+	return &UserStatsCache{cache: c, generations: make(map[uuid.UUID]uint64)}
+}
+
+// generation - текущее поколение статистики пользователя; его нужно взять до чтения хранилища.
+func (c *UserStatsCache) generation(userID uuid.UUID) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generations[userID]
+}
+
+func (c *UserStatsCache) get(userID uuid.UUID, generation uint64) (*dto.UserStatsDto, bool) {
+	cached, ok := c.cache.Get(userStatsCacheKey(userID, generation))
+	if !ok {
+		return nil, false
+	}
+	return cached.(*dto.UserStatsDto), true
+}
+
+func (c *UserStatsCache) set(userID uuid.UUID, generation uint64, stats *dto.UserStatsDto, ttl time.Duration) {
+	c.cache.Set(userStatsCacheKey(userID, generation), stats, ttl)
+}
+
+// invalidate начинает новое поколение статистики пользователя; вызывается после сохранения результата.
+func (c *UserStatsCache) invalidate(userID uuid.UUID) {
+	c.mu.Lock()
+	generation := c.generations[userID]
+	c.generations[userID] = generation + 1
+	c.mu.Unlock()
+
+	c.cache.Delete(userStatsCacheKey(userID, generation))
+}
+
+// userStatsCacheKey - ключ кэша статистики пользователя в поколении generation
+func userStatsCacheKey(userID uuid.UUID, generation uint64) string {
+	return "user-stats:" + userID.String() + ":" + strconv.FormatUint(generation, 10)
+}
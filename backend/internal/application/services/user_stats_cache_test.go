@@ -0,0 +1,37 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/infrastructure/cache"
+
+	"github.com/google/uuid"
+)
+
+func TestUserStatsCache_InvalidateDuringComputation(t *testing.T) {
+	c := NewUserStatsCache(cache.NewMemoryCache())
+	userID := uuid.New()
+
+	// Подсчет начался до результата, а закончился после него
+	generation := c.generation(userID)
+	c.invalidate(userID)
+	c.set(userID, generation, &dto.UserStatsDto{UserID: userID}, time.Minute)
+
+	if _, ok := c.get(userID, c.generation(userID)); ok {
+		t.Fatalf("stats computed before invalidate must not be served")
+	}
+
+	fresh := &dto.UserStatsDto{UserID: userID, GeneratedAt: time.Now().UTC()}
+	c.set(userID, c.generation(userID), fresh, time.Minute)
+	if got, ok := c.get(userID, c.generation(userID)); !ok || got != fresh {
+		t.Fatalf("expected fresh stats, got %v, %v", got, ok)
+	}
+
+	// Поколения у пользователей независимы
+	other := uuid.New()
+	if c.generation(other) != 0 {
+		t.Fatalf("expected zero generation for other user, got %d", c.generation(other))
+	}
+}
@@ -0,0 +1,12 @@
+package services
+
+import "example/web-service-gin/internal/application/abstraction/repository"
+
+// checkVersion сверяет ожидаемую клиентом версию (If-Match) с прочитанной записью;
+// 0 - подходит любая версия. Атомарно версию еще раз проверяет репозиторий при записи.
+func checkVersion(expected, current int) error {
+	if expected != 0 && expected != current {
+		return repository.ErrVersionConflict
+	}
+	return nil
+}
@@ -1,30 +1,87 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
+	// DevMode - режим разработки: без собственных ключей подписи они выводятся из JWTSecret
+	DevMode    bool
 	DBPath     string
 	JWTSecret  string
 	JWTIssuer  string
 	JWTTTLHours int
+	// CursorSecret - ключ подписи курсоров пагинации (в режиме разработки по умолчанию совпадает с JWTSecret)
+	CursorSecret string
+	// TrashRetentionDays - сколько дней удаленные записи хранятся в корзине (0 - не очищать автоматически)
+	TrashRetentionDays int
+	// TrashPurgeInterval - период фоновой очистки корзины
+	TrashPurgeInterval time.Duration
+	// MediaDir - каталог хранилища изображений игр
+	MediaDir string
+	// MediaMaxUploadMB - предельный размер загружаемого изображения в мегабайтах
+	MediaMaxUploadMB int
+	// BuildDir - каталог хранилища архивов сборок Unity
+	BuildDir string
+	// BuildMaxUploadMB - предельный размер архива сборки в мегабайтах
+	BuildMaxUploadMB int
+	// BuildURLSecret - ключ подписи ссылок на скачивание сборок (в режиме разработки по умолчанию совпадает с JWTSecret)
+	BuildURLSecret string
+	// BuildURLTTL - срок действия ссылки на скачивание сборки
+	BuildURLTTL time.Duration
+	// BuildSigningKey - seed ключа Ed25519 для подписи манифестов в base64;
+	// пустой - ключ выводится из JWTSecret (только в режиме разработки)
+	BuildSigningKey string
+	// Политика игр (model.GamePolicy): длина названия и описания в символах, на сколько дней
+	// вперед можно назначить релиз, разрешены ли релизы по выходным и сколько игр
+	// администратор может создать за сутки (0 - без ограничения)
+	GameMaxTitleLength       int
+	GameMaxDescriptionLength int
+	GameMaxReleaseAheadDays  int
+	GameAllowWeekendReleases bool
+	GameDailyCreateQuota     int
+	// PublishCheckInterval - самая долгая пауза планировщика публикаций между проверками;
+	// ближайшую запланированную публикацию он выполняет вовремя независимо от нее
+	PublishCheckInterval time.Duration
+	// SimilarityRebuildInterval - период фонового пересчета похожести игр для рекомендаций
+	SimilarityRebuildInterval time.Duration
+	// LaunchTokenTTL - за какое время игра должна предъявить одноразовый токен запуска попытки
+	LaunchTokenTTL time.Duration
+	// PublicBaseURL - внешний адрес API (например, https://api.example.com) для ссылок,
+	// которые передаются игре при запуске; пустой - ссылки относительные
+	PublicBaseURL string
+	// ResultSigningSecret - секрет, из которого выводятся ключи подписи результатов игр
+	// (в режиме разработки по умолчанию совпадает с JWTSecret)
+	ResultSigningSecret string
+	// ResultTokenTTL - сколько после запуска игры принимается ее результат
+	ResultTokenTTL time.Duration
+	// StatsCacheTTL - сколько хранится посчитанная статистика пользователя
+	StatsCacheTTL time.Duration
 }
 
 const defaultDBPath = "data/app.db"
 
-// NOTE: dev default secret. Override via JWT_SECRET in production.
+const defaultMediaDir = "data/media"
+
+const defaultBuildDir = "data/builds"
+
+// NOTE: dev default secret, used only with DEV_MODE=true. Set JWT_SECRET in production.
 const defaultJWTSecret = "game_task_lab_dev_secret_2026_change_me"
 
 func Load() Config {
+	devMode, _ := strconv.ParseBool(strings.TrimSpace(os.Getenv("DEV_MODE")))
+
 	dbPath := strings.TrimSpace(os.Getenv("DB_PATH"))
 	if dbPath == "" {
 		dbPath = defaultDBPath
 	}
 
 	secret := strings.TrimSpace(os.Getenv("JWT_SECRET"))
-	if secret == "" {
+	if secret == "" && devMode {
 		secret = defaultJWTSecret
 	}
 
@@ -35,11 +92,159 @@ func Load() Config {
 
 	ttlHours := 24
 
+	cursorSecret := strings.TrimSpace(os.Getenv("CURSOR_SECRET"))
+	if cursorSecret == "" && devMode {
+		cursorSecret = secret
+	}
+
+	retentionDays := 30
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("TRASH_RETENTION_DAYS"))); err == nil && v >= 0 {
+		retentionDays = v
+	}
+
+	purgeInterval := 24 * time.Hour
+	if v, err := time.ParseDuration(strings.TrimSpace(os.Getenv("TRASH_PURGE_INTERVAL"))); err == nil && v > 0 {
+		purgeInterval = v
+	}
+
+	mediaDir := strings.TrimSpace(os.Getenv("MEDIA_DIR"))
+	if mediaDir == "" {
+		mediaDir = defaultMediaDir
+	}
+
+	mediaMaxUploadMB := 10
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("MEDIA_MAX_UPLOAD_MB"))); err == nil && v > 0 {
+		mediaMaxUploadMB = v
+	}
+
+	buildDir := strings.TrimSpace(os.Getenv("BUILD_DIR"))
+	if buildDir == "" {
+		buildDir = defaultBuildDir
+	}
+
+	buildMaxUploadMB := 2048
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("BUILD_MAX_UPLOAD_MB"))); err == nil && v > 0 {
+		buildMaxUploadMB = v
+	}
+
+	buildURLSecret := strings.TrimSpace(os.Getenv("BUILD_URL_SECRET"))
+	if buildURLSecret == "" && devMode {
+		buildURLSecret = secret
+	}
+
+	buildURLTTL := 15 * time.Minute
+	if v, err := time.ParseDuration(strings.TrimSpace(os.Getenv("BUILD_URL_TTL"))); err == nil && v > 0 {
+		buildURLTTL = v
+	}
+
+	gameMaxTitleLength := 200
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("GAME_MAX_TITLE_LENGTH"))); err == nil && v > 0 {
+		gameMaxTitleLength = v
+	}
+
+	gameMaxDescriptionLength := 2000
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("GAME_MAX_DESCRIPTION_LENGTH"))); err == nil && v >= 0 {
+		gameMaxDescriptionLength = v
+	}
+
+	gameMaxReleaseAheadDays := 365
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("GAME_MAX_RELEASE_AHEAD_DAYS"))); err == nil && v >= 0 {
+		gameMaxReleaseAheadDays = v
+	}
+
+	gameAllowWeekendReleases := true
+	if v, err := strconv.ParseBool(strings.TrimSpace(os.Getenv("GAME_ALLOW_WEEKEND_RELEASES"))); err == nil {
+		gameAllowWeekendReleases = v
+	}
+
+	gameDailyCreateQuota := 0
+	if v, err := strconv.Atoi(strings.TrimSpace(os.Getenv("GAME_DAILY_CREATE_QUOTA"))); err == nil && v >= 0 {
+		gameDailyCreateQuota = v
+	}
+
+	publishCheckInterval := time.Minute
+	if v, err := time.ParseDuration(strings.TrimSpace(os.Getenv("PUBLISH_CHECK_INTERVAL"))); err == nil && v > 0 {
+		publishCheckInterval = v
+	}
+
+	similarityRebuildInterval := time.Hour
+	if v, err := time.ParseDuration(strings.TrimSpace(os.Getenv("SIMILARITY_REBUILD_INTERVAL"))); err == nil && v > 0 {
+		similarityRebuildInterval = v
+	}
+
+	launchTokenTTL := 5 * time.Minute
+	if v, err := time.ParseDuration(strings.TrimSpace(os.Getenv("LAUNCH_TOKEN_TTL"))); err == nil && v > 0 {
+		launchTokenTTL = v
+	}
+
+	resultSigningSecret := strings.TrimSpace(os.Getenv("RESULT_SIGNING_SECRET"))
+	if resultSigningSecret == "" && devMode {
+		resultSigningSecret = secret
+	}
+
+	resultTokenTTL := 6 * time.Hour
+	if v, err := time.ParseDuration(strings.TrimSpace(os.Getenv("RESULT_TOKEN_TTL"))); err == nil && v > 0 {
+		resultTokenTTL = v
+	}
+
+	statsCacheTTL := 5 * time.Minute
+	if v, err := time.ParseDuration(strings.TrimSpace(os.Getenv("STATS_CACHE_TTL"))); err == nil && v > 0 {
+		statsCacheTTL = v
+	}
+
 	return Config{
+		DevMode:    devMode,
 		DBPath:     dbPath,
 		JWTSecret:  secret,
 		JWTIssuer:  issuer,
 		JWTTTLHours: ttlHours,
+		CursorSecret: cursorSecret,
+		TrashRetentionDays: retentionDays,
+		TrashPurgeInterval: purgeInterval,
+		MediaDir: mediaDir,
+		MediaMaxUploadMB: mediaMaxUploadMB,
+		BuildDir: buildDir,
+		BuildMaxUploadMB: buildMaxUploadMB,
+		BuildURLSecret: buildURLSecret,
+		BuildURLTTL: buildURLTTL,
+		BuildSigningKey: strings.TrimSpace(os.Getenv("BUILD_SIGNING_KEY")),
+		GameMaxTitleLength: gameMaxTitleLength,
+		GameMaxDescriptionLength: gameMaxDescriptionLength,
+		GameMaxReleaseAheadDays: gameMaxReleaseAheadDays,
+		GameAllowWeekendReleases: gameAllowWeekendReleases,
+		GameDailyCreateQuota: gameDailyCreateQuota,
+		PublishCheckInterval: publishCheckInterval,
+		SimilarityRebuildInterval: similarityRebuildInterval,
+		LaunchTokenTTL: launchTokenTTL,
+		PublicBaseURL: strings.TrimRight(strings.TrimSpace(os.Getenv("PUBLIC_BASE_URL")), "/"),
+		ResultSigningSecret: resultSigningSecret,
+		ResultTokenTTL: resultTokenTTL,
+		StatsCacheTTL: statsCacheTTL,
 	}
 }
 
+
+// Validate проверяет, что вне режима разработки заданы собственные секреты. Значение JWT_SECRET
+// по умолчанию опубликовано вместе с кодом, поэтому выведенными из него ключами подписал бы кто угодно.
+func (c Config) Validate() error {
+	if c.DevMode {
+		return nil
+	}
+
+	var missing []string
+	for _, secret := range []struct{ env, value string }{
+		{"JWT_SECRET", c.JWTSecret},
+		{"CURSOR_SECRET", c.CursorSecret},
+		{"BUILD_URL_SECRET", c.BuildURLSecret},
+		{"BUILD_SIGNING_KEY", c.BuildSigningKey},
+		{"RESULT_SIGNING_SECRET", c.ResultSigningSecret},
+	} {
+		if secret.value == "" {
+			missing = append(missing, secret.env)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s must be set (or DEV_MODE=true for local development)", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -5,9 +5,6 @@ const (
 	ErrGameNotFound        = "игра не найдена"
 	ErrGameIDRequired      = "ID игры обязателен"
 	ErrTitleRequired       = "название игры обязательно"
-	ErrTitleTooLong        = "название игры слишком длинное (максимум 200 символов)"
-	ErrDescriptionTooLong  = "описание игры слишком длинное (максимум 2000 символов)"
-	ErrReleaseDateTooFar   = "дата релиза слишком далеко в будущем (максимум 1 год)"
 	ErrIDMismatch          = "несоответствие ID игры"
 	ErrGameAlreadyExists   = "игра уже существует"
 	ErrGameCannotBeDeleted = "игра не может быть удалена"
@@ -25,25 +22,139 @@ const (
 	ErrUserPasswordEmpty   = "пароль обязателен"
 	ErrUserRoleInvalid     = "некорректная роль пользователя"
 	ErrUserAlreadyExists   = "пользователь уже существует"
+
+	ErrRatingNotFound      = "оценка не найдена"
+	ErrRatingAlreadyExists = "пользователь уже оценил игру"
+
+	ErrMediaNotFound    = "изображение не найдено"
+	ErrMediaUnsupported = "поддерживаются только изображения JPEG, PNG и GIF"
+	ErrMediaTooLarge    = "файл или изображение слишком большое"
+
+	ErrBuildNotFound      = "сборка не найдена"
+	ErrBuildAlreadyExists = "сборка этой версии для платформы уже загружена"
+	ErrBuildTooLarge      = "архив сборки слишком большой (или распаковывается в слишком большой объем)"
+	ErrBuildUnsupported   = "сборка должна быть архивом zip или tar.gz"
+	ErrBuildLinkExpired   = "ссылка на архив сборки устарела, запросите новую"
+	ErrBuildLinkInvalid   = "неверная подпись ссылки на архив сборки"
+
+	ErrRelationNotFound    = "связь игр не найдена"
+	ErrRelatedGameNotFound = "связанная игра не найдена"
+	ErrSeriesNotFound      = "серия не найдена"
+	ErrSeriesGameNotFound  = "игра из состава серии не найдена"
+
+	ErrTaskNotFound = "задача не найдена"
+
+	ErrAttemptNotFound    = "попытка не найдена"
+	ErrLaunchTokenInvalid = "токен запуска недействителен или уже использован"
+	ErrLaunchTokenExpired = "срок действия токена запуска истек, начните попытку заново"
+
+	ErrResultTokenInvalid     = "токен результата недействителен"
+	ErrResultTokenExpired     = "срок приема результата попытки истек"
+	ErrResultSignatureInvalid = "подпись запроса с результатом не совпадает"
+	ErrResultRequestExpired   = "время запроса расходится с часами сервера больше чем на 5 минут"
+	ErrResultNonceUsed        = "запрос с этим nonce уже принимался"
 )
 
 // Ошибки валидации
 const (
 	ErrValidationTitleLength = "название должно содержать от 1 до 200 символов"
-	ErrValidationDescription = "описание не должно превышать 2000 символов"
-	ErrValidationReleaseDate = "дата релиза не может быть больше чем на год вперед"
 	ErrValidationGenreID     = "ID жанра обязателен"
 	ErrValidationLimit       = "лимит не может быть отрицательным"
 	ErrValidationOffset      = "смещение не может быть отрицательным"
 	ErrValidationMaxLimit    = "лимит не может превышать 100"
+	ErrValidationPage        = "номер страницы должен быть положительным"
+	ErrValidationSort        = "некорректное поле сортировки"
+	ErrValidationDateFilter  = "некорректная дата в фильтре (ожидается YYYY-MM-DD или RFC3339)"
+	ErrValidationDateRange   = "начало периода не может быть позже конца"
+	ErrValidationCursor      = "некорректный курсор"
+	ErrValidationCursorFilter = "курсор выдан для других фильтров; начните с первой страницы"
+	ErrValidationGenreMatch  = "genreMatch должен быть any или all"
+	ErrValidationSearchQuery = "поисковый запрос должен содержать хотя бы одно слово"
+	ErrValidationSearchAfter = "поиск не поддерживает курсоры, используйте page"
+	ErrValidationSimilarTitle = "укажите название для поиска похожих игр"
+	ErrValidationTrashKind   = "тип записи в корзине должен быть game, genre или user"
+	ErrValidationGameStatus  = "статус должен быть draft, scheduled, published или archived"
+	ErrValidationStatusFilter = "фильтр по статусу доступен только администраторам"
+	ErrValidationPublishAt   = "время публикации должно быть в будущем"
+	ErrValidationRetention   = "срок хранения в корзине должен быть положительным"
+	ErrValidationRevision    = "номер ревизии должен быть положительным"
+	ErrValidationDiffRange   = "для сравнения нужны две ревизии"
+	ErrValidationIfMatch     = "заголовок If-Match должен содержать версию записи из ETag"
+	ErrValidationNoIfMatch   = "нужен заголовок If-Match с версией записи из ETag"
+	ErrValidationPatchNull   = "обязательное поле нельзя удалить через null"
+	ErrValidationPatchType   = "PATCH принимает application/merge-patch+json"
+	ErrValidationCatalogType = "формат каталога должен быть csv, json или ndjson"
+	ErrValidationCatalogFile = "не удалось разобрать файл каталога"
+	ErrValidationMediaKind   = "kind должен быть cover или screenshot"
+	ErrValidationMediaFile   = "нужен файл изображения в поле file"
+	ErrValidationMediaSize   = "размер должен быть small, medium или large"
+	ErrValidationMediaOrder  = "порядок должен содержать все скриншоты игры ровно по одному разу"
+	ErrValidationRelationType = "тип связи должен быть sequel, dlc или remaster"
+	ErrValidationSelfRelation = "игру нельзя связать с самой собой"
+	ErrValidationSeriesTitle  = "название серии должно содержать от 1 до 200 символов"
+	ErrValidationSeriesGames  = "игра указана в составе серии дважды"
+	ErrValidationTaskTitle        = "название задачи должно содержать от 1 до 200 символов, инструкция - до 10000"
+	ErrValidationTaskDifficulty   = "сложность задачи должна быть easy, medium или hard"
+	ErrValidationTaskLaunchParams = "параметров запуска не больше 50, имя - от 1 до 64 латинских букв, цифр или _, значение - до 1024"
+	ErrValidationTaskParamNames   = "имена параметров запуска не должны совпадать между собой и с launchToken, callbackUrl, attemptId, userId, gameId, taskId, mode, seed без учета регистра"
+	ErrValidationTaskCriteria     = "время прохождения задачи не может быть отрицательным"
+	ErrValidationTaskOrder        = "порядковый номер задачи должен быть положительным"
+	ErrValidationAttemptTask      = "задача не относится к этой игре"
+	ErrValidationResultHeaders    = "нужны заголовки X-Result-Token, X-Result-Nonce (16-128 символов: латиница, цифры, _ и -), X-Result-Timestamp (секунды Unix) и X-Result-Signature"
+	ErrValidationResultBody       = "нужны score, durationMs и success"
+	ErrValidationResultDuration   = "durationMs должно быть от 0 до 24 часов"
+	ErrValidationResultDetails    = "details должно быть JSON-объектом не больше 16 КБ"
+	ErrValidationLeaderboardWindow = "окно таблицы рекордов должно быть all, month или week"
+	ErrValidationLeaderboardTask   = "taskId должен быть ID задачи этой игры"
+
+	ErrValidationBuildVersion    = "версия сборки - до 50 символов из латиницы, цифр и . _ + -, начиная с буквы или цифры"
+	ErrValidationBuildPlatform   = "платформа должна быть windows-x86_64, linux-x86_64, macos-x86_64 или macos-arm64"
+	ErrValidationBuildEntrypoint = "нужен относительный путь к исполняемому файлу внутри архива"
+	ErrValidationBuildNotes      = "описание изменений не должно превышать 5000 символов"
+	ErrValidationBuildFile       = "нужен архив сборки в поле file"
+	ErrValidationBuildUnsafePath = "архив содержит абсолютные пути или пути с .."
+	ErrValidationBuildNoEntry    = "исполняемый файл не найден в архиве"
+	ErrValidationBuildWindowsExe = "исполняемый файл сборки Windows должен иметь расширение .exe"
+	ErrValidationBuildDataDir    = "рядом с исполняемым файлом нет папки <имя>_Data сборки Unity"
+	ErrValidationBuildLink       = "нужны параметры expires и signature из ссылки на скачивание"
+	ErrValidationBuildMacBundle  = "исполняемый файл сборки macOS должен лежать в <имя>.app/Contents/MacOS рядом с Contents/Info.plist"
+)
+
+// Нарушения политики игр; %d - предел из настроек
+const (
+	ErrPolicyTitleLength       = "название игры должно содержать от 1 до %d символов"
+	ErrPolicyDescriptionLength = "описание игры не должно превышать %d символов"
+	ErrPolicyReleaseHorizon    = "дата релиза не может быть дальше чем на %d дн. вперед"
 )
 
 // Бизнес-ошибки
 const (
-	ErrBusinessCannotReleaseOnWeekend = "игры нельзя выпускать по выходным"
-	ErrBusinessDailyLimitExceeded     = "превышен дневной лимит создания игр"
+	ErrBusinessCannotReleaseOnWeekend = "будущий релиз нельзя назначать на выходные"
+	ErrBusinessDailyLimitExceeded     = "превышен дневной лимит создания игр (%d в сутки)"
 	ErrBusinessGameNotReleased        = "игра еще не выпущена"
 	ErrBusinessDuplicateTitle         = "игра с таким названием уже существует"
+	ErrBusinessGenreInUse             = "жанр используется играми, сначала удалите игры или смените им жанр"
+	ErrBusinessGameGenreDeleted       = "жанр игры находится в корзине, сначала восстановите жанр"
+	ErrBusinessVersionConflict        = "запись была изменена другим пользователем, обновите данные и повторите"
+	ErrBusinessStatusTransition       = "игру в статусе %s нельзя перевести в %s"
+	ErrBusinessRelationCycle          = "связь замыкает цикл: игра оказалась бы продолжением, дополнением или переизданием самой себя"
+	ErrBusinessGameInAnotherSeries    = "игра уже входит в другую серию"
+	ErrBusinessAttemptFinished        = "попытка уже завершена"
+	ErrBusinessResultSubmitted        = "результат попытки уже отправлен"
+)
+
+// Ошибки отдельных записей при импорте каталога
+const (
+	ErrCatalogKind           = "kind должен быть genre или game"
+	ErrCatalogID             = "некорректный id"
+	ErrCatalogDuplicateID    = "id уже встречался в файле"
+	ErrCatalogGenreDeleted   = "жанр с таким id находится в корзине"
+	ErrCatalogGameDeleted    = "игра с таким id находится в корзине"
+	ErrCatalogReleaseDate    = "некорректная дата релиза (ожидается YYYY-MM-DD или RFC3339)"
+	ErrCatalogNoGenres       = "у игры должен быть хотя бы один жанр"
+	ErrCatalogGenreUnknown   = "жанр не найден, добавьте его в файл или включите createGenres"
+	ErrCatalogGenreAmbiguous = "несколько жанров с таким названием, укажите жанр однозначно"
+	ErrCatalogPublishAt      = "publishAt (RFC3339) задается только у игр со статусом scheduled и обязателен для них"
 )
 
 // Сообщения успеха
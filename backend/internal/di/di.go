@@ -2,12 +2,22 @@ package di
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 
 	"example/web-service-gin/internal/application/services"
 	"example/web-service-gin/internal/config"
+	"example/web-service-gin/internal/domain/model"
 	jwtinfra "example/web-service-gin/internal/infrastructure/auth/jwt"
+	"example/web-service-gin/internal/infrastructure/blobstore"
+	"example/web-service-gin/internal/infrastructure/buildarchive"
+	"example/web-service-gin/internal/infrastructure/cache"
+	"example/web-service-gin/internal/infrastructure/catalogio"
+	"example/web-service-gin/internal/infrastructure/imaging"
+	"example/web-service-gin/internal/infrastructure/cursor"
 	"example/web-service-gin/internal/infrastructure/persistence/sqlite"
+	"example/web-service-gin/internal/infrastructure/signing"
 	"example/web-service-gin/internal/interfaces/http/handlers"
 	"example/web-service-gin/internal/interfaces/http/middleware"
 	"example/web-service-gin/internal/interfaces/http/router"
@@ -16,6 +26,19 @@ import (
 	"time"
 )
 
+// mediaMaxPixels - предельное число пикселей загружаемого изображения (около 8K x 5K)
+const mediaMaxPixels = 40_000_000
+
+// buildMaxEntries - предельное число файлов в архиве сборки
+const buildMaxEntries = 100_000
+
+// buildMaxUnpackedBytes - предельный объем распакованного архива сборки
+const buildMaxUnpackedBytes = 64 << 30
+
+// buildMaxCompressionRatio - во сколько раз распакованный архив сборки может быть больше
+// сжатого; у настоящих сборок степень сжатия на порядок меньше
+const buildMaxCompressionRatio = 100
+
 type App struct {
 	Router *gin.Engine
 	Close  func() error
@@ -27,6 +50,9 @@ func Build(ctx context.Context) (*App, error) {
 	}
 
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 	db, err := sqlite.Open(ctx, sqlite.Config{Path: cfg.DBPath})
 	if err != nil {
 		return nil, err
@@ -35,10 +61,63 @@ func Build(ctx context.Context) (*App, error) {
 	gameRepo := sqlite.NewGameRepository(db.SQL)
 	genreRepo := sqlite.NewGenreRepository(db.SQL)
 	userRepo := sqlite.NewUserRepository(db.SQL)
+	userRatingRepo := sqlite.NewUserRatingRepository(db.SQL)
+	revisionRepo := sqlite.NewRevisionRepository(db.SQL)
+	transactor := sqlite.NewTransactor(db.SQL)
+	mediaRepo := sqlite.NewGameMediaRepository(db.SQL)
+	buildRepo := sqlite.NewGameBuildRepository(db.SQL)
+	relationRepo := sqlite.NewGameRelationRepository(db.SQL)
+	seriesRepo := sqlite.NewSeriesRepository(db.SQL)
+	similarityRepo := sqlite.NewGameSimilarityRepository(db.SQL)
+	taskRepo := sqlite.NewTaskRepository(db.SQL)
+	attemptRepo := sqlite.NewAttemptRepository(db.SQL)
+	playResultRepo := sqlite.NewPlayResultRepository(db.SQL)
 
-	gameService := services.NewGameService(gameRepo)
-	genreService := services.NewGenreService(genreRepo)
-	userService := services.NewUserService(userRepo)
+	cursorCodec := cursor.NewHMACCodec(cfg.CursorSecret)
+
+	blobStore, err := blobstore.NewLocalStore(cfg.MediaDir)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	mediaMaxBytes := int64(cfg.MediaMaxUploadMB) << 20
+
+	// Архивы сборок хранятся отдельно от изображений: их объем на порядки больше
+	buildStore, err := blobstore.NewLocalStore(cfg.BuildDir)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	buildMaxBytes := int64(cfg.BuildMaxUploadMB) << 20
+	manifestSigner, err := newManifestSigner(cfg)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	gamePolicy := newGamePolicy(cfg)
+	gameService := services.NewGameService(gameRepo, genreRepo, userRatingRepo, revisionRepo, transactor, cursorCodec, gamePolicy)
+	genreService := services.NewGenreService(genreRepo, gameRepo, revisionRepo, transactor, cursorCodec)
+	userService := services.NewUserService(userRepo, cursorCodec)
+	ratingService := services.NewRatingService(gameRepo, userRatingRepo)
+	mediaService := services.NewMediaService(gameRepo, mediaRepo, blobStore,
+		imaging.NewProcessor(mediaMaxPixels), mediaMaxBytes)
+	buildService := services.NewBuildService(gameRepo, buildRepo, buildStore,
+		buildarchive.NewInspector(buildMaxEntries, buildMaxUnpackedBytes, buildMaxCompressionRatio), signing.NewHMACURLSigner(cfg.BuildURLSecret),
+		manifestSigner, buildMaxBytes, cfg.BuildURLTTL)
+	relationService := services.NewRelationService(gameRepo, relationRepo, seriesRepo, transactor)
+	recommendationService := services.NewRecommendationService(gameRepo, userRatingRepo, similarityRepo)
+	taskService := services.NewTaskService(gameRepo, taskRepo)
+	resultSigner := signing.NewHMACRequestSigner(cfg.ResultSigningSecret)
+	attemptService := services.NewAttemptService(gameRepo, taskRepo, attemptRepo, resultSigner,
+		cfg.LaunchTokenTTL, cfg.ResultTokenTTL, cfg.PublicBaseURL)
+	statsCache := services.NewUserStatsCache(cache.NewMemoryCache())
+	playResultService := services.NewPlayResultService(attemptRepo, taskRepo, playResultRepo, resultSigner, statsCache)
+	playStatsService := services.NewPlayStatsService(userRepo, playResultRepo, statsCache, cfg.StatsCacheTTL)
+	leaderboardService := services.NewLeaderboardService(gameRepo, taskRepo, playResultRepo)
+	catalogService := newCatalogService(db, gameRepo, genreRepo, revisionRepo, gamePolicy)
+	trashService := services.NewTrashService(gameRepo, genreRepo, userRepo, mediaRepo, blobStore, buildRepo, buildStore,
+		time.Duration(cfg.TrashRetentionDays)*24*time.Hour)
 	jwtProvider := jwtinfra.NewProvider(cfg.JWTSecret, cfg.JWTIssuer, time.Duration(cfg.JWTTTLHours)*time.Hour)
 	authService := services.NewAuthService(userRepo, jwtProvider)
 
@@ -46,13 +125,99 @@ func Build(ctx context.Context) (*App, error) {
 	genreHandler := handlers.NewGenreHandler(genreService)
 	userHandler := handlers.NewUserHandler(userService)
 	authHandler := handlers.NewAuthHandler(authService)
+	ratingHandler := handlers.NewRatingHandler(ratingService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	catalogHandler := handlers.NewCatalogHandler(catalogService)
+	mediaHandler := handlers.NewMediaHandler(mediaService, mediaMaxBytes)
+	buildHandler := handlers.NewBuildHandler(buildService, buildMaxBytes)
+	relationHandler := handlers.NewRelationHandler(relationService)
+	recommendationHandler := handlers.NewRecommendationHandler(recommendationService)
+	taskHandler := handlers.NewTaskHandler(taskService)
+	attemptHandler := handlers.NewAttemptHandler(attemptService)
+	playResultHandler := handlers.NewPlayResultHandler(playResultService)
+	playStatsHandler := handlers.NewPlayStatsHandler(playStatsService)
+	leaderboardHandler := handlers.NewLeaderboardHandler(leaderboardService)
 
 	adminOnly := middleware.RequireAdmin(jwtProvider)
-	r := router.NewRouter(gameHandler, genreHandler, userHandler, authHandler, adminOnly)
+	authRequired := middleware.RequireAuth(jwtProvider)
+	optionalAuth := middleware.OptionalAuth(jwtProvider)
+	r := router.NewRouter(gameHandler, genreHandler, userHandler, authHandler, ratingHandler, trashHandler, catalogHandler, mediaHandler, buildHandler, relationHandler, recommendationHandler, taskHandler, attemptHandler, playResultHandler, playStatsHandler, leaderboardHandler, adminOnly, authRequired, optionalAuth)
+
+	// Фоновые задачи (очистка корзины, публикация по расписанию, пересчет похожести игр)
+	// живут, пока приложение не закрыто
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	go trashService.RunPurgeJob(jobCtx, cfg.TrashPurgeInterval)
+	go gameService.RunPublishScheduler(jobCtx, cfg.PublishCheckInterval)
+	go recommendationService.RunSimilarityJob(jobCtx, cfg.SimilarityRebuildInterval)
 
 	return &App{
 		Router: r,
-		Close:  db.Close,
+		Close: func() error {
+			stopJobs()
+			return db.Close()
+		},
+	}, nil
+}
+
+
+// Catalog - сервис каталога для консольных команд, без HTTP и фоновых задач
+type Catalog struct {
+	Service *services.CatalogService
+	Close   func() error
+}
+
+func BuildCatalog(ctx context.Context) (*Catalog, error) {
+	if ctx == nil {
+		return nil, errors.New("context is nil")
+	}
+
+	cfg := config.Load()
+	db, err := sqlite.Open(ctx, sqlite.Config{Path: cfg.DBPath})
+	if err != nil {
+		return nil, err
+	}
+
+	catalogService := newCatalogService(db,
+		sqlite.NewGameRepository(db.SQL),
+		sqlite.NewGenreRepository(db.SQL),
+		sqlite.NewRevisionRepository(db.SQL),
+		newGamePolicy(cfg))
+
+	return &Catalog{
+		Service: catalogService,
+		Close:   db.Close,
 	}, nil
 }
 
+func newCatalogService(db *sqlite.DB, gameRepo *sqlite.GameRepository,
+	genreRepo *sqlite.GenreRepository, revisionRepo *sqlite.RevisionRepository,
+	policy model.GamePolicy) *services.CatalogService {
+
+	return services.NewCatalogService(gameRepo, genreRepo, sqlite.NewCatalogRepository(db.SQL), revisionRepo,
+		sqlite.NewTransactor(db.SQL), policy,
+		catalogio.NewCSVCodec(), catalogio.NewJSONCodec(), catalogio.NewNDJSONCodec())
+}
+
+// newGamePolicy собирает политику игр из настроек.
+func newGamePolicy(cfg config.Config) model.GamePolicy {
+	return model.GamePolicy{
+		MaxTitleLength:       cfg.GameMaxTitleLength,
+		MaxDescriptionLength: cfg.GameMaxDescriptionLength,
+		MaxReleaseAheadDays:  cfg.GameMaxReleaseAheadDays,
+		AllowWeekendReleases: cfg.GameAllowWeekendReleases,
+		DailyCreateQuota:     cfg.GameDailyCreateQuota,
+	}
+}
+
+// newManifestSigner создает ключ подписи манифестов сборок из BUILD_SIGNING_KEY
+// или, если он не задан, из секрета JWT (cfg.Validate допускает это только в режиме разработки).
+func newManifestSigner(cfg config.Config) (*signing.Ed25519Signer, error) {
+	if cfg.BuildSigningKey == "" {
+		return signing.NewEd25519SignerFromSecret(cfg.JWTSecret), nil
+	}
+	seed, err := base64.StdEncoding.DecodeString(cfg.BuildSigningKey)
+	if err != nil {
+		return nil, fmt.Errorf("BUILD_SIGNING_KEY must be base64: %w", err)
+	}
+	return signing.NewEd25519Signer(seed)
+}
@@ -8,9 +8,17 @@ import (
 	"github.com/google/uuid"
 )
 
+var (
+	ErrRatingOutOfRange    = errors.New("rating must be between 1 and 5")
+	ErrRatingAlreadyExists = errors.New("Пользователь уже оценил игру")
+	ErrRatingNotFound      = errors.New("user hasn't rated this game yet")
+)
+
 type GameDetailsAggregate struct {
-	Game    *model.Game
+	Game *model.Game
+	// Genre - основной жанр, Genres - все жанры игры (основной первый)
 	Genre   *model.Genre
+	Genres  []*model.Genre
 	Ratings []*model.UserRating
 
 	averageRating float64
@@ -27,15 +35,40 @@ func NewGameDetailsAggregate(game *model.Game, genre *model.Genre, ratings []*mo
 	return agg
 }
 
+// NewGameDetailsAggregateFromStats собирает агрегат для чтения по заранее посчитанной статистике,
+// не загружая все оценки игры. Ratings содержит только оценку текущего пользователя, если она есть,
+// поэтому изменять оценки через такой агрегат нельзя. genres - жанры игры в порядке game.GenreIDs.
+func NewGameDetailsAggregateFromStats(game *model.Game, genres []*model.Genre, stats *model.RatingStats, own *model.UserRating) *GameDetailsAggregate {
+	agg := &GameDetailsAggregate{
+		Game:    game,
+		Genres:  genres,
+		Ratings: []*model.UserRating{},
+	}
+	for _, genre := range genres {
+		if genre != nil && genre.ID == game.GenreID {
+			agg.Genre = genre
+			break
+		}
+	}
+	if own != nil {
+		agg.Ratings = append(agg.Ratings, own)
+	}
+	if stats != nil {
+		agg.averageRating = stats.Average
+		agg.ratingCount = stats.Count
+	}
+	return agg
+}
+
 func (g *GameDetailsAggregate) AddRating(userID uuid.UUID, rating int) error {
 
-	if rating < 1 || rating > 5 {
-		return errors.New("rating must be between 1 and 5")
+	if err := validateRating(rating); err != nil {
+		return err
 	}
 
 	for _, i := range g.Ratings {
 		if i.UserID == userID {
-			return errors.New("Пользователь уже оценил игру")
+			return ErrRatingAlreadyExists
 		}
 	}
 
@@ -55,15 +88,41 @@ func (g *GameDetailsAggregate) AddRating(userID uuid.UUID, rating int) error {
 }
 
 func (g *GameDetailsAggregate) UpdateRating(userID uuid.UUID, newRating int) error {
+	if err := validateRating(newRating); err != nil {
+		return err
+	}
+
 	for _, r := range g.Ratings {
 		if r.UserID == userID {
 			r.Rating = newRating
-			r.CreatedAt = time.Now()
+			r.CreatedAt = time.Now().UTC()
 			g.calculateDerivedFields()
 			return nil
 		}
 	}
-	return errors.New("user hasn't rated this game yet")
+	return ErrRatingNotFound
+}
+
+// RemoveRating удаляет оценку пользователя и возвращает её для удаления из хранилища.
+func (g *GameDetailsAggregate) RemoveRating(userID uuid.UUID) (*model.UserRating, error) {
+	for i, r := range g.Ratings {
+		if r.UserID == userID {
+			g.Ratings = append(g.Ratings[:i], g.Ratings[i+1:]...)
+			g.calculateDerivedFields()
+			return r, nil
+		}
+	}
+	return nil, ErrRatingNotFound
+}
+
+// FindRatingByUser возвращает оценку пользователя или nil, если он не оценивал игру.
+func (g *GameDetailsAggregate) FindRatingByUser(userID uuid.UUID) *model.UserRating {
+	for _, r := range g.Ratings {
+		if r.UserID == userID {
+			return r
+		}
+	}
+	return nil
 }
 
 func (g *GameDetailsAggregate) CalculateAverageRating() float64 {
@@ -78,6 +137,10 @@ func (g *GameDetailsAggregate) CalculateAverageRating() float64 {
 	return float64(sum) / float64(len(g.Ratings))
 }
 
+func (g *GameDetailsAggregate) GetAverageRating() float64 {
+	return g.averageRating
+}
+
 func (g *GameDetailsAggregate) GetRatingCount() int {
 	return g.ratingCount
 }
@@ -86,3 +149,10 @@ func (g *GameDetailsAggregate) calculateDerivedFields() {
 	g.averageRating = g.CalculateAverageRating()
 	g.ratingCount = len(g.Ratings)
 }
+
+func validateRating(rating int) error {
+	if rating < 1 || rating > 5 {
+		return ErrRatingOutOfRange
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Статусы попытки: started -> running -> completed | failed; незавершенную попытку
+// (started или running) можно бросить (abandoned).
+const (
+	// AttemptStarted - пользователь запросил запуск, игра еще не предъявила токен запуска
+	AttemptStarted = "started"
+	// AttemptRunning - игра запущена и предъявила токен запуска
+	AttemptRunning   = "running"
+	AttemptCompleted = "completed"
+	AttemptFailed    = "failed"
+	AttemptAbandoned = "abandoned"
+)
+
+// Режимы запуска игры
+const (
+	// AttemptModeFree - свободная игра без задачи
+	AttemptModeFree = "free"
+	// AttemptModeTask - прохождение задачи TaskID
+	AttemptModeTask = "task"
+)
+
+var (
+	// ErrAttemptTransition - попытку нельзя перевести в запрошенный статус из текущего
+	ErrAttemptTransition = errors.New("attempt status transition is not allowed")
+	// ErrLaunchTokenExpired - токен запуска предъявлен после LaunchExpiresAt
+	ErrLaunchTokenExpired = errors.New("launch token expired")
+	// ErrResultTokenExpired - результат отправлен после ResultExpiresAt
+	ErrResultTokenExpired = errors.New("result token expired")
+)
+
+// Attempt - попытка пройти игру или задачу. Игра запускается по одноразовому токену запуска:
+// хранится только его хэш LaunchTokenHash, после предъявления он стирается. Взамен игра
+// получает токен результата (хранится хэш ResultTokenHash), с которым отправляет результат.
+type Attempt struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	GameID uuid.UUID
+	// TaskID - задача попытки; uuid.Nil в свободном режиме
+	TaskID uuid.UUID
+	Mode   string
+	// Seed - зерно генератора случайных чисел игры, чтобы попытку можно было воспроизвести
+	Seed   int64
+	Status string
+
+	LaunchTokenHash string
+	LaunchExpiresAt time.Time
+	// ResultTokenHash и ResultExpiresAt выдаются при запуске игры
+	ResultTokenHash string
+	ResultExpiresAt *time.Time
+
+	StartedAt  time.Time
+	RunningAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// NewAttempt создает попытку в статусе started с токеном запуска, действующим до launchExpiresAt.
+func NewAttempt(userID, gameID, taskID uuid.UUID, seed int64, launchTokenHash string, launchExpiresAt time.Time) (*Attempt, error) {
+	if userID == uuid.Nil || gameID == uuid.Nil {
+		return nil, errors.New("user ID and game ID are required")
+	}
+	if launchTokenHash == "" {
+		return nil, errors.New("launch token is required")
+	}
+
+	mode := AttemptModeFree
+	if taskID != uuid.Nil {
+		mode = AttemptModeTask
+	}
+	return &Attempt{
+		ID:              uuid.New(),
+		UserID:          userID,
+		GameID:          gameID,
+		TaskID:          taskID,
+		Mode:            mode,
+		Seed:            seed,
+		Status:          AttemptStarted,
+		LaunchTokenHash: launchTokenHash,
+		LaunchExpiresAt: launchExpiresAt.UTC(),
+		StartedAt:       time.Now().UTC(),
+	}, nil
+}
+
+// IsFinished - попытка завершена (completed, failed или abandoned).
+func (a *Attempt) IsFinished() bool {
+	return a.Status == AttemptCompleted || a.Status == AttemptFailed || a.Status == AttemptAbandoned
+}
+
+// Run отмечает запуск игры: started -> running. Токен запуска после этого недействителен,
+// результат принимается по токену с хэшем resultTokenHash до resultExpiresAt.
+func (a *Attempt) Run(at time.Time, resultTokenHash string, resultExpiresAt time.Time) error {
+	if a.Status != AttemptStarted {
+		return ErrAttemptTransition
+	}
+	if at.After(a.LaunchExpiresAt) {
+		return ErrLaunchTokenExpired
+	}
+	at = at.UTC()
+	a.Status = AttemptRunning
+	a.RunningAt = &at
+	a.LaunchTokenHash = ""
+	resultExpiresAt = resultExpiresAt.UTC()
+	a.ResultTokenHash = resultTokenHash
+	a.ResultExpiresAt = &resultExpiresAt
+	return nil
+}
+
+// CanSubmitResult - результат, отправленный в момент at, можно принять: попытка в running
+// и токен результата не истек. Завершенная попытка дает ErrAttemptTransition.
+func (a *Attempt) CanSubmitResult(at time.Time) error {
+	if a.Status != AttemptRunning {
+		return ErrAttemptTransition
+	}
+	if a.ResultExpiresAt == nil || at.After(*a.ResultExpiresAt) {
+		return ErrResultTokenExpired
+	}
+	return nil
+}
+
+// Finish завершает попытку: running -> completed | failed, started | running -> abandoned.
+func (a *Attempt) Finish(status string, at time.Time) error {
+	switch status {
+	case AttemptCompleted, AttemptFailed:
+		if a.Status != AttemptRunning {
+			return ErrAttemptTransition
+		}
+	case AttemptAbandoned:
+		if a.IsFinished() {
+			return ErrAttemptTransition
+		}
+	default:
+		return ErrAttemptTransition
+	}
+
+	at = at.UTC()
+	a.Status = status
+	a.FinishedAt = &at
+	a.LaunchTokenHash = ""
+	return nil
+}
@@ -1,7 +1,6 @@
 package model
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,42 +11,93 @@ type Game struct {
 	Title       string
 	Description string
 	ReleaseDate time.Time
-	GenreID     uuid.UUID
+	// GenreID - основной жанр игры
+	GenreID uuid.UUID
+	// GenreIDs - все жанры игры без повторов; основной всегда первый
+	GenreIDs []uuid.UUID
+	// Status - статус публикации (GameStatus*)
+	Status string
+	// PublishAt - момент запланированной публикации; задан только у статуса GameStatusScheduled
+	PublishAt *time.Time
+	// DeletedAt - момент перемещения в корзину; nil у активных записей
+	DeletedAt *time.Time
+	// Version растет на 1 при каждом изменении записи (оптимистическая блокировка)
+	Version int
 }
 
-func (g *Game) UpdateGameWithValidate(title string,
+// SetGenres задает основной жанр и набор дополнительных (основной в genreIds может быть, а может и не быть).
+func (g *Game) SetGenres(primary uuid.UUID, genreIds []uuid.UUID) {
+	g.GenreID = primary
+	g.GenreIDs = []uuid.UUID{primary}
+	for _, id := range genreIds {
+		if id == uuid.Nil || g.HasGenre(id) {
+			continue
+		}
+		g.GenreIDs = append(g.GenreIDs, id)
+	}
+}
+
+// HasGenre проверяет, относится ли игра к жанру (основному или дополнительному).
+func (g *Game) HasGenre(genreID uuid.UUID) bool {
+	for _, id := range g.GenreIDs {
+		if id == genreID {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateGameWithValidate меняет поля игры по правилам policy. Правила даты релиза
+// применяются только к новой дате: уже назначенная остается допустимой, даже если политика ужесточилась.
+func (g *Game) UpdateGameWithValidate(policy GamePolicy,
+	title string,
 	description string,
 	releaseDate time.Time,
-	genreId uuid.UUID) error {
+	genreId uuid.UUID,
+	genreIds []uuid.UUID) error {
 
-	if releaseDate.After(time.Now().AddDate(0, 0, 30)) {
-		return errors.New("release date cannot be more than 30 days in the future")
+	if err := policy.CheckFields(title, description); err != nil {
+		return err
+	}
+	if !releaseDate.Equal(g.ReleaseDate) {
+		if err := policy.CheckReleaseDate(releaseDate, time.Now()); err != nil {
+			return err
+		}
 	}
 
 	g.Title = title
 	g.Description = description
 	g.ReleaseDate = releaseDate
-	g.GenreID = genreId
+	g.SetGenres(genreId, genreIds)
 
 	return nil
 }
 
+// NewGameWithValidate создает черновик игры, проверяя поля по правилам policy.
 func NewGameWithValidate(
+	policy GamePolicy,
 	title string,
 	description string,
 	releaseDate time.Time,
 	genreId uuid.UUID,
+	genreIds []uuid.UUID,
 ) (*Game, error) {
 
-	if releaseDate.After(time.Now().AddDate(0, 0, 30)) {
-		return nil, errors.New("release date cannot be more than 30 days in the future")
+	if err := policy.CheckFields(title, description); err != nil {
+		return nil, err
+	}
+	if err := policy.CheckReleaseDate(releaseDate, time.Now()); err != nil {
+		return nil, err
 	}
 
-	return &Game{
+	game := &Game{
 		ID:          uuid.New(),
 		Title:       title,
 		Description: description,
 		ReleaseDate: releaseDate,
-		GenreID:     genreId,
-	}, nil
+		Status:      GameStatusDraft,
+	}
+	game.SetGenres(genreId, genreIds)
+
+	return game, nil
 }
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Платформы сборок Unity; значение совпадает с параметром platform у лаунчера
+const (
+	BuildPlatformWindows  = "windows-x86_64"
+	BuildPlatformLinux    = "linux-x86_64"
+	BuildPlatformMacOS    = "macos-x86_64"
+	BuildPlatformMacOSArm = "macos-arm64"
+)
+
+// Форматы архивов сборок
+const (
+	ArchiveFormatZip   = "zip"
+	ArchiveFormatTarGz = "tar.gz"
+)
+
+// MaxBuildReleaseNotes - предельная длина описания изменений в символах
+const MaxBuildReleaseNotes = 5000
+
+var buildVersionPattern = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z._+-]{0,49}$`)
+
+// BuildFile - файл внутри архива сборки; по хешу лаунчер проверяет распакованную сборку.
+type BuildFile struct {
+	Path   string
+	Size   int64
+	SHA256 string
+}
+
+// GameBuild - архив сборки игры для одной платформы. Архив лежит в хранилище файлов
+// под ключом SHA256, лаунчер запускает Entrypoint после распаковки.
+type GameBuild struct {
+	ID       uuid.UUID
+	GameID   uuid.UUID
+	Version  string
+	Platform string
+	// Entrypoint - путь к исполняемому файлу внутри архива (через "/")
+	Entrypoint    string
+	ArchiveFormat string
+	SHA256        string
+	Size          int64
+	ReleaseNotes  string
+	UploadedBy    uuid.UUID
+	// IsCurrent - сборку получает лаунчер; на платформу игры не больше одной текущей
+	IsCurrent bool
+	CreatedAt time.Time
+	// Files - файлы архива; заполняется при загрузке, в списках сборок не читается
+	Files []BuildFile
+}
+
+func NewGameBuildWithValidate(gameID uuid.UUID, version, platform, entrypoint, releaseNotes string) (*GameBuild, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID is required")
+	}
+	version = strings.TrimSpace(version)
+	if !IsBuildVersion(version) {
+		return nil, errors.New("build version is invalid")
+	}
+	if !IsBuildPlatform(platform) {
+		return nil, errors.New("build platform is unknown")
+	}
+	entrypoint = strings.TrimSpace(entrypoint)
+	if entrypoint == "" {
+		return nil, errors.New("build entrypoint is required")
+	}
+	if len([]rune(releaseNotes)) > MaxBuildReleaseNotes {
+		return nil, errors.New("build release notes are too long")
+	}
+
+	return &GameBuild{
+		ID:           uuid.New(),
+		GameID:       gameID,
+		Version:      version,
+		Platform:     platform,
+		Entrypoint:   entrypoint,
+		ReleaseNotes: releaseNotes,
+		CreatedAt:    time.Now().UTC(),
+	}, nil
+}
+
+func IsBuildVersion(version string) bool {
+	return buildVersionPattern.MatchString(version)
+}
+
+func IsBuildPlatform(platform string) bool {
+	switch platform {
+	case BuildPlatformWindows, BuildPlatformLinux, BuildPlatformMacOS, BuildPlatformMacOSArm:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MediaKindCover - обложка игры, у игры не больше одной
+	MediaKindCover = "cover"
+	// MediaKindScreenshot - скриншот; скриншоты упорядочены по Position
+	MediaKindScreenshot = "screenshot"
+)
+
+// MediaThumbnail - уменьшенная копия изображения одного из размеров сервиса.
+type MediaThumbnail struct {
+	Size        string
+	BlobKey     string
+	ContentType string
+	Bytes       int64
+	Width       int
+	Height      int
+}
+
+// GameMedia - изображение игры. Содержимое лежит в хранилище файлов под ключом BlobKey
+// (SHA-256 содержимого), поэтому одинаковые файлы хранятся один раз.
+type GameMedia struct {
+	ID          uuid.UUID
+	GameID      uuid.UUID
+	Kind        string
+	Position    int
+	BlobKey     string
+	ContentType string
+	Bytes       int64
+	Width       int
+	Height      int
+	Thumbnails  []MediaThumbnail
+	UploadedBy  uuid.UUID
+	CreatedAt   time.Time
+}
+
+func NewGameMediaWithValidate(gameID uuid.UUID, kind string) (*GameMedia, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID is required")
+	}
+	if !IsMediaKind(kind) {
+		return nil, errors.New("media kind must be cover or screenshot")
+	}
+
+	return &GameMedia{
+		ID:        uuid.New(),
+		GameID:    gameID,
+		Kind:      kind,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+func IsMediaKind(kind string) bool {
+	return kind == MediaKindCover || kind == MediaKindScreenshot
+}
+
+// Thumbnail возвращает миниатюру размера size или nil, если ее нет.
+func (m *GameMedia) Thumbnail(size string) *MediaThumbnail {
+	for i := range m.Thumbnails {
+		if m.Thumbnails[i].Size == size {
+			return &m.Thumbnails[i]
+		}
+	}
+	return nil
+}
+
+// BlobKeys возвращает ключи оригинала и всех миниатюр.
+func (m *GameMedia) BlobKeys() []string {
+	keys := make([]string, 0, len(m.Thumbnails)+1)
+	keys = append(keys, m.BlobKey)
+	for _, t := range m.Thumbnails {
+		keys = append(keys, t.BlobKey)
+	}
+	return keys
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Правила политики игр; их имена попадают в ответ API вместе с нарушением
+const (
+	RuleTitleLength       = "titleLength"
+	RuleDescriptionLength = "descriptionLength"
+	RuleReleaseHorizon    = "releaseHorizon"
+	RuleWeekendRelease    = "weekendRelease"
+	RuleDailyCreateQuota  = "dailyCreateQuota"
+)
+
+// GamePolicy - настраиваемые правила для игр. Одна политика применяется при создании,
+// изменении, откате и импорте, значения задаются конфигурацией.
+type GamePolicy struct {
+	// MaxTitleLength - предельная длина названия в символах
+	MaxTitleLength int
+	// MaxDescriptionLength - предельная длина описания в символах
+	MaxDescriptionLength int
+	// MaxReleaseAheadDays - на сколько дней вперед от текущего момента можно назначить релиз
+	MaxReleaseAheadDays int
+	// AllowWeekendReleases - можно ли назначать будущий релиз на субботу и воскресенье
+	AllowWeekendReleases bool
+	// DailyCreateQuota - сколько игр один администратор может создать за сутки (UTC); 0 - без ограничения
+	DailyCreateQuota int
+}
+
+// PolicyViolation - нарушенное правило политики и его предел (для RuleWeekendRelease - 0).
+type PolicyViolation struct {
+	Rule  string
+	Limit int
+}
+
+func (v *PolicyViolation) Error() string {
+	return fmt.Sprintf("game policy rule %s violated (limit %d)", v.Rule, v.Limit)
+}
+
+// CheckFields проверяет название и описание.
+func (p GamePolicy) CheckFields(title, description string) error {
+	if strings.TrimSpace(title) == "" || utf8.RuneCountInString(title) > p.MaxTitleLength {
+		return &PolicyViolation{Rule: RuleTitleLength, Limit: p.MaxTitleLength}
+	}
+	if utf8.RuneCountInString(description) > p.MaxDescriptionLength {
+		return &PolicyViolation{Rule: RuleDescriptionLength, Limit: p.MaxDescriptionLength}
+	}
+	return nil
+}
+
+// CheckReleaseDate проверяет назначаемую дату релиза относительно now. Запрет выходных
+// касается только будущих релизов: уже вышедшая в выходной игра допустима. День недели
+// берется в часовом поясе, в котором дата передана.
+func (p GamePolicy) CheckReleaseDate(releaseDate, now time.Time) error {
+	if releaseDate.After(now.AddDate(0, 0, p.MaxReleaseAheadDays)) {
+		return &PolicyViolation{Rule: RuleReleaseHorizon, Limit: p.MaxReleaseAheadDays}
+	}
+	if !p.AllowWeekendReleases && releaseDate.After(now) {
+		if day := releaseDate.Weekday(); day == time.Saturday || day == time.Sunday {
+			return &PolicyViolation{Rule: RuleWeekendRelease}
+		}
+	}
+	return nil
+}
+
+// CheckDailyQuota проверяет, может ли администратор, уже создавший created игр за сутки,
+// создать еще count.
+func (p GamePolicy) CheckDailyQuota(created, count int) error {
+	if p.DailyCreateQuota > 0 && created+count > p.DailyCreateQuota {
+		return &PolicyViolation{Rule: RuleDailyCreateQuota, Limit: p.DailyCreateQuota}
+	}
+	return nil
+}
+
+// QuotaDayStart - начало суток (UTC), за которые считается квота создания.
+func QuotaDayStart(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
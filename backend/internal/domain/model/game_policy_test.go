@@ -0,0 +1,35 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGamePolicy_CheckReleaseDate_Weekend(t *testing.T) {
+	policy := GamePolicy{MaxReleaseAheadDays: 365, AllowWeekendReleases: false}
+	// Среда, от нее ближайшие выходные известны
+	now := time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC)
+
+	// Игра, вышедшая в выходной, допустима: правило касается только назначения релиза
+	pastSaturday := time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC)
+	if err := policy.CheckReleaseDate(pastSaturday, now); err != nil {
+		t.Fatalf("expected past weekend release to be allowed, got %v", err)
+	}
+
+	futureSunday := time.Date(2024, 6, 16, 0, 0, 0, 0, time.UTC)
+	var violation *PolicyViolation
+	if err := policy.CheckReleaseDate(futureSunday, now); !errors.As(err, &violation) || violation.Rule != RuleWeekendRelease {
+		t.Fatalf("expected weekendRelease violation for future sunday, got %v", err)
+	}
+
+	futureMonday := time.Date(2024, 6, 17, 0, 0, 0, 0, time.UTC)
+	if err := policy.CheckReleaseDate(futureMonday, now); err != nil {
+		t.Fatalf("expected future weekday release to be allowed, got %v", err)
+	}
+
+	policy.AllowWeekendReleases = true
+	if err := policy.CheckReleaseDate(futureSunday, now); err != nil {
+		t.Fatalf("expected weekend release to be allowed by policy, got %v", err)
+	}
+}
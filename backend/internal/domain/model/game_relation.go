@@ -0,0 +1,90 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы связей между играми. Связь направлена: GameID - продолжение, дополнение
+// или переиздание игры RelatedID. У игры не больше одной связи каждого типа.
+const (
+	// RelationSequel - GameID продолжает RelatedID (RelatedID - приквел)
+	RelationSequel = "sequel"
+	// RelationDLC - GameID - дополнение к RelatedID
+	RelationDLC = "dlc"
+	// RelationRemaster - GameID - ремастер или ремейк RelatedID
+	RelationRemaster = "remaster"
+)
+
+var (
+	ErrSelfRelation = errors.New("game cannot be related to itself")
+	// ErrRelationCycle - с новой связью игра через цепочку связей стала бы связана сама с собой
+	ErrRelationCycle = errors.New("game relation creates a cycle")
+)
+
+type GameRelation struct {
+	GameID    uuid.UUID
+	RelatedID uuid.UUID
+	Type      string
+	CreatedAt time.Time
+}
+
+func NewGameRelationWithValidate(gameID, relatedID uuid.UUID, relationType string) (*GameRelation, error) {
+	if gameID == uuid.Nil || relatedID == uuid.Nil {
+		return nil, errors.New("game ID is required")
+	}
+	if !IsRelationType(relationType) {
+		return nil, errors.New("relation type must be sequel, dlc or remaster")
+	}
+	if gameID == relatedID {
+		return nil, ErrSelfRelation
+	}
+
+	return &GameRelation{
+		GameID:    gameID,
+		RelatedID: relatedID,
+		Type:      relationType,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+func IsRelationType(relationType string) bool {
+	switch relationType {
+	case RelationSequel, RelationDLC, RelationRemaster:
+		return true
+	}
+	return false
+}
+
+// CheckRelationCycle проверяет, что связь candidate не замыкает цикл среди связей relations.
+// Связи всех типов рассматриваются как один граф: игра не может, пройдя по цепочке
+// продолжений, дополнений и переизданий, оказаться предком самой себя.
+// Прежняя связь candidate.GameID того же типа не учитывается - candidate ее заменяет.
+func CheckRelationCycle(relations []*GameRelation, candidate *GameRelation) error {
+	next := make(map[uuid.UUID][]uuid.UUID, len(relations))
+	for _, r := range relations {
+		if r.GameID == candidate.GameID && r.Type == candidate.Type {
+			continue
+		}
+		next[r.GameID] = append(next[r.GameID], r.RelatedID)
+	}
+
+	// Цикл есть, если из RelatedID по связям достижима GameID
+	visited := map[uuid.UUID]bool{}
+	stack := []uuid.UUID{candidate.RelatedID}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == candidate.GameID {
+			return ErrRelationCycle
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		stack = append(stack, next[id]...)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"bytes"
+	"math"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// GameSimilarity - насколько игра SimilarID похожа на игру GameID. Score - взвешенная сумма
+// пересечения жанров (GenreScore) и похожести оценок пользователей (RatingScore), все от 0 до 1.
+type GameSimilarity struct {
+	GameID      uuid.UUID
+	SimilarID   uuid.UUID
+	Score       float64
+	GenreScore  float64
+	RatingScore float64
+	// CoRatings - сколько пользователей оценили обе игры
+	CoRatings int
+}
+
+// SimilarityParams - параметры расчета похожести.
+type SimilarityParams struct {
+	// GenreWeight - доля пересечения жанров в итоговой похожести, остальное - оценки
+	GenreWeight float64
+	// Shrinkage - сглаживание похожести по оценкам: при n совместных оценках она умножается
+	// на n / (n + Shrinkage), чтобы пара игр с одним-двумя общими игроками не выходила вперед
+	Shrinkage float64
+	// TopK - сколько самых похожих игр хранится для каждой игры
+	TopK int
+}
+
+// similarityPair - накопленные суммы по паре игр a < b
+type similarityPair struct {
+	dot, normA, normB float64
+	coRatings         int
+}
+
+type pairKey struct {
+	a, b uuid.UUID
+}
+
+func newPairKey(x, y uuid.UUID) pairKey {
+	if bytes.Compare(x[:], y[:]) > 0 {
+		x, y = y, x
+	}
+	return pairKey{a: x, b: y}
+}
+
+// ComputeSimilarities считает похожесть игр друг на друга и оставляет для каждой игры
+// TopK самых похожих (только с Score > 0), самые похожие первыми.
+//
+// Жанры сравниваются по коэффициенту Жаккара. Оценки - скорректированным косинусом
+// (item-item collaborative filtering): из оценки вычитается средняя оценка ее автора,
+// косинус считается по пользователям, оценившим обе игры; отрицательная похожесть
+// считается нулевой. Оценки игр не из games не учитываются.
+func ComputeSimilarities(games []*Game, ratings []*UserRating, params SimilarityParams) []*GameSimilarity {
+	genres := make(map[uuid.UUID]map[uuid.UUID]bool, len(games))
+	byGenre := map[uuid.UUID][]uuid.UUID{}
+	for _, game := range games {
+		ids := game.GenreIDs
+		if len(ids) == 0 {
+			ids = []uuid.UUID{game.GenreID}
+		}
+		set := make(map[uuid.UUID]bool, len(ids))
+		for _, genreID := range ids {
+			if !set[genreID] {
+				set[genreID] = true
+				byGenre[genreID] = append(byGenre[genreID], game.ID)
+			}
+		}
+		genres[game.ID] = set
+	}
+
+	pairs := map[pairKey]*similarityPair{}
+	pair := func(key pairKey) *similarityPair {
+		p, ok := pairs[key]
+		if !ok {
+			p = &similarityPair{}
+			pairs[key] = p
+		}
+		return p
+	}
+
+	// Кандидаты по жанрам - игры хотя бы с одним общим жанром
+	for _, ids := range byGenre {
+		for i := range ids {
+			for j := i + 1; j < len(ids); j++ {
+				pair(newPairKey(ids[i], ids[j]))
+			}
+		}
+	}
+
+	// Оценки каждого пользователя за вычетом его средней оценки
+	byUser := map[uuid.UUID][]*UserRating{}
+	for _, rating := range ratings {
+		if _, ok := genres[rating.GameID]; ok {
+			byUser[rating.UserID] = append(byUser[rating.UserID], rating)
+		}
+	}
+	for _, userRatings := range byUser {
+		mean := 0.0
+		for _, rating := range userRatings {
+			mean += float64(rating.Rating)
+		}
+		mean /= float64(len(userRatings))
+
+		for i := range userRatings {
+			for j := i + 1; j < len(userRatings); j++ {
+				x, y := userRatings[i], userRatings[j]
+				if x.GameID == y.GameID {
+					continue
+				}
+				key := newPairKey(x.GameID, y.GameID)
+				dx, dy := float64(x.Rating)-mean, float64(y.Rating)-mean
+				if key.a != x.GameID {
+					dx, dy = dy, dx
+				}
+				p := pair(key)
+				p.dot += dx * dy
+				p.normA += dx * dx
+				p.normB += dy * dy
+				p.coRatings++
+			}
+		}
+	}
+
+	neighbours := map[uuid.UUID][]*GameSimilarity{}
+	for key, p := range pairs {
+		genreScore := jaccard(genres[key.a], genres[key.b])
+		ratingScore := 0.0
+		if p.normA > 0 && p.normB > 0 {
+			cosine := p.dot / math.Sqrt(p.normA*p.normB)
+			n := float64(p.coRatings)
+			ratingScore = math.Max(0, cosine) * n / (n + params.Shrinkage)
+		}
+		score := params.GenreWeight*genreScore + (1-params.GenreWeight)*ratingScore
+		if score <= 0 {
+			continue
+		}
+
+		for _, ids := range [][2]uuid.UUID{{key.a, key.b}, {key.b, key.a}} {
+			neighbours[ids[0]] = append(neighbours[ids[0]], &GameSimilarity{
+				GameID:      ids[0],
+				SimilarID:   ids[1],
+				Score:       score,
+				GenreScore:  genreScore,
+				RatingScore: ratingScore,
+				CoRatings:   p.coRatings,
+			})
+		}
+	}
+
+	res := []*GameSimilarity{}
+	for _, items := range neighbours {
+		SortSimilarities(items)
+		if params.TopK > 0 && len(items) > params.TopK {
+			items = items[:params.TopK]
+		}
+		res = append(res, items...)
+	}
+	return res
+}
+
+// SortSimilarities упорядочивает похожие игры: самые похожие первыми, при равенстве - по ID.
+func SortSimilarities(items []*GameSimilarity) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return bytes.Compare(items[i].SimilarID[:], items[j].SimilarID[:]) < 0
+	})
+}
+
+func jaccard(a, b map[uuid.UUID]bool) float64 {
+	common := 0
+	for id := range a {
+		if b[id] {
+			common++
+		}
+	}
+	union := len(a) + len(b) - common
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// Статусы публикации игры. Публичный каталог показывает только опубликованные игры.
+const (
+	// GameStatusDraft - черновик, виден только администраторам
+	GameStatusDraft = "draft"
+	// GameStatusScheduled - публикация запланирована на PublishAt
+	GameStatusScheduled = "scheduled"
+	// GameStatusPublished - игра видна всем
+	GameStatusPublished = "published"
+	// GameStatusArchived - игра снята с витрины, но осталась в истории
+	GameStatusArchived = "archived"
+)
+
+var (
+	// ErrInvalidStatusTransition - из текущего статуса в запрошенный перейти нельзя
+	ErrInvalidStatusTransition = errors.New("invalid game status transition")
+	// ErrPublishAtNotInFuture - момент запланированной публикации уже наступил
+	ErrPublishAtNotInFuture = errors.New("publish time must be in the future")
+	// ErrUnknownGameStatus - статус не из GameStatus*
+	ErrUnknownGameStatus = errors.New("unknown game status")
+	// ErrPublishAtMismatch - момент публикации задан не у запланированной игры или не задан у нее
+	ErrPublishAtMismatch = errors.New("publish time must be set for scheduled games only")
+)
+
+// IsGameStatus проверяет, что status - один из известных статусов.
+func IsGameStatus(status string) bool {
+	switch status {
+	case GameStatusDraft, GameStatusScheduled, GameStatusPublished, GameStatusArchived:
+		return true
+	}
+	return false
+}
+
+// IsPublished сообщает, видна ли игра в публичном каталоге.
+func (g *Game) IsPublished() bool {
+	return g.Status == GameStatusPublished
+}
+
+// Publish публикует игру сразу: из черновика, запланированной или архивной.
+func (g *Game) Publish() error {
+	switch g.Status {
+	case GameStatusDraft, GameStatusScheduled, GameStatusArchived:
+	default:
+		return ErrInvalidStatusTransition
+	}
+	g.Status = GameStatusPublished
+	g.PublishAt = nil
+	return nil
+}
+
+// Schedule назначает публикацию черновика на момент at (позже now); у запланированной
+// игры переносит этот момент.
+func (g *Game) Schedule(at, now time.Time) error {
+	switch g.Status {
+	case GameStatusDraft, GameStatusScheduled:
+	default:
+		return ErrInvalidStatusTransition
+	}
+	if !at.After(now) {
+		return ErrPublishAtNotInFuture
+	}
+	at = at.UTC()
+	g.Status = GameStatusScheduled
+	g.PublishAt = &at
+	return nil
+}
+
+// Unpublish возвращает опубликованную или запланированную игру в черновики.
+func (g *Game) Unpublish() error {
+	switch g.Status {
+	case GameStatusScheduled, GameStatusPublished:
+	default:
+		return ErrInvalidStatusTransition
+	}
+	g.Status = GameStatusDraft
+	g.PublishAt = nil
+	return nil
+}
+
+// Archive снимает опубликованную игру с витрины.
+func (g *Game) Archive() error {
+	if g.Status != GameStatusPublished {
+		return ErrInvalidStatusTransition
+	}
+	g.Status = GameStatusArchived
+	g.PublishAt = nil
+	return nil
+}
+
+// PublishDue публикует запланированную игру, если ее время наступило к now;
+// false, если публиковать пока нечего.
+func (g *Game) PublishDue(now time.Time) bool {
+	if g.Status != GameStatusScheduled || g.PublishAt == nil || g.PublishAt.After(now) {
+		return false
+	}
+	g.Status = GameStatusPublished
+	g.PublishAt = nil
+	return true
+}
+
+// RestoreStatus задает статус, сохраненный ранее (например, в выгрузке каталога), без правил
+// переходов. publishAt нужен только статусу GameStatusScheduled; если он уже прошел, игру
+// опубликует ближайшая проверка расписания.
+func (g *Game) RestoreStatus(status string, publishAt *time.Time) error {
+	if !IsGameStatus(status) {
+		return ErrUnknownGameStatus
+	}
+	if (status == GameStatusScheduled) != (publishAt != nil) {
+		return ErrPublishAtMismatch
+	}
+	g.Status = status
+	g.PublishAt = publishAt
+	return nil
+}
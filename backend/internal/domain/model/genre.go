@@ -3,6 +3,7 @@ package model
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -10,6 +11,10 @@ import (
 type Genre struct {
 	ID    uuid.UUID
 	Title string
+	// DeletedAt - момент перемещения в корзину; nil у активных записей
+	DeletedAt *time.Time
+	// Version растет на 1 при каждом изменении записи (оптимистическая блокировка)
+	Version int
 }
 
 func NewGenreWithValidate(title string) (*Genre, error) {
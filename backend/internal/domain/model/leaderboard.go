@@ -0,0 +1,76 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Окна таблицы рекордов
+const (
+	LeaderboardWindowAll = "all"
+	// LeaderboardWindowMonth - результаты за последний месяц
+	LeaderboardWindowMonth = "month"
+	// LeaderboardWindowWeek - результаты за последние 7 дней
+	LeaderboardWindowWeek = "week"
+)
+
+var ErrLeaderboardWindow = errors.New("leaderboard window must be all, month or week")
+
+// LeaderboardEntry - лучший результат пользователя в таблице рекордов и его место (с 1).
+type LeaderboardEntry struct {
+	Rank       int
+	UserID     uuid.UUID
+	Username   string
+	Score      int64
+	Duration   time.Duration
+	AchievedAt time.Time
+}
+
+// Leaderboard - первые места таблицы рекордов, место запросившего пользователя (nil, если
+// у него нет результатов) и число участников.
+type Leaderboard struct {
+	Entries      []*LeaderboardEntry
+	Caller       *LeaderboardEntry
+	Participants int
+}
+
+// LeaderboardSince - с какого момента учитываются результаты окна window; zero для all.
+func LeaderboardSince(window string, now time.Time) (time.Time, error) {
+	switch window {
+	case "", LeaderboardWindowAll:
+		return time.Time{}, nil
+	case LeaderboardWindowMonth:
+		return now.AddDate(0, -1, 0), nil
+	case LeaderboardWindowWeek:
+		return now.AddDate(0, 0, -7), nil
+	default:
+		return time.Time{}, ErrLeaderboardWindow
+	}
+}
+
+// CompareResults упорядочивает результаты для таблицы рекордов: больший счет выше,
+// при равном счете - меньшее время, затем более раннее достижение. Отрицательное
+// значение - a выше b.
+func CompareResults(a, b *PlayResult) int {
+	switch {
+	case a.Score != b.Score:
+		if a.Score > b.Score {
+			return -1
+		}
+		return 1
+	case a.Duration != b.Duration:
+		if a.Duration < b.Duration {
+			return -1
+		}
+		return 1
+	case !a.SubmittedAt.Equal(b.SubmittedAt):
+		if a.SubmittedAt.Before(b.SubmittedAt) {
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PlayResultMaxDuration - наибольшее время игры, которое может сообщить игра
+	PlayResultMaxDuration = 24 * time.Hour
+	// PlayResultMaxDetailsBytes - предельный размер подробностей результата в JSON
+	PlayResultMaxDetailsBytes = 16 << 10
+)
+
+var (
+	ErrPlayResultDuration = errors.New("play result duration is out of range")
+	ErrPlayResultDetails  = errors.New("play result details must be a JSON object")
+)
+
+// PlayResult - результат попытки, который отправила игра: счет, время, признак успеха
+// и произвольные подробности (Details, JSON-объект). Passed - засчитана ли попытка:
+// в задаче - по ее условиям прохождения, в свободной игре - по признаку успеха.
+type PlayResult struct {
+	ID        uuid.UUID
+	AttemptID uuid.UUID
+	UserID    uuid.UUID
+	GameID    uuid.UUID
+	// TaskID - задача попытки; uuid.Nil в свободном режиме
+	TaskID      uuid.UUID
+	Score       int64
+	Duration    time.Duration
+	Success     bool
+	Passed      bool
+	Details     json.RawMessage
+	SubmittedAt time.Time
+}
+
+// NewPlayResultWithValidate создает результат попытки attempt. task - задача попытки
+// (nil в свободном режиме или если задачу уже удалили), по ее условиям вычисляется Passed.
+// Пустые details сохраняются как пустой объект.
+func NewPlayResultWithValidate(attempt *Attempt, task *Task,
+	score int64, duration time.Duration, success bool, details []byte) (*PlayResult, error) {
+
+	if attempt == nil {
+		return nil, errors.New("attempt is required")
+	}
+	if duration < 0 || duration > PlayResultMaxDuration {
+		return nil, ErrPlayResultDuration
+	}
+	normalized, err := normalizeDetails(details)
+	if err != nil {
+		return nil, err
+	}
+
+	passed := success
+	if task != nil {
+		passed = task.PassCriteria.IsMet(score, duration, success)
+	}
+	return &PlayResult{
+		ID:          uuid.New(),
+		AttemptID:   attempt.ID,
+		UserID:      attempt.UserID,
+		GameID:      attempt.GameID,
+		TaskID:      attempt.TaskID,
+		Score:       score,
+		Duration:    duration,
+		Success:     success,
+		Passed:      passed,
+		Details:     normalized,
+		SubmittedAt: time.Now().UTC(),
+	}, nil
+}
+
+// AttemptStatus - статус, в который результат переводит попытку.
+func (r *PlayResult) AttemptStatus() string {
+	if r.Passed {
+		return AttemptCompleted
+	}
+	return AttemptFailed
+}
+
+// normalizeDetails проверяет, что подробности - JSON-объект допустимого размера, и убирает из них пробелы.
+func normalizeDetails(details []byte) (json.RawMessage, error) {
+	details = bytes.TrimSpace(details)
+	if len(details) == 0 || bytes.Equal(details, []byte("null")) {
+		return json.RawMessage("{}"), nil
+	}
+	if len(details) > PlayResultMaxDetailsBytes || details[0] != '{' || !json.Valid(details) {
+		return nil, ErrPlayResultDetails
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, details); err != nil {
+		return nil, ErrPlayResultDetails
+	}
+	return json.RawMessage(compact.Bytes()), nil
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GamePlayStats - статистика результатов пользователя в одной игре. Лучшее и медианное
+// время считаются по засчитанным попыткам (Passed); nil, если таких нет.
+type GamePlayStats struct {
+	GameID       uuid.UUID
+	GameTitle    string
+	Plays        int
+	Completions  int
+	BestScore    int64
+	BestTime     *time.Duration
+	MedianTime   *time.Duration
+	LastPlayedAt time.Time
+}
+
+// GenrePlayStats - итоги пользователя по жанру. Игра учитывается во всех своих жанрах,
+// поэтому сумма по жанрам может быть больше общего числа результатов.
+type GenrePlayStats struct {
+	GenreID     uuid.UUID
+	GenreTitle  string
+	Games       int
+	Plays       int
+	Completions int
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Типы сущностей, для которых ведется история изменений
+const (
+	RevisionEntityGame  = "game"
+	RevisionEntityGenre = "genre"
+)
+
+// Действия, после которых сохраняется ревизия
+const (
+	RevisionActionCreate  = "create"
+	RevisionActionUpdate  = "update"
+	RevisionActionDelete  = "delete"
+	RevisionActionRestore = "restore"
+	RevisionActionRevert  = "revert"
+	// Смена статуса публикации (в том числе публикация по расписанию)
+	RevisionActionPublish   = "publish"
+	RevisionActionSchedule  = "schedule"
+	RevisionActionUnpublish = "unpublish"
+	RevisionActionArchive   = "archive"
+)
+
+// Revision - неизменяемая запись истории: состояние сущности после действия.
+type Revision struct {
+	ID         uuid.UUID
+	EntityType string
+	EntityID   uuid.UUID
+	// Number - порядковый номер ревизии в истории сущности, с 1
+	Number int
+	Action string
+	// Snapshot - состояние сущности в JSON (GameSnapshot или GenreSnapshot)
+	Snapshot json.RawMessage
+	// ActorID - кто внес изменение; uuid.Nil, если пользователь неизвестен
+	ActorID uuid.UUID
+	// RevertedFrom - номер ревизии, к которой выполнен откат (только для revert)
+	RevertedFrom int
+	CreatedAt    time.Time
+}
+
+// GameSnapshot - сохраняемое в ревизии состояние игры. Статус публикации попадает
+// в снимок для истории, но откат его не возвращает: статус меняют только переходы.
+type GameSnapshot struct {
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	ReleaseDate time.Time   `json:"releaseDate"`
+	GenreID     uuid.UUID   `json:"genreId"`
+	GenreIDs    []uuid.UUID `json:"genreIds"`
+	Status      string      `json:"status,omitempty"`
+	PublishAt   *time.Time  `json:"publishAt,omitempty"`
+}
+
+func NewGameSnapshot(g *Game) GameSnapshot {
+	return GameSnapshot{
+		Title:       g.Title,
+		Description: g.Description,
+		ReleaseDate: g.ReleaseDate,
+		GenreID:     g.GenreID,
+		GenreIDs:    append([]uuid.UUID(nil), g.GenreIDs...),
+		Status:      g.Status,
+		PublishAt:   g.PublishAt,
+	}
+}
+
+// GenreSnapshot - сохраняемое в ревизии состояние жанра
+type GenreSnapshot struct {
+	Title string `json:"title"`
+}
+
+func NewGenreSnapshot(g *Genre) GenreSnapshot {
+	return GenreSnapshot{Title: g.Title}
+}
+
+// FieldChange - изменение одного поля между двумя ревизиями; nil - поля не было
+type FieldChange struct {
+	Field string
+	From  any
+	To    any
+}
+
+// DiffSnapshots сравнивает два снимка по полям верхнего уровня.
+// Изменения упорядочены по имени поля.
+func DiffSnapshots(from, to json.RawMessage) ([]FieldChange, error) {
+	var a, b map[string]any
+	if err := json.Unmarshal(from, &a); err != nil {
+		return nil, errors.New("invalid revision snapshot")
+	}
+	if err := json.Unmarshal(to, &b); err != nil {
+		return nil, errors.New("invalid revision snapshot")
+	}
+
+	fields := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		fields[k] = struct{}{}
+	}
+	for k := range b {
+		fields[k] = struct{}{}
+	}
+
+	changes := make([]FieldChange, 0)
+	for field := range fields {
+		if !reflect.DeepEqual(a[field], b[field]) {
+			changes = append(changes, FieldChange{Field: field, From: a[field], To: b[field]})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	return changes, nil
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrSeriesDuplicateGame = errors.New("game is listed in series twice")
+
+// Series - серия игр. Игра входит не больше чем в одну серию;
+// порядок игр в серии задается порядком GameIDs.
+type Series struct {
+	ID        uuid.UUID
+	Title     string
+	GameIDs   []uuid.UUID
+	CreatedAt time.Time
+}
+
+func NewSeriesWithValidate(title string, gameIDs []uuid.UUID) (*Series, error) {
+	series := &Series{
+		ID:        uuid.New(),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := series.UpdateWithValidate(title, gameIDs); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// UpdateWithValidate заменяет название и состав серии.
+func (s *Series) UpdateWithValidate(title string, gameIDs []uuid.UUID) error {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return errors.New("series title is required")
+	}
+	if len(title) > 200 {
+		return errors.New("series title is too long")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(gameIDs))
+	for _, id := range gameIDs {
+		if seen[id] {
+			return ErrSeriesDuplicateGame
+		}
+		seen[id] = true
+	}
+
+	s.Title = title
+	s.GameIDs = append([]uuid.UUID{}, gameIDs...)
+	return nil
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Сложность задачи
+const (
+	TaskDifficultyEasy   = "easy"
+	TaskDifficultyMedium = "medium"
+	TaskDifficultyHard   = "hard"
+)
+
+const (
+	maxTaskTitleLength        = 200
+	maxTaskInstructionsLength = 10000
+	maxTaskLaunchParams       = 50
+	maxTaskLaunchParamKey     = 64
+	maxTaskLaunchParamValue   = 1024
+)
+
+var (
+	ErrTaskDifficulty           = errors.New("task difficulty must be easy, medium or hard")
+	ErrTaskLaunchParams         = errors.New("task launch parameters are invalid")
+	ErrTaskLaunchParamsReserved = errors.New("task launch parameter name is reserved or duplicated")
+	ErrTaskPassCriteria         = errors.New("task pass criteria are invalid")
+	ErrTaskOrder                = errors.New("task order cannot be negative")
+)
+
+// reservedLaunchParams - имена, под которыми игра получает данные самой попытки;
+// параметр задачи с таким именем (в любом регистре) подменил бы их.
+var reservedLaunchParams = []string{
+	"launchToken", "callbackUrl", "attemptId", "userId", "gameId", "taskId", "mode", "seed",
+}
+
+// IsLaunchParamName - имя подходит для параметра запуска задачи: латинские буквы, цифры и "_"
+// и не совпадает с зарезервированным без учета регистра.
+func IsLaunchParamName(key string) bool {
+	if key == "" || len(key) > maxTaskLaunchParamKey {
+		return false
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return !isReservedLaunchParam(key)
+}
+
+func isReservedLaunchParam(key string) bool {
+	for _, reserved := range reservedLaunchParams {
+		if strings.EqualFold(key, reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskPassCriteria - условия, при которых результат игры засчитывается как прохождение задачи.
+// Пустые условия засчитывают любой завершенный запуск.
+type TaskPassCriteria struct {
+	// MinScore - минимальный счет; nil - счет не важен
+	MinScore *int64
+	// MaxDuration - наибольшее время прохождения; 0 - без ограничения
+	MaxDuration time.Duration
+	// RequireSuccess - игра должна сообщить об успешном завершении
+	RequireSuccess bool
+}
+
+// IsMet - результат игры (счет, время, признак успеха) удовлетворяет условиям.
+func (c TaskPassCriteria) IsMet(score int64, duration time.Duration, success bool) bool {
+	if c.MinScore != nil && score < *c.MinScore {
+		return false
+	}
+	if c.MaxDuration > 0 && duration > c.MaxDuration {
+		return false
+	}
+	return success || !c.RequireSuccess
+}
+
+// Task - задача в игре: что нужно сделать игроку (Instructions), с какими параметрами
+// запускается игра (LaunchParams) и когда задача считается пройденной (PassCriteria).
+// Задачи игры упорядочены по Order, при равенстве - по времени создания.
+type Task struct {
+	ID           uuid.UUID
+	GameID       uuid.UUID
+	Title        string
+	Instructions string
+	Difficulty   string
+	LaunchParams map[string]string
+	PassCriteria TaskPassCriteria
+	Order        int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewTaskWithValidate создает задачу игры gameID. Order 0 означает "в конец списка",
+// номер назначает репозиторий.
+func NewTaskWithValidate(gameID uuid.UUID, title, instructions, difficulty string,
+	launchParams map[string]string, criteria TaskPassCriteria, order int) (*Task, error) {
+
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID is required")
+	}
+
+	now := time.Now().UTC()
+	task := &Task{
+		ID:        uuid.New(),
+		GameID:    gameID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := task.UpdateWithValidate(title, instructions, difficulty, launchParams, criteria, order); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// UpdateWithValidate заменяет содержимое задачи; пустая сложность считается средней.
+func (t *Task) UpdateWithValidate(title, instructions, difficulty string,
+	launchParams map[string]string, criteria TaskPassCriteria, order int) error {
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return errors.New("task title is required")
+	}
+	if len(title) > maxTaskTitleLength {
+		return errors.New("task title is too long")
+	}
+	instructions = strings.TrimSpace(instructions)
+	if len(instructions) > maxTaskInstructionsLength {
+		return errors.New("task instructions are too long")
+	}
+
+	difficulty = strings.ToLower(strings.TrimSpace(difficulty))
+	if difficulty == "" {
+		difficulty = TaskDifficultyMedium
+	}
+	if !IsTaskDifficulty(difficulty) {
+		return ErrTaskDifficulty
+	}
+
+	if len(launchParams) > maxTaskLaunchParams {
+		return ErrTaskLaunchParams
+	}
+	params := make(map[string]string, len(launchParams))
+	// Игры разбирают аргументы по-разному, в том числе без учета регистра,
+	// поэтому имена, различающиеся только регистром, считаются одинаковыми
+	folded := make(map[string]bool, len(launchParams))
+	for key, value := range launchParams {
+		key = strings.TrimSpace(key)
+		if isReservedLaunchParam(key) {
+			return ErrTaskLaunchParamsReserved
+		}
+		if !IsLaunchParamName(key) || len(value) > maxTaskLaunchParamValue {
+			return ErrTaskLaunchParams
+		}
+		if folded[strings.ToLower(key)] {
+			return ErrTaskLaunchParamsReserved
+		}
+		folded[strings.ToLower(key)] = true
+		params[key] = value
+	}
+
+	if criteria.MaxDuration < 0 {
+		return ErrTaskPassCriteria
+	}
+	if order < 0 {
+		return ErrTaskOrder
+	}
+
+	t.Title = title
+	t.Instructions = instructions
+	t.Difficulty = difficulty
+	t.LaunchParams = params
+	t.PassCriteria = criteria
+	t.Order = order
+	t.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func IsTaskDifficulty(difficulty string) bool {
+	return difficulty == TaskDifficultyEasy || difficulty == TaskDifficultyMedium || difficulty == TaskDifficultyHard
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeTitle приводит название к ключу уникальности: NFKC, свертка регистра,
+// без пробелов по краям и с одиночными пробелами внутри. "Tetris" и " ＴＥＴＲＩＳ "
+// дают один и тот же ключ.
+func NormalizeTitle(title string) string {
+	title = norm.NFKC.String(title)
+	title = cases.Fold().String(title)
+	// Свертка регистра может нарушить нормальную форму (например, у лигатур)
+	title = norm.NFKC.String(title)
+	return strings.Join(strings.Fields(title), " ")
+}
+
+// TitleSimilarity оценивает похожесть двух названий от 0 до 1 по нормализованным ключам:
+// берется лучшая из оценок по совпадению триграмм и по расстоянию Левенштейна.
+// Триграммы ловят перестановку слов, Левенштейн - опечатки в коротких названиях.
+func TitleSimilarity(a, b string) float64 {
+	a, b = NormalizeTitle(a), NormalizeTitle(b)
+	if a == b {
+		return 1
+	}
+	if a == "" || b == "" {
+		return 0
+	}
+	return max(trigramSimilarity(a, b), levenshteinSimilarity(a, b))
+}
+
+// trigramSimilarity - коэффициент Жаккара по множествам триграмм слов (как pg_trgm:
+// каждое слово дополняется двумя пробелами слева и одним справа).
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+func trigrams(s string) map[string]struct{} {
+	res := make(map[string]struct{})
+	for _, word := range strings.Fields(s) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			res[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+	return res
+}
+
+// levenshteinSimilarity - 1 минус расстояние Левенштейна (в символах), деленное на длину более длинной строки.
+func levenshteinSimilarity(a, b string) float64 {
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	return 1 - float64(levenshtein([]rune(a), []rune(b)))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
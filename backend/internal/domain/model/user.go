@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	specifictype "example/web-service-gin/internal/domain/specific_type"
 
 	"github.com/google/uuid"
@@ -11,4 +13,8 @@ type User struct {
 	Password string
 	Username string
 	UserRole specifictype.UserRole
+	// DeletedAt - момент перемещения в корзину; nil у активных записей
+	DeletedAt *time.Time
+	// Version растет на 1 при каждом изменении записи (оптимистическая блокировка)
+	Version int
 }
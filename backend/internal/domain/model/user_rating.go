@@ -13,3 +13,10 @@ type UserRating struct {
 	Rating    int
 	CreatedAt time.Time
 }
+
+// RatingStats - агрегированная статистика оценок игры.
+type RatingStats struct {
+	GameID  uuid.UUID
+	Average float64
+	Count   int
+}
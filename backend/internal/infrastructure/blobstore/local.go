@@ -0,0 +1,97 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"example/web-service-gin/internal/application/abstraction/blob"
+)
+
+// compile-time check
+var _ blob.Store = (*LocalStore)(nil)
+
+// LocalStore хранит файлы в каталоге root: ключ abcdef... лежит в root/ab/cd/abcdef...
+// Запись идет во временный файл и переименовывается, поэтому читатели не видят недописанных файлов.
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore(root string) (*LocalStore, error) {
+	if err := os.MkdirAll(filepath.Join(root, "tmp"), 0o755); err != nil {
+		return nil, fmt.Errorf("mkdir blob store: %w", err)
+	}
+	return &LocalStore{root: root}, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, r io.Reader) (blob.Object, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.root, "tmp"), "upload-*")
+	if err != nil {
+		return blob.Object{}, fmt.Errorf("create temp blob: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return blob.Object{}, fmt.Errorf("write blob: %w", err)
+	}
+
+	obj := blob.Object{Key: hex.EncodeToString(hash.Sum(nil)), Size: size}
+	path := s.path(obj.Key)
+	if _, err := os.Stat(path); err == nil {
+		return obj, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return blob.Object{}, fmt.Errorf("mkdir blob dir: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return blob.Object{}, fmt.Errorf("store blob: %w", err)
+	}
+	return obj, nil
+}
+
+func (s *LocalStore) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	if !validKey(key) {
+		return nil, blob.ErrNotFound
+	}
+	f, err := os.Open(s.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, blob.ErrNotFound
+		}
+		return nil, fmt.Errorf("open blob: %w", err)
+	}
+	return f, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if !validKey(key) {
+		return nil
+	}
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete blob: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.root, key[:2], key[2:4], key)
+}
+
+// validKey пропускает только hex SHA-256, чтобы ключ нельзя было использовать как путь.
+func validKey(key string) bool {
+	if len(key) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
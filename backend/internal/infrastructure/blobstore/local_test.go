@@ -0,0 +1,58 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"example/web-service-gin/internal/application/abstraction/blob"
+)
+
+func TestLocalStore_ContentAddressed(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+
+	first, err := store.Put(ctx, strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	// sha256("hello")
+	if first.Key != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" || first.Size != 5 {
+		t.Fatalf("unexpected object: %+v", first)
+	}
+	second, err := store.Put(ctx, strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("Put again: %v", err)
+	}
+	if second != first {
+		t.Fatalf("same content must get the same key: %+v vs %+v", first, second)
+	}
+
+	f, err := store.Open(ctx, first.Key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	data, _ := io.ReadAll(f)
+	_ = f.Close()
+	if string(data) != "hello" {
+		t.Fatalf("unexpected content %q", data)
+	}
+
+	if err := store.Delete(ctx, first.Key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := store.Delete(ctx, first.Key); err != nil {
+		t.Fatalf("Delete missing: %v", err)
+	}
+	if _, err := store.Open(ctx, first.Key); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := store.Open(ctx, "../../etc/passwd"); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for path-like key, got %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+package buildarchive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"strings"
+
+	"example/web-service-gin/internal/application/abstraction/archive"
+	"example/web-service-gin/internal/domain/model"
+)
+
+// compile-time check
+var _ archive.Inspector = (*Inspector)(nil)
+
+var (
+	zipMagic  = []byte("PK\x03\x04")
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// maxLinkTarget - предельная длина цели символьной ссылки в zip (в zip цель хранится как содержимое)
+const maxLinkTarget = 4096
+
+// Inspector читает оглавление zip и tar.gz средствами стандартной библиотеки
+// и считает SHA-256 каждого файла, читая содержимое потоком.
+type Inspector struct {
+	// maxEntries ограничивает оглавление, чтобы архив из миллионов пустых файлов не занял память
+	maxEntries int
+	// maxUnpacked и maxRatio ограничивают объем распакованных данных (всего и относительно
+	// размера архива), чтобы zip- или gzip-бомба не заняла процессор надолго
+	maxUnpacked int64
+	maxRatio    int64
+}
+
+// NewInspector создает Inspector; нулевой предел не ограничивает.
+func NewInspector(maxEntries int, maxUnpacked int64, maxRatio int64) *Inspector {
+	return &Inspector{maxEntries: maxEntries, maxUnpacked: maxUnpacked, maxRatio: maxRatio}
+}
+
+func (i *Inspector) Inspect(r io.ReaderAt, size int64) (string, []archive.Entry, error) {
+	head := make([]byte, len(zipMagic))
+	n, _ := r.ReadAt(head, 0)
+	head = head[:n]
+
+	budget := i.newBudget(size)
+	switch {
+	case bytes.HasPrefix(head, zipMagic):
+		entries, err := i.inspectZip(r, size, budget)
+		return model.ArchiveFormatZip, entries, err
+	case bytes.HasPrefix(head, gzipMagic):
+		entries, err := i.inspectTarGz(io.NewSectionReader(r, 0, size), budget)
+		return model.ArchiveFormatTarGz, entries, err
+	default:
+		return "", nil, archive.ErrUnsupported
+	}
+}
+
+func (i *Inspector) inspectZip(r io.ReaderAt, size int64, budget *unpackBudget) ([]archive.Entry, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", archive.ErrUnsupported, err)
+	}
+	// Размеры из заголовков позволяют отказать бомбе сразу; фактический объем все равно
+	// считается при чтении, потому что заголовки могут лгать
+	var declared uint64
+	for _, f := range zr.File {
+		declared += f.UncompressedSize64
+	}
+	if err := budget.check(declared); err != nil {
+		return nil, err
+	}
+
+	entries := make([]archive.Entry, 0, len(zr.File))
+	for _, f := range zr.File {
+		if err := i.checkCount(len(entries)); err != nil {
+			return nil, err
+		}
+		entry, err := newEntry(f.Name, int64(f.UncompressedSize64), f.FileInfo().IsDir())
+		if err != nil {
+			return nil, err
+		}
+		if entry.Path == "" {
+			continue
+		}
+		if !entry.IsDir {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", archive.ErrUnsupported, err)
+			}
+			if f.Mode()&fs.ModeSymlink != 0 {
+				// Как и в tar, ссылка допустима только в пределах архива
+				err = checkZipLink(f.Name, budget.reader(rc))
+				entry.Size = 0
+			} else {
+				// Размер из заголовка может не совпадать с содержимым, считаем фактический
+				entry.Size, entry.SHA256, err = hashContent(budget.reader(rc))
+			}
+			_ = rc.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (i *Inspector) inspectTarGz(r io.Reader, budget *unpackBudget) ([]archive.Entry, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", archive.ErrUnsupported, err)
+	}
+	defer gz.Close()
+
+	// Считается весь распакованный поток, включая заголовки и пропускаемое содержимое
+	tr := tar.NewReader(budget.reader(gz))
+	var entries []archive.Entry
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, readError(err)
+		}
+		if err := i.checkCount(len(entries)); err != nil {
+			return nil, err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeXGlobalHeader:
+			continue
+		case tar.TypeSymlink, tar.TypeLink:
+			// Ссылки внутри сборки допустимы (фреймворки macOS), но только в пределах архива
+			if !safeTarLink(hdr) {
+				return nil, fmt.Errorf("%w: %s -> %s", archive.ErrUnsafePath, hdr.Name, hdr.Linkname)
+			}
+		}
+
+		entry, err := newEntry(hdr.Name, hdr.Size, hdr.Typeflag == tar.TypeDir)
+		if err != nil {
+			return nil, err
+		}
+		if entry.Path == "" {
+			continue
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			if entry.Size, entry.SHA256, err = hashContent(tr); err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// hashContent дочитывает файл архива и возвращает его размер и hex SHA-256.
+func hashContent(r io.Reader) (int64, string, error) {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return 0, "", readError(err)
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// checkZipLink читает цель символьной ссылки zip и проверяет, что она не выходит за архив.
+func checkZipLink(name string, r io.Reader) error {
+	target, err := io.ReadAll(io.LimitReader(r, maxLinkTarget+1))
+	if err != nil {
+		return readError(err)
+	}
+	if len(target) > maxLinkTarget || !safeSymlink(name, string(target)) {
+		return fmt.Errorf("%w: %s -> %s", archive.ErrUnsafePath, name, target)
+	}
+	return nil
+}
+
+// readError оставляет archive.ErrTooLarge от unpackBudget как есть, остальное - поврежденный архив.
+func readError(err error) error {
+	if errors.Is(err, archive.ErrTooLarge) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", archive.ErrUnsupported, err)
+}
+
+// unpackBudget - сколько еще байт можно распаковать из архива; limit < 0 - без ограничения.
+type unpackBudget struct {
+	limit int64
+	left  int64
+}
+
+// newBudget: меньший из пределов maxUnpacked и maxRatio * размер архива.
+func (i *Inspector) newBudget(size int64) *unpackBudget {
+	limit := int64(-1)
+	if i.maxUnpacked > 0 {
+		limit = i.maxUnpacked
+	}
+	if i.maxRatio > 0 && size <= math.MaxInt64/i.maxRatio && (limit < 0 || size*i.maxRatio < limit) {
+		limit = size * i.maxRatio
+	}
+	return &unpackBudget{limit: limit, left: limit}
+}
+
+func (b *unpackBudget) check(n uint64) error {
+	if b.limit >= 0 && n > uint64(b.limit) {
+		return b.exceeded()
+	}
+	return nil
+}
+
+func (b *unpackBudget) exceeded() error {
+	return fmt.Errorf("%w: more than %d bytes unpacked", archive.ErrTooLarge, b.limit)
+}
+
+func (b *unpackBudget) reader(r io.Reader) io.Reader {
+	return &budgetReader{r: r, budget: b}
+}
+
+type budgetReader struct {
+	r      io.Reader
+	budget *unpackBudget
+}
+
+func (br *budgetReader) Read(p []byte) (int, error) {
+	n, err := br.r.Read(p)
+	if br.budget.limit >= 0 {
+		br.budget.left -= int64(n)
+		if br.budget.left < 0 {
+			return n, br.budget.exceeded()
+		}
+	}
+	return n, err
+}
+
+func (i *Inspector) checkCount(n int) error {
+	if i.maxEntries > 0 && n >= i.maxEntries {
+		return fmt.Errorf("%w: more than %d entries", archive.ErrUnsupported, i.maxEntries)
+	}
+	return nil
+}
+
+// newEntry нормализует путь элемента; корневой каталог архива дает пустой Path.
+func newEntry(name string, size int64, isDir bool) (archive.Entry, error) {
+	if !safePath(name) {
+		return archive.Entry{}, fmt.Errorf("%w: %s", archive.ErrUnsafePath, name)
+	}
+	clean := strings.TrimPrefix(path.Clean("/"+name), "/")
+	return archive.Entry{Path: clean, Size: size, IsDir: isDir || strings.HasSuffix(name, "/")}, nil
+}
+
+// safeTarLink: цель жесткой ссылки задается от корня архива, символьной - от каталога ссылки.
+func safeTarLink(hdr *tar.Header) bool {
+	if hdr.Typeflag == tar.TypeLink {
+		return safePath(hdr.Linkname)
+	}
+	return safeSymlink(hdr.Name, hdr.Linkname)
+}
+
+// safeSymlink - цель символьной ссылки name относительна и не выходит за пределы архива.
+func safeSymlink(name, target string) bool {
+	if strings.HasPrefix(target, "/") {
+		return false
+	}
+	return safePath(path.Join(path.Dir(name), target))
+}
+
+// safePath - относительный путь без ".." и обратных слешей (их по-разному понимают распаковщики).
+func safePath(name string) bool {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") || strings.Contains(name, ":") {
+		return false
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+package buildarchive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/fs"
+	"reflect"
+	"testing"
+
+	"example/web-service-gin/internal/application/abstraction/archive"
+	"example/web-service-gin/internal/domain/model"
+)
+
+func zipArchive(t *testing.T, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip Create: %v", err)
+		}
+		_, _ = w.Write([]byte("x"))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func tarGzArchive(t *testing.T, headers ...*tar.Header) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, hdr := range headers {
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("tar WriteHeader: %v", err)
+		}
+		if hdr.Size > 0 {
+			_, _ = tw.Write(bytes.Repeat([]byte("x"), int(hdr.Size)))
+		}
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	return buf.Bytes()
+}
+
+func TestInspector_Zip(t *testing.T) {
+	data := zipArchive(t, "Game.exe", "Game_Data/", "./Game_Data/level0")
+
+	format, entries, err := NewInspector(0, 0, 0).Inspect(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	// SHA-256 содержимого "x"
+	const xHash = "2d711642b726b04401627ca9fbac32f5c8530fb1903cc4db02258717921a4881"
+	want := []archive.Entry{
+		{Path: "Game.exe", Size: 1, SHA256: xHash},
+		{Path: "Game_Data", IsDir: true},
+		{Path: "Game_Data/level0", Size: 1, SHA256: xHash},
+	}
+	if format != model.ArchiveFormatZip || !reflect.DeepEqual(entries, want) {
+		t.Fatalf("unexpected result %s %+v", format, entries)
+	}
+
+	unsafe := zipArchive(t, "Game.exe", "../evil")
+	if _, _, err := NewInspector(0, 0, 0).Inspect(bytes.NewReader(unsafe), int64(len(unsafe))); !errors.Is(err, archive.ErrUnsafePath) {
+		t.Fatalf("expected ErrUnsafePath, got %v", err)
+	}
+	if _, _, err := NewInspector(1, 0, 0).Inspect(bytes.NewReader(data), int64(len(data))); !errors.Is(err, archive.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported for too many entries, got %v", err)
+	}
+}
+
+func TestInspector_TarGz(t *testing.T) {
+	data := tarGzArchive(t,
+		&tar.Header{Name: "Game.app/Contents/MacOS/Game", Typeflag: tar.TypeReg, Size: 3, Mode: 0o755},
+		&tar.Header{Name: "Game.app/Contents/Frameworks/Current", Typeflag: tar.TypeSymlink, Linkname: "A"},
+	)
+	format, entries, err := NewInspector(0, 0, 0).Inspect(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if format != model.ArchiveFormatTarGz || len(entries) != 2 || entries[0].Size != 3 || entries[0].SHA256 == "" || entries[1].SHA256 != "" {
+		t.Fatalf("unexpected result %s %+v", format, entries)
+	}
+
+	escaping := tarGzArchive(t, &tar.Header{Name: "Game/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc/passwd"})
+	if _, _, err := NewInspector(0, 0, 0).Inspect(bytes.NewReader(escaping), int64(len(escaping))); !errors.Is(err, archive.ErrUnsafePath) {
+		t.Fatalf("expected ErrUnsafePath for escaping symlink, got %v", err)
+	}
+	absolute := tarGzArchive(t, &tar.Header{Name: "/Game", Typeflag: tar.TypeReg})
+	if _, _, err := NewInspector(0, 0, 0).Inspect(bytes.NewReader(absolute), int64(len(absolute))); !errors.Is(err, archive.ErrUnsafePath) {
+		t.Fatalf("expected ErrUnsafePath for absolute path, got %v", err)
+	}
+
+	text := []byte("not an archive")
+	if _, _, err := NewInspector(0, 0, 0).Inspect(bytes.NewReader(text), int64(len(text))); !errors.Is(err, archive.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestInspector_ZipLinks(t *testing.T) {
+	link := func(name, target string) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		hdr := &zip.FileHeader{Name: name, Method: zip.Store}
+		hdr.SetMode(fs.ModeSymlink | 0o777)
+		w, err := zw.CreateHeader(hdr)
+		if err != nil {
+			t.Fatalf("zip CreateHeader: %v", err)
+		}
+		_, _ = w.Write([]byte(target))
+		_ = zw.Close()
+		return buf.Bytes()
+	}
+
+	inside := link("Game.app/Contents/Frameworks/Current", "A")
+	_, entries, err := NewInspector(0, 0, 0).Inspect(bytes.NewReader(inside), int64(len(inside)))
+	if err != nil || len(entries) != 1 || entries[0].SHA256 != "" || entries[0].Size != 0 {
+		t.Fatalf("unexpected result %+v, %v", entries, err)
+	}
+	for _, target := range []string{"../../etc/passwd", "/etc/passwd"} {
+		escaping := link("Game/link", target)
+		if _, _, err := NewInspector(0, 0, 0).Inspect(bytes.NewReader(escaping), int64(len(escaping))); !errors.Is(err, archive.ErrUnsafePath) {
+			t.Fatalf("expected ErrUnsafePath for symlink to %s, got %v", target, err)
+		}
+	}
+}
+
+func TestInspector_UnpackLimits(t *testing.T) {
+	// Нули сжимаются примерно в 1000 раз: маленький архив обещает много распакованных данных
+	zeros := make([]byte, 1<<20)
+
+	var zbuf bytes.Buffer
+	zw := zip.NewWriter(&zbuf)
+	w, _ := zw.Create("zeros")
+	_, _ = w.Write(zeros)
+	_ = zw.Close()
+	zipBomb := zbuf.Bytes()
+
+	var gbuf bytes.Buffer
+	gz := gzip.NewWriter(&gbuf)
+	tw := tar.NewWriter(gz)
+	_ = tw.WriteHeader(&tar.Header{Name: "zeros", Typeflag: tar.TypeReg, Size: int64(len(zeros))})
+	_, _ = tw.Write(zeros)
+	_ = tw.Close()
+	_ = gz.Close()
+	gzipBomb := gbuf.Bytes()
+
+	for name, data := range map[string][]byte{"zip": zipBomb, "tar.gz": gzipBomb} {
+		if _, _, err := NewInspector(0, 0, 0).Inspect(bytes.NewReader(data), int64(len(data))); err != nil {
+			t.Fatalf("%s without limits: %v", name, err)
+		}
+		if _, _, err := NewInspector(0, 0, 100).Inspect(bytes.NewReader(data), int64(len(data))); !errors.Is(err, archive.ErrTooLarge) {
+			t.Fatalf("%s: expected ErrTooLarge for compression ratio, got %v", name, err)
+		}
+		if _, _, err := NewInspector(0, 1<<19, 0).Inspect(bytes.NewReader(data), int64(len(data))); !errors.Is(err, archive.ErrTooLarge) {
+			t.Fatalf("%s: expected ErrTooLarge for unpacked size, got %v", name, err)
+		}
+	}
+}
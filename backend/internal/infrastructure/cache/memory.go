@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/cache"
+)
+
+var _ cache.Cache = (*MemoryCache)(nil)
+
+// minSweepSize - с какого числа записей MemoryCache начинает выбрасывать просроченные
+const minSweepSize = 1024
+
+type entry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// MemoryCache - кэш в памяти процесса. Просроченные записи удаляются при чтении, а когда
+// записей становится вдвое больше, чем после прошлой очистки, - все сразу.
+type MemoryCache struct {
+	mu        sync.Mutex
+	entries   map[string]entry
+	sweepSize int
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]entry), sweepSize: minSweepSize}
+}
+
+func (c *MemoryCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *MemoryCache) Set(key string, value any, ttl time.Duration) {
+	if ttl <= 0 {
+		c.Delete(key)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= c.sweepSize {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.sweepSize = max(2*len(c.entries), minSweepSize)
+	}
+	c.entries[key] = entry{value: value, expiresAt: now.Add(ttl)}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache()
+
+	c.Set("a", 1, time.Minute)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get: %v, %v", v, ok)
+	}
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("value must be gone after Delete")
+	}
+
+	c.Set("short", 2, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("short"); ok {
+		t.Fatalf("value must expire")
+	}
+
+	// Просроченные записи выбрасываются, когда кэш разрастается
+	for i := 0; i < minSweepSize; i++ {
+		c.Set(strconv.Itoa(i), i, time.Nanosecond)
+	}
+	time.Sleep(time.Millisecond)
+	c.Set("fresh", 3, time.Minute)
+	if len(c.entries) != 1 {
+		t.Fatalf("expected expired entries to be swept, got %d", len(c.entries))
+	}
+	if v, ok := c.Get("fresh"); !ok || v != 3 {
+		t.Fatalf("Get fresh: %v, %v", v, ok)
+	}
+}
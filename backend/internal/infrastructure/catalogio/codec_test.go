@@ -0,0 +1,68 @@
+package catalogio
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"example/web-service-gin/internal/application/abstraction/catalog"
+)
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	records := []catalog.Record{
+		{Kind: catalog.KindGenre, ID: "0b8f1f5e-3a4c-4d1e-9b59-7f8a2c2d0c11", Title: "Action"},
+		{
+			Kind:        catalog.KindGame,
+			Title:       `Quake, "Arena"`,
+			Description: "line1\nline2",
+			ReleaseDate: "1999-12-02T00:00:00Z",
+			Genres:      []string{"Action", "Shooter"},
+			Status:      "scheduled",
+			PublishAt:   "2030-01-01T10:00:00Z",
+		},
+	}
+
+	for _, codec := range []catalog.Codec{NewCSVCodec(), NewJSONCodec(), NewNDJSONCodec()} {
+		var buf bytes.Buffer
+		if err := codec.Encode(&buf, records); err != nil {
+			t.Fatalf("%s Encode: %v", codec.Format(), err)
+		}
+		got, err := codec.Decode(&buf)
+		if err != nil {
+			t.Fatalf("%s Decode: %v", codec.Format(), err)
+		}
+		if !reflect.DeepEqual(got, records) {
+			t.Fatalf("%s: expected %+v, got %+v", codec.Format(), records, got)
+		}
+	}
+}
+
+func TestCSVCodec_HeaderOrderAndBOM(t *testing.T) {
+	input := "\ufefftitle,kind,genres,extra\nRPG,genre,,x\nWitcher,game,RPG | Action,y\n"
+
+	got, err := NewCSVCodec().Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	want := []catalog.Record{
+		{Kind: "genre", Title: "RPG"},
+		{Kind: "game", Title: "Witcher", Genres: []string{"RPG", "Action"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+
+	if _, err := NewCSVCodec().Decode(strings.NewReader("id,description\n1,x\n")); err == nil {
+		t.Fatalf("expected error for missing kind/title columns")
+	}
+}
+
+func TestNDJSONCodec_ReportsLine(t *testing.T) {
+	input := "{\"kind\":\"genre\",\"title\":\"RPG\"}\n\n{broken\n"
+
+	_, err := NewNDJSONCodec().Decode(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "3") {
+		t.Fatalf("expected error with line 3, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package catalogio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"example/web-service-gin/internal/application/abstraction/catalog"
+)
+
+// compile-time check
+var _ catalog.Codec = (*CSVCodec)(nil)
+
+// csvGenreSeparator разделяет названия жанров в колонке genres
+const csvGenreSeparator = "|"
+
+var csvHeader = []string{"kind", "id", "title", "description", "releaseDate", "genres", "status", "publishAt"}
+
+// CSVCodec - CSV с заголовком; колонки можно переставлять, лишние игнорируются.
+type CSVCodec struct{}
+
+func NewCSVCodec() *CSVCodec {
+	return &CSVCodec{}
+}
+
+func (c *CSVCodec) Format() string { return "csv" }
+
+func (c *CSVCodec) ContentType() string { return "text/csv; charset=utf-8" }
+
+func (c *CSVCodec) Encode(w io.Writer, records []catalog.Record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+	for _, rec := range records {
+		row := []string{
+			rec.Kind,
+			rec.ID,
+			rec.Title,
+			rec.Description,
+			rec.ReleaseDate,
+			strings.Join(rec.Genres, csvGenreSeparator),
+			rec.Status,
+			rec.PublishAt,
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("write csv row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (c *CSVCodec) Decode(r io.Reader) ([]catalog.Record, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return []catalog.Record{}, nil
+		}
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // BOM от Excel
+		}
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"kind", "title"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header has no %q column", required)
+		}
+	}
+
+	res := []catalog.Record{}
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read csv: %w", err)
+		}
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return row[i]
+		}
+		rec := catalog.Record{
+			Kind:        field("kind"),
+			ID:          field("id"),
+			Title:       field("title"),
+			Description: field("description"),
+			ReleaseDate: field("releaseDate"),
+			Status:      field("status"),
+			PublishAt:   field("publishAt"),
+		}
+		if genres := strings.TrimSpace(field("genres")); genres != "" {
+			for _, title := range strings.Split(genres, csvGenreSeparator) {
+				rec.Genres = append(rec.Genres, strings.TrimSpace(title))
+			}
+		}
+		res = append(res, rec)
+	}
+	return res, nil
+}
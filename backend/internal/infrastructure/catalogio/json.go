@@ -0,0 +1,92 @@
+package catalogio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"example/web-service-gin/internal/application/abstraction/catalog"
+)
+
+// compile-time check
+var (
+	_ catalog.Codec = (*JSONCodec)(nil)
+	_ catalog.Codec = (*NDJSONCodec)(nil)
+)
+
+// JSONCodec - массив записей.
+type JSONCodec struct{}
+
+func NewJSONCodec() *JSONCodec {
+	return &JSONCodec{}
+}
+
+func (c *JSONCodec) Format() string { return "json" }
+
+func (c *JSONCodec) ContentType() string { return "application/json; charset=utf-8" }
+
+func (c *JSONCodec) Encode(w io.Writer, records []catalog.Record) error {
+	if records == nil {
+		records = []catalog.Record{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+func (c *JSONCodec) Decode(r io.Reader) ([]catalog.Record, error) {
+	res := []catalog.Record{}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		if err == io.EOF {
+			return res, nil
+		}
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+	return res, nil
+}
+
+// NDJSONCodec - одна запись JSON на строку; пустые строки пропускаются.
+type NDJSONCodec struct{}
+
+func NewNDJSONCodec() *NDJSONCodec {
+	return &NDJSONCodec{}
+}
+
+func (c *NDJSONCodec) Format() string { return "ndjson" }
+
+func (c *NDJSONCodec) ContentType() string { return "application/x-ndjson" }
+
+func (c *NDJSONCodec) Encode(w io.Writer, records []catalog.Record) error {
+	enc := json.NewEncoder(w)
+	for _, rec := range records {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("encode ndjson: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *NDJSONCodec) Decode(r io.Reader) ([]catalog.Record, error) {
+	scanner := bufio.NewScanner(r)
+	// Стандартного буфера в 64 КБ может не хватить на запись с длинным описанием
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	res := []catalog.Record{}
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		var rec catalog.Record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("decode ndjson line %d: %w", line, err)
+		}
+		res = append(res, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ndjson: %w", err)
+	}
+	return res, nil
+}
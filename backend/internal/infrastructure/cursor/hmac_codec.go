@@ -0,0 +1,78 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"example/web-service-gin/internal/application/abstraction/pagination"
+	"example/web-service-gin/internal/application/abstraction/repository"
+
+	"github.com/google/uuid"
+)
+
+var _ pagination.CursorCodec = (*HMACCodec)(nil)
+
+// payload - содержимое токена; короткие имена полей, чтобы токен был компактнее.
+type payload struct {
+	Sort   string `json:"s"`
+	Filter string `json:"f,omitempty"`
+	Key    string `json:"k"`
+	ID     string `json:"i"`
+}
+
+// HMACCodec кодирует курсор как base64url(JSON) + "." + base64url(HMAC-SHA256).
+// Подпись не дает клиенту подделать ключ сортировки.
+type HMACCodec struct {
+	secret []byte
+}
+
+func NewHMACCodec(secret string) *HMACCodec {
+	return &HMACCodec{secret: []byte(secret)}
+}
+
+func (c *HMACCodec) Encode(cur repository.Cursor) (string, error) {
+	raw, err := json.Marshal(payload{Sort: cur.Sort, Filter: cur.Filter, Key: cur.Key, ID: cur.ID.String()})
+	if err != nil {
+		return "", err
+	}
+
+	body := base64.RawURLEncoding.EncodeToString(raw)
+	return body + "." + base64.RawURLEncoding.EncodeToString(c.sign(body)), nil
+}
+
+func (c *HMACCodec) Decode(token string) (repository.Cursor, error) {
+	body, sig, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok {
+		return repository.Cursor{}, pagination.ErrInvalidCursor
+	}
+
+	gotSig, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(gotSig, c.sign(body)) {
+		return repository.Cursor{}, pagination.ErrInvalidCursor
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return repository.Cursor{}, pagination.ErrInvalidCursor
+	}
+
+	var p payload
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return repository.Cursor{}, pagination.ErrInvalidCursor
+	}
+	id, err := uuid.Parse(p.ID)
+	if err != nil {
+		return repository.Cursor{}, pagination.ErrInvalidCursor
+	}
+
+	return repository.Cursor{Sort: p.Sort, Filter: p.Filter, Key: p.Key, ID: id}, nil
+}
+
+func (c *HMACCodec) sign(body string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
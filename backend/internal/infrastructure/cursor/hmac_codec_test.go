@@ -0,0 +1,36 @@
+package cursor
+
+import (
+	"testing"
+
+	"example/web-service-gin/internal/application/abstraction/pagination"
+	"example/web-service-gin/internal/application/abstraction/repository"
+
+	"github.com/google/uuid"
+)
+
+func TestHMACCodec_RoundTripAndTamper(t *testing.T) {
+	codec := NewHMACCodec("test-secret")
+	cur := repository.Cursor{Sort: "-releaseDate", Filter: "f1", Key: "2024-01-01T00:00:00Z", ID: uuid.New()}
+
+	token, err := codec.Encode(cur)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got, err := codec.Decode(token)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got != cur {
+		t.Fatalf("expected %+v, got %+v", cur, got)
+	}
+
+	forged, _ := NewHMACCodec("other-secret").Encode(cur)
+	if _, err := codec.Decode(forged); err != pagination.ErrInvalidCursor {
+		t.Fatalf("expected ErrInvalidCursor for foreign signature, got %v", err)
+	}
+	if _, err := codec.Decode("garbage"); err != pagination.ErrInvalidCursor {
+		t.Fatalf("expected ErrInvalidCursor for garbage, got %v", err)
+	}
+}
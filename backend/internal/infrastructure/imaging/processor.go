@@ -0,0 +1,139 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/media"
+)
+
+// compile-time check
+var _ media.ImageProcessor = (*Processor)(nil)
+
+// jpegQuality - качество миниатюр JPEG
+const jpegQuality = 85
+
+// decoders - поддерживаемые форматы по MIME-типу, определенному по содержимому
+var decoders = map[string]func(data []byte) (image.Image, error){
+	"image/jpeg": func(data []byte) (image.Image, error) { return jpeg.Decode(bytes.NewReader(data)) },
+	"image/png":  func(data []byte) (image.Image, error) { return png.Decode(bytes.NewReader(data)) },
+	"image/gif":  func(data []byte) (image.Image, error) { return gif.Decode(bytes.NewReader(data)) },
+}
+
+var configDecoders = map[string]func(data []byte) (image.Config, error){
+	"image/jpeg": func(data []byte) (image.Config, error) { return jpeg.DecodeConfig(bytes.NewReader(data)) },
+	"image/png":  func(data []byte) (image.Config, error) { return png.DecodeConfig(bytes.NewReader(data)) },
+	"image/gif":  func(data []byte) (image.Config, error) { return gif.DecodeConfig(bytes.NewReader(data)) },
+}
+
+// Processor работает с JPEG, PNG и GIF средствами стандартной библиотеки.
+// Миниатюры JPEG остаются JPEG, остальные сохраняются в PNG (прозрачность не теряется).
+type Processor struct {
+	// maxPixels защищает от изображений, которые занимают мало байт, но огромны после распаковки
+	maxPixels int
+}
+
+func NewProcessor(maxPixels int) *Processor {
+	return &Processor{maxPixels: maxPixels}
+}
+
+func (p *Processor) Inspect(data []byte) (media.Image, error) {
+	contentType := http.DetectContentType(data)
+	decodeConfig, ok := configDecoders[contentType]
+	if !ok {
+		return media.Image{}, media.ErrUnsupportedImage
+	}
+
+	cfg, err := decodeConfig(data)
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return media.Image{}, media.ErrUnsupportedImage
+	}
+	if p.maxPixels > 0 && cfg.Width*cfg.Height > p.maxPixels {
+		return media.Image{}, media.ErrTooLarge
+	}
+
+	return media.Image{ContentType: contentType, Width: cfg.Width, Height: cfg.Height}, nil
+}
+
+func (p *Processor) Thumbnail(data []byte, maxSide int) ([]byte, media.Image, error) {
+	info, err := p.Inspect(data)
+	if err != nil {
+		return nil, media.Image{}, err
+	}
+	if info.Width <= maxSide && info.Height <= maxSide {
+		return data, info, nil
+	}
+
+	src, err := decoders[info.ContentType](data)
+	if err != nil {
+		return nil, media.Image{}, media.ErrUnsupportedImage
+	}
+
+	width, height := fit(info.Width, info.Height, maxSide)
+	dst := downscale(src, width, height)
+
+	var buf bytes.Buffer
+	res := media.Image{Width: width, Height: height}
+	if info.ContentType == "image/jpeg" {
+		res.ContentType = "image/jpeg"
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality})
+	} else {
+		res.ContentType = "image/png"
+		err = png.Encode(&buf, dst)
+	}
+	if err != nil {
+		return nil, media.Image{}, fmt.Errorf("encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), res, nil
+}
+
+// fit уменьшает размеры пропорционально так, чтобы большая сторона стала maxSide.
+func fit(width, height, maxSide int) (int, int) {
+	if width >= height {
+		h := height * maxSide / width
+		return maxSide, max(h, 1)
+	}
+	w := width * maxSide / height
+	return max(w, 1), maxSide
+}
+
+// downscale усредняет пиксели исходника, попадающие в каждый пиксель результата (box filter).
+func downscale(src image.Image, width, height int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := b.Min.Y + y*sh/height
+		y1 := b.Min.Y + max((y+1)*sh/height, y*sh/height+1)
+		for x := 0; x < width; x++ {
+			x0 := b.Min.X + x*sw/width
+			x1 := b.Min.X + max((x+1)*sw/width, x*sw/width+1)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					bl += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
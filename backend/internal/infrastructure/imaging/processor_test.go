@@ -0,0 +1,77 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"example/web-service-gin/internal/application/abstraction/media"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessor_InspectSniffsContent(t *testing.T) {
+	p := NewProcessor(1000)
+
+	info, err := p.Inspect(encodePNG(t, 40, 20))
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if info != (media.Image{ContentType: "image/png", Width: 40, Height: 20}) {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+
+	if _, err := p.Inspect([]byte("<html>not an image</html>")); !errors.Is(err, media.ErrUnsupportedImage) {
+		t.Fatalf("expected ErrUnsupportedImage, got %v", err)
+	}
+	if _, err := p.Inspect(encodePNG(t, 50, 50)); !errors.Is(err, media.ErrTooLarge) {
+		t.Fatalf("expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestProcessor_Thumbnail(t *testing.T) {
+	p := NewProcessor(0)
+	data := encodePNG(t, 300, 100)
+
+	thumb, info, err := p.Thumbnail(data, 60)
+	if err != nil {
+		t.Fatalf("Thumbnail: %v", err)
+	}
+	if info.Width != 60 || info.Height != 20 || info.ContentType != "image/png" {
+		t.Fatalf("unexpected thumbnail info: %+v", info)
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(thumb))
+	if err != nil || cfg.Width != 60 || cfg.Height != 20 {
+		t.Fatalf("thumbnail is not a 60x20 png: %+v %v", cfg, err)
+	}
+
+	// Маленькие изображения не увеличиваются
+	same, info, err := p.Thumbnail(data, 1000)
+	if err != nil || !bytes.Equal(same, data) || info.Width != 300 {
+		t.Fatalf("expected original for small image, got %+v %v", info, err)
+	}
+
+	var jpg bytes.Buffer
+	_ = jpeg.Encode(&jpg, image.NewRGBA(image.Rect(0, 0, 100, 300)), nil)
+	_, info, err = p.Thumbnail(jpg.Bytes(), 30)
+	if err != nil || info.ContentType != "image/jpeg" || info.Width != 10 || info.Height != 30 {
+		t.Fatalf("unexpected jpeg thumbnail: %+v %v", info, err)
+	}
+}
@@ -2,6 +2,7 @@ package data
 
 import (
 	"sync"
+	"time"
 
 	"example/web-service-gin/internal/domain/model"
 
@@ -17,6 +18,30 @@ type Data struct {
 	Genres      map[uuid.UUID]*model.Genre
 	Users       map[uuid.UUID]*model.User
 	UserRatings map[uuid.UUID]*model.UserRating
+	GameMedia   map[uuid.UUID]*model.GameMedia
+	GameBuilds  map[uuid.UUID]*model.GameBuild
+	// GameRelations - связи игр; у игры не больше одной связи каждого типа
+	GameRelations map[RelationKey]*model.GameRelation
+	Series        map[uuid.UUID]*model.Series
+	// GameSimilarities - похожие игры для каждой игры, самые похожие первыми
+	GameSimilarities map[uuid.UUID][]*model.GameSimilarity
+	Tasks            map[uuid.UUID]*model.Task
+	Attempts         map[uuid.UUID]*model.Attempt
+	// PlayResults - результаты попыток по ID попытки
+	PlayResults map[uuid.UUID]*model.PlayResult
+	// ResultNonces - использованные nonce подписанных запросов по ID попытки
+	ResultNonces map[uuid.UUID]map[string]time.Time
+	// Leaderboards - результаты каждой игры (те же, что в PlayResults), отсортированные
+	// по model.CompareResults, при равенстве - по ID пользователя
+	Leaderboards map[uuid.UUID][]*model.PlayResult
+	// Revisions - история изменений в порядке добавления (только дописывается)
+	Revisions []*model.Revision
+}
+
+// RelationKey - ключ связи игры: игра и тип связи
+type RelationKey struct {
+	GameID uuid.UUID
+	Type   string
 }
 
 func New() *Data {
@@ -25,6 +50,18 @@ func New() *Data {
 		Genres:      make(map[uuid.UUID]*model.Genre),
 		Users:       make(map[uuid.UUID]*model.User),
 		UserRatings: make(map[uuid.UUID]*model.UserRating),
+		GameMedia:   make(map[uuid.UUID]*model.GameMedia),
+		GameBuilds:  make(map[uuid.UUID]*model.GameBuild),
+
+		GameRelations: make(map[RelationKey]*model.GameRelation),
+		Series:        make(map[uuid.UUID]*model.Series),
+
+		GameSimilarities: make(map[uuid.UUID][]*model.GameSimilarity),
+		Tasks:            make(map[uuid.UUID]*model.Task),
+		Attempts:         make(map[uuid.UUID]*model.Attempt),
+		PlayResults:      make(map[uuid.UUID]*model.PlayResult),
+		ResultNonces:     make(map[uuid.UUID]map[string]time.Time),
+		Leaderboards:     make(map[uuid.UUID][]*model.PlayResult),
 	}
 }
 
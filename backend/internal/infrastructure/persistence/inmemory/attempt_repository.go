@@ -0,0 +1,167 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.AttemptRepository = (*AttemptRepository)(nil)
+
+// AttemptRepository in-memory реализация
+type AttemptRepository struct {
+	data *data.Data
+}
+
+// NewAttemptRepository создает новый in-memory репозиторий
+func NewAttemptRepository(store *data.Data) *AttemptRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &AttemptRepository{data: store}
+}
+
+func (r *AttemptRepository) Create(ctx context.Context, attempt *model.Attempt) (*model.Attempt, error) {
+	if attempt == nil {
+		return nil, errors.New("attempt cannot be nil")
+	}
+	if attempt.ID == uuid.Nil {
+		attempt.ID = uuid.New()
+	}
+	if attempt.StartedAt.IsZero() {
+		attempt.StartedAt = time.Now().UTC()
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	game, exists := r.data.Games[attempt.GameID]
+	if !exists || game.DeletedAt != nil {
+		return nil, repository.ErrNotFound
+	}
+	r.data.Attempts[attempt.ID] = cloneAttempt(attempt)
+	return attempt, nil
+}
+
+func (r *AttemptRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Attempt, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("attempt ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	attempt, exists := r.data.Attempts[id]
+	if !exists {
+		return nil, repository.ErrAttemptNotFound
+	}
+	return cloneAttempt(attempt), nil
+}
+
+func (r *AttemptRepository) FindByLaunchTokenHash(ctx context.Context, hash string) (*model.Attempt, error) {
+	if hash == "" {
+		return nil, repository.ErrAttemptNotFound
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	for _, attempt := range r.data.Attempts {
+		if attempt.LaunchTokenHash == hash {
+			return cloneAttempt(attempt), nil
+		}
+	}
+	return nil, repository.ErrAttemptNotFound
+}
+
+func (r *AttemptRepository) FindByResultTokenHash(ctx context.Context, hash string) (*model.Attempt, error) {
+	if hash == "" {
+		return nil, repository.ErrAttemptNotFound
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	for _, attempt := range r.data.Attempts {
+		if attempt.ResultTokenHash == hash {
+			return cloneAttempt(attempt), nil
+		}
+	}
+	return nil, repository.ErrAttemptNotFound
+}
+
+func (r *AttemptRepository) FindOpen(ctx context.Context, userID, gameID uuid.UUID) ([]*model.Attempt, error) {
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	res := []*model.Attempt{}
+	for _, attempt := range r.data.Attempts {
+		if attempt.UserID == userID && attempt.GameID == gameID && !attempt.IsFinished() {
+			res = append(res, cloneAttempt(attempt))
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].StartedAt.Equal(res[j].StartedAt) {
+			return res[i].StartedAt.Before(res[j].StartedAt)
+		}
+		return res[i].ID.String() < res[j].ID.String()
+	})
+	return res, nil
+}
+
+func (r *AttemptRepository) Update(ctx context.Context, attempt *model.Attempt, fromStatus string) (*model.Attempt, error) {
+	if attempt == nil {
+		return nil, errors.New("attempt cannot be nil")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	existing, exists := r.data.Attempts[attempt.ID]
+	if !exists {
+		return nil, repository.ErrAttemptNotFound
+	}
+	if existing.Status != fromStatus {
+		return nil, repository.ErrAttemptStatusChanged
+	}
+
+	r.data.Attempts[attempt.ID] = withAttemptStatus(existing, attempt)
+	return attempt, nil
+}
+
+// withAttemptStatus - копия existing со статусом, отметками времени и токенами attempt
+func withAttemptStatus(existing, attempt *model.Attempt) *model.Attempt {
+	updated := cloneAttempt(existing)
+	updated.Status = attempt.Status
+	updated.LaunchTokenHash = attempt.LaunchTokenHash
+	updated.RunningAt = cloneTime(attempt.RunningAt)
+	updated.FinishedAt = cloneTime(attempt.FinishedAt)
+	updated.ResultTokenHash = attempt.ResultTokenHash
+	updated.ResultExpiresAt = cloneTime(attempt.ResultExpiresAt)
+	return updated
+}
+
+func cloneAttempt(attempt *model.Attempt) *model.Attempt {
+	copied := *attempt
+	copied.RunningAt = cloneTime(attempt.RunningAt)
+	copied.FinishedAt = cloneTime(attempt.FinishedAt)
+	copied.ResultExpiresAt = cloneTime(attempt.ResultExpiresAt)
+	return &copied
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
@@ -0,0 +1,130 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.CatalogRepository = (*CatalogRepository)(nil)
+
+// CatalogRepository in-memory реализация пакетного импорта
+type CatalogRepository struct {
+	data *data.Data
+}
+
+func NewCatalogRepository(store *data.Data) *CatalogRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &CatalogRepository{data: store}
+}
+
+// Apply сначала проверяет весь импорт и только потом меняет данные - аналог отката транзакции
+func (r *CatalogRepository) Apply(ctx context.Context, in repository.CatalogImport) error {
+	defer lock(ctx, r.data)()
+
+	liveGenres := make(map[uuid.UUID]bool)
+	for id, genre := range r.data.Genres {
+		liveGenres[id] = genre.DeletedAt == nil
+	}
+
+	for _, genre := range in.CreateGenres {
+		if genre.ID == uuid.Nil {
+			genre.ID = uuid.New()
+		}
+		if _, exists := liveGenres[genre.ID]; exists {
+			return repository.ErrGenreAlreadyExists
+		}
+		liveGenres[genre.ID] = true
+	}
+	for _, genre := range in.UpdateGenres {
+		existing, exists := r.data.Genres[genre.ID]
+		if !exists || existing.DeletedAt != nil {
+			return repository.ErrGenreNotFound
+		}
+		if existing.Version != genre.Version {
+			return repository.ErrVersionConflict
+		}
+	}
+
+	// Названия активных игр с учетом импорта - аналог уникального индекса по нормализованному названию
+	titles := make(map[string]uuid.UUID, len(r.data.Games))
+	for id, game := range r.data.Games {
+		if game.DeletedAt == nil {
+			titles[model.NormalizeTitle(game.Title)] = id
+		}
+	}
+
+	created := make(map[uuid.UUID]bool, len(in.CreateGames))
+	for _, game := range in.CreateGames {
+		if game.ID == uuid.Nil {
+			game.ID = uuid.New()
+		}
+		if _, exists := r.data.Games[game.ID]; exists || created[game.ID] {
+			return repository.ErrAlreadyExists
+		}
+		created[game.ID] = true
+		if _, taken := titles[model.NormalizeTitle(game.Title)]; taken {
+			return repository.ErrDuplicateTitle
+		}
+		titles[model.NormalizeTitle(game.Title)] = game.ID
+		game.SetGenres(game.GenreID, game.GenreIDs)
+		if !genresLive(liveGenres, game) {
+			return errors.New(constants.ErrGenreNotFound)
+		}
+	}
+	for _, game := range in.UpdateGames {
+		existing, exists := r.data.Games[game.ID]
+		if !exists || existing.DeletedAt != nil {
+			return repository.ErrNotFound
+		}
+		if existing.Version != game.Version {
+			return repository.ErrVersionConflict
+		}
+		if owner, taken := titles[model.NormalizeTitle(game.Title)]; taken && owner != game.ID {
+			return repository.ErrDuplicateTitle
+		}
+		titles[model.NormalizeTitle(game.Title)] = game.ID
+		game.SetGenres(game.GenreID, game.GenreIDs)
+		if !genresLive(liveGenres, game) {
+			return errors.New(constants.ErrGenreNotFound)
+		}
+	}
+
+	for _, genre := range in.CreateGenres {
+		genre.Version = 1
+		stored := *genre
+		r.data.Genres[genre.ID] = &stored
+	}
+	for _, genre := range in.UpdateGenres {
+		genre.Version++
+		stored := *genre
+		r.data.Genres[genre.ID] = &stored
+	}
+	for _, game := range in.CreateGames {
+		game.Version = 1
+		r.data.Games[game.ID] = cloneGame(game)
+	}
+	for _, game := range in.UpdateGames {
+		game.Version++
+		r.data.Games[game.ID] = cloneGame(game)
+	}
+	return nil
+}
+
+func genresLive(liveGenres map[uuid.UUID]bool, game *model.Game) bool {
+	for _, id := range game.GenreIDs {
+		if !liveGenres[id] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.GameBuildRepository = (*GameBuildRepository)(nil)
+
+// GameBuildRepository in-memory реализация
+type GameBuildRepository struct {
+	data *data.Data
+}
+
+// NewGameBuildRepository создает новый in-memory репозиторий
+func NewGameBuildRepository(store *data.Data) *GameBuildRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &GameBuildRepository{data: store}
+}
+
+func (r *GameBuildRepository) Create(ctx context.Context, build *model.GameBuild) (*model.GameBuild, error) {
+	if build == nil {
+		return nil, errors.New("build cannot be nil")
+	}
+	if build.ID == uuid.Nil {
+		build.ID = uuid.New()
+	}
+	if build.CreatedAt.IsZero() {
+		build.CreatedAt = time.Now().UTC()
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	for _, existing := range r.data.GameBuilds {
+		if existing.GameID == build.GameID && existing.Platform == build.Platform && existing.Version == build.Version {
+			return nil, repository.ErrBuildAlreadyExists
+		}
+	}
+	if build.IsCurrent {
+		r.clearCurrent(build.GameID, build.Platform)
+	}
+
+	r.data.GameBuilds[build.ID] = cloneGameBuild(build)
+	return build, nil
+}
+
+func (r *GameBuildRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.GameBuild, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("build ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	build, exists := r.data.GameBuilds[id]
+	if !exists {
+		return nil, repository.ErrBuildNotFound
+	}
+	return withoutFiles(build), nil
+}
+
+func (r *GameBuildRepository) FindFiles(ctx context.Context, buildID uuid.UUID) ([]model.BuildFile, error) {
+	if buildID == uuid.Nil {
+		return nil, errors.New("build ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	build, exists := r.data.GameBuilds[buildID]
+	if !exists {
+		return nil, repository.ErrBuildNotFound
+	}
+
+	files := append([]model.BuildFile{}, build.Files...)
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+func (r *GameBuildRepository) FindByGameID(ctx context.Context, gameID uuid.UUID, platform string) ([]*model.GameBuild, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	res := []*model.GameBuild{}
+	for _, build := range r.data.GameBuilds {
+		if build.GameID == gameID && (platform == "" || build.Platform == platform) {
+			res = append(res, withoutFiles(build))
+		}
+	}
+
+	// Новые сборки первыми
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.After(res[j].CreatedAt)
+		}
+		return res[i].ID.String() < res[j].ID.String()
+	})
+	return res, nil
+}
+
+func (r *GameBuildRepository) FindCurrent(ctx context.Context, gameID uuid.UUID, platform string) (*model.GameBuild, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	for _, build := range r.data.GameBuilds {
+		if build.GameID == gameID && build.Platform == platform && build.IsCurrent {
+			return withoutFiles(build), nil
+		}
+	}
+	return nil, repository.ErrBuildNotFound
+}
+
+func (r *GameBuildRepository) SetCurrent(ctx context.Context, id uuid.UUID) (*model.GameBuild, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("build ID cannot be empty")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	build, exists := r.data.GameBuilds[id]
+	if !exists {
+		return nil, repository.ErrBuildNotFound
+	}
+	r.clearCurrent(build.GameID, build.Platform)
+	build.IsCurrent = true
+
+	return withoutFiles(build), nil
+}
+
+func (r *GameBuildRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("build ID cannot be empty")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	if _, exists := r.data.GameBuilds[id]; !exists {
+		return repository.ErrBuildNotFound
+	}
+	delete(r.data.GameBuilds, id)
+	return nil
+}
+
+func (r *GameBuildRepository) BlobInUse(ctx context.Context, key string) (bool, error) {
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	for _, build := range r.data.GameBuilds {
+		if build.SHA256 == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// clearCurrent снимает отметку текущей со сборок платформы; вызывается под блокировкой.
+func (r *GameBuildRepository) clearCurrent(gameID uuid.UUID, platform string) {
+	for _, build := range r.data.GameBuilds {
+		if build.GameID == gameID && build.Platform == platform {
+			build.IsCurrent = false
+		}
+	}
+}
+
+func cloneGameBuild(build *model.GameBuild) *model.GameBuild {
+	copied := *build
+	copied.Files = append([]model.BuildFile(nil), build.Files...)
+	return &copied
+}
+
+// withoutFiles - копия для чтения: как и в SQLite, файлы отдает только FindFiles.
+func withoutFiles(build *model.GameBuild) *model.GameBuild {
+	copied := *build
+	copied.Files = nil
+	return &copied
+}
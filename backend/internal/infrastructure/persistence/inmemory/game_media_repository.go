@@ -0,0 +1,175 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.GameMediaRepository = (*GameMediaRepository)(nil)
+
+// GameMediaRepository in-memory реализация
+type GameMediaRepository struct {
+	data *data.Data
+}
+
+// NewGameMediaRepository создает новый in-memory репозиторий
+func NewGameMediaRepository(store *data.Data) *GameMediaRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &GameMediaRepository{data: store}
+}
+
+func (r *GameMediaRepository) Create(ctx context.Context, media *model.GameMedia) (*model.GameMedia, error) {
+	if media == nil {
+		return nil, errors.New("media cannot be nil")
+	}
+	if media.ID == uuid.Nil {
+		media.ID = uuid.New()
+	}
+	if media.CreatedAt.IsZero() {
+		media.CreatedAt = time.Now().UTC()
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	media.Position = 0
+	for id, existing := range r.data.GameMedia {
+		if existing.GameID != media.GameID || existing.Kind != media.Kind {
+			continue
+		}
+		// Новая обложка заменяет прежнюю
+		if media.Kind == model.MediaKindCover {
+			delete(r.data.GameMedia, id)
+			continue
+		}
+		media.Position = max(media.Position, existing.Position)
+	}
+	if media.Kind == model.MediaKindScreenshot {
+		media.Position++
+	}
+
+	r.data.GameMedia[media.ID] = cloneGameMedia(media)
+	return media, nil
+}
+
+func (r *GameMediaRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.GameMedia, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("media ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	media, exists := r.data.GameMedia[id]
+	if !exists {
+		return nil, repository.ErrMediaNotFound
+	}
+	return cloneGameMedia(media), nil
+}
+
+func (r *GameMediaRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.GameMedia, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	res := []*model.GameMedia{}
+	for _, media := range r.data.GameMedia {
+		if media.GameID == gameID {
+			res = append(res, cloneGameMedia(media))
+		}
+	}
+
+	// Обложка первой, затем скриншоты по позиции
+	sort.Slice(res, func(i, j int) bool {
+		if (res[i].Kind == model.MediaKindCover) != (res[j].Kind == model.MediaKindCover) {
+			return res[i].Kind == model.MediaKindCover
+		}
+		if res[i].Position != res[j].Position {
+			return res[i].Position < res[j].Position
+		}
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+	return res, nil
+}
+
+func (r *GameMediaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("media ID cannot be empty")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	if _, exists := r.data.GameMedia[id]; !exists {
+		return repository.ErrMediaNotFound
+	}
+	delete(r.data.GameMedia, id)
+	return nil
+}
+
+func (r *GameMediaRepository) Reorder(ctx context.Context, gameID uuid.UUID, ids []uuid.UUID) error {
+	if gameID == uuid.Nil {
+		return errors.New("game ID cannot be empty")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	screenshots := 0
+	for _, media := range r.data.GameMedia {
+		if media.GameID == gameID && media.Kind == model.MediaKindScreenshot {
+			screenshots++
+		}
+	}
+	if screenshots != len(ids) {
+		return repository.ErrMediaOrderMismatch
+	}
+
+	// Сначала проверяем весь список, чтобы при ошибке порядок не поменялся частично
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		media, exists := r.data.GameMedia[id]
+		if !exists || seen[id] || media.GameID != gameID || media.Kind != model.MediaKindScreenshot {
+			return repository.ErrMediaOrderMismatch
+		}
+		seen[id] = true
+	}
+	for i, id := range ids {
+		r.data.GameMedia[id].Position = i + 1
+	}
+	return nil
+}
+
+func (r *GameMediaRepository) BlobInUse(ctx context.Context, key string) (bool, error) {
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	for _, media := range r.data.GameMedia {
+		for _, k := range media.BlobKeys() {
+			if k == key {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func cloneGameMedia(media *model.GameMedia) *model.GameMedia {
+	copied := *media
+	copied.Thumbnails = append([]model.MediaThumbnail(nil), media.Thumbnails...)
+	return &copied
+}
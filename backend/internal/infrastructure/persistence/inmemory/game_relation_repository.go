@@ -0,0 +1,125 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.GameRelationRepository = (*GameRelationRepository)(nil)
+
+// GameRelationRepository in-memory реализация
+type GameRelationRepository struct {
+	data *data.Data
+}
+
+// NewGameRelationRepository создает новый in-memory репозиторий
+func NewGameRelationRepository(store *data.Data) *GameRelationRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &GameRelationRepository{data: store}
+}
+
+func (r *GameRelationRepository) Set(ctx context.Context, relation *model.GameRelation) (*model.GameRelation, error) {
+	if relation == nil {
+		return nil, errors.New("relation cannot be nil")
+	}
+	if relation.CreatedAt.IsZero() {
+		relation.CreatedAt = time.Now().UTC()
+	}
+
+	defer lock(ctx, r.data)()
+
+	if !r.isLive(relation.GameID) || !r.isLive(relation.RelatedID) {
+		return nil, repository.ErrNotFound
+	}
+
+	copied := *relation
+	r.data.GameRelations[data.RelationKey{GameID: relation.GameID, Type: relation.Type}] = &copied
+	return relation, nil
+}
+
+func (r *GameRelationRepository) Delete(ctx context.Context, gameID uuid.UUID, relationType string) error {
+	if gameID == uuid.Nil {
+		return errors.New("game ID cannot be empty")
+	}
+
+	defer lock(ctx, r.data)()
+
+	key := data.RelationKey{GameID: gameID, Type: relationType}
+	if _, exists := r.data.GameRelations[key]; !exists {
+		return repository.ErrRelationNotFound
+	}
+	delete(r.data.GameRelations, key)
+	return nil
+}
+
+func (r *GameRelationRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.GameRelation, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	defer rlock(ctx, r.data)()
+
+	res := []*model.GameRelation{}
+	for _, relation := range r.data.GameRelations {
+		if relation.GameID != gameID && relation.RelatedID != gameID {
+			continue
+		}
+		if !r.isLive(relation.GameID) || !r.isLive(relation.RelatedID) {
+			continue
+		}
+		copied := *relation
+		res = append(res, &copied)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Type != res[j].Type {
+			return res[i].Type < res[j].Type
+		}
+		return res[i].CreatedAt.Before(res[j].CreatedAt)
+	})
+	return res, nil
+}
+
+func (r *GameRelationRepository) FindReachable(ctx context.Context, from uuid.UUID) ([]*model.GameRelation, error) {
+	defer rlock(ctx, r.data)()
+
+	outgoing := make(map[uuid.UUID][]*model.GameRelation)
+	for _, relation := range r.data.GameRelations {
+		outgoing[relation.GameID] = append(outgoing[relation.GameID], relation)
+	}
+
+	res := []*model.GameRelation{}
+	visited := map[uuid.UUID]bool{}
+	stack := []uuid.UUID{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		for _, relation := range outgoing[id] {
+			copied := *relation
+			res = append(res, &copied)
+			stack = append(stack, relation.RelatedID)
+		}
+	}
+	return res, nil
+}
+
+// isLive - игра есть и не в корзине; вызывается под блокировкой
+func (r *GameRelationRepository) isLive(id uuid.UUID) bool {
+	game, exists := r.data.Games[id]
+	return exists && game.DeletedAt == nil
+}
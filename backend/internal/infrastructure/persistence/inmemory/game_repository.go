@@ -3,6 +3,13 @@ package inmemory
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/infrastructure/persistence/data"
@@ -14,6 +21,50 @@ import (
 // Проверка что реализуем интерфейс
 var _ repository.GameRepository = (*GameRepository)(nil)
 
+// gameKey - значения, по которым можно сортировать игры
+type gameKey struct {
+	title       string
+	releaseDate time.Time
+	rating      float64
+}
+
+func (k gameKey) compare(other gameKey, sortBy repository.GameSortField) int {
+	switch sortBy {
+	case repository.GameSortTitle:
+		return strings.Compare(k.title, other.title)
+	case repository.GameSortRating:
+		switch {
+		case k.rating < other.rating:
+			return -1
+		case k.rating > other.rating:
+			return 1
+		}
+		return 0
+	default:
+		return k.releaseDate.Compare(other.releaseDate)
+	}
+}
+
+// parseGameKey восстанавливает ключ сортировки из курсора
+func parseGameKey(raw string, sortBy repository.GameSortField) (gameKey, error) {
+	switch sortBy {
+	case repository.GameSortTitle:
+		return gameKey{title: raw}, nil
+	case repository.GameSortRating:
+		rating, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return gameKey{}, fmt.Errorf("parse cursor rating: %w", err)
+		}
+		return gameKey{rating: rating}, nil
+	default:
+		releaseDate, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			return gameKey{}, fmt.Errorf("parse cursor release date: %w", err)
+		}
+		return gameKey{releaseDate: releaseDate}, nil
+	}
+}
+
 // GameRepository in-memory реализация
 type GameRepository struct {
 	data *data.Data
@@ -40,13 +91,23 @@ func (r *GameRepository) Create(ctx context.Context, game *model.Game) (*model.G
 		game.ID = uuid.New()
 	}
 
-	r.data.Mu.Lock()
-	defer r.data.Mu.Unlock()
+	defer lock(ctx, r.data)()
 
 	// Проверяем уникальность ID
 	if _, exists := r.data.Games[game.ID]; exists {
 		return nil, repository.ErrAlreadyExists
 	}
+	// Аналог уникального индекса по нормализованному названию
+	if titleOwner(r.data.Games, game.Title, game.ID) != nil {
+		return nil, repository.ErrDuplicateTitle
+	}
+
+	// Пустой статус - опубликована, как DEFAULT колонки status
+	if game.Status == "" {
+		game.Status = model.GameStatusPublished
+	}
+	game.SetGenres(game.GenreID, game.GenreIDs)
+	game.Version = 1
 
 	// Сохраняем
 	r.data.Games[game.ID] = game
@@ -60,26 +121,27 @@ func (r *GameRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Gam
 		return nil, errors.New("game ID cannot be empty")
 	}
 
-	r.data.Mu.RLock()
-	defer r.data.Mu.RUnlock()
+	defer rlock(ctx, r.data)()
 
 	game, exists := r.data.Games[id]
-	if !exists {
+	if !exists || game.DeletedAt != nil {
 		return nil, repository.ErrNotFound
 	}
 
-	return game, nil
+	// Копия: изменения вызывающего кода попадают в хранилище только через Update
+	return cloneGame(game), nil
 }
 
 // FindAll возвращает все игры с пагинацией
 func (r *GameRepository) FindAll(ctx context.Context, limit, offset int) ([]*model.Game, error) {
-	r.data.Mu.RLock()
-	defer r.data.Mu.RUnlock()
+	defer rlock(ctx, r.data)()
 
 	// Получаем все игры
 	allGames := make([]*model.Game, 0, len(r.data.Games))
 	for _, game := range r.data.Games {
-		allGames = append(allGames, game)
+		if game.DeletedAt == nil {
+			allGames = append(allGames, game)
+		}
 	}
 
 	// Сортируем по дате создания (если есть)
@@ -103,6 +165,153 @@ func (r *GameRepository) FindAll(ctx context.Context, limit, offset int) ([]*mod
 	return allGames[start:end], nil
 }
 
+// FindByQuery возвращает страницу игр по критериям и общее количество подходящих игр
+func (r *GameRepository) FindByQuery(ctx context.Context, q repository.GameQuery) ([]*model.Game, int, error) {
+	defer rlock(ctx, r.data)()
+
+	search := strings.TrimSpace(q.Search)
+
+	matched := make([]*model.Game, 0, len(r.data.Games))
+	for _, game := range r.data.Games {
+		if game.DeletedAt != nil {
+			continue
+		}
+		if len(q.GenreIDs) > 0 && !matchGenres(game, q.GenreIDs, q.MatchAllGenres) {
+			continue
+		}
+		if q.ReleasedFrom != nil && game.ReleaseDate.Before(*q.ReleasedFrom) {
+			continue
+		}
+		if q.ReleasedTo != nil && game.ReleaseDate.After(*q.ReleasedTo) {
+			continue
+		}
+		if search != "" && !containsFold(game.Title, search) && !containsFold(game.Description, search) {
+			continue
+		}
+		if q.Status != "" && game.Status != q.Status {
+			continue
+		}
+		matched = append(matched, game)
+	}
+
+	var averages map[uuid.UUID]float64
+	if q.SortBy == repository.GameSortRating {
+		averages = r.averageRatings()
+	}
+	keyOf := func(g *model.Game) gameKey {
+		return gameKey{title: g.Title, releaseDate: g.ReleaseDate, rating: averages[g.ID]}
+	}
+
+	// compare сравнивает по возрастанию; при равенстве ключей порядок задает ID
+	compare := func(a gameKey, aID uuid.UUID, b gameKey, bID uuid.UUID) int {
+		c := a.compare(b, q.SortBy)
+		if c == 0 {
+			c = strings.Compare(aID.String(), bID.String())
+		}
+		if q.SortDesc {
+			c = -c
+		}
+		return c
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return compare(keyOf(matched[i]), matched[i].ID, keyOf(matched[j]), matched[j].ID) < 0
+	})
+
+	total := len(matched)
+	offset := q.Offset
+	if q.After != nil {
+		cursorKey, err := parseGameKey(q.After.Key, q.SortBy)
+		if err != nil {
+			return nil, 0, err
+		}
+		idx := sort.Search(len(matched), func(i int) bool {
+			return compare(keyOf(matched[i]), matched[i].ID, cursorKey, q.After.ID) > 0
+		})
+		matched = matched[idx:]
+		offset = 0
+	}
+
+	return page(matched, q.Limit, offset), total, nil
+}
+
+// Search - упрощенный полнотекстовый поиск: каждое слово запроса должно быть
+// префиксом какого-либо слова в названии или описании. Совпадения в названии
+// весят больше; Rank отрицательный, как у bm25 (меньше - релевантнее).
+func (r *GameRepository) Search(ctx context.Context, q repository.GameSearchQuery) ([]*repository.GameSearchHit, int, error) {
+	defer rlock(ctx, r.data)()
+
+	terms := searchTerms(q.Text)
+	hits := []*repository.GameSearchHit{}
+	if len(terms) == 0 {
+		return hits, 0, nil
+	}
+
+	for _, game := range r.data.Games {
+		if game.DeletedAt != nil || (q.Status != "" && game.Status != q.Status) {
+			continue
+		}
+		title, titleHits, titleFound := highlightTerms(game.Title, terms, 0)
+		snippet, descHits, descFound := highlightTerms(game.Description, terms, 24)
+
+		all := true
+		for _, term := range terms {
+			if !titleFound[term] && !descFound[term] {
+				all = false
+				break
+			}
+		}
+		if !all {
+			continue
+		}
+
+		hits = append(hits, &repository.GameSearchHit{
+			Game:               game,
+			Rank:               -float64(10*titleHits + descHits),
+			TitleHighlight:     title,
+			DescriptionSnippet: snippet,
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank < hits[j].Rank
+		}
+		return hits[i].Game.ID.String() < hits[j].Game.ID.String()
+	})
+
+	return page(hits, q.Limit, q.Offset), len(hits), nil
+}
+
+// matchGenres - игра относится хотя бы к одному из жанров (all - ко всем)
+func matchGenres(game *model.Game, genreIDs []uuid.UUID, all bool) bool {
+	for _, id := range genreIDs {
+		has := game.HasGenre(id)
+		if all && !has {
+			return false
+		}
+		if !all && has {
+			return true
+		}
+	}
+	return all
+}
+
+// averageRatings считает среднюю оценку по каждой игре (вызывать под блокировкой)
+func (r *GameRepository) averageRatings() map[uuid.UUID]float64 {
+	sums := make(map[uuid.UUID]int)
+	counts := make(map[uuid.UUID]int)
+	for _, rating := range r.data.UserRatings {
+		sums[rating.GameID] += rating.Rating
+		counts[rating.GameID]++
+	}
+
+	res := make(map[uuid.UUID]float64, len(sums))
+	for id, sum := range sums {
+		res[id] = float64(sum) / float64(counts[id])
+	}
+	return res
+}
+
 // Update обновляет игру
 func (r *GameRepository) Update(ctx context.Context, game *model.Game) (*model.Game, error) {
 	if game == nil {
@@ -113,63 +322,289 @@ func (r *GameRepository) Update(ctx context.Context, game *model.Game) (*model.G
 		return nil, errors.New("game ID cannot be empty")
 	}
 
-	r.data.Mu.Lock()
-	defer r.data.Mu.Unlock()
+	defer lock(ctx, r.data)()
 
-	// Проверяем существование
-	_, exists := r.data.Games[game.ID]
-	if !exists {
+	// Проверяем существование (игру из корзины изменять нельзя)
+	existing, exists := r.data.Games[game.ID]
+	if !exists || existing.DeletedAt != nil {
 		return nil, repository.ErrNotFound
 	}
+	// Аналог UPDATE ... WHERE version = ?
+	if existing.Version != game.Version {
+		return nil, repository.ErrVersionConflict
+	}
+	if titleOwner(r.data.Games, game.Title, game.ID) != nil {
+		return nil, repository.ErrDuplicateTitle
+	}
+
+	game.SetGenres(game.GenreID, game.GenreIDs)
+	game.Version++
 
 	// Обновляем
-	r.data.Games[game.ID] = game
+	r.data.Games[game.ID] = cloneGame(game)
 
 	return game, nil
 }
 
-// Delete удаляет игру
-func (r *GameRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete перемещает игру в корзину
+func (r *GameRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	if id == uuid.Nil {
 		return errors.New("game ID cannot be empty")
 	}
 
-	r.data.Mu.Lock()
-	defer r.data.Mu.Unlock()
+	defer lock(ctx, r.data)()
 
 	// Проверяем существование
-	_, exists := r.data.Games[id]
-	if !exists {
+	game, exists := r.data.Games[id]
+	if !exists || game.DeletedAt != nil {
 		return repository.ErrNotFound
 	}
+	if game.Version != version {
+		return repository.ErrVersionConflict
+	}
 
-	// Удаляем
-	delete(r.data.Games, id)
+	now := time.Now().UTC()
+	game.DeletedAt = &now
+	game.Version++
 
 	return nil
 }
 
+// FindDeleted возвращает игры из корзины, недавно удаленные первыми
+func (r *GameRepository) FindDeleted(ctx context.Context) ([]*model.Game, error) {
+	defer rlock(ctx, r.data)()
+
+	res := []*model.Game{}
+	for _, game := range r.data.Games {
+		if game.DeletedAt != nil {
+			res = append(res, game)
+		}
+	}
+	sortByDeletedAt(res, func(g *model.Game) (time.Time, uuid.UUID) { return *g.DeletedAt, g.ID })
+
+	return res, nil
+}
+
+// Restore возвращает игру из корзины, если ни один из ее жанров не удален
+func (r *GameRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("game ID cannot be empty")
+	}
+
+	defer lock(ctx, r.data)()
+
+	game, exists := r.data.Games[id]
+	if !exists || game.DeletedAt == nil {
+		return repository.ErrNotFound
+	}
+	for _, genreID := range game.GenreIDs {
+		if genre, ok := r.data.Genres[genreID]; ok && genre.DeletedAt != nil {
+			return repository.ErrGameGenreDeleted
+		}
+	}
+	if titleOwner(r.data.Games, game.Title, game.ID) != nil {
+		return repository.ErrDuplicateTitle
+	}
+
+	game.DeletedAt = nil
+	game.Version++
+
+	return nil
+}
+
+// Purge окончательно удаляет игры из корзины, удаленные раньше before
+func (r *GameRepository) Purge(ctx context.Context, before time.Time) (*repository.PurgedGames, error) {
+	defer lock(ctx, r.data)()
+
+	res := &repository.PurgedGames{}
+	mediaKeys, buildKeys := make(map[string]bool), make(map[string]bool)
+	for id, game := range r.data.Games {
+		if game.DeletedAt == nil || !game.DeletedAt.Before(before) {
+			continue
+		}
+
+		// Удаляем вместе с оценками, медиа, сборками, связями, похожестью, задачами и попытками (аналог ON DELETE CASCADE)
+		delete(r.data.Games, id)
+		for ratingID, rating := range r.data.UserRatings {
+			if rating.GameID == id {
+				delete(r.data.UserRatings, ratingID)
+			}
+		}
+		for mediaID, media := range r.data.GameMedia {
+			if media.GameID == id {
+				delete(r.data.GameMedia, mediaID)
+				for _, key := range media.BlobKeys() {
+					mediaKeys[key] = true
+				}
+			}
+		}
+		for buildID, build := range r.data.GameBuilds {
+			if build.GameID == id {
+				delete(r.data.GameBuilds, buildID)
+				buildKeys[build.SHA256] = true
+			}
+		}
+		for key, relation := range r.data.GameRelations {
+			if relation.GameID == id || relation.RelatedID == id {
+				delete(r.data.GameRelations, key)
+			}
+		}
+		for _, series := range r.data.Series {
+			series.GameIDs = slices.DeleteFunc(series.GameIDs, func(gameID uuid.UUID) bool { return gameID == id })
+		}
+		delete(r.data.GameSimilarities, id)
+		for gameID, items := range r.data.GameSimilarities {
+			r.data.GameSimilarities[gameID] = slices.DeleteFunc(items, func(item *model.GameSimilarity) bool { return item.SimilarID == id })
+		}
+		for taskID, task := range r.data.Tasks {
+			if task.GameID == id {
+				delete(r.data.Tasks, taskID)
+			}
+		}
+		for attemptID, attempt := range r.data.Attempts {
+			if attempt.GameID == id {
+				delete(r.data.Attempts, attemptID)
+				delete(r.data.PlayResults, attemptID)
+				delete(r.data.ResultNonces, attemptID)
+			}
+		}
+		delete(r.data.Leaderboards, id)
+		res.Count++
+	}
+
+	res.MediaKeys = slices.Collect(maps.Keys(mediaKeys))
+	res.BuildKeys = slices.Collect(maps.Keys(buildKeys))
+	return res, nil
+}
+
 // Exists проверяет существование игры
 func (r *GameRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	if id == uuid.Nil {
 		return false, errors.New("game ID cannot be empty")
 	}
 
-	r.data.Mu.RLock()
-	defer r.data.Mu.RUnlock()
+	defer rlock(ctx, r.data)()
 
-	_, exists := r.data.Games[id]
-	return exists, nil
+	game, exists := r.data.Games[id]
+	return exists && game.DeletedAt == nil, nil
+}
+
+// FindByTitle ищет активную игру с тем же нормализованным названием
+func (r *GameRepository) FindByTitle(ctx context.Context, title string) (*model.Game, error) {
+	defer rlock(ctx, r.data)()
+
+	game := titleOwner(r.data.Games, title, uuid.Nil)
+	if game == nil {
+		return nil, repository.ErrNotFound
+	}
+	return cloneGame(game), nil
+}
+
+// FindTitles возвращает названия активных игр
+func (r *GameRepository) FindTitles(ctx context.Context) ([]*repository.GameTitle, error) {
+	defer rlock(ctx, r.data)()
+
+	res := []*repository.GameTitle{}
+	for _, game := range r.data.Games {
+		if game.DeletedAt == nil {
+			res = append(res, &repository.GameTitle{ID: game.ID, Title: game.Title})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Title != res[j].Title {
+			return res[i].Title < res[j].Title
+		}
+		return res[i].ID.String() < res[j].ID.String()
+	})
+	return res, nil
+}
+
+// UpdateStatus сохраняет статус публикации игры
+func (r *GameRepository) UpdateStatus(ctx context.Context, game *model.Game) (*model.Game, error) {
+	if game == nil {
+		return nil, errors.New("game cannot be nil")
+	}
+
+	if game.ID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	defer lock(ctx, r.data)()
+
+	existing, exists := r.data.Games[game.ID]
+	if !exists || existing.DeletedAt != nil {
+		return nil, repository.ErrNotFound
+	}
+	if existing.Version != game.Version {
+		return nil, repository.ErrVersionConflict
+	}
+
+	existing.Status = game.Status
+	existing.PublishAt = game.PublishAt
+	existing.Version++
+	game.Version = existing.Version
+
+	return game, nil
+}
+
+// PublishDue публикует игры, время публикации которых наступило к now
+func (r *GameRepository) PublishDue(ctx context.Context, now time.Time) ([]*model.Game, error) {
+	defer lock(ctx, r.data)()
+
+	res := []*model.Game{}
+	for _, game := range r.data.Games {
+		if game.DeletedAt == nil && game.PublishDue(now) {
+			game.Version++
+			res = append(res, cloneGame(game))
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].ID.String() < res[j].ID.String() })
+
+	return res, nil
+}
+
+// NextPublishAt возвращает ближайший момент запланированной публикации
+func (r *GameRepository) NextPublishAt(ctx context.Context) (*time.Time, error) {
+	defer rlock(ctx, r.data)()
+
+	var next *time.Time
+	for _, game := range r.data.Games {
+		if game.DeletedAt != nil || game.Status != model.GameStatusScheduled || game.PublishAt == nil {
+			continue
+		}
+		if next == nil || game.PublishAt.Before(*next) {
+			at := *game.PublishAt
+			next = &at
+		}
+	}
+
+	return next, nil
+}
+
+// titleOwner возвращает активную игру (кроме except) с тем же нормализованным названием, что и title
+func titleOwner(games map[uuid.UUID]*model.Game, title string, except uuid.UUID) *model.Game {
+	key := model.NormalizeTitle(title)
+	for id, game := range games {
+		if id != except && game.DeletedAt == nil && model.NormalizeTitle(game.Title) == key {
+			return game
+		}
+	}
+	return nil
 }
 
 // ========== Дополнительные методы ==========
 
 // Count возвращает количество игр
 func (r *GameRepository) Count(ctx context.Context) (int, error) {
-	r.data.Mu.RLock()
-	defer r.data.Mu.RUnlock()
+	defer rlock(ctx, r.data)()
 
-	return len(r.data.Games), nil
+	count := 0
+	for _, game := range r.data.Games {
+		if game.DeletedAt == nil {
+			count++
+		}
+	}
+	return count, nil
 }
 
 // Clear очищает все данные (для тестов)
@@ -189,8 +624,22 @@ func (r *GameRepository) Seed(games []*model.Game) error {
 		if game.ID == uuid.Nil {
 			game.ID = uuid.New()
 		}
+		game.SetGenres(game.GenreID, game.GenreIDs)
+		if game.Status == "" {
+			game.Status = model.GameStatusPublished
+		}
+		if game.Version == 0 {
+			game.Version = 1
+		}
 		r.data.Games[game.ID] = game
 	}
 
 	return nil
 }
+
+// cloneGame копирует игру вместе со срезом жанров
+func cloneGame(game *model.Game) *model.Game {
+	copied := *game
+	copied.GenreIDs = append([]uuid.UUID(nil), game.GenreIDs...)
+	return &copied
+}
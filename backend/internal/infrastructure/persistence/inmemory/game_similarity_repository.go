@@ -0,0 +1,68 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.GameSimilarityRepository = (*GameSimilarityRepository)(nil)
+
+// GameSimilarityRepository in-memory реализация
+type GameSimilarityRepository struct {
+	data *data.Data
+}
+
+// NewGameSimilarityRepository создает новый in-memory репозиторий
+func NewGameSimilarityRepository(store *data.Data) *GameSimilarityRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &GameSimilarityRepository{data: store}
+}
+
+func (r *GameSimilarityRepository) Replace(ctx context.Context, items []*model.GameSimilarity, computedAt time.Time) error {
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	similarities := make(map[uuid.UUID][]*model.GameSimilarity)
+	for _, item := range items {
+		_, gameExists := r.data.Games[item.GameID]
+		_, similarExists := r.data.Games[item.SimilarID]
+		if !gameExists || !similarExists {
+			continue
+		}
+		copied := *item
+		similarities[item.GameID] = append(similarities[item.GameID], &copied)
+	}
+	for _, list := range similarities {
+		model.SortSimilarities(list)
+	}
+
+	r.data.GameSimilarities = similarities
+	return nil
+}
+
+func (r *GameSimilarityRepository) FindByGameIDs(ctx context.Context, gameIDs []uuid.UUID) (map[uuid.UUID][]*model.GameSimilarity, error) {
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	res := make(map[uuid.UUID][]*model.GameSimilarity, len(gameIDs))
+	for _, id := range gameIDs {
+		for _, item := range r.data.GameSimilarities[id] {
+			similar, exists := r.data.Games[item.SimilarID]
+			if !exists || similar.DeletedAt != nil {
+				continue
+			}
+			copied := *item
+			res[id] = append(res[id], &copied)
+		}
+	}
+	return res, nil
+}
@@ -3,6 +3,9 @@ package inmemory
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
+	"time"
 
 	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/domain/model"
@@ -35,13 +38,13 @@ func (r *GenreRepository) Create(ctx context.Context, genre *model.Genre) (*mode
 		genre.ID = uuid.New()
 	}
 
-	r.data.Mu.Lock()
-	defer r.data.Mu.Unlock()
+	defer lock(ctx, r.data)()
 
 	if _, exists := r.data.Genres[genre.ID]; exists {
 		return nil, repository.ErrGenreAlreadyExists
 	}
 
+	genre.Version = 1
 	r.data.Genres[genre.ID] = genre
 	return genre, nil
 }
@@ -51,24 +54,26 @@ func (r *GenreRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Ge
 		return nil, errors.New("genre ID cannot be empty")
 	}
 
-	r.data.Mu.RLock()
-	defer r.data.Mu.RUnlock()
+	defer rlock(ctx, r.data)()
 
 	genre, exists := r.data.Genres[id]
-	if !exists {
+	if !exists || genre.DeletedAt != nil {
 		return nil, repository.ErrGenreNotFound
 	}
 
-	return genre, nil
+	// Копия: изменения вызывающего кода попадают в хранилище только через Update
+	copied := *genre
+	return &copied, nil
 }
 
 func (r *GenreRepository) FindAll(ctx context.Context, limit, offset int) ([]*model.Genre, error) {
-	r.data.Mu.RLock()
-	defer r.data.Mu.RUnlock()
+	defer rlock(ctx, r.data)()
 
 	all := make([]*model.Genre, 0, len(r.data.Genres))
 	for _, g := range r.data.Genres {
-		all = append(all, g)
+		if g.DeletedAt == nil {
+			all = append(all, g)
+		}
 	}
 
 	start := offset
@@ -87,6 +92,66 @@ func (r *GenreRepository) FindAll(ctx context.Context, limit, offset int) ([]*mo
 	return all[start:end], nil
 }
 
+func (r *GenreRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*model.Genre, error) {
+	defer rlock(ctx, r.data)()
+
+	res := make([]*model.Genre, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		genre, exists := r.data.Genres[id]
+		if !exists || genre.DeletedAt != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		copied := *genre
+		res = append(res, &copied)
+	}
+	return res, nil
+}
+
+func (r *GenreRepository) FindByQuery(ctx context.Context, q repository.GenreQuery) ([]*model.Genre, int, error) {
+	defer rlock(ctx, r.data)()
+
+	search := strings.TrimSpace(q.Search)
+
+	matched := make([]*model.Genre, 0, len(r.data.Genres))
+	for _, g := range r.data.Genres {
+		if g.DeletedAt != nil {
+			continue
+		}
+		if search != "" && !containsFold(g.Title, search) {
+			continue
+		}
+		matched = append(matched, g)
+	}
+
+	compare := func(aTitle string, aID uuid.UUID, bTitle string, bID uuid.UUID) int {
+		c := strings.Compare(aTitle, bTitle)
+		if c == 0 {
+			c = strings.Compare(aID.String(), bID.String())
+		}
+		if q.SortDesc {
+			c = -c
+		}
+		return c
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return compare(matched[i].Title, matched[i].ID, matched[j].Title, matched[j].ID) < 0
+	})
+
+	total := len(matched)
+	offset := q.Offset
+	if q.After != nil {
+		idx := sort.Search(len(matched), func(i int) bool {
+			return compare(matched[i].Title, matched[i].ID, q.After.Key, q.After.ID) > 0
+		})
+		matched = matched[idx:]
+		offset = 0
+	}
+
+	return page(matched, q.Limit, offset), total, nil
+}
+
 func (r *GenreRepository) Update(ctx context.Context, genre *model.Genre) (*model.Genre, error) {
 	if genre == nil {
 		return nil, errors.New("genre cannot be nil")
@@ -95,42 +160,110 @@ func (r *GenreRepository) Update(ctx context.Context, genre *model.Genre) (*mode
 		return nil, errors.New("genre ID cannot be empty")
 	}
 
-	r.data.Mu.Lock()
-	defer r.data.Mu.Unlock()
+	defer lock(ctx, r.data)()
 
-	if _, exists := r.data.Genres[genre.ID]; !exists {
+	existing, exists := r.data.Genres[genre.ID]
+	if !exists || existing.DeletedAt != nil {
 		return nil, repository.ErrGenreNotFound
 	}
+	// Аналог UPDATE ... WHERE version = ?
+	if existing.Version != genre.Version {
+		return nil, repository.ErrVersionConflict
+	}
 
-	r.data.Genres[genre.ID] = genre
+	genre.Version++
+	stored := *genre
+	r.data.Genres[genre.ID] = &stored
 	return genre, nil
 }
 
-func (r *GenreRepository) Delete(ctx context.Context, id uuid.UUID) error {
+// Delete перемещает жанр в корзину
+func (r *GenreRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
+	if id == uuid.Nil {
+		return errors.New("genre ID cannot be empty")
+	}
+
+	defer lock(ctx, r.data)()
+
+	genre, exists := r.data.Genres[id]
+	if !exists || genre.DeletedAt != nil {
+		return repository.ErrGenreNotFound
+	}
+	if genre.Version != version {
+		return repository.ErrVersionConflict
+	}
+
+	now := time.Now().UTC()
+	genre.DeletedAt = &now
+	genre.Version++
+	return nil
+}
+
+// FindDeleted возвращает жанры из корзины, недавно удаленные первыми
+func (r *GenreRepository) FindDeleted(ctx context.Context) ([]*model.Genre, error) {
+	defer rlock(ctx, r.data)()
+
+	res := []*model.Genre{}
+	for _, g := range r.data.Genres {
+		if g.DeletedAt != nil {
+			res = append(res, g)
+		}
+	}
+	sortByDeletedAt(res, func(g *model.Genre) (time.Time, uuid.UUID) { return *g.DeletedAt, g.ID })
+
+	return res, nil
+}
+
+// Restore возвращает жанр из корзины
+func (r *GenreRepository) Restore(ctx context.Context, id uuid.UUID) error {
 	if id == uuid.Nil {
 		return errors.New("genre ID cannot be empty")
 	}
 
-	r.data.Mu.Lock()
-	defer r.data.Mu.Unlock()
+	defer lock(ctx, r.data)()
 
-	if _, exists := r.data.Genres[id]; !exists {
+	genre, exists := r.data.Genres[id]
+	if !exists || genre.DeletedAt == nil {
 		return repository.ErrGenreNotFound
 	}
 
-	delete(r.data.Genres, id)
+	genre.DeletedAt = nil
+	genre.Version++
 	return nil
 }
 
+// Purge окончательно удаляет жанры из корзины, удаленные раньше before.
+// Жанр, к которому еще относятся игры (в том числе из корзины), остается (аналог ON DELETE RESTRICT).
+func (r *GenreRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	defer lock(ctx, r.data)()
+
+	used := make(map[uuid.UUID]bool)
+	for _, game := range r.data.Games {
+		for _, genreID := range game.GenreIDs {
+			used[genreID] = true
+		}
+	}
+
+	purged := 0
+	for id, g := range r.data.Genres {
+		if g.DeletedAt == nil || !g.DeletedAt.Before(before) || used[id] {
+			continue
+		}
+		delete(r.data.Genres, id)
+		purged++
+	}
+
+	return purged, nil
+}
+
 func (r *GenreRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	if id == uuid.Nil {
 		return false, errors.New("genre ID cannot be empty")
 	}
 
-	r.data.Mu.RLock()
-	defer r.data.Mu.RUnlock()
+	defer rlock(ctx, r.data)()
 
-	_, exists := r.data.Genres[id]
-	return exists, nil
+	genre, exists := r.data.Genres[id]
+	return exists && genre.DeletedAt == nil, nil
 }
 
@@ -0,0 +1,239 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.PlayResultRepository = (*PlayResultRepository)(nil)
+
+// PlayResultRepository in-memory реализация
+type PlayResultRepository struct {
+	data *data.Data
+}
+
+// NewPlayResultRepository создает новый in-memory репозиторий
+func NewPlayResultRepository(store *data.Data) *PlayResultRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &PlayResultRepository{data: store}
+}
+
+func (r *PlayResultRepository) UseNonce(ctx context.Context, attemptID uuid.UUID, nonce string, at time.Time) error {
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	if _, exists := r.data.Attempts[attemptID]; !exists {
+		return repository.ErrAttemptNotFound
+	}
+	nonces, exists := r.data.ResultNonces[attemptID]
+	if !exists {
+		nonces = make(map[string]time.Time)
+		r.data.ResultNonces[attemptID] = nonces
+	}
+	if _, used := nonces[nonce]; used {
+		return repository.ErrNonceUsed
+	}
+	nonces[nonce] = at.UTC()
+	return nil
+}
+
+func (r *PlayResultRepository) Submit(ctx context.Context,
+	result *model.PlayResult, attempt *model.Attempt) (*model.PlayResult, error) {
+
+	if result == nil || attempt == nil {
+		return nil, errors.New("result and attempt cannot be nil")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	existing, exists := r.data.Attempts[attempt.ID]
+	if !exists {
+		return nil, repository.ErrAttemptNotFound
+	}
+	if _, submitted := r.data.PlayResults[attempt.ID]; submitted {
+		return nil, repository.ErrResultAlreadySubmitted
+	}
+	if existing.Status != model.AttemptRunning {
+		return nil, repository.ErrAttemptStatusChanged
+	}
+
+	stored := clonePlayResult(result)
+	r.data.Attempts[attempt.ID] = withAttemptStatus(existing, attempt)
+	r.data.PlayResults[attempt.ID] = stored
+
+	board := r.data.Leaderboards[stored.GameID]
+	i, _ := slices.BinarySearchFunc(board, stored, compareBoardResults)
+	r.data.Leaderboards[stored.GameID] = slices.Insert(board, i, stored)
+	return result, nil
+}
+
+func (r *PlayResultRepository) FindGameStatsByUser(ctx context.Context, userID uuid.UUID) ([]*model.GamePlayStats, error) {
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	byGame := make(map[uuid.UUID]*model.GamePlayStats)
+	passedTimes := make(map[uuid.UUID][]time.Duration)
+	for _, result := range r.userResults(userID) {
+		stats, exists := byGame[result.GameID]
+		if !exists {
+			stats = &model.GamePlayStats{
+				GameID:    result.GameID,
+				GameTitle: r.data.Games[result.GameID].Title,
+				BestScore: result.Score,
+			}
+			byGame[result.GameID] = stats
+		}
+		stats.Plays++
+		stats.BestScore = max(stats.BestScore, result.Score)
+		if result.SubmittedAt.After(stats.LastPlayedAt) {
+			stats.LastPlayedAt = result.SubmittedAt
+		}
+		if result.Passed {
+			stats.Completions++
+			passedTimes[result.GameID] = append(passedTimes[result.GameID], result.Duration)
+		}
+	}
+
+	res := make([]*model.GamePlayStats, 0, len(byGame))
+	for gameID, stats := range byGame {
+		if times := passedTimes[gameID]; len(times) > 0 {
+			slices.Sort(times)
+			best := times[0]
+			median := (times[(len(times)-1)/2] + times[len(times)/2]) / 2
+			stats.BestTime, stats.MedianTime = &best, &median
+		}
+		res = append(res, stats)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].LastPlayedAt.Equal(res[j].LastPlayedAt) {
+			return res[i].LastPlayedAt.After(res[j].LastPlayedAt)
+		}
+		return res[i].GameID.String() < res[j].GameID.String()
+	})
+	return res, nil
+}
+
+func (r *PlayResultRepository) FindGenreStatsByUser(ctx context.Context, userID uuid.UUID) ([]*model.GenrePlayStats, error) {
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	byGenre := make(map[uuid.UUID]*model.GenrePlayStats)
+	games := make(map[uuid.UUID]map[uuid.UUID]bool)
+	for _, result := range r.userResults(userID) {
+		for _, genreID := range r.data.Games[result.GameID].GenreIDs {
+			genre, exists := r.data.Genres[genreID]
+			if !exists || genre.DeletedAt != nil {
+				continue
+			}
+			stats, exists := byGenre[genreID]
+			if !exists {
+				stats = &model.GenrePlayStats{GenreID: genreID, GenreTitle: genre.Title}
+				byGenre[genreID] = stats
+				games[genreID] = make(map[uuid.UUID]bool)
+			}
+			stats.Plays++
+			if result.Passed {
+				stats.Completions++
+			}
+			games[genreID][result.GameID] = true
+		}
+	}
+
+	res := make([]*model.GenrePlayStats, 0, len(byGenre))
+	for genreID, stats := range byGenre {
+		stats.Games = len(games[genreID])
+		res = append(res, stats)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Plays != res[j].Plays {
+			return res[i].Plays > res[j].Plays
+		}
+		if res[i].GenreTitle != res[j].GenreTitle {
+			return res[i].GenreTitle < res[j].GenreTitle
+		}
+		return res[i].GenreID.String() < res[j].GenreID.String()
+	})
+	return res, nil
+}
+
+// FindLeaderboard идет по отсортированным результатам игры: первый встреченный результат
+// пользователя - его лучший, а порядок встречи - его место.
+func (r *PlayResultRepository) FindLeaderboard(ctx context.Context, q repository.LeaderboardQuery) (*model.Leaderboard, error) {
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	board := &model.Leaderboard{Entries: []*model.LeaderboardEntry{}}
+	seen := make(map[uuid.UUID]bool)
+	for _, result := range r.data.Leaderboards[q.GameID] {
+		if seen[result.UserID] ||
+			(q.TaskID != uuid.Nil && result.TaskID != q.TaskID) ||
+			(!q.Since.IsZero() && result.SubmittedAt.Before(q.Since)) {
+			continue
+		}
+		user, exists := r.data.Users[result.UserID]
+		if !exists || user.DeletedAt != nil || (q.ExcludeAdmins && user.UserRole == specifictype.RoleAdmin) {
+			continue
+		}
+		seen[result.UserID] = true
+		board.Participants++
+
+		entry := &model.LeaderboardEntry{
+			Rank:       board.Participants,
+			UserID:     result.UserID,
+			Username:   user.Username,
+			Score:      result.Score,
+			Duration:   result.Duration,
+			AchievedAt: result.SubmittedAt,
+		}
+		if entry.UserID == q.CallerID {
+			board.Caller = entry
+		}
+		if entry.Rank <= q.Limit {
+			board.Entries = append(board.Entries, entry)
+		}
+	}
+	return board, nil
+}
+
+// compareBoardResults - порядок Data.Leaderboards
+func compareBoardResults(a, b *model.PlayResult) int {
+	if c := model.CompareResults(a, b); c != 0 {
+		return c
+	}
+	return strings.Compare(a.UserID.String(), b.UserID.String())
+}
+
+// userResults - результаты пользователя в играх не из корзины; вызывается под блокировкой
+func (r *PlayResultRepository) userResults(userID uuid.UUID) []*model.PlayResult {
+	res := []*model.PlayResult{}
+	for _, result := range r.data.PlayResults {
+		game, exists := r.data.Games[result.GameID]
+		if result.UserID == userID && exists && game.DeletedAt == nil {
+			res = append(res, result)
+		}
+	}
+	return res
+}
+
+func clonePlayResult(result *model.PlayResult) *model.PlayResult {
+	copied := *result
+	copied.Details = slices.Clone(result.Details)
+	return &copied
+}
@@ -0,0 +1,155 @@
+package inmemory
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+
+	"github.com/google/uuid"
+)
+
+// page вырезает страницу из уже отсортированного среза (limit <= 0 - без ограничения).
+func page[T any](items []T, limit, offset int) []T {
+	start := offset
+	if start > len(items) {
+		start = len(items)
+	}
+
+	end := len(items)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+
+	return items[start:end]
+}
+
+// sortByDeletedAt упорядочивает содержимое корзины: недавно удаленные первыми, затем по ID.
+func sortByDeletedAt[T any](items []T, key func(T) (time.Time, uuid.UUID)) {
+	sort.Slice(items, func(i, j int) bool {
+		ti, idI := key(items[i])
+		tj, idJ := key(items[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return idI.String() < idJ.String()
+	})
+}
+
+// containsFold - аналог LIKE '%s%' без учета регистра.
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// searchTerms разбивает поисковый запрос на слова в нижнем регистре
+// (упрощенный аналог токенизатора unicode61 из FTS5).
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isWordSeparator)
+}
+
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// wordSpan - границы слова в исходной строке (в байтах)
+type wordSpan struct {
+	start, end int
+}
+
+func wordSpans(text string) []wordSpan {
+	var spans []wordSpan
+	start := -1
+	for i, r := range text {
+		if isWordSeparator(r) {
+			if start >= 0 {
+				spans = append(spans, wordSpan{start, i})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, wordSpan{start, len(text)})
+	}
+	return spans
+}
+
+// highlightTerms выделяет слова, начинающиеся с одного из термов, и возвращает
+// экранированный HTML с выделением, количество таких слов и множество найденных термов.
+// maxWords > 0 обрезает текст до окна вокруг первого совпадения (как snippet в FTS5).
+func highlightTerms(text string, terms []string, maxWords int) (string, int, map[string]bool) {
+	spans := wordSpans(text)
+	found := make(map[string]bool)
+	matched := make([]bool, len(spans))
+	hits := 0
+	first := -1
+	for i, sp := range spans {
+		word := strings.ToLower(text[sp.start:sp.end])
+		for _, term := range terms {
+			if strings.HasPrefix(word, term) {
+				found[term] = true
+				matched[i] = true
+			}
+		}
+		if matched[i] {
+			hits++
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+
+	from, to := 0, len(spans)
+	if maxWords > 0 && len(spans) > maxWords {
+		if first > 0 {
+			from = first - maxWords/4
+		}
+		if from < 0 {
+			from = 0
+		}
+		to = from + maxWords
+		if to > len(spans) {
+			to = len(spans)
+			from = to - maxWords
+		}
+	}
+
+	if len(spans) == 0 {
+		return html.EscapeString(text), 0, found
+	}
+
+	var b strings.Builder
+	pos := 0
+	if from > 0 {
+		b.WriteString("…")
+		pos = spans[from].start
+	}
+	end := len(text)
+	if to < len(spans) {
+		end = spans[to-1].end
+	}
+	for i := from; i < to; i++ {
+		sp := spans[i]
+		b.WriteString(html.EscapeString(text[pos:sp.start]))
+		if matched[i] {
+			b.WriteString(repository.SearchHighlightStart)
+			b.WriteString(html.EscapeString(text[sp.start:sp.end]))
+			b.WriteString(repository.SearchHighlightEnd)
+		} else {
+			b.WriteString(html.EscapeString(text[sp.start:sp.end]))
+		}
+		pos = sp.end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	if to < len(spans) {
+		b.WriteString("…")
+	}
+
+	return b.String(), hits, found
+}
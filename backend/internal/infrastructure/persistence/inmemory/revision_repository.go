@@ -0,0 +1,108 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.RevisionRepository = (*RevisionRepository)(nil)
+
+// RevisionRepository in-memory реализация
+type RevisionRepository struct {
+	data *data.Data
+}
+
+// NewRevisionRepository создает новый in-memory репозиторий
+func NewRevisionRepository(store *data.Data) *RevisionRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &RevisionRepository{data: store}
+}
+
+func (r *RevisionRepository) Append(ctx context.Context, revision *model.Revision) (*model.Revision, error) {
+	if revision == nil {
+		return nil, errors.New("revision cannot be nil")
+	}
+	if revision.EntityID == uuid.Nil {
+		return nil, errors.New("revision entity ID cannot be empty")
+	}
+	if revision.ID == uuid.Nil {
+		revision.ID = uuid.New()
+	}
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now().UTC()
+	}
+
+	defer lock(ctx, r.data)()
+
+	// Ревизии одной сущности добавляются по порядку, так что последняя найденная - с наибольшим номером
+	revision.Number = 1
+	for _, existing := range r.data.Revisions {
+		if existing.EntityType == revision.EntityType && existing.EntityID == revision.EntityID {
+			revision.Number = existing.Number + 1
+		}
+	}
+
+	// Храним копию, чтобы вызывающий код не мог изменить историю
+	stored := *revision
+	r.data.Revisions = append(r.data.Revisions, &stored)
+	return revision, nil
+}
+
+func (r *RevisionRepository) FindByEntity(ctx context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]*model.Revision, int, error) {
+	if entityID == uuid.Nil {
+		return nil, 0, errors.New("revision entity ID cannot be empty")
+	}
+
+	defer rlock(ctx, r.data)()
+
+	// Последние ревизии первыми, как ORDER BY number DESC
+	res := []*model.Revision{}
+	for i := len(r.data.Revisions) - 1; i >= 0; i-- {
+		revision := r.data.Revisions[i]
+		if revision.EntityType == entityType && revision.EntityID == entityID {
+			copied := *revision
+			res = append(res, &copied)
+		}
+	}
+
+	return page(res, limit, offset), len(res), nil
+}
+
+func (r *RevisionRepository) FindByNumber(ctx context.Context, entityType string, entityID uuid.UUID, number int) (*model.Revision, error) {
+	if entityID == uuid.Nil {
+		return nil, errors.New("revision entity ID cannot be empty")
+	}
+
+	defer rlock(ctx, r.data)()
+
+	for _, revision := range r.data.Revisions {
+		if revision.EntityType == entityType && revision.EntityID == entityID && revision.Number == number {
+			copied := *revision
+			return &copied, nil
+		}
+	}
+	return nil, repository.ErrRevisionNotFound
+}
+
+func (r *RevisionRepository) CountByActor(ctx context.Context, entityType, action string, actorID uuid.UUID, since time.Time) (int, error) {
+	defer rlock(ctx, r.data)()
+
+	count := 0
+	for _, revision := range r.data.Revisions {
+		if revision.EntityType == entityType && revision.Action == action &&
+			revision.ActorID == actorID && !revision.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,167 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.SeriesRepository = (*SeriesRepository)(nil)
+
+// SeriesRepository in-memory реализация
+type SeriesRepository struct {
+	data *data.Data
+}
+
+// NewSeriesRepository создает новый in-memory репозиторий
+func NewSeriesRepository(store *data.Data) *SeriesRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &SeriesRepository{data: store}
+}
+
+func (r *SeriesRepository) Create(ctx context.Context, series *model.Series) (*model.Series, error) {
+	if series == nil {
+		return nil, errors.New("series cannot be nil")
+	}
+	if series.ID == uuid.Nil {
+		series.ID = uuid.New()
+	}
+	if series.CreatedAt.IsZero() {
+		series.CreatedAt = time.Now().UTC()
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	if err := r.checkGames(series); err != nil {
+		return nil, err
+	}
+	r.data.Series[series.ID] = cloneSeries(series)
+	return series, nil
+}
+
+func (r *SeriesRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Series, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("series ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	series, exists := r.data.Series[id]
+	if !exists {
+		return nil, repository.ErrSeriesNotFound
+	}
+	return cloneSeries(series), nil
+}
+
+func (r *SeriesRepository) FindAll(ctx context.Context, limit, offset int) ([]*model.Series, int, error) {
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	res := make([]*model.Series, 0, len(r.data.Series))
+	for _, series := range r.data.Series {
+		res = append(res, cloneSeries(series))
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Title != res[j].Title {
+			return res[i].Title < res[j].Title
+		}
+		return res[i].ID.String() < res[j].ID.String()
+	})
+	return page(res, limit, offset), len(res), nil
+}
+
+func (r *SeriesRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) (*model.Series, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	for _, series := range r.data.Series {
+		for _, id := range series.GameIDs {
+			if id == gameID {
+				return cloneSeries(series), nil
+			}
+		}
+	}
+	return nil, repository.ErrSeriesNotFound
+}
+
+func (r *SeriesRepository) Update(ctx context.Context, series *model.Series) (*model.Series, error) {
+	if series == nil {
+		return nil, errors.New("series cannot be nil")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	existing, exists := r.data.Series[series.ID]
+	if !exists {
+		return nil, repository.ErrSeriesNotFound
+	}
+	if err := r.checkGames(series); err != nil {
+		return nil, err
+	}
+
+	existing.Title = series.Title
+	existing.GameIDs = append([]uuid.UUID{}, series.GameIDs...)
+	return series, nil
+}
+
+func (r *SeriesRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("series ID cannot be empty")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	if _, exists := r.data.Series[id]; !exists {
+		return repository.ErrSeriesNotFound
+	}
+	delete(r.data.Series, id)
+	return nil
+}
+
+// checkGames проверяет, что игры серии активны и не входят в другие серии; вызывается под блокировкой
+func (r *SeriesRepository) checkGames(series *model.Series) error {
+	for _, id := range series.GameIDs {
+		game, exists := r.data.Games[id]
+		if !exists || game.DeletedAt != nil {
+			return repository.ErrNotFound
+		}
+	}
+	for _, other := range r.data.Series {
+		if other.ID == series.ID {
+			continue
+		}
+		for _, id := range other.GameIDs {
+			for _, member := range series.GameIDs {
+				if id == member {
+					return repository.ErrGameInAnotherSeries
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func cloneSeries(series *model.Series) *model.Series {
+	copied := *series
+	copied.GameIDs = append([]uuid.UUID{}, series.GameIDs...)
+	return &copied
+}
@@ -0,0 +1,182 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sort"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.TaskRepository = (*TaskRepository)(nil)
+
+// TaskRepository in-memory реализация
+type TaskRepository struct {
+	data *data.Data
+}
+
+// NewTaskRepository создает новый in-memory репозиторий
+func NewTaskRepository(store *data.Data) *TaskRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &TaskRepository{data: store}
+}
+
+func (r *TaskRepository) Create(ctx context.Context, task *model.Task) (*model.Task, error) {
+	if task == nil {
+		return nil, errors.New("task cannot be nil")
+	}
+	if task.ID == uuid.Nil {
+		task.ID = uuid.New()
+	}
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now().UTC()
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = task.CreatedAt
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	if !r.liveGame(task.GameID) {
+		return nil, repository.ErrNotFound
+	}
+	r.assignPosition(task)
+	r.data.Tasks[task.ID] = cloneTask(task)
+	return task, nil
+}
+
+func (r *TaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Task, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("task ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	task, exists := r.data.Tasks[id]
+	if !exists || !r.liveGame(task.GameID) {
+		return nil, repository.ErrTaskNotFound
+	}
+	return cloneTask(task), nil
+}
+
+func (r *TaskRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.Task, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	res := []*model.Task{}
+	if !r.liveGame(gameID) {
+		return res, nil
+	}
+	for _, task := range r.data.Tasks {
+		if task.GameID == gameID {
+			res = append(res, cloneTask(task))
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Order != res[j].Order {
+			return res[i].Order < res[j].Order
+		}
+		if !res[i].CreatedAt.Equal(res[j].CreatedAt) {
+			return res[i].CreatedAt.Before(res[j].CreatedAt)
+		}
+		return res[i].ID.String() < res[j].ID.String()
+	})
+	return res, nil
+}
+
+func (r *TaskRepository) Update(ctx context.Context, task *model.Task) (*model.Task, error) {
+	if task == nil {
+		return nil, errors.New("task cannot be nil")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	existing, exists := r.data.Tasks[task.ID]
+	if !exists || !r.liveGame(existing.GameID) {
+		return nil, repository.ErrTaskNotFound
+	}
+	r.assignPosition(task)
+
+	updated := cloneTask(task)
+	updated.GameID = existing.GameID
+	updated.CreatedAt = existing.CreatedAt
+	r.data.Tasks[task.ID] = updated
+	return task, nil
+}
+
+func (r *TaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("task ID cannot be empty")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	task, exists := r.data.Tasks[id]
+	if !exists || !r.liveGame(task.GameID) {
+		return repository.ErrTaskNotFound
+	}
+	delete(r.data.Tasks, id)
+	// Попытки и результаты удаленной задачи остаются без задачи (аналог ON DELETE SET NULL)
+	for _, attempt := range r.data.Attempts {
+		if attempt.TaskID == id {
+			attempt.TaskID = uuid.Nil
+		}
+	}
+	for _, result := range r.data.PlayResults {
+		if result.TaskID == id {
+			result.TaskID = uuid.Nil
+		}
+	}
+	return nil
+}
+
+// liveGame - игра существует и не в корзине; вызывается под блокировкой
+func (r *TaskRepository) liveGame(gameID uuid.UUID) bool {
+	game, exists := r.data.Games[gameID]
+	return exists && game.DeletedAt == nil
+}
+
+// assignPosition назначает задаче с Order 0 номер после последней задачи игры; вызывается под блокировкой
+func (r *TaskRepository) assignPosition(task *model.Task) {
+	if task.Order > 0 {
+		return
+	}
+	last := 0
+	for _, other := range r.data.Tasks {
+		if other.GameID == task.GameID && other.ID != task.ID && other.Order > last {
+			last = other.Order
+		}
+	}
+	task.Order = last + 1
+}
+
+func cloneTask(task *model.Task) *model.Task {
+	copied := *task
+	copied.LaunchParams = maps.Clone(task.LaunchParams)
+	if copied.LaunchParams == nil {
+		copied.LaunchParams = map[string]string{}
+	}
+	if task.PassCriteria.MinScore != nil {
+		minScore := *task.PassCriteria.MinScore
+		copied.PassCriteria.MinScore = &minScore
+	}
+	return &copied
+}
@@ -0,0 +1,87 @@
+package inmemory
+
+import (
+	"context"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.Transactor = (*Transactor)(nil)
+
+type txKey struct{}
+
+// Transactor in-memory реализация: транзакция держит блокировку данных до конца,
+// а при ошибке возвращает игры, жанры, связи игр и историю к состоянию на ее начало
+type Transactor struct {
+	data *data.Data
+}
+
+func NewTransactor(store *data.Data) *Transactor {
+	if store == nil {
+		store = data.New()
+	}
+	return &Transactor{data: store}
+}
+
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if inTx(ctx) {
+		return fn(ctx)
+	}
+
+	t.data.Mu.Lock()
+	defer t.data.Mu.Unlock()
+
+	// Внутри транзакции меняются только игры, жанры, связи игр и история
+	games := make(map[uuid.UUID]*model.Game, len(t.data.Games))
+	for id, game := range t.data.Games {
+		games[id] = cloneGame(game)
+	}
+	genres := make(map[uuid.UUID]*model.Genre, len(t.data.Genres))
+	for id, genre := range t.data.Genres {
+		stored := *genre
+		genres[id] = &stored
+	}
+	relations := make(map[data.RelationKey]*model.GameRelation, len(t.data.GameRelations))
+	for key, relation := range t.data.GameRelations {
+		stored := *relation
+		relations[key] = &stored
+	}
+	revisions := len(t.data.Revisions)
+
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		t.data.Games = games
+		t.data.Genres = genres
+		t.data.GameRelations = relations
+		t.data.Revisions = t.data.Revisions[:revisions]
+		return err
+	}
+	return nil
+}
+
+func inTx(ctx context.Context) bool {
+	held, _ := ctx.Value(txKey{}).(bool)
+	return held
+}
+
+// lock захватывает данные на запись; в транзакции они уже захвачены ею
+func lock(ctx context.Context, store *data.Data) func() {
+	if inTx(ctx) {
+		return func() {}
+	}
+	store.Mu.Lock()
+	return store.Mu.Unlock
+}
+
+// rlock захватывает данные на чтение; в транзакции они уже захвачены ею
+func rlock(ctx context.Context, store *data.Data) func() {
+	if inTx(ctx) {
+		return func() {}
+	}
+	store.Mu.RLock()
+	return store.Mu.RUnlock
+}
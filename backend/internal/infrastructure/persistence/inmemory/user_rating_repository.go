@@ -0,0 +1,178 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/infrastructure/persistence/data"
+
+	"github.com/google/uuid"
+)
+
+// Проверка что реализуем интерфейс
+var _ repository.UserRatingRepository = (*UserRatingRepository)(nil)
+
+// UserRatingRepository in-memory реализация
+type UserRatingRepository struct {
+	data *data.Data
+}
+
+// NewUserRatingRepository создает новый in-memory репозиторий
+func NewUserRatingRepository(store *data.Data) *UserRatingRepository {
+	if store == nil {
+		store = data.New()
+	}
+	return &UserRatingRepository{data: store}
+}
+
+func (r *UserRatingRepository) Create(ctx context.Context, rating *model.UserRating) (*model.UserRating, error) {
+	if rating == nil {
+		return nil, errors.New("rating cannot be nil")
+	}
+	if rating.ID == uuid.Nil {
+		rating.ID = uuid.New()
+	}
+	if rating.CreatedAt.IsZero() {
+		rating.CreatedAt = time.Now().UTC()
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	if _, exists := r.data.UserRatings[rating.ID]; exists {
+		return nil, repository.ErrUserRatingAlreadyExists
+	}
+	// Аналог UNIQUE (user_id, game_id)
+	for _, existing := range r.data.UserRatings {
+		if existing.UserID == rating.UserID && existing.GameID == rating.GameID {
+			return nil, repository.ErrUserRatingAlreadyExists
+		}
+	}
+
+	r.data.UserRatings[rating.ID] = rating
+	return rating, nil
+}
+
+func (r *UserRatingRepository) Update(ctx context.Context, rating *model.UserRating) (*model.UserRating, error) {
+	if rating == nil {
+		return nil, errors.New("rating cannot be nil")
+	}
+	if rating.ID == uuid.Nil {
+		return nil, errors.New("rating ID cannot be empty")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	existing, exists := r.data.UserRatings[rating.ID]
+	if !exists {
+		return nil, repository.ErrUserRatingNotFound
+	}
+
+	// Как и в SQL-реализации, меняются только значение и дата
+	existing.Rating = rating.Rating
+	existing.CreatedAt = rating.CreatedAt
+	return existing, nil
+}
+
+func (r *UserRatingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("rating ID cannot be empty")
+	}
+
+	r.data.Mu.Lock()
+	defer r.data.Mu.Unlock()
+
+	if _, exists := r.data.UserRatings[id]; !exists {
+		return repository.ErrUserRatingNotFound
+	}
+
+	delete(r.data.UserRatings, id)
+	return nil
+}
+
+func (r *UserRatingRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.UserRating, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+	return r.filter(func(ur *model.UserRating) bool { return ur.GameID == gameID }), nil
+}
+
+func (r *UserRatingRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserRating, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	return r.filter(func(ur *model.UserRating) bool { return ur.UserID == userID }), nil
+}
+
+func (r *UserRatingRepository) FindAll(ctx context.Context) ([]*model.UserRating, error) {
+	return r.filter(func(ur *model.UserRating) bool { return true }), nil
+}
+
+func (r *UserRatingRepository) FindByUserAndGame(ctx context.Context, userID, gameID uuid.UUID) (*model.UserRating, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	for _, ur := range r.data.UserRatings {
+		if ur.UserID == userID && ur.GameID == gameID {
+			return ur, nil
+		}
+	}
+	return nil, repository.ErrUserRatingNotFound
+}
+
+func (r *UserRatingRepository) FindStatsByGameIDs(ctx context.Context, gameIDs []uuid.UUID) (map[uuid.UUID]*model.RatingStats, error) {
+	wanted := make(map[uuid.UUID]struct{}, len(gameIDs))
+	for _, id := range gameIDs {
+		wanted[id] = struct{}{}
+	}
+
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	sums := make(map[uuid.UUID]int)
+	res := make(map[uuid.UUID]*model.RatingStats)
+	for _, ur := range r.data.UserRatings {
+		if _, ok := wanted[ur.GameID]; !ok {
+			continue
+		}
+		stats, ok := res[ur.GameID]
+		if !ok {
+			stats = &model.RatingStats{GameID: ur.GameID}
+			res[ur.GameID] = stats
+		}
+		stats.Count++
+		sums[ur.GameID] += ur.Rating
+	}
+	for id, stats := range res {
+		stats.Average = float64(sums[id]) / float64(stats.Count)
+	}
+	return res, nil
+}
+
+func (r *UserRatingRepository) filter(match func(*model.UserRating) bool) []*model.UserRating {
+	r.data.Mu.RLock()
+	defer r.data.Mu.RUnlock()
+
+	res := []*model.UserRating{}
+	for _, ur := range r.data.UserRatings {
+		if match(ur) {
+			res = append(res, ur)
+		}
+	}
+
+	// Тот же порядок, что и в SQL-реализации
+	sort.Slice(res, func(i, j int) bool { return res[i].CreatedAt.Before(res[j].CreatedAt) })
+	return res
+}
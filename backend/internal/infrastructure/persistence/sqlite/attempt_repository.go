@@ -0,0 +1,238 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.AttemptRepository = (*AttemptRepository)(nil)
+
+const attemptColumns = `id, user_id, game_id, task_id, mode, seed, status, launch_token_hash, launch_expires_at,
+	started_at, running_at, finished_at, result_token_hash, result_expires_at`
+
+type AttemptRepository struct {
+	db *sql.DB
+}
+
+func NewAttemptRepository(db *sql.DB) *AttemptRepository {
+	return &AttemptRepository{db: db}
+}
+
+func (r *AttemptRepository) Create(ctx context.Context, attempt *model.Attempt) (*model.Attempt, error) {
+	if attempt == nil {
+		return nil, errors.New("attempt cannot be nil")
+	}
+	if attempt.ID == uuid.Nil {
+		attempt.ID = uuid.New()
+	}
+	if attempt.StartedAt.IsZero() {
+		attempt.StartedAt = time.Now().UTC()
+	}
+
+	res, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO attempts (`+attemptColumns+`)
+		SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		WHERE EXISTS (SELECT 1 FROM games WHERE id = ? AND deleted_at IS NULL)`,
+		attempt.ID.String(),
+		attempt.UserID.String(),
+		attempt.GameID.String(),
+		nullableUUID(attempt.TaskID),
+		attempt.Mode,
+		attempt.Seed,
+		attempt.Status,
+		nullableString(attempt.LaunchTokenHash),
+		formatSortableTime(attempt.LaunchExpiresAt),
+		formatSortableTime(attempt.StartedAt),
+		nullableSortableTime(attempt.RunningAt),
+		nullableSortableTime(attempt.FinishedAt),
+		nullableString(attempt.ResultTokenHash),
+		nullableSortableTime(attempt.ResultExpiresAt),
+		attempt.GameID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert attempt: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return attempt, nil
+}
+
+func (r *AttemptRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Attempt, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("attempt ID cannot be empty")
+	}
+	return r.findOne(ctx, `SELECT `+attemptColumns+` FROM attempts WHERE id = ?`, id.String())
+}
+
+func (r *AttemptRepository) FindByLaunchTokenHash(ctx context.Context, hash string) (*model.Attempt, error) {
+	if hash == "" {
+		return nil, repository.ErrAttemptNotFound
+	}
+	return r.findOne(ctx, `SELECT `+attemptColumns+` FROM attempts WHERE launch_token_hash = ?`, hash)
+}
+
+func (r *AttemptRepository) FindByResultTokenHash(ctx context.Context, hash string) (*model.Attempt, error) {
+	if hash == "" {
+		return nil, repository.ErrAttemptNotFound
+	}
+	return r.findOne(ctx, `SELECT `+attemptColumns+` FROM attempts WHERE result_token_hash = ?`, hash)
+}
+
+func (r *AttemptRepository) FindOpen(ctx context.Context, userID, gameID uuid.UUID) ([]*model.Attempt, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+attemptColumns+` FROM attempts
+		WHERE user_id = ? AND game_id = ? AND status IN (?, ?)
+		ORDER BY started_at, id`,
+		userID.String(), gameID.String(), model.AttemptStarted, model.AttemptRunning,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select open attempts: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.Attempt{}
+	for rows.Next() {
+		attempt, err := scanAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate attempts: %w", err)
+	}
+	return res, nil
+}
+
+func (r *AttemptRepository) Update(ctx context.Context, attempt *model.Attempt, fromStatus string) (*model.Attempt, error) {
+	if attempt == nil {
+		return nil, errors.New("attempt cannot be nil")
+	}
+
+	res, err := r.db.ExecContext(
+		ctx,
+		`UPDATE attempts SET `+attemptUpdateColumns+`
+		WHERE id = ? AND status = ?`,
+		append(attemptUpdateArgs(attempt), attempt.ID.String(), fromStatus)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update attempt: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		if _, err := r.FindByID(ctx, attempt.ID); err != nil {
+			return nil, err
+		}
+		return nil, repository.ErrAttemptStatusChanged
+	}
+	return attempt, nil
+}
+
+func (r *AttemptRepository) findOne(ctx context.Context, query string, args ...any) (*model.Attempt, error) {
+	attempt, err := scanAttempt(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrAttemptNotFound
+		}
+		return nil, err
+	}
+	return attempt, nil
+}
+
+func scanAttempt(row rowScanner) (*model.Attempt, error) {
+	var idStr, userIDStr, gameIDStr, launchExpiresAtStr, startedAtStr string
+	var taskIDStr, tokenHash, runningAt, finishedAt, resultTokenHash, resultExpiresAt sql.NullString
+	attempt := &model.Attempt{}
+	err := row.Scan(&idStr, &userIDStr, &gameIDStr, &taskIDStr, &attempt.Mode, &attempt.Seed, &attempt.Status,
+		&tokenHash, &launchExpiresAtStr, &startedAtStr, &runningAt, &finishedAt, &resultTokenHash, &resultExpiresAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan attempt: %w", err)
+	}
+
+	if attempt.ID, err = uuid.Parse(idStr); err != nil {
+		return nil, fmt.Errorf("parse attempt id from db: %w", err)
+	}
+	if attempt.UserID, err = uuid.Parse(userIDStr); err != nil {
+		return nil, fmt.Errorf("parse user_id from db: %w", err)
+	}
+	if attempt.GameID, err = uuid.Parse(gameIDStr); err != nil {
+		return nil, fmt.Errorf("parse game_id from db: %w", err)
+	}
+	if taskIDStr.Valid {
+		if attempt.TaskID, err = uuid.Parse(taskIDStr.String); err != nil {
+			return nil, fmt.Errorf("parse task_id from db: %w", err)
+		}
+	}
+	attempt.LaunchTokenHash = tokenHash.String
+	if attempt.LaunchExpiresAt, err = time.Parse(time.RFC3339Nano, launchExpiresAtStr); err != nil {
+		return nil, fmt.Errorf("parse launch_expires_at from db: %w", err)
+	}
+	if attempt.StartedAt, err = time.Parse(time.RFC3339Nano, startedAtStr); err != nil {
+		return nil, fmt.Errorf("parse started_at from db: %w", err)
+	}
+	if attempt.RunningAt, err = parseNullableTime(runningAt, "running_at"); err != nil {
+		return nil, err
+	}
+	if attempt.FinishedAt, err = parseNullableTime(finishedAt, "finished_at"); err != nil {
+		return nil, err
+	}
+	attempt.ResultTokenHash = resultTokenHash.String
+	if attempt.ResultExpiresAt, err = parseNullableTime(resultExpiresAt, "result_expires_at"); err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+// attemptUpdateColumns - колонки, которые меняются при переходе попытки между статусами;
+// значения в том же порядке дает attemptUpdateArgs.
+const attemptUpdateColumns = `status = ?, launch_token_hash = ?, running_at = ?, finished_at = ?,
+	result_token_hash = ?, result_expires_at = ?`
+
+func attemptUpdateArgs(attempt *model.Attempt) []any {
+	return []any{
+		attempt.Status,
+		nullableString(attempt.LaunchTokenHash),
+		nullableSortableTime(attempt.RunningAt),
+		nullableSortableTime(attempt.FinishedAt),
+		nullableString(attempt.ResultTokenHash),
+		nullableSortableTime(attempt.ResultExpiresAt),
+	}
+}
+
+// nullableUUID - значение необязательной колонки ID: NULL для uuid.Nil.
+func nullableUUID(id uuid.UUID) any {
+	if id == uuid.Nil {
+		return nil
+	}
+	return id.String()
+}
+
+// nullableString - значение необязательной текстовой колонки: NULL для пустой строки.
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
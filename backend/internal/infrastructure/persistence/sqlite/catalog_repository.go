@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+)
+
+// compile-time check
+var _ repository.CatalogRepository = (*CatalogRepository)(nil)
+
+type CatalogRepository struct {
+	db *sql.DB
+}
+
+func NewCatalogRepository(db *sql.DB) *CatalogRepository {
+	return &CatalogRepository{db: db}
+}
+
+func (r *CatalogRepository) Apply(ctx context.Context, in repository.CatalogImport) error {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		// Сначала жанры: новые игры могут ссылаться на жанры из того же импорта
+		for _, genre := range in.CreateGenres {
+			if err := insertGenre(ctx, tx, genre); err != nil {
+				return err
+			}
+		}
+		for _, genre := range in.UpdateGenres {
+			if err := updateGenre(ctx, tx, genre); err != nil {
+				return err
+			}
+		}
+		for _, game := range in.CreateGames {
+			if err := insertGame(ctx, tx, game); err != nil {
+				return err
+			}
+		}
+		for _, game := range in.UpdateGames {
+			if err := updateGame(ctx, tx, game); err != nil {
+				return err
+			}
+			// Статус из файла; версия уже увеличена updateGame
+			if _, err := tx.ExecContext(ctx, `UPDATE games SET status = ?, publish_at = ? WHERE id = ?`,
+				game.Status, nullableSortableTime(game.PublishAt), game.ID.String()); err != nil {
+				return fmt.Errorf("update game status: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, genre := range in.UpdateGenres {
+		genre.Version++
+	}
+	for _, game := range in.UpdateGames {
+		game.Version++
+	}
+	return nil
+}
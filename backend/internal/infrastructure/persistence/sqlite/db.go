@@ -81,17 +81,29 @@ func ApplySchema(ctx context.Context, db *sql.DB) error {
 
 	schema := string(schemaBytes)
 
+	if err := applyStatements(ctx, db, schema); err != nil {
+		return err
+	}
+
+	migrated, err := migrate(ctx, db)
+	if err != nil {
+		return err
+	}
+	if migrated {
+		// Миграции пересоздают таблицы, вместе с ними пропадают индексы и триггеры
+		return applyStatements(ctx, db, schema)
+	}
+	return nil
+}
+
+func applyStatements(ctx context.Context, db *sql.DB, schema string) error {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin tx: %w", err)
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	for _, stmt := range strings.Split(schema, ";") {
-		s := strings.TrimSpace(stmt)
-		if s == "" {
-			continue
-		}
+	for _, s := range splitStatements(schema) {
 		if _, err := tx.ExecContext(ctx, s); err != nil {
 			return fmt.Errorf("apply schema stmt %q: %w", s, err)
 		}
@@ -103,3 +115,39 @@ func ApplySchema(ctx context.Context, db *sql.DB) error {
 	return nil
 }
 
+// splitStatements режет схему по ";". Тело триггера (BEGIN ... END) содержит
+// собственные ";", поэтому CREATE TRIGGER собирается целиком до завершающего END.
+func splitStatements(schema string) []string {
+	var (
+		res     []string
+		current strings.Builder
+	)
+	for _, part := range strings.Split(schema, ";") {
+		current.WriteString(part)
+
+		stmt := strings.TrimSpace(current.String())
+		if stmt == "" {
+			current.Reset()
+			continue
+		}
+		if isTriggerStatement(stmt) && !strings.HasSuffix(strings.ToUpper(stmt), "END") {
+			current.WriteString(";")
+			continue
+		}
+
+		res = append(res, stmt)
+		current.Reset()
+	}
+	return res
+}
+
+func isTriggerStatement(stmt string) bool {
+	for _, line := range strings.Split(stmt, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		return strings.HasPrefix(strings.ToUpper(line), "CREATE TRIGGER")
+	}
+	return false
+}
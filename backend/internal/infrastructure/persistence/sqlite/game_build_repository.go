@@ -0,0 +1,312 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.GameBuildRepository = (*GameBuildRepository)(nil)
+
+const gameBuildColumns = `id, game_id, version, platform, entrypoint, archive_format, sha256, size, release_notes, uploaded_by, is_current, created_at`
+
+type GameBuildRepository struct {
+	db *sql.DB
+}
+
+func NewGameBuildRepository(db *sql.DB) *GameBuildRepository {
+	return &GameBuildRepository{db: db}
+}
+
+func (r *GameBuildRepository) Create(ctx context.Context, build *model.GameBuild) (*model.GameBuild, error) {
+	if build == nil {
+		return nil, errors.New("build cannot be nil")
+	}
+	if build.ID == uuid.Nil {
+		build.ID = uuid.New()
+	}
+	if build.CreatedAt.IsZero() {
+		build.CreatedAt = time.Now().UTC()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if build.IsCurrent {
+		if err := clearCurrentBuild(ctx, tx, build.GameID, build.Platform); err != nil {
+			return nil, err
+		}
+	}
+
+	var uploadedBy any
+	if build.UploadedBy != uuid.Nil {
+		uploadedBy = build.UploadedBy.String()
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO game_builds (`+gameBuildColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		build.ID.String(),
+		build.GameID.String(),
+		build.Version,
+		build.Platform,
+		build.Entrypoint,
+		build.ArchiveFormat,
+		build.SHA256,
+		build.Size,
+		build.ReleaseNotes,
+		uploadedBy,
+		build.IsCurrent,
+		formatSortableTime(build.CreatedAt),
+	)
+	if err != nil {
+		msg := err.Error()
+		if strings.Contains(msg, "UNIQUE constraint failed") {
+			return nil, repository.ErrBuildAlreadyExists
+		}
+		if strings.Contains(msg, "FOREIGN KEY constraint failed") {
+			return nil, errors.New(constants.ErrInvalidData)
+		}
+		return nil, fmt.Errorf("insert game build: %w", err)
+	}
+
+	for _, f := range build.Files {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO game_build_files (build_id, path, size, sha256) VALUES (?, ?, ?, ?)`,
+			build.ID.String(), f.Path, f.Size, f.SHA256,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("insert build file: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return build, nil
+}
+
+func (r *GameBuildRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.GameBuild, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("build ID cannot be empty")
+	}
+
+	build, err := scanGameBuild(r.db.QueryRowContext(ctx, `SELECT `+gameBuildColumns+` FROM game_builds WHERE id = ?`, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBuildNotFound
+		}
+		return nil, err
+	}
+	return build, nil
+}
+
+func (r *GameBuildRepository) FindFiles(ctx context.Context, buildID uuid.UUID) ([]model.BuildFile, error) {
+	if _, err := r.FindByID(ctx, buildID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT path, size, sha256 FROM game_build_files WHERE build_id = ? ORDER BY path`,
+		buildID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select build files: %w", err)
+	}
+	defer rows.Close()
+
+	res := []model.BuildFile{}
+	for rows.Next() {
+		var f model.BuildFile
+		if err := rows.Scan(&f.Path, &f.Size, &f.SHA256); err != nil {
+			return nil, fmt.Errorf("scan build file: %w", err)
+		}
+		res = append(res, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate build files: %w", err)
+	}
+	return res, nil
+}
+
+func (r *GameBuildRepository) FindByGameID(ctx context.Context, gameID uuid.UUID, platform string) ([]*model.GameBuild, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	conds := []string{"game_id = ?"}
+	args := []any{gameID.String()}
+	if platform != "" {
+		conds = append(conds, "platform = ?")
+		args = append(args, platform)
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+gameBuildColumns+` FROM game_builds`+whereClause(conds)+` ORDER BY created_at DESC, id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select game builds: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.GameBuild{}
+	for rows.Next() {
+		build, err := scanGameBuild(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, build)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate game builds: %w", err)
+	}
+	return res, nil
+}
+
+func (r *GameBuildRepository) FindCurrent(ctx context.Context, gameID uuid.UUID, platform string) (*model.GameBuild, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	build, err := scanGameBuild(r.db.QueryRowContext(
+		ctx,
+		`SELECT `+gameBuildColumns+` FROM game_builds WHERE game_id = ? AND platform = ? AND is_current = 1`,
+		gameID.String(),
+		platform,
+	))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBuildNotFound
+		}
+		return nil, err
+	}
+	return build, nil
+}
+
+func (r *GameBuildRepository) SetCurrent(ctx context.Context, id uuid.UUID) (*model.GameBuild, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("build ID cannot be empty")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	build, err := scanGameBuild(tx.QueryRowContext(ctx, `SELECT `+gameBuildColumns+` FROM game_builds WHERE id = ?`, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrBuildNotFound
+		}
+		return nil, err
+	}
+
+	if !build.IsCurrent {
+		if err := clearCurrentBuild(ctx, tx, build.GameID, build.Platform); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE game_builds SET is_current = 1 WHERE id = ?`, id.String()); err != nil {
+			return nil, fmt.Errorf("set current build: %w", err)
+		}
+		build.IsCurrent = true
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return build, nil
+}
+
+func (r *GameBuildRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("build ID cannot be empty")
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM game_builds WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("delete game build: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrBuildNotFound
+	}
+	return nil
+}
+
+func (r *GameBuildRepository) BlobInUse(ctx context.Context, key string) (bool, error) {
+	var one int
+	err := r.db.QueryRowContext(ctx, `SELECT 1 FROM game_builds WHERE sha256 = ? LIMIT 1`, key).Scan(&one)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("check blob usage: %w", err)
+	}
+	return true, nil
+}
+
+// clearCurrentBuild снимает отметку текущей с прежней сборки платформы
+// (уникальный индекс не допускает двух текущих одновременно).
+func clearCurrentBuild(ctx context.Context, tx execer, gameID uuid.UUID, platform string) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`UPDATE game_builds SET is_current = 0 WHERE game_id = ? AND platform = ? AND is_current = 1`,
+		gameID.String(),
+		platform,
+	)
+	if err != nil {
+		return fmt.Errorf("clear current build: %w", err)
+	}
+	return nil
+}
+
+func scanGameBuild(row rowScanner) (*model.GameBuild, error) {
+	var idStr, gameIDStr, createdAtStr string
+	var uploadedByStr sql.NullString
+	build := &model.GameBuild{}
+	if err := row.Scan(&idStr, &gameIDStr, &build.Version, &build.Platform, &build.Entrypoint, &build.ArchiveFormat,
+		&build.SHA256, &build.Size, &build.ReleaseNotes, &uploadedByStr, &build.IsCurrent, &createdAtStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan game build: %w", err)
+	}
+
+	var err error
+	if build.ID, err = uuid.Parse(idStr); err != nil {
+		return nil, fmt.Errorf("parse build id from db: %w", err)
+	}
+	if build.GameID, err = uuid.Parse(gameIDStr); err != nil {
+		return nil, fmt.Errorf("parse game_id from db: %w", err)
+	}
+	if uploadedByStr.Valid {
+		if build.UploadedBy, err = uuid.Parse(uploadedByStr.String); err != nil {
+			return nil, fmt.Errorf("parse uploaded_by from db: %w", err)
+		}
+	}
+	if build.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAtStr); err != nil {
+		return nil, fmt.Errorf("parse created_at from db: %w", err)
+	}
+	return build, nil
+}
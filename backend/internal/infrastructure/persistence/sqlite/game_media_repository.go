@@ -0,0 +1,336 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.GameMediaRepository = (*GameMediaRepository)(nil)
+
+const gameMediaColumns = `id, game_id, kind, position, blob_key, content_type, bytes, width, height, uploaded_by, created_at`
+
+type GameMediaRepository struct {
+	db *sql.DB
+}
+
+func NewGameMediaRepository(db *sql.DB) *GameMediaRepository {
+	return &GameMediaRepository{db: db}
+}
+
+func (r *GameMediaRepository) Create(ctx context.Context, media *model.GameMedia) (*model.GameMedia, error) {
+	if media == nil {
+		return nil, errors.New("media cannot be nil")
+	}
+	if media.ID == uuid.Nil {
+		media.ID = uuid.New()
+	}
+	if media.CreatedAt.IsZero() {
+		media.CreatedAt = time.Now().UTC()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	position := 0
+	if media.Kind == model.MediaKindCover {
+		_, err := tx.ExecContext(
+			ctx,
+			`DELETE FROM game_media WHERE game_id = ? AND kind = ?`,
+			media.GameID.String(),
+			model.MediaKindCover,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("replace game cover: %w", err)
+		}
+	} else {
+		err := tx.QueryRowContext(
+			ctx,
+			`SELECT COALESCE(MAX(position), 0) + 1 FROM game_media WHERE game_id = ? AND kind = ?`,
+			media.GameID.String(),
+			model.MediaKindScreenshot,
+		).Scan(&position)
+		if err != nil {
+			return nil, fmt.Errorf("next media position: %w", err)
+		}
+	}
+
+	var uploadedBy any
+	if media.UploadedBy != uuid.Nil {
+		uploadedBy = media.UploadedBy.String()
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO game_media (`+gameMediaColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		media.ID.String(),
+		media.GameID.String(),
+		media.Kind,
+		position,
+		media.BlobKey,
+		media.ContentType,
+		media.Bytes,
+		media.Width,
+		media.Height,
+		uploadedBy,
+		media.CreatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return nil, errors.New(constants.ErrInvalidData)
+		}
+		return nil, fmt.Errorf("insert game media: %w", err)
+	}
+
+	for _, t := range media.Thumbnails {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO game_media_thumbnails (media_id, size, blob_key, content_type, bytes, width, height)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			media.ID.String(), t.Size, t.BlobKey, t.ContentType, t.Bytes, t.Width, t.Height,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("insert media thumbnail: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	media.Position = position
+	return media, nil
+}
+
+func (r *GameMediaRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.GameMedia, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("media ID cannot be empty")
+	}
+
+	media, err := scanGameMedia(r.db.QueryRowContext(ctx, `SELECT `+gameMediaColumns+` FROM game_media WHERE id = ?`, id.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrMediaNotFound
+		}
+		return nil, err
+	}
+
+	if err := r.loadThumbnails(ctx, []*model.GameMedia{media}); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+func (r *GameMediaRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.GameMedia, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+gameMediaColumns+` FROM game_media WHERE game_id = ?
+		ORDER BY CASE kind WHEN 'cover' THEN 0 ELSE 1 END, position, created_at`,
+		gameID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select game media: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.GameMedia{}
+	for rows.Next() {
+		media, err := scanGameMedia(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, media)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate game media: %w", err)
+	}
+
+	if err := r.loadThumbnails(ctx, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *GameMediaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("media ID cannot be empty")
+	}
+
+	// Миниатюры удаляются каскадно
+	result, err := r.db.ExecContext(ctx, `DELETE FROM game_media WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("delete game media: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrMediaNotFound
+	}
+	return nil
+}
+
+func (r *GameMediaRepository) Reorder(ctx context.Context, gameID uuid.UUID, ids []uuid.UUID) error {
+	if gameID == uuid.Nil {
+		return errors.New("game ID cannot be empty")
+	}
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return repository.ErrMediaOrderMismatch
+		}
+		seen[id] = true
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var count int
+	err = tx.QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM game_media WHERE game_id = ? AND kind = ?`,
+		gameID.String(),
+		model.MediaKindScreenshot,
+	).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("count screenshots: %w", err)
+	}
+	if count != len(ids) {
+		return repository.ErrMediaOrderMismatch
+	}
+
+	for i, id := range ids {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE game_media SET position = ? WHERE id = ? AND game_id = ? AND kind = ?`,
+			i+1,
+			id.String(),
+			gameID.String(),
+			model.MediaKindScreenshot,
+		)
+		if err != nil {
+			return fmt.Errorf("update media position: %w", err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("rows affected: %w", err)
+		}
+		// Чужой id: иначе часть скриншотов осталась бы без новой позиции
+		if affected == 0 {
+			return repository.ErrMediaOrderMismatch
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func (r *GameMediaRepository) BlobInUse(ctx context.Context, key string) (bool, error) {
+	var one int
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT 1 WHERE EXISTS (SELECT 1 FROM game_media WHERE blob_key = ?)
+		OR EXISTS (SELECT 1 FROM game_media_thumbnails WHERE blob_key = ?)`,
+		key,
+		key,
+	).Scan(&one)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("check blob usage: %w", err)
+	}
+	return true, nil
+}
+
+// loadThumbnails заполняет Thumbnails у записей одним запросом.
+func (r *GameMediaRepository) loadThumbnails(ctx context.Context, items []*model.GameMedia) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*model.GameMedia, len(items))
+	args := make([]any, 0, len(items))
+	for _, m := range items {
+		byID[m.ID.String()] = m
+		args = append(args, m.ID.String())
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT media_id, size, blob_key, content_type, bytes, width, height FROM game_media_thumbnails
+		WHERE media_id IN (`+placeholders(len(args))+`) ORDER BY width`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("select media thumbnails: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mediaID string
+		var t model.MediaThumbnail
+		if err := rows.Scan(&mediaID, &t.Size, &t.BlobKey, &t.ContentType, &t.Bytes, &t.Width, &t.Height); err != nil {
+			return fmt.Errorf("scan media thumbnail: %w", err)
+		}
+		if m, ok := byID[mediaID]; ok {
+			m.Thumbnails = append(m.Thumbnails, t)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate media thumbnails: %w", err)
+	}
+	return nil
+}
+
+func scanGameMedia(row rowScanner) (*model.GameMedia, error) {
+	var idStr, gameIDStr, createdAtStr string
+	var uploadedByStr sql.NullString
+	media := &model.GameMedia{}
+	if err := row.Scan(&idStr, &gameIDStr, &media.Kind, &media.Position, &media.BlobKey, &media.ContentType,
+		&media.Bytes, &media.Width, &media.Height, &uploadedByStr, &createdAtStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan game media: %w", err)
+	}
+
+	var err error
+	if media.ID, err = uuid.Parse(idStr); err != nil {
+		return nil, fmt.Errorf("parse media id from db: %w", err)
+	}
+	if media.GameID, err = uuid.Parse(gameIDStr); err != nil {
+		return nil, fmt.Errorf("parse game_id from db: %w", err)
+	}
+	if uploadedByStr.Valid {
+		if media.UploadedBy, err = uuid.Parse(uploadedByStr.String); err != nil {
+			return nil, fmt.Errorf("parse uploaded_by from db: %w", err)
+		}
+	}
+	if media.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAtStr); err != nil {
+		return nil, fmt.Errorf("parse created_at from db: %w", err)
+	}
+	return media, nil
+}
@@ -0,0 +1,156 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.GameRelationRepository = (*GameRelationRepository)(nil)
+
+const gameRelationColumns = `r.game_id, r.related_id, r.type, r.created_at`
+
+type GameRelationRepository struct {
+	db *sql.DB
+}
+
+func NewGameRelationRepository(db *sql.DB) *GameRelationRepository {
+	return &GameRelationRepository{db: db}
+}
+
+func (r *GameRelationRepository) Set(ctx context.Context, relation *model.GameRelation) (*model.GameRelation, error) {
+	if relation == nil {
+		return nil, errors.New("relation cannot be nil")
+	}
+	if relation.CreatedAt.IsZero() {
+		relation.CreatedAt = time.Now().UTC()
+	}
+
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		var live int
+		err := tx.QueryRowContext(
+			ctx,
+			`SELECT COUNT(*) FROM games WHERE id IN (?, ?) AND deleted_at IS NULL`,
+			relation.GameID.String(),
+			relation.RelatedID.String(),
+		).Scan(&live)
+		if err != nil {
+			return fmt.Errorf("check related games: %w", err)
+		}
+		if live != 2 {
+			return repository.ErrNotFound
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO game_relations (game_id, related_id, type, created_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT (game_id, type) DO UPDATE SET related_id = excluded.related_id, created_at = excluded.created_at`,
+			relation.GameID.String(),
+			relation.RelatedID.String(),
+			relation.Type,
+			relation.CreatedAt.UTC().Format(time.RFC3339Nano),
+		)
+		if err != nil {
+			return fmt.Errorf("upsert game relation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return relation, nil
+}
+
+func (r *GameRelationRepository) Delete(ctx context.Context, gameID uuid.UUID, relationType string) error {
+	if gameID == uuid.Nil {
+		return errors.New("game ID cannot be empty")
+	}
+
+	res, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`DELETE FROM game_relations WHERE game_id = ? AND type = ?`,
+		gameID.String(),
+		relationType,
+	)
+	if err != nil {
+		return fmt.Errorf("delete game relation: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrRelationNotFound
+	}
+	return nil
+}
+
+func (r *GameRelationRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.GameRelation, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	return r.findMany(
+		ctx,
+		`SELECT `+gameRelationColumns+` FROM game_relations r
+		JOIN games g ON g.id = r.game_id AND g.deleted_at IS NULL
+		JOIN games rg ON rg.id = r.related_id AND rg.deleted_at IS NULL
+		WHERE r.game_id = ? OR r.related_id = ?
+		ORDER BY r.type, r.created_at`,
+		gameID.String(),
+		gameID.String(),
+	)
+}
+
+func (r *GameRelationRepository) FindReachable(ctx context.Context, from uuid.UUID) ([]*model.GameRelation, error) {
+	// UNION убирает повторы, поэтому обход завершается и на уже сохраненном цикле
+	return r.findMany(
+		ctx,
+		`WITH RECURSIVE reachable(id) AS (
+			SELECT ?
+			UNION
+			SELECT rel.related_id FROM game_relations rel JOIN reachable ON rel.game_id = reachable.id
+		)
+		SELECT `+gameRelationColumns+` FROM game_relations r WHERE r.game_id IN (SELECT id FROM reachable)`,
+		from.String(),
+	)
+}
+
+func (r *GameRelationRepository) findMany(ctx context.Context, query string, args ...any) ([]*model.GameRelation, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select game relations: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.GameRelation{}
+	for rows.Next() {
+		var gameIDStr, relatedIDStr, createdAtStr string
+		relation := &model.GameRelation{}
+		if err := rows.Scan(&gameIDStr, &relatedIDStr, &relation.Type, &createdAtStr); err != nil {
+			return nil, fmt.Errorf("scan game relation: %w", err)
+		}
+		if relation.GameID, err = uuid.Parse(gameIDStr); err != nil {
+			return nil, fmt.Errorf("parse game_id from db: %w", err)
+		}
+		if relation.RelatedID, err = uuid.Parse(relatedIDStr); err != nil {
+			return nil, fmt.Errorf("parse related_id from db: %w", err)
+		}
+		if relation.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAtStr); err != nil {
+			return nil, fmt.Errorf("parse created_at from db: %w", err)
+		}
+		res = append(res, relation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate game relations: %w", err)
+	}
+	return res, nil
+}
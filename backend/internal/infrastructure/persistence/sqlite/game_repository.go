@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"html"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +20,11 @@ import (
 // compile-time check
 var _ repository.GameRepository = (*GameRepository)(nil)
 
+const (
+	gameColumns          = `id, title, description, release_date, status, publish_at, deleted_at, version`
+	qualifiedGameColumns = `games.id, games.title, games.description, games.release_date, games.status, games.publish_at, games.deleted_at, games.version`
+)
+
 type GameRepository struct {
 	db *sql.DB
 }
@@ -30,31 +37,52 @@ func (r *GameRepository) Create(ctx context.Context, game *model.Game) (*model.G
 	if game == nil {
 		return nil, errors.New("game cannot be nil")
 	}
+
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		return insertGame(ctx, tx, game)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return game, nil
+}
+
+// insertGame добавляет игру с жанрами в рамках транзакции; пустой ID заменяется новым,
+// пустой статус считается опубликованным (как у игр, созданных до появления статусов).
+func insertGame(ctx context.Context, tx *sql.Tx, game *model.Game) error {
 	if game.ID == uuid.Nil {
 		game.ID = uuid.New()
 	}
+	if game.Status == "" {
+		game.Status = model.GameStatusPublished
+	}
+	game.SetGenres(game.GenreID, game.GenreIDs)
+	game.Version = 1
 
-	_, err := r.db.ExecContext(
+	_, err := tx.ExecContext(
 		ctx,
-		`INSERT INTO games (id, title, description, release_date, genre_id) VALUES (?, ?, ?, ?, ?)`,
+		`INSERT INTO games (id, title, title_key, description, release_date, status, publish_at, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 1)`,
 		game.ID.String(),
 		game.Title,
+		model.NormalizeTitle(game.Title),
 		game.Description,
-		game.ReleaseDate.UTC().Format(time.RFC3339Nano),
-		game.GenreID.String(),
+		formatSortableTime(game.ReleaseDate),
+		game.Status,
+		nullableSortableTime(game.PublishAt),
 	)
 	if err != nil {
 		msg := err.Error()
-		if strings.Contains(msg, "UNIQUE constraint failed") {
-			return nil, repository.ErrAlreadyExists
+		if isTitleConflict(err) {
+			return repository.ErrDuplicateTitle
 		}
-		if strings.Contains(msg, "FOREIGN KEY constraint failed") {
-			return nil, errors.New(constants.ErrGenreNotFound)
+		if strings.Contains(msg, "UNIQUE constraint failed") {
+			return repository.ErrAlreadyExists
 		}
-		return nil, fmt.Errorf("insert game: %w", err)
+		return fmt.Errorf("insert game: %w", err)
 	}
-
-	return game, nil
+	return insertGameGenres(ctx, tx, game)
 }
 
 func (r *GameRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Game, error) {
@@ -62,90 +90,210 @@ func (r *GameRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Gam
 		return nil, errors.New("game ID cannot be empty")
 	}
 
-	var (
-		idStr, title, description, releaseDateStr, genreIDStr string
-	)
-
-	err := r.db.QueryRowContext(
+	row := conn(ctx, r.db).QueryRowContext(
 		ctx,
-		`SELECT id, title, description, release_date, genre_id FROM games WHERE id = ?`,
+		`SELECT `+gameColumns+` FROM games WHERE id = ? AND deleted_at IS NULL`,
 		id.String(),
-	).Scan(&idStr, &title, &description, &releaseDateStr, &genreIDStr)
+	)
+	game, err := scanGame(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrNotFound
 		}
-		return nil, fmt.Errorf("select game: %w", err)
+		return nil, err
+	}
+	if err := r.loadGenres(ctx, []*model.Game{game}); err != nil {
+		return nil, err
 	}
 
-	gameID, err := uuid.Parse(idStr)
-	if err != nil {
-		return nil, fmt.Errorf("parse game id from db: %w", err)
+	return game, nil
+}
+
+func (r *GameRepository) FindAll(ctx context.Context, limit, offset int) ([]*model.Game, error) {
+	query, args := appendPage(`SELECT `+gameColumns+` FROM games WHERE deleted_at IS NULL ORDER BY release_date DESC`, []any{}, limit, offset)
+	return r.findMany(ctx, query, args...)
+}
+
+func (r *GameRepository) FindByQuery(ctx context.Context, q repository.GameQuery) ([]*model.Game, int, error) {
+	conds := []string{`games.deleted_at IS NULL`}
+	args := []any{}
+
+	if len(q.GenreIDs) > 0 {
+		cond := `games.id IN (SELECT game_id FROM game_genres WHERE genre_id IN (` + placeholders(len(q.GenreIDs)) + `)`
+		for _, id := range q.GenreIDs {
+			args = append(args, id.String())
+		}
+		if q.MatchAllGenres {
+			cond += ` GROUP BY game_id HAVING COUNT(*) = ?`
+			args = append(args, len(q.GenreIDs))
+		}
+		conds = append(conds, cond+`)`)
 	}
-	genreID, err := uuid.Parse(genreIDStr)
-	if err != nil {
-		return nil, fmt.Errorf("parse genre_id from db: %w", err)
+	if q.ReleasedFrom != nil {
+		conds = append(conds, `games.release_date >= ?`)
+		args = append(args, formatSortableTime(*q.ReleasedFrom))
+	}
+	if q.ReleasedTo != nil {
+		conds = append(conds, `games.release_date <= ?`)
+		args = append(args, formatSortableTime(*q.ReleasedTo))
+	}
+	if search := strings.TrimSpace(q.Search); search != "" {
+		conds = append(conds, `(games.title LIKE ? ESCAPE '\' OR games.description LIKE ? ESCAPE '\')`)
+		args = append(args, containsPattern(search), containsPattern(search))
+	}
+	if q.Status != "" {
+		conds = append(conds, `games.status = ?`)
+		args = append(args, q.Status)
 	}
-	releaseDate, err := time.Parse(time.RFC3339Nano, releaseDateStr)
+	where := whereClause(conds)
+
+	var total int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM games`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count games: %w", err)
+	}
+
+	from := ` FROM games`
+	dir := sortDirection(q.SortDesc)
+	var orderKey string
+	switch q.SortBy {
+	case repository.GameSortTitle:
+		orderKey = `games.title`
+	case repository.GameSortRating:
+		from += ` LEFT JOIN (SELECT game_id, AVG(rating) AS avg_rating FROM user_ratings GROUP BY game_id) stats ON stats.game_id = games.id`
+		orderKey = `COALESCE(stats.avg_rating, 0)`
+	default:
+		orderKey = `games.release_date`
+	}
+
+	offset := q.Offset
+	if q.After != nil {
+		var key any = q.After.Key
+		switch q.SortBy {
+		case repository.GameSortTitle:
+		case repository.GameSortRating:
+			rating, err := strconv.ParseFloat(q.After.Key, 64)
+			if err != nil {
+				return nil, 0, fmt.Errorf("parse cursor rating: %w", err)
+			}
+			key = rating
+		default:
+			// Ключ курсора - RFC3339Nano, а release_date хранится в sortableTime
+			releaseDate, err := time.Parse(time.RFC3339Nano, q.After.Key)
+			if err != nil {
+				return nil, 0, fmt.Errorf("parse cursor release date: %w", err)
+			}
+			key = formatSortableTime(releaseDate)
+		}
+		conds = append(conds, keysetCondition(orderKey, `games.id`, q.SortDesc))
+		args = append(args, key, key, q.After.ID.String())
+		offset = 0
+	}
+
+	query, pageArgs := appendPage(
+		`SELECT `+qualifiedGameColumns+from+whereClause(conds)+` ORDER BY `+orderKey+` `+dir+`, games.id `+dir,
+		args,
+		q.Limit,
+		offset,
+	)
+	games, err := r.findMany(ctx, query, pageArgs...)
 	if err != nil {
-		return nil, fmt.Errorf("parse release_date from db: %w", err)
+		return nil, 0, err
 	}
 
-	return &model.Game{
-		ID:          gameID,
-		Title:       title,
-		Description: description,
-		ReleaseDate: releaseDate,
-		GenreID:     genreID,
-	}, nil
+	return games, total, nil
 }
 
-func (r *GameRepository) FindAll(ctx context.Context, limit, offset int) ([]*model.Game, error) {
-	query := `SELECT id, title, description, release_date, genre_id FROM games ORDER BY release_date DESC`
-	args := []any{}
+func (r *GameRepository) Search(ctx context.Context, q repository.GameSearchQuery) ([]*repository.GameSearchHit, int, error) {
+	match := ftsMatchExpression(q.Text)
+	if match == "" {
+		return []*repository.GameSearchHit{}, 0, nil
+	}
 
-	if limit > 0 {
-		query += ` LIMIT ? OFFSET ?`
-		args = append(args, limit, offset)
+	where := `games_fts MATCH ? AND games.deleted_at IS NULL`
+	filterArgs := []any{match}
+	if q.Status != "" {
+		where += ` AND games.status = ?`
+		filterArgs = append(filterArgs, q.Status)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	var total int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM games_fts JOIN games ON games.id = games_fts.game_id
+		WHERE `+where, filterArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count game search: %w", err)
+	}
+
+	// Совпадение в названии весит больше, чем в описании
+	query, args := appendPage(
+		`SELECT `+qualifiedGameColumns+`,
+			bm25(games_fts, 0, 10.0, 1.0) AS rank,
+			highlight(games_fts, 1, ?, ?),
+			snippet(games_fts, 2, ?, ?, '…', 24)
+		FROM games_fts
+		JOIN games ON games.id = games_fts.game_id
+		WHERE `+where+`
+		ORDER BY rank, games.id`,
+		append([]any{
+			ftsMarkStart, ftsMarkEnd,
+			ftsMarkStart, ftsMarkEnd,
+		}, filterArgs...),
+		q.Limit,
+		q.Offset,
+	)
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("select games: %w", err)
+		return nil, 0, fmt.Errorf("search games: %w", err)
 	}
 	defer rows.Close()
 
-	var res []*model.Game
+	res := []*repository.GameSearchHit{}
 	for rows.Next() {
-		var idStr, title, description, releaseDateStr, genreIDStr string
-		if err := rows.Scan(&idStr, &title, &description, &releaseDateStr, &genreIDStr); err != nil {
-			return nil, fmt.Errorf("scan game: %w", err)
-		}
-		gameID, err := uuid.Parse(idStr)
-		if err != nil {
-			return nil, fmt.Errorf("parse game id from db: %w", err)
-		}
-		genreID, err := uuid.Parse(genreIDStr)
-		if err != nil {
-			return nil, fmt.Errorf("parse genre_id from db: %w", err)
+		var (
+			row gameRow
+			hit repository.GameSearchHit
+		)
+		if err := rows.Scan(append(row.dest(), &hit.Rank, &hit.TitleHighlight, &hit.DescriptionSnippet)...); err != nil {
+			return nil, 0, fmt.Errorf("scan game search hit: %w", err)
 		}
-		releaseDate, err := time.Parse(time.RFC3339Nano, releaseDateStr)
+		hit.Game, err = row.parse()
 		if err != nil {
-			return nil, fmt.Errorf("parse release_date from db: %w", err)
+			return nil, 0, err
 		}
-		res = append(res, &model.Game{
-			ID:          gameID,
-			Title:       title,
-			Description: description,
-			ReleaseDate: releaseDate,
-			GenreID:     genreID,
-		})
+		hit.TitleHighlight = escapeHighlight(hit.TitleHighlight)
+		hit.DescriptionSnippet = escapeHighlight(hit.DescriptionSnippet)
+		res = append(res, &hit)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate games: %w", err)
+		return nil, 0, fmt.Errorf("iterate game search: %w", err)
 	}
+	rows.Close()
 
-	return res, nil
+	games := make([]*model.Game, len(res))
+	for i, hit := range res {
+		games[i] = hit.Game
+	}
+	if err := r.loadGenres(ctx, games); err != nil {
+		return nil, 0, err
+	}
+
+	return res, total, nil
+}
+
+// FTS5 не экранирует текст вокруг выделения, поэтому выделяет символами из области
+// частного использования Unicode, а escapeHighlight после экранирования заменяет их разметкой.
+const (
+	ftsMarkStart = "\uE000"
+	ftsMarkEnd   = "\uE001"
+)
+
+var highlightReplacer = strings.NewReplacer(
+	ftsMarkStart, repository.SearchHighlightStart,
+	ftsMarkEnd, repository.SearchHighlightEnd,
+)
+
+// escapeHighlight превращает текст с выделением FTS5 в безопасный HTML.
+func escapeHighlight(text string) string {
+	return highlightReplacer.Replace(html.EscapeString(text))
 }
 
 func (r *GameRepository) Update(ctx context.Context, game *model.Game) (*model.Game, error) {
@@ -156,54 +304,177 @@ func (r *GameRepository) Update(ctx context.Context, game *model.Game) (*model.G
 		return nil, errors.New("game ID cannot be empty")
 	}
 
-	result, err := r.db.ExecContext(
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		return updateGame(ctx, tx, game)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	game.Version++
+	return game, nil
+}
+
+// updateGame сохраняет игру с версией game.Version и переписывает ее жанры в рамках транзакции.
+// Версию в game вызывающий увеличивает после коммита.
+func updateGame(ctx context.Context, tx *sql.Tx, game *model.Game) error {
+	game.SetGenres(game.GenreID, game.GenreIDs)
+
+	result, err := tx.ExecContext(
 		ctx,
-		`UPDATE games SET title = ?, description = ?, release_date = ?, genre_id = ? WHERE id = ?`,
+		`UPDATE games SET title = ?, title_key = ?, description = ?, release_date = ?, version = version + 1
+		WHERE id = ? AND version = ? AND deleted_at IS NULL`,
 		game.Title,
+		model.NormalizeTitle(game.Title),
 		game.Description,
-		game.ReleaseDate.UTC().Format(time.RFC3339Nano),
-		game.GenreID.String(),
+		formatSortableTime(game.ReleaseDate),
 		game.ID.String(),
+		game.Version,
 	)
 	if err != nil {
-		msg := err.Error()
-		if strings.Contains(msg, "FOREIGN KEY constraint failed") {
-			return nil, errors.New(constants.ErrGenreNotFound)
+		if isTitleConflict(err) {
+			return repository.ErrDuplicateTitle
 		}
-		return nil, fmt.Errorf("update game: %w", err)
+		return fmt.Errorf("update game: %w", err)
 	}
 	affected, _ := result.RowsAffected()
 	if affected == 0 {
-		return nil, repository.ErrNotFound
+		return versionMismatch(ctx, tx, "games", game.ID, repository.ErrNotFound)
 	}
 
-	return game, nil
+	if _, err := tx.ExecContext(ctx, `DELETE FROM game_genres WHERE game_id = ?`, game.ID.String()); err != nil {
+		return fmt.Errorf("delete game genres: %w", err)
+	}
+	return insertGameGenres(ctx, tx, game)
 }
 
-func (r *GameRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (r *GameRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	if id == uuid.Nil {
 		return errors.New("game ID cannot be empty")
 	}
 
-	result, err := r.db.ExecContext(ctx, `DELETE FROM games WHERE id = ?`, id.String())
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE games SET deleted_at = ?, version = version + 1 WHERE id = ? AND version = ? AND deleted_at IS NULL`,
+		formatSortableTime(time.Now()),
+		id.String(),
+		version,
+	)
 	if err != nil {
 		return fmt.Errorf("delete game: %w", err)
 	}
 	affected, _ := result.RowsAffected()
 	if affected == 0 {
-		return repository.ErrNotFound
+		return versionMismatch(ctx, conn(ctx, r.db), "games", id, repository.ErrNotFound)
 	}
 
 	return nil
 }
 
+func (r *GameRepository) FindDeleted(ctx context.Context) ([]*model.Game, error) {
+	return r.findMany(ctx, `SELECT `+gameColumns+` FROM games WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id`)
+}
+
+func (r *GameRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("game ID cannot be empty")
+	}
+
+	return inTx(ctx, r.db, func(tx *sql.Tx) error {
+		// Жанры проверяем заранее, но ошибку отдаем после UPDATE: если игры нет в корзине, важнее ErrNotFound
+		var deletedGenres int
+		err := tx.QueryRowContext(
+			ctx,
+			`SELECT COUNT(*) FROM game_genres
+		JOIN genres ON genres.id = game_genres.genre_id
+		WHERE game_genres.game_id = ? AND genres.deleted_at IS NOT NULL`,
+			id.String(),
+		).Scan(&deletedGenres)
+		if err != nil {
+			return fmt.Errorf("check game genres: %w", err)
+		}
+
+		result, err := tx.ExecContext(ctx, `UPDATE games SET deleted_at = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL`, id.String())
+		if err != nil {
+			if isTitleConflict(err) {
+				return repository.ErrDuplicateTitle
+			}
+			return fmt.Errorf("restore game: %w", err)
+		}
+		affected, _ := result.RowsAffected()
+		if affected == 0 {
+			return repository.ErrNotFound
+		}
+		if deletedGenres > 0 {
+			return repository.ErrGameGenreDeleted
+		}
+
+		return nil
+	})
+}
+
+func (r *GameRepository) Purge(ctx context.Context, before time.Time) (*repository.PurgedGames, error) {
+	const purgeable = `SELECT id FROM games WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+	res := &repository.PurgedGames{}
+	due := formatSortableTime(before)
+
+	// Ключи собираются в той же транзакции, что и удаление, чтобы не пропустить файлы
+	// записей, добавленных между ними
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		var err error
+		res.MediaKeys, err = queryStrings(ctx, tx, `SELECT blob_key FROM game_media WHERE game_id IN (`+purgeable+`)
+			UNION SELECT game_media_thumbnails.blob_key FROM game_media_thumbnails
+			JOIN game_media ON game_media.id = game_media_thumbnails.media_id
+			WHERE game_media.game_id IN (`+purgeable+`)`, due, due)
+		if err != nil {
+			return fmt.Errorf("select purged media: %w", err)
+		}
+		res.BuildKeys, err = queryStrings(ctx, tx, `SELECT DISTINCT sha256 FROM game_builds WHERE game_id IN (`+purgeable+`)`, due)
+		if err != nil {
+			return fmt.Errorf("select purged builds: %w", err)
+		}
+
+		// Оценки, медиа, сборки и связи с жанрами удаляются каскадно, индекс поиска - триггером
+		result, err := tx.ExecContext(ctx, `DELETE FROM games WHERE deleted_at IS NOT NULL AND deleted_at < ?`, due)
+		if err != nil {
+			return fmt.Errorf("purge games: %w", err)
+		}
+		affected, _ := result.RowsAffected()
+		res.Count = int(affected)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// queryStrings возвращает первый столбец всех строк запроса.
+func queryStrings(ctx context.Context, q querier, query string, args ...any) ([]string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var res []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		res = append(res, value)
+	}
+	return res, rows.Err()
+}
+
 func (r *GameRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	if id == uuid.Nil {
 		return false, errors.New("game ID cannot be empty")
 	}
 
 	var one int
-	err := r.db.QueryRowContext(ctx, `SELECT 1 FROM games WHERE id = ?`, id.String()).Scan(&one)
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT 1 FROM games WHERE id = ? AND deleted_at IS NULL`, id.String()).Scan(&one)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -213,3 +484,290 @@ func (r *GameRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error)
 	return true, nil
 }
 
+func (r *GameRepository) FindByTitle(ctx context.Context, title string) (*model.Game, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT `+gameColumns+` FROM games WHERE title_key = ? AND deleted_at IS NULL`,
+		model.NormalizeTitle(title),
+	)
+	game, err := scanGame(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, err
+	}
+	if err := r.loadGenres(ctx, []*model.Game{game}); err != nil {
+		return nil, err
+	}
+	return game, nil
+}
+
+func (r *GameRepository) FindTitles(ctx context.Context) ([]*repository.GameTitle, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT id, title FROM games WHERE deleted_at IS NULL ORDER BY title, id`)
+	if err != nil {
+		return nil, fmt.Errorf("select game titles: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*repository.GameTitle
+	for rows.Next() {
+		var idStr, title string
+		if err := rows.Scan(&idStr, &title); err != nil {
+			return nil, fmt.Errorf("scan game title: %w", err)
+		}
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, fmt.Errorf("parse game id from db: %w", err)
+		}
+		res = append(res, &repository.GameTitle{ID: id, Title: title})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate game titles: %w", err)
+	}
+	return res, nil
+}
+
+func (r *GameRepository) UpdateStatus(ctx context.Context, game *model.Game) (*model.Game, error) {
+	if game == nil {
+		return nil, errors.New("game cannot be nil")
+	}
+	if game.ID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE games SET status = ?, publish_at = ?, version = version + 1
+		WHERE id = ? AND version = ? AND deleted_at IS NULL`,
+		game.Status,
+		nullableSortableTime(game.PublishAt),
+		game.ID.String(),
+		game.Version,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update game status: %w", err)
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return nil, versionMismatch(ctx, conn(ctx, r.db), "games", game.ID, repository.ErrNotFound)
+	}
+
+	game.Version++
+	return game, nil
+}
+
+func (r *GameRepository) PublishDue(ctx context.Context, now time.Time) ([]*model.Game, error) {
+	// Выбираем и публикуем в одной транзакции, чтобы игра, которую успели снять
+	// с публикации, не попала в результат
+	var ids []any
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		due := formatSortableTime(now)
+		rows, err := tx.QueryContext(ctx, `SELECT id FROM games
+			WHERE status = 'scheduled' AND publish_at <= ? AND deleted_at IS NULL
+			ORDER BY publish_at, id`, due)
+		if err != nil {
+			return fmt.Errorf("select due games: %w", err)
+		}
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				_ = rows.Close()
+				return fmt.Errorf("scan due game: %w", err)
+			}
+			ids = append(ids, id)
+		}
+		_ = rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("iterate due games: %w", err)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE games SET status = 'published', publish_at = NULL, version = version + 1
+			WHERE id IN (`+placeholders(len(ids))+`)`, ids...); err != nil {
+			return fmt.Errorf("publish due games: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []*model.Game{}, nil
+	}
+
+	return r.findMany(ctx, `SELECT `+gameColumns+` FROM games WHERE id IN (`+placeholders(len(ids))+`) ORDER BY id`, ids...)
+}
+
+func (r *GameRepository) NextPublishAt(ctx context.Context) (*time.Time, error) {
+	var next sql.NullString
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT MIN(publish_at) FROM games
+		WHERE status = 'scheduled' AND deleted_at IS NULL`).Scan(&next)
+	if err != nil {
+		return nil, fmt.Errorf("select next publish_at: %w", err)
+	}
+	return parseNullableTime(next, "publish_at")
+}
+
+// isTitleConflict распознает нарушение уникальности нормализованного названия (idx_games_title_key).
+func isTitleConflict(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed: games.title_key")
+}
+
+func (r *GameRepository) findMany(ctx context.Context, query string, args ...any) ([]*model.Game, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select games: %w", err)
+	}
+	defer rows.Close()
+
+	var res []*model.Game
+	for rows.Next() {
+		game, err := scanGame(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, game)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate games: %w", err)
+	}
+	rows.Close()
+
+	if err := r.loadGenres(ctx, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// loadGenres заполняет GenreID и GenreIDs одним запросом к game_genres.
+func (r *GameRepository) loadGenres(ctx context.Context, games []*model.Game) error {
+	if len(games) == 0 {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*model.Game, len(games))
+	args := make([]any, 0, len(games))
+	for _, game := range games {
+		byID[game.ID] = game
+		args = append(args, game.ID.String())
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT game_id, genre_id, is_primary FROM game_genres
+		WHERE game_id IN (`+placeholders(len(args))+`)
+		ORDER BY game_id, is_primary DESC, rowid`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("select game genres: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var gameIDStr, genreIDStr string
+		var primary bool
+		if err := rows.Scan(&gameIDStr, &genreIDStr, &primary); err != nil {
+			return fmt.Errorf("scan game genre: %w", err)
+		}
+		gameID, err := uuid.Parse(gameIDStr)
+		if err != nil {
+			return fmt.Errorf("parse game_id from db: %w", err)
+		}
+		genreID, err := uuid.Parse(genreIDStr)
+		if err != nil {
+			return fmt.Errorf("parse genre_id from db: %w", err)
+		}
+
+		game := byID[gameID]
+		if primary {
+			game.GenreID = genreID
+		}
+		game.GenreIDs = append(game.GenreIDs, genreID)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate game genres: %w", err)
+	}
+
+	return nil
+}
+
+// insertGameGenres записывает жанры игры; первый в GenreIDs - основной.
+// Жанр из корзины считается несуществующим.
+func insertGameGenres(ctx context.Context, tx *sql.Tx, game *model.Game) error {
+	for i, genreID := range game.GenreIDs {
+		result, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO game_genres (game_id, genre_id, is_primary)
+			SELECT ?, id, ? FROM genres WHERE id = ? AND deleted_at IS NULL`,
+			game.ID.String(),
+			i == 0,
+			genreID.String(),
+		)
+		if err != nil {
+			return fmt.Errorf("insert game genre: %w", err)
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			return errors.New(constants.ErrGenreNotFound)
+		}
+	}
+	return nil
+}
+
+// gameRow - значения колонок gameColumns в том порядке, в котором их выбирают запросы.
+type gameRow struct {
+	id, title, description, releaseDate, status string
+	publishAt, deletedAt                        sql.NullString
+	version                                     int
+}
+
+func (g *gameRow) dest() []any {
+	return []any{&g.id, &g.title, &g.description, &g.releaseDate, &g.status, &g.publishAt, &g.deletedAt, &g.version}
+}
+
+func scanGame(row rowScanner) (*model.Game, error) {
+	var g gameRow
+	if err := row.Scan(g.dest()...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan game: %w", err)
+	}
+
+	return g.parse()
+}
+
+// parse собирает игру без жанров - их подгружает loadGenres.
+func (g *gameRow) parse() (*model.Game, error) {
+	gameID, err := uuid.Parse(g.id)
+	if err != nil {
+		return nil, fmt.Errorf("parse game id from db: %w", err)
+	}
+	releaseDate, err := time.Parse(time.RFC3339Nano, g.releaseDate)
+	if err != nil {
+		return nil, fmt.Errorf("parse release_date from db: %w", err)
+	}
+	publishAt, err := parseNullableTime(g.publishAt, "publish_at")
+	if err != nil {
+		return nil, err
+	}
+	deletedAt, err := parseDeletedAt(g.deletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Game{
+		ID:          gameID,
+		Title:       g.title,
+		Description: g.description,
+		ReleaseDate: releaseDate,
+		Status:      g.status,
+		PublishAt:   publishAt,
+		DeletedAt:   deletedAt,
+		Version:     g.version,
+	}, nil
+}
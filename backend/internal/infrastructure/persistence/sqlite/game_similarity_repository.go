@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.GameSimilarityRepository = (*GameSimilarityRepository)(nil)
+
+type GameSimilarityRepository struct {
+	db *sql.DB
+}
+
+func NewGameSimilarityRepository(db *sql.DB) *GameSimilarityRepository {
+	return &GameSimilarityRepository{db: db}
+}
+
+func (r *GameSimilarityRepository) Replace(ctx context.Context, items []*model.GameSimilarity, computedAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM game_similarities`); err != nil {
+		return fmt.Errorf("clear game similarities: %w", err)
+	}
+
+	stmt, err := tx.PrepareContext(
+		ctx,
+		`INSERT INTO game_similarities (game_id, similar_id, score, genre_score, rating_score, co_ratings, computed_at)
+		SELECT ?, ?, ?, ?, ?, ?, ?
+		WHERE EXISTS (SELECT 1 FROM games WHERE id = ?) AND EXISTS (SELECT 1 FROM games WHERE id = ?)`,
+	)
+	if err != nil {
+		return fmt.Errorf("prepare game similarity insert: %w", err)
+	}
+	defer stmt.Close()
+
+	at := computedAt.UTC().Format(time.RFC3339Nano)
+	for _, item := range items {
+		gameID, similarID := item.GameID.String(), item.SimilarID.String()
+		_, err := stmt.ExecContext(ctx, gameID, similarID, item.Score, item.GenreScore, item.RatingScore, item.CoRatings, at,
+			gameID, similarID)
+		if err != nil {
+			return fmt.Errorf("insert game similarity: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+	return nil
+}
+
+func (r *GameSimilarityRepository) FindByGameIDs(ctx context.Context, gameIDs []uuid.UUID) (map[uuid.UUID][]*model.GameSimilarity, error) {
+	res := make(map[uuid.UUID][]*model.GameSimilarity, len(gameIDs))
+	if len(gameIDs) == 0 {
+		return res, nil
+	}
+
+	args := make([]any, len(gameIDs))
+	for i, id := range gameIDs {
+		args[i] = id.String()
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT s.game_id, s.similar_id, s.score, s.genre_score, s.rating_score, s.co_ratings
+		FROM game_similarities s
+		JOIN games g ON g.id = s.similar_id AND g.deleted_at IS NULL
+		WHERE s.game_id IN (`+placeholders(len(gameIDs))+`)
+		ORDER BY s.game_id, s.score DESC, s.similar_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select game similarities: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var gameIDStr, similarIDStr string
+		item := &model.GameSimilarity{}
+		if err := rows.Scan(&gameIDStr, &similarIDStr, &item.Score, &item.GenreScore, &item.RatingScore, &item.CoRatings); err != nil {
+			return nil, fmt.Errorf("scan game similarity: %w", err)
+		}
+		if item.GameID, err = uuid.Parse(gameIDStr); err != nil {
+			return nil, fmt.Errorf("parse game_id from db: %w", err)
+		}
+		if item.SimilarID, err = uuid.Parse(similarIDStr); err != nil {
+			return nil, fmt.Errorf("parse similar_id from db: %w", err)
+		}
+		res[item.GameID] = append(res[item.GameID], item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate game similarities: %w", err)
+	}
+	return res, nil
+}
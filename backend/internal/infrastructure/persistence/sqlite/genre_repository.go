@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/domain/model"
@@ -16,6 +17,8 @@ import (
 // compile-time check
 var _ repository.GenreRepository = (*GenreRepository)(nil)
 
+const genreColumns = `id, title, deleted_at, version`
+
 type GenreRepository struct {
 	db *sql.DB
 }
@@ -28,24 +31,33 @@ func (r *GenreRepository) Create(ctx context.Context, genre *model.Genre) (*mode
 	if genre == nil {
 		return nil, errors.New("genre cannot be nil")
 	}
+	if err := insertGenre(ctx, conn(ctx, r.db), genre); err != nil {
+		return nil, err
+	}
+
+	return genre, nil
+}
+
+// insertGenre добавляет жанр; пустой ID заменяется новым.
+func insertGenre(ctx context.Context, q execer, genre *model.Genre) error {
 	if genre.ID == uuid.Nil {
 		genre.ID = uuid.New()
 	}
+	genre.Version = 1
 
-	_, err := r.db.ExecContext(
+	_, err := q.ExecContext(
 		ctx,
-		`INSERT INTO genres (id, title) VALUES (?, ?)`,
+		`INSERT INTO genres (id, title, version) VALUES (?, ?, 1)`,
 		genre.ID.String(),
 		genre.Title,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
-			return nil, repository.ErrGenreAlreadyExists
+			return repository.ErrGenreAlreadyExists
 		}
-		return nil, fmt.Errorf("insert genre: %w", err)
+		return fmt.Errorf("insert genre: %w", err)
 	}
-
-	return genre, nil
+	return nil
 }
 
 func (r *GenreRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Genre, error) {
@@ -53,56 +65,65 @@ func (r *GenreRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Ge
 		return nil, errors.New("genre ID cannot be empty")
 	}
 
-	var idStr, title string
-	err := r.db.QueryRowContext(ctx, `SELECT id, title FROM genres WHERE id = ?`, id.String()).
-		Scan(&idStr, &title)
+	row := conn(ctx, r.db).QueryRowContext(ctx, `SELECT `+genreColumns+` FROM genres WHERE id = ? AND deleted_at IS NULL`, id.String())
+	genre, err := scanGenre(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrGenreNotFound
 		}
-		return nil, fmt.Errorf("select genre: %w", err)
-	}
-
-	uid, err := uuid.Parse(idStr)
-	if err != nil {
-		return nil, fmt.Errorf("parse genre id from db: %w", err)
+		return nil, err
 	}
 
-	return &model.Genre{ID: uid, Title: title}, nil
+	return genre, nil
 }
 
 func (r *GenreRepository) FindAll(ctx context.Context, limit, offset int) ([]*model.Genre, error) {
-	query := `SELECT id, title FROM genres ORDER BY title`
-	args := []any{}
+	query, args := appendPage(`SELECT `+genreColumns+` FROM genres WHERE deleted_at IS NULL ORDER BY title`, []any{}, limit, offset)
+	return r.findMany(ctx, query, args...)
+}
 
-	if limit > 0 {
-		query += ` LIMIT ? OFFSET ?`
-		args = append(args, limit, offset)
+func (r *GenreRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]*model.Genre, error) {
+	if len(ids) == 0 {
+		return []*model.Genre{}, nil
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id.String()
 	}
+	return r.findMany(ctx, `SELECT `+genreColumns+` FROM genres
+		WHERE id IN (`+placeholders(len(args))+`) AND deleted_at IS NULL ORDER BY title`, args...)
+}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("select genres: %w", err)
+func (r *GenreRepository) FindByQuery(ctx context.Context, q repository.GenreQuery) ([]*model.Genre, int, error) {
+	conds := []string{`deleted_at IS NULL`}
+	args := []any{}
+	if search := strings.TrimSpace(q.Search); search != "" {
+		conds = append(conds, `title LIKE ? ESCAPE '\'`)
+		args = append(args, containsPattern(search))
 	}
-	defer rows.Close()
+	where := whereClause(conds)
 
-	var res []*model.Genre
-	for rows.Next() {
-		var idStr, title string
-		if err := rows.Scan(&idStr, &title); err != nil {
-			return nil, fmt.Errorf("scan genre: %w", err)
-		}
-		uid, err := uuid.Parse(idStr)
-		if err != nil {
-			return nil, fmt.Errorf("parse genre id from db: %w", err)
-		}
-		res = append(res, &model.Genre{ID: uid, Title: title})
+	var total int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM genres`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count genres: %w", err)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate genres: %w", err)
+
+	offset := q.Offset
+	if q.After != nil {
+		conds = append(conds, keysetCondition(`title`, `id`, q.SortDesc))
+		args = append(args, q.After.Key, q.After.Key, q.After.ID.String())
+		offset = 0
 	}
 
-	return res, nil
+	dir := sortDirection(q.SortDesc)
+	query, pageArgs := appendPage(`SELECT `+genreColumns+` FROM genres`+whereClause(conds)+` ORDER BY title `+dir+`, id `+dir, args, q.Limit, offset)
+
+	res, err := r.findMany(ctx, query, pageArgs...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return res, total, nil
 }
 
 func (r *GenreRepository) Update(ctx context.Context, genre *model.Genre) (*model.Genre, error) {
@@ -113,28 +134,70 @@ func (r *GenreRepository) Update(ctx context.Context, genre *model.Genre) (*mode
 		return nil, errors.New("genre ID cannot be empty")
 	}
 
-	result, err := r.db.ExecContext(ctx, `UPDATE genres SET title = ? WHERE id = ?`, genre.Title, genre.ID.String())
+	if err := updateGenre(ctx, conn(ctx, r.db), genre); err != nil {
+		return nil, err
+	}
+
+	genre.Version++
+	return genre, nil
+}
+
+// updateGenre сохраняет жанр с версией genre.Version; версию в genre увеличивает вызывающий.
+func updateGenre(ctx context.Context, q queryExecer, genre *model.Genre) error {
+	result, err := q.ExecContext(
+		ctx,
+		`UPDATE genres SET title = ?, version = version + 1 WHERE id = ? AND version = ? AND deleted_at IS NULL`,
+		genre.Title,
+		genre.ID.String(),
+		genre.Version,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("update genre: %w", err)
+		return fmt.Errorf("update genre: %w", err)
 	}
 	affected, _ := result.RowsAffected()
 	if affected == 0 {
-		return nil, repository.ErrGenreNotFound
+		return versionMismatch(ctx, q, "genres", genre.ID, repository.ErrGenreNotFound)
 	}
-
-	return genre, nil
+	return nil
 }
 
-func (r *GenreRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (r *GenreRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	if id == uuid.Nil {
 		return errors.New("genre ID cannot be empty")
 	}
 
-	result, err := r.db.ExecContext(ctx, `DELETE FROM genres WHERE id = ?`, id.String())
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE genres SET deleted_at = ?, version = version + 1 WHERE id = ? AND version = ? AND deleted_at IS NULL`,
+		formatSortableTime(time.Now()),
+		id.String(),
+		version,
+	)
 	if err != nil {
 		return fmt.Errorf("delete genre: %w", err)
 	}
 	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return versionMismatch(ctx, conn(ctx, r.db), "genres", id, repository.ErrGenreNotFound)
+	}
+
+	return nil
+}
+
+func (r *GenreRepository) FindDeleted(ctx context.Context) ([]*model.Genre, error) {
+	return r.findMany(ctx, `SELECT `+genreColumns+` FROM genres WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id`)
+}
+
+func (r *GenreRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("genre ID cannot be empty")
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE genres SET deleted_at = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL`, id.String())
+	if err != nil {
+		return fmt.Errorf("restore genre: %w", err)
+	}
+	affected, _ := result.RowsAffected()
 	if affected == 0 {
 		return repository.ErrGenreNotFound
 	}
@@ -142,13 +205,29 @@ func (r *GenreRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (r *GenreRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	// Жанр, на который еще ссылаются игры (в том числе из корзины), остается до их окончательного удаления
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`DELETE FROM genres
+		WHERE deleted_at IS NOT NULL AND deleted_at < ?
+			AND id NOT IN (SELECT genre_id FROM game_genres)`,
+		formatSortableTime(before),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("purge genres: %w", err)
+	}
+	affected, _ := result.RowsAffected()
+	return int(affected), nil
+}
+
 func (r *GenreRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	if id == uuid.Nil {
 		return false, errors.New("genre ID cannot be empty")
 	}
 
 	var one int
-	err := r.db.QueryRowContext(ctx, `SELECT 1 FROM genres WHERE id = ?`, id.String()).Scan(&one)
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT 1 FROM genres WHERE id = ? AND deleted_at IS NULL`, id.String()).Scan(&one)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -158,3 +237,47 @@ func (r *GenreRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error
 	return true, nil
 }
 
+func (r *GenreRepository) findMany(ctx context.Context, query string, args ...any) ([]*model.Genre, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select genres: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.Genre{}
+	for rows.Next() {
+		genre, err := scanGenre(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, genre)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate genres: %w", err)
+	}
+
+	return res, nil
+}
+
+func scanGenre(row rowScanner) (*model.Genre, error) {
+	var idStr, title string
+	var deletedAtStr sql.NullString
+	var version int
+	if err := row.Scan(&idStr, &title, &deletedAtStr, &version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan genre: %w", err)
+	}
+
+	uid, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("parse genre id from db: %w", err)
+	}
+	deletedAt, err := parseDeletedAt(deletedAtStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Genre{ID: uid, Title: title, DeletedAt: deletedAt, Version: version}, nil
+}
@@ -0,0 +1,272 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"example/web-service-gin/internal/domain/model"
+)
+
+// migration переводит базу, созданную старой версией схемы, к текущей.
+// Возвращает true, если что-то было изменено.
+type migration struct {
+	name  string
+	apply func(ctx context.Context, db *sql.DB) (bool, error)
+}
+
+// migrations выполняются по порядку после применения schema.sql; каждая сама
+// проверяет, нужна ли она, поэтому повторный запуск безопасен.
+var migrations = []migration{
+	{name: "games.genre_id -> game_genres", apply: migrateGameGenres},
+	{name: "games.deleted_at", apply: addColumn("games", "deleted_at", "TEXT")},
+	{name: "genres.deleted_at", apply: addColumn("genres", "deleted_at", "TEXT")},
+	{name: "users.deleted_at", apply: addColumn("users", "deleted_at", "TEXT")},
+	{name: "games.version", apply: addColumn("games", "version", "INTEGER NOT NULL DEFAULT 1")},
+	{name: "genres.version", apply: addColumn("genres", "version", "INTEGER NOT NULL DEFAULT 1")},
+	{name: "users.version", apply: addColumn("users", "version", "INTEGER NOT NULL DEFAULT 1")},
+	{name: "games.title_key", apply: addColumn("games", "title_key", "TEXT")},
+	{name: "games.title_key unique", apply: migrateGameTitleKeys},
+	{name: "games.release_date sortable", apply: migrateReleaseDates},
+	{name: "games.status", apply: addColumn("games", "status",
+		"TEXT NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'scheduled', 'published', 'archived'))")},
+	{name: "games.publish_at", apply: addColumn("games", "publish_at", "TEXT")},
+	{name: "games.publish_at index", apply: addIndex("idx_games_publish_at",
+		`CREATE INDEX idx_games_publish_at ON games(publish_at) WHERE status = 'scheduled' AND deleted_at IS NULL`)},
+	{name: "attempts.result_token_hash", apply: addColumn("attempts", "result_token_hash", "TEXT")},
+	{name: "attempts.result_expires_at", apply: addColumn("attempts", "result_expires_at", "TEXT")},
+	{name: "attempts.result_token_hash index", apply: addIndex("idx_attempts_result_token_hash",
+		`CREATE UNIQUE INDEX idx_attempts_result_token_hash ON attempts(result_token_hash)`)},
+}
+
+func migrate(ctx context.Context, db *sql.DB) (bool, error) {
+	migrated := false
+	for _, m := range migrations {
+		changed, err := m.apply(ctx, db)
+		if err != nil {
+			return false, fmt.Errorf("migration %q: %w", m.name, err)
+		}
+		migrated = migrated || changed
+	}
+	return migrated, nil
+}
+
+// migrateGameGenres переносит единственный жанр из games.genre_id в game_genres
+// как основной и пересоздает games без этой колонки (SQLite не умеет удалять
+// колонку, на которую ссылаются индекс и внешний ключ).
+func migrateGameGenres(ctx context.Context, db *sql.DB) (bool, error) {
+	legacy, err := hasColumn(ctx, db, "games", "genre_id")
+	if err != nil || !legacy {
+		return false, err
+	}
+
+	// Иначе DROP TABLE games каскадно удалит оценки. PRAGMA внутри транзакции не действует,
+	// а соединение в пуле одно, так что настройка относится и к транзакции ниже.
+	if _, err := db.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return false, fmt.Errorf("disable foreign keys: %w", err)
+	}
+	defer func() { _, _ = db.ExecContext(context.Background(), `PRAGMA foreign_keys = ON`) }()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmts := []string{
+		`INSERT OR IGNORE INTO game_genres (game_id, genre_id, is_primary) SELECT id, genre_id, 1 FROM games`,
+		`CREATE TABLE games_new (
+			id TEXT PRIMARY KEY,
+			title TEXT NOT NULL,
+			description TEXT NOT NULL,
+			release_date TEXT NOT NULL
+		)`,
+		`INSERT INTO games_new (id, title, description, release_date) SELECT id, title, description, release_date FROM games`,
+		`DROP TABLE games`,
+		`ALTER TABLE games_new RENAME TO games`,
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return false, fmt.Errorf("exec %q: %w", stmt, err)
+		}
+	}
+
+	var violations int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM pragma_foreign_key_check`).Scan(&violations); err != nil {
+		return false, fmt.Errorf("foreign key check: %w", err)
+	}
+	if violations > 0 {
+		return false, fmt.Errorf("foreign key check: %d violations", violations)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit tx: %w", err)
+	}
+	return true, nil
+}
+
+// migrateGameTitleKeys заполняет games.title_key и создает по нему уникальный индекс.
+// Ключ вычисляется в Go (NFKC и свертку регистра SQLite не умеет), поэтому индекса нет в schema.sql.
+// Из активных игр с одинаковым ключом ключ получает самая ранняя, остальные остаются
+// с NULL и попадают в лог: при следующем сохранении их придется переименовать.
+func migrateGameTitleKeys(ctx context.Context, db *sql.DB) (bool, error) {
+	var one int
+	err := db.QueryRowContext(ctx, `SELECT 1 FROM sqlite_master WHERE type = 'index' AND name = 'idx_games_title_key'`).Scan(&one)
+	if err == nil {
+		return false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("inspect idx_games_title_key: %w", err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	type gameTitle struct {
+		id, title string
+		live      bool
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id, title, deleted_at IS NULL FROM games ORDER BY rowid`)
+	if err != nil {
+		return false, fmt.Errorf("select games: %w", err)
+	}
+	var games []gameTitle
+	for rows.Next() {
+		var g gameTitle
+		if err := rows.Scan(&g.id, &g.title, &g.live); err != nil {
+			_ = rows.Close()
+			return false, fmt.Errorf("scan game: %w", err)
+		}
+		games = append(games, g)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("iterate games: %w", err)
+	}
+
+	owners := make(map[string]string)
+	for _, g := range games {
+		key := model.NormalizeTitle(g.title)
+		if g.live {
+			if owner, taken := owners[key]; taken {
+				log.Printf("migration: game %s %q duplicates title of game %s, title_key left NULL", g.id, g.title, owner)
+				continue
+			}
+			owners[key] = g.id
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE games SET title_key = ? WHERE id = ?`, key, g.id); err != nil {
+			return false, fmt.Errorf("update title_key: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `CREATE UNIQUE INDEX idx_games_title_key ON games(title_key) WHERE deleted_at IS NULL`); err != nil {
+		return false, fmt.Errorf("create idx_games_title_key: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit tx: %w", err)
+	}
+	return true, nil
+}
+
+// migrateReleaseDates переводит games.release_date из RFC3339Nano в sortableTime: у RFC3339Nano
+// дробная часть разной длины, и строки сравниваются не так, как моменты времени.
+// Все значения в sortableTime одной длины, поэтому переписываются только строки другой длины.
+func migrateReleaseDates(ctx context.Context, db *sql.DB) (bool, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	type releaseDate struct {
+		id, value string
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id, release_date FROM games WHERE length(release_date) <> ?`,
+		len(formatSortableTime(time.Time{})))
+	if err != nil {
+		return false, fmt.Errorf("select release dates: %w", err)
+	}
+	var legacy []releaseDate
+	for rows.Next() {
+		var d releaseDate
+		if err := rows.Scan(&d.id, &d.value); err != nil {
+			_ = rows.Close()
+			return false, fmt.Errorf("scan release date: %w", err)
+		}
+		legacy = append(legacy, d)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("iterate release dates: %w", err)
+	}
+	if len(legacy) == 0 {
+		return false, nil
+	}
+
+	for _, d := range legacy {
+		t, err := time.Parse(time.RFC3339Nano, d.value)
+		if err != nil {
+			return false, fmt.Errorf("parse release_date of game %s: %w", d.id, err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE games SET release_date = ? WHERE id = ?`, formatSortableTime(t), d.id); err != nil {
+			return false, fmt.Errorf("update release_date: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit tx: %w", err)
+	}
+	return true, nil
+}
+
+// addColumn добавляет колонку, которой нет в таблицах, созданных до ее появления в schema.sql.
+func addColumn(table, column, definition string) func(ctx context.Context, db *sql.DB) (bool, error) {
+	return func(ctx context.Context, db *sql.DB) (bool, error) {
+		exists, err := hasColumn(ctx, db, table, column)
+		if err != nil || exists {
+			return false, err
+		}
+		if _, err := db.ExecContext(ctx, `ALTER TABLE `+table+` ADD COLUMN `+column+` `+definition); err != nil {
+			return false, fmt.Errorf("add column %s.%s: %w", table, column, err)
+		}
+		return true, nil
+	}
+}
+
+// addIndex создает индекс по колонкам, добавленным миграциями: в schema.sql его не описать,
+// потому что схема применяется к старой базе раньше, чем появятся колонки.
+func addIndex(name, stmt string) func(ctx context.Context, db *sql.DB) (bool, error) {
+	return func(ctx context.Context, db *sql.DB) (bool, error) {
+		var one int
+		err := db.QueryRowContext(ctx, `SELECT 1 FROM sqlite_master WHERE type = 'index' AND name = ?`, name).Scan(&one)
+		if err == nil {
+			return false, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("inspect %s: %w", name, err)
+		}
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return false, fmt.Errorf("create %s: %w", name, err)
+		}
+		return true, nil
+	}
+}
+
+func hasColumn(ctx context.Context, db *sql.DB, table, column string) (bool, error) {
+	var one int
+	err := db.QueryRowContext(ctx, `SELECT 1 FROM pragma_table_info(?) WHERE name = ?`, table, column).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("inspect %s.%s: %w", table, column, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,312 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.PlayResultRepository = (*PlayResultRepository)(nil)
+
+const playResultColumns = `id, attempt_id, user_id, game_id, task_id, score, duration_ms, success, passed,
+	details, submitted_at`
+
+type PlayResultRepository struct {
+	db *sql.DB
+}
+
+func NewPlayResultRepository(db *sql.DB) *PlayResultRepository {
+	return &PlayResultRepository{db: db}
+}
+
+func (r *PlayResultRepository) UseNonce(ctx context.Context, attemptID uuid.UUID, nonce string, at time.Time) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO result_nonces (attempt_id, nonce, used_at) VALUES (?, ?, ?)`,
+		attemptID.String(), nonce, formatSortableTime(at),
+	)
+	if err != nil {
+		msg := err.Error()
+		if strings.Contains(msg, "UNIQUE constraint failed") || strings.Contains(msg, "PRIMARY KEY") {
+			return repository.ErrNonceUsed
+		}
+		if strings.Contains(msg, "FOREIGN KEY constraint failed") {
+			return repository.ErrAttemptNotFound
+		}
+		return fmt.Errorf("insert result nonce: %w", err)
+	}
+	return nil
+}
+
+func (r *PlayResultRepository) Submit(ctx context.Context,
+	result *model.PlayResult, attempt *model.Attempt) (*model.PlayResult, error) {
+
+	if result == nil || attempt == nil {
+		return nil, errors.New("result and attempt cannot be nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(
+		ctx,
+		`UPDATE attempts SET `+attemptUpdateColumns+`
+		WHERE id = ? AND status = ?`,
+		append(attemptUpdateArgs(attempt), attempt.ID.String(), model.AttemptRunning)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update attempt: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return nil, r.notRunning(ctx, tx, attempt.ID)
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO play_results (`+playResultColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		result.ID.String(),
+		result.AttemptID.String(),
+		result.UserID.String(),
+		result.GameID.String(),
+		nullableUUID(result.TaskID),
+		result.Score,
+		result.Duration.Milliseconds(),
+		result.Success,
+		result.Passed,
+		string(result.Details),
+		formatSortableTime(result.SubmittedAt),
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return nil, repository.ErrResultAlreadySubmitted
+		}
+		return nil, fmt.Errorf("insert play result: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return result, nil
+}
+
+// notRunning объясняет, почему попытку не удалось завершить: ее нет, у нее уже есть результат
+// или она завершилась иначе.
+func (r *PlayResultRepository) notRunning(ctx context.Context, tx *sql.Tx, attemptID uuid.UUID) error {
+	var hasResult bool
+	err := tx.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM play_results WHERE attempt_id = a.id) FROM attempts a WHERE a.id = ?`,
+		attemptID.String(),
+	).Scan(&hasResult)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return repository.ErrAttemptNotFound
+	case err != nil:
+		return fmt.Errorf("select attempt: %w", err)
+	case hasResult:
+		return repository.ErrResultAlreadySubmitted
+	default:
+		return repository.ErrAttemptStatusChanged
+	}
+}
+
+// FindGameStatsByUser считает статистику одним запросом: медиана времени - среднее одной
+// или двух средних строк засчитанных результатов, пронумерованных оконной функцией.
+func (r *PlayResultRepository) FindGameStatsByUser(ctx context.Context, userID uuid.UUID) ([]*model.GamePlayStats, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`WITH results AS (
+			SELECT pr.game_id, pr.score, pr.duration_ms, pr.passed, pr.submitted_at
+			FROM play_results pr
+			JOIN games g ON g.id = pr.game_id AND g.deleted_at IS NULL
+			WHERE pr.user_id = ?
+		),
+		ranked AS (
+			SELECT game_id, duration_ms,
+				ROW_NUMBER() OVER (PARTITION BY game_id ORDER BY duration_ms) AS n,
+				COUNT(*) OVER (PARTITION BY game_id) AS total
+			FROM results
+			WHERE passed = 1
+		),
+		medians AS (
+			SELECT game_id, AVG(duration_ms) AS median_ms
+			FROM ranked
+			WHERE n IN ((total + 1) / 2, (total + 2) / 2)
+			GROUP BY game_id
+		)
+		SELECT r.game_id, g.title, COUNT(*), SUM(r.passed), MAX(r.score),
+			MIN(CASE WHEN r.passed = 1 THEN r.duration_ms END), m.median_ms, MAX(r.submitted_at)
+		FROM results r
+		JOIN games g ON g.id = r.game_id
+		LEFT JOIN medians m ON m.game_id = r.game_id
+		GROUP BY r.game_id, g.title, m.median_ms
+		ORDER BY MAX(r.submitted_at) DESC, r.game_id`,
+		userID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select game stats: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.GamePlayStats{}
+	for rows.Next() {
+		var gameIDStr, lastPlayedAt string
+		var bestMs sql.NullInt64
+		var medianMs sql.NullFloat64
+		stats := &model.GamePlayStats{}
+		if err := rows.Scan(&gameIDStr, &stats.GameTitle, &stats.Plays, &stats.Completions, &stats.BestScore,
+			&bestMs, &medianMs, &lastPlayedAt); err != nil {
+			return nil, fmt.Errorf("scan game stats: %w", err)
+		}
+		if stats.GameID, err = uuid.Parse(gameIDStr); err != nil {
+			return nil, fmt.Errorf("parse game_id from db: %w", err)
+		}
+		if bestMs.Valid {
+			best := time.Duration(bestMs.Int64) * time.Millisecond
+			stats.BestTime = &best
+		}
+		if medianMs.Valid {
+			median := time.Duration(medianMs.Float64 * float64(time.Millisecond))
+			stats.MedianTime = &median
+		}
+		if stats.LastPlayedAt, err = time.Parse(time.RFC3339Nano, lastPlayedAt); err != nil {
+			return nil, fmt.Errorf("parse submitted_at from db: %w", err)
+		}
+		res = append(res, stats)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate game stats: %w", err)
+	}
+	return res, nil
+}
+
+func (r *PlayResultRepository) FindGenreStatsByUser(ctx context.Context, userID uuid.UUID) ([]*model.GenrePlayStats, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT ge.id, ge.title, COUNT(DISTINCT pr.game_id), COUNT(*), SUM(pr.passed)
+		FROM play_results pr
+		JOIN games g ON g.id = pr.game_id AND g.deleted_at IS NULL
+		JOIN game_genres gg ON gg.game_id = pr.game_id
+		JOIN genres ge ON ge.id = gg.genre_id AND ge.deleted_at IS NULL
+		WHERE pr.user_id = ?
+		GROUP BY ge.id, ge.title
+		ORDER BY COUNT(*) DESC, ge.title, ge.id`,
+		userID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select genre stats: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.GenrePlayStats{}
+	for rows.Next() {
+		var genreIDStr string
+		stats := &model.GenrePlayStats{}
+		if err := rows.Scan(&genreIDStr, &stats.GenreTitle, &stats.Games, &stats.Plays, &stats.Completions); err != nil {
+			return nil, fmt.Errorf("scan genre stats: %w", err)
+		}
+		if stats.GenreID, err = uuid.Parse(genreIDStr); err != nil {
+			return nil, fmt.Errorf("parse genre id from db: %w", err)
+		}
+		res = append(res, stats)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate genre stats: %w", err)
+	}
+	return res, nil
+}
+
+// FindLeaderboard выбирает лучший результат каждого пользователя оконной функцией по индексу
+// idx_play_results_leaderboard, затем нумерует пользователей; первые места и место
+// запросившего берутся из одной выборки.
+func (r *PlayResultRepository) FindLeaderboard(ctx context.Context, q repository.LeaderboardQuery) (*model.Leaderboard, error) {
+	where := `game_id = ?`
+	args := []any{q.GameID.String()}
+	if q.TaskID != uuid.Nil {
+		where += ` AND task_id = ?`
+		args = append(args, q.TaskID.String())
+	}
+	if !q.Since.IsZero() {
+		where += ` AND submitted_at >= ?`
+		args = append(args, formatSortableTime(q.Since))
+	}
+	users := `u.deleted_at IS NULL`
+	if q.ExcludeAdmins {
+		users += ` AND u.user_role <> ?`
+		args = append(args, string(specifictype.RoleAdmin))
+	}
+	args = append(args, q.Limit, q.CallerID.String())
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`WITH best AS (
+			SELECT user_id, score, duration_ms, submitted_at,
+				ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY score DESC, duration_ms, submitted_at) AS n
+			FROM play_results
+			WHERE `+where+`
+		),
+		ranked AS (
+			SELECT b.user_id, u.username, b.score, b.duration_ms, b.submitted_at,
+				ROW_NUMBER() OVER (ORDER BY b.score DESC, b.duration_ms, b.submitted_at, b.user_id) AS position,
+				COUNT(*) OVER () AS participants
+			FROM best b
+			JOIN users u ON u.id = b.user_id
+			WHERE b.n = 1 AND `+users+`
+		)
+		SELECT user_id, username, score, duration_ms, submitted_at, position, participants
+		FROM ranked
+		WHERE position <= ? OR user_id = ?
+		ORDER BY position`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select leaderboard: %w", err)
+	}
+	defer rows.Close()
+
+	board := &model.Leaderboard{Entries: []*model.LeaderboardEntry{}}
+	for rows.Next() {
+		var userIDStr, achievedAt string
+		var durationMs int64
+		entry := &model.LeaderboardEntry{}
+		if err := rows.Scan(&userIDStr, &entry.Username, &entry.Score, &durationMs, &achievedAt,
+			&entry.Rank, &board.Participants); err != nil {
+			return nil, fmt.Errorf("scan leaderboard entry: %w", err)
+		}
+		if entry.UserID, err = uuid.Parse(userIDStr); err != nil {
+			return nil, fmt.Errorf("parse user_id from db: %w", err)
+		}
+		entry.Duration = time.Duration(durationMs) * time.Millisecond
+		if entry.AchievedAt, err = time.Parse(time.RFC3339Nano, achievedAt); err != nil {
+			return nil, fmt.Errorf("parse submitted_at from db: %w", err)
+		}
+
+		if entry.UserID == q.CallerID {
+			board.Caller = entry
+		}
+		if entry.Rank <= q.Limit {
+			board.Entries = append(board.Entries, entry)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate leaderboard: %w", err)
+	}
+	return board, nil
+}
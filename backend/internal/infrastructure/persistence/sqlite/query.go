@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+
+	"github.com/google/uuid"
+)
+
+// rowScanner - общий интерфейс *sql.Row и *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// sortableTime - RFC3339 с фиксированным числом знаков после запятой: такие строки
+// сравниваются в SQL так же, как сами моменты времени (у RFC3339Nano нули отбрасываются).
+const sortableTime = "2006-01-02T15:04:05.000000000Z07:00"
+
+func formatSortableTime(t time.Time) string {
+	return t.UTC().Format(sortableTime)
+}
+
+// nullableSortableTime - значение необязательной колонки времени: NULL для nil.
+func nullableSortableTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return formatSortableTime(*t)
+}
+
+// parseDeletedAt разбирает deleted_at; NULL - запись не удалена.
+func parseDeletedAt(v sql.NullString) (*time.Time, error) {
+	return parseNullableTime(v, "deleted_at")
+}
+
+// parseNullableTime разбирает необязательную колонку времени column; NULL - nil.
+func parseNullableTime(v sql.NullString, column string) (*time.Time, error) {
+	if !v.Valid {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, v.String)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s from db: %w", column, err)
+	}
+	return &t, nil
+}
+
+// rowQuerier - общий интерфейс *sql.DB и *sql.Tx для запросов одной строки.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// execer - общий интерфейс *sql.DB и *sql.Tx для изменяющих запросов.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// queryExecer - *sql.DB или *sql.Tx, когда нужны и изменения, и чтение.
+type queryExecer interface {
+	execer
+	rowQuerier
+}
+
+// versionMismatch объясняет, почему условный UPDATE по id и version не затронул строк:
+// активной записи нет (notFound) или ее версия уже другая (ErrVersionConflict).
+func versionMismatch(ctx context.Context, q rowQuerier, table string, id uuid.UUID, notFound error) error {
+	var one int
+	err := q.QueryRowContext(ctx, `SELECT 1 FROM `+table+` WHERE id = ? AND deleted_at IS NULL`, id.String()).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	if err != nil {
+		return fmt.Errorf("check %s version: %w", table, err)
+	}
+	return repository.ErrVersionConflict
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern строит шаблон LIKE для поиска подстроки (используется с ESCAPE '\').
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// whereClause собирает условия через AND; пустой список - без WHERE.
+func whereClause(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conds, " AND ")
+}
+
+// sortDirection возвращает направление сортировки для ORDER BY.
+func sortDirection(desc bool) string {
+	if desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// placeholders возвращает "?, ?, ..." для IN (...) из n элементов.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// appendPage добавляет LIMIT/OFFSET, если лимит задан.
+func appendPage(query string, args []any, limit, offset int) (string, []any) {
+	if limit > 0 {
+		query += ` LIMIT ? OFFSET ?`
+		args = append(args, limit, offset)
+	}
+	return query, args
+}
+
+// keysetCondition - условие "строго после курсора" для ORDER BY keyExpr dir, idExpr dir.
+// Аргументы: ключ, ключ, id.
+func keysetCondition(keyExpr, idExpr string, desc bool) string {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	return "(" + keyExpr + " " + op + " ? OR (" + keyExpr + " = ? AND " + idExpr + " " + op + " ?))"
+}
+
+// ftsMatchExpression превращает пользовательский ввод в выражение MATCH для FTS5:
+// каждое слово берется в кавычки (спецсинтаксис FTS5 не интерпретируется)
+// и ищется как префикс, слова объединяются через AND. Пустая строка - нет слов.
+func ftsMatchExpression(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = `"` + w + `"*`
+	}
+	return strings.Join(terms, " ")
+}
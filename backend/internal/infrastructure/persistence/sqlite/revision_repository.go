@@ -0,0 +1,190 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.RevisionRepository = (*RevisionRepository)(nil)
+
+const revisionColumns = `id, entity_type, entity_id, number, action, snapshot, actor_id, reverted_from, created_at`
+
+type RevisionRepository struct {
+	db *sql.DB
+}
+
+func NewRevisionRepository(db *sql.DB) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+func (r *RevisionRepository) Append(ctx context.Context, revision *model.Revision) (*model.Revision, error) {
+	if revision == nil {
+		return nil, errors.New("revision cannot be nil")
+	}
+	if revision.EntityID == uuid.Nil {
+		return nil, errors.New("revision entity ID cannot be empty")
+	}
+	if revision.ID == uuid.Nil {
+		revision.ID = uuid.New()
+	}
+	if revision.CreatedAt.IsZero() {
+		revision.CreatedAt = time.Now().UTC()
+	}
+
+	var actorID, revertedFrom any
+	if revision.ActorID != uuid.Nil {
+		actorID = revision.ActorID.String()
+	}
+	if revision.RevertedFrom > 0 {
+		revertedFrom = revision.RevertedFrom
+	}
+
+	// Номер вычисляется в том же запросе, поэтому параллельные записи не получат одинаковый
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`INSERT INTO revisions (`+revisionColumns+`)
+		SELECT ?, ?, ?, COALESCE(MAX(number), 0) + 1, ?, ?, ?, ?, ?
+		FROM revisions WHERE entity_type = ? AND entity_id = ?
+		RETURNING number`,
+		revision.ID.String(),
+		revision.EntityType,
+		revision.EntityID.String(),
+		revision.Action,
+		string(revision.Snapshot),
+		actorID,
+		revertedFrom,
+		revision.CreatedAt.UTC().Format(time.RFC3339Nano),
+		revision.EntityType,
+		revision.EntityID.String(),
+	).Scan(&revision.Number)
+	if err != nil {
+		return nil, fmt.Errorf("insert revision: %w", err)
+	}
+
+	return revision, nil
+}
+
+func (r *RevisionRepository) FindByEntity(ctx context.Context, entityType string, entityID uuid.UUID, limit, offset int) ([]*model.Revision, int, error) {
+	if entityID == uuid.Nil {
+		return nil, 0, errors.New("revision entity ID cannot be empty")
+	}
+
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM revisions WHERE entity_type = ? AND entity_id = ?`,
+		entityType,
+		entityID.String(),
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count revisions: %w", err)
+	}
+
+	query, args := appendPage(
+		`SELECT `+revisionColumns+` FROM revisions WHERE entity_type = ? AND entity_id = ? ORDER BY number DESC`,
+		[]any{entityType, entityID.String()},
+		limit,
+		offset,
+	)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("select revisions: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.Revision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		res = append(res, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate revisions: %w", err)
+	}
+	return res, total, nil
+}
+
+func (r *RevisionRepository) FindByNumber(ctx context.Context, entityType string, entityID uuid.UUID, number int) (*model.Revision, error) {
+	if entityID == uuid.Nil {
+		return nil, errors.New("revision entity ID cannot be empty")
+	}
+
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT `+revisionColumns+` FROM revisions WHERE entity_type = ? AND entity_id = ? AND number = ?`,
+		entityType,
+		entityID.String(),
+		number,
+	)
+	revision, err := scanRevision(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (r *RevisionRepository) CountByActor(ctx context.Context, entityType, action string, actorID uuid.UUID, since time.Time) (int, error) {
+	// created_at хранится в RFC3339Nano с разной длиной дробной части, строки сравнивать нельзя
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT COUNT(*) FROM revisions
+		WHERE entity_type = ? AND action = ? AND actor_id = ? AND julianday(created_at) >= julianday(?)`,
+		entityType,
+		action,
+		actorID.String(),
+		since.UTC().Format(time.RFC3339Nano),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count revisions: %w", err)
+	}
+	return count, nil
+}
+
+func scanRevision(row rowScanner) (*model.Revision, error) {
+	var idStr, entityIDStr, snapshot, createdAtStr string
+	var actorIDStr sql.NullString
+	var revertedFrom sql.NullInt64
+	revision := &model.Revision{}
+	if err := row.Scan(&idStr, &revision.EntityType, &entityIDStr, &revision.Number, &revision.Action,
+		&snapshot, &actorIDStr, &revertedFrom, &createdAtStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan revision: %w", err)
+	}
+
+	var err error
+	if revision.ID, err = uuid.Parse(idStr); err != nil {
+		return nil, fmt.Errorf("parse revision id from db: %w", err)
+	}
+	if revision.EntityID, err = uuid.Parse(entityIDStr); err != nil {
+		return nil, fmt.Errorf("parse entity_id from db: %w", err)
+	}
+	if actorIDStr.Valid {
+		if revision.ActorID, err = uuid.Parse(actorIDStr.String); err != nil {
+			return nil, fmt.Errorf("parse actor_id from db: %w", err)
+		}
+	}
+	if revision.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAtStr); err != nil {
+		return nil, fmt.Errorf("parse created_at from db: %w", err)
+	}
+	revision.Snapshot = []byte(snapshot)
+	revision.RevertedFrom = int(revertedFrom.Int64)
+
+	return revision, nil
+}
@@ -1,31 +1,341 @@
--- SQLite schema for games and genres
--- One genre -> many games
+-- SQLite schema for games, genres, users and ratings
+-- Games <-> genres: many-to-many via game_genres, exactly one primary genre per game
 
 PRAGMA foreign_keys = ON;
 
+-- deleted_at (games, genres, users): NULL for live rows, otherwise the moment
+-- the row was moved to trash (see sortableTime in query.go)
+-- version (games, genres, users): starts at 1 and is bumped by every change;
+-- updates are conditional on the version the client has read (ETag / If-Match)
+-- title_key (games): model.NormalizeTitle(title), unique among live games. The
+-- partial index idx_games_title_key is created by migrate.go after the backfill
+-- status (games): publication state, publish_at is set only for 'scheduled'.
+-- Games created before statuses existed stay 'published'. The index
+-- idx_games_publish_at is created by migrate.go once the columns exist
+
 CREATE TABLE IF NOT EXISTS genres (
   id TEXT PRIMARY KEY,
-  title TEXT NOT NULL
+  title TEXT NOT NULL,
+  deleted_at TEXT,
+  version INTEGER NOT NULL DEFAULT 1
 );
 
 CREATE TABLE IF NOT EXISTS games (
   id TEXT PRIMARY KEY,
   title TEXT NOT NULL,
+  title_key TEXT,
   description TEXT NOT NULL,
-  release_date TEXT NOT NULL, -- RFC3339Nano
+  release_date TEXT NOT NULL, -- sortableTime
+  status TEXT NOT NULL DEFAULT 'published' CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+  publish_at TEXT, -- sortableTime
+  deleted_at TEXT,
+  version INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS idx_games_release_date ON games(release_date);
+
+CREATE TABLE IF NOT EXISTS game_genres (
+  game_id TEXT NOT NULL,
   genre_id TEXT NOT NULL,
+  is_primary INTEGER NOT NULL DEFAULT 0 CHECK (is_primary IN (0, 1)),
+  PRIMARY KEY (game_id, genre_id),
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE,
   FOREIGN KEY (genre_id) REFERENCES genres(id) ON UPDATE CASCADE ON DELETE RESTRICT
 );
 
-CREATE INDEX IF NOT EXISTS idx_games_genre_id ON games(genre_id);
-CREATE INDEX IF NOT EXISTS idx_games_release_date ON games(release_date);
+CREATE INDEX IF NOT EXISTS idx_game_genres_genre_id ON game_genres(genre_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_game_genres_primary ON game_genres(game_id) WHERE is_primary = 1;
 
 CREATE TABLE IF NOT EXISTS users (
   id TEXT PRIMARY KEY,
   username TEXT NOT NULL UNIQUE,
   password TEXT NOT NULL,
-  user_role TEXT NOT NULL
+  user_role TEXT NOT NULL,
+  deleted_at TEXT,
+  version INTEGER NOT NULL DEFAULT 1
 );
 
 CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);
 
+
+-- One user -> at most one rating per game
+CREATE TABLE IF NOT EXISTS user_ratings (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  game_id TEXT NOT NULL,
+  rating INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 5),
+  created_at TEXT NOT NULL, -- RFC3339Nano
+  UNIQUE (user_id, game_id),
+  FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_ratings_game_id ON user_ratings(game_id);
+
+-- Cover and screenshots of games. Files live in the blob store under
+-- blob_key (SHA-256 of the content), so identical files are stored once and
+-- a blob is removed only when no media row or thumbnail references it.
+CREATE TABLE IF NOT EXISTS game_media (
+  id TEXT PRIMARY KEY,
+  game_id TEXT NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('cover', 'screenshot')),
+  position INTEGER NOT NULL DEFAULT 0, -- order of screenshots, 0 for the cover
+  blob_key TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  bytes INTEGER NOT NULL,
+  width INTEGER NOT NULL,
+  height INTEGER NOT NULL,
+  uploaded_by TEXT,
+  created_at TEXT NOT NULL, -- RFC3339Nano
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_media_game_id ON game_media(game_id, kind, position);
+CREATE INDEX IF NOT EXISTS idx_game_media_blob_key ON game_media(blob_key);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_game_media_cover ON game_media(game_id) WHERE kind = 'cover';
+
+CREATE TABLE IF NOT EXISTS game_media_thumbnails (
+  media_id TEXT NOT NULL,
+  size TEXT NOT NULL,
+  blob_key TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  bytes INTEGER NOT NULL,
+  width INTEGER NOT NULL,
+  height INTEGER NOT NULL,
+  PRIMARY KEY (media_id, size),
+  FOREIGN KEY (media_id) REFERENCES game_media(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_media_thumbnails_blob_key ON game_media_thumbnails(blob_key);
+
+-- Unity builds of games. The archive lives in the blob store under sha256;
+-- at most one build per game and platform is current (served to the launcher).
+CREATE TABLE IF NOT EXISTS game_builds (
+  id TEXT PRIMARY KEY,
+  game_id TEXT NOT NULL,
+  version TEXT NOT NULL,
+  platform TEXT NOT NULL,
+  entrypoint TEXT NOT NULL, -- executable path inside the archive
+  archive_format TEXT NOT NULL CHECK (archive_format IN ('zip', 'tar.gz')),
+  sha256 TEXT NOT NULL,
+  size INTEGER NOT NULL,
+  release_notes TEXT NOT NULL DEFAULT '',
+  uploaded_by TEXT,
+  is_current INTEGER NOT NULL DEFAULT 0,
+  created_at TEXT NOT NULL, -- RFC3339 with fixed precision (see sortableTime in query.go)
+  UNIQUE (game_id, platform, version),
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_builds_game_id ON game_builds(game_id, platform, created_at);
+CREATE INDEX IF NOT EXISTS idx_game_builds_sha256 ON game_builds(sha256);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_game_builds_current ON game_builds(game_id, platform) WHERE is_current = 1;
+
+-- Files inside a build archive with their hashes (source of the signed manifest).
+CREATE TABLE IF NOT EXISTS game_build_files (
+  build_id TEXT NOT NULL,
+  path TEXT NOT NULL,
+  size INTEGER NOT NULL,
+  sha256 TEXT NOT NULL,
+  PRIMARY KEY (build_id, path),
+  FOREIGN KEY (build_id) REFERENCES game_builds(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- Typed relations between games: game_id is a sequel, DLC or remaster of
+-- related_id. A game has at most one relation of each type, the relation
+-- graph is kept acyclic by the service.
+CREATE TABLE IF NOT EXISTS game_relations (
+  game_id TEXT NOT NULL,
+  related_id TEXT NOT NULL,
+  type TEXT NOT NULL CHECK (type IN ('sequel', 'dlc', 'remaster')),
+  created_at TEXT NOT NULL, -- RFC3339Nano
+  PRIMARY KEY (game_id, type),
+  CHECK (game_id <> related_id),
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY (related_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_relations_related_id ON game_relations(related_id);
+
+-- Game series. A game belongs to at most one series, position orders the
+-- games inside it (starting with 1).
+CREATE TABLE IF NOT EXISTS series (
+  id TEXT PRIMARY KEY,
+  title TEXT NOT NULL,
+  created_at TEXT NOT NULL -- RFC3339Nano
+);
+
+CREATE INDEX IF NOT EXISTS idx_series_title ON series(title);
+
+CREATE TABLE IF NOT EXISTS series_games (
+  series_id TEXT NOT NULL,
+  game_id TEXT NOT NULL UNIQUE,
+  position INTEGER NOT NULL CHECK (position > 0),
+  PRIMARY KEY (series_id, position),
+  FOREIGN KEY (series_id) REFERENCES series(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- Precomputed item-item similarity of games (model.ComputeSimilarities):
+-- genre overlap plus co-ratings, rebuilt as a whole by a background job.
+-- Only the top neighbours of every game are kept.
+CREATE TABLE IF NOT EXISTS game_similarities (
+  game_id TEXT NOT NULL,
+  similar_id TEXT NOT NULL,
+  score REAL NOT NULL,
+  genre_score REAL NOT NULL,
+  rating_score REAL NOT NULL,
+  co_ratings INTEGER NOT NULL,
+  computed_at TEXT NOT NULL, -- RFC3339Nano, the same for the whole rebuild
+  PRIMARY KEY (game_id, similar_id),
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY (similar_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_similarities_score ON game_similarities(game_id, score DESC);
+CREATE INDEX IF NOT EXISTS idx_game_similarities_similar_id ON game_similarities(similar_id);
+
+-- Tasks inside games: instructions for the player, launch parameters passed
+-- to the game (JSON object of strings) and pass criteria. NULL min_score and
+-- zero max_duration_ms mean "no limit". position orders the tasks of a game.
+CREATE TABLE IF NOT EXISTS tasks (
+  id TEXT PRIMARY KEY,
+  game_id TEXT NOT NULL,
+  title TEXT NOT NULL,
+  instructions TEXT NOT NULL DEFAULT '',
+  difficulty TEXT NOT NULL CHECK (difficulty IN ('easy', 'medium', 'hard')),
+  launch_params TEXT NOT NULL DEFAULT '{}',
+  min_score INTEGER,
+  max_duration_ms INTEGER NOT NULL DEFAULT 0 CHECK (max_duration_ms >= 0),
+  require_success INTEGER NOT NULL DEFAULT 0,
+  position INTEGER NOT NULL CHECK (position > 0),
+  created_at TEXT NOT NULL, -- RFC3339Nano
+  updated_at TEXT NOT NULL, -- RFC3339Nano
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_game_position ON tasks(game_id, position);
+
+-- Attempts to play a game or a task. The desktop app launches the game with
+-- a one-time launch token, only its SHA-256 (launch_token_hash) is stored and
+-- it is cleared once the game presents it. In exchange the game gets a result
+-- token (result_token_hash, valid until result_expires_at) to submit the result
+-- with. A deleted task leaves its attempts in place (task_id becomes NULL).
+-- The result token columns and their index idx_attempts_result_token_hash are
+-- added by migrate.go for attempts created before them
+CREATE TABLE IF NOT EXISTS attempts (
+  id TEXT PRIMARY KEY,
+  user_id TEXT NOT NULL,
+  game_id TEXT NOT NULL,
+  task_id TEXT,
+  mode TEXT NOT NULL CHECK (mode IN ('free', 'task')),
+  seed INTEGER NOT NULL,
+  status TEXT NOT NULL CHECK (status IN ('started', 'running', 'completed', 'failed', 'abandoned')),
+  launch_token_hash TEXT UNIQUE,
+  launch_expires_at TEXT NOT NULL, -- sortableTime
+  started_at TEXT NOT NULL, -- sortableTime
+  running_at TEXT, -- sortableTime
+  finished_at TEXT, -- sortableTime
+  result_token_hash TEXT,
+  result_expires_at TEXT, -- sortableTime
+  FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_attempts_user_game_status ON attempts(user_id, game_id, status);
+CREATE INDEX IF NOT EXISTS idx_attempts_game_id ON attempts(game_id);
+CREATE INDEX IF NOT EXISTS idx_attempts_task_id ON attempts(task_id);
+
+-- Results submitted by games, at most one per attempt. details is a JSON
+-- object, passed tells whether the attempt counts (task pass criteria or,
+-- in free mode, the success flag). user_id, game_id and task_id repeat the
+-- attempt so stats and leaderboards need no join.
+CREATE TABLE IF NOT EXISTS play_results (
+  id TEXT PRIMARY KEY,
+  attempt_id TEXT NOT NULL UNIQUE,
+  user_id TEXT NOT NULL,
+  game_id TEXT NOT NULL,
+  task_id TEXT,
+  score INTEGER NOT NULL,
+  duration_ms INTEGER NOT NULL CHECK (duration_ms >= 0),
+  success INTEGER NOT NULL,
+  passed INTEGER NOT NULL,
+  details TEXT NOT NULL DEFAULT '{}',
+  submitted_at TEXT NOT NULL, -- sortableTime
+  FOREIGN KEY (attempt_id) REFERENCES attempts(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY (game_id) REFERENCES games(id) ON UPDATE CASCADE ON DELETE CASCADE,
+  FOREIGN KEY (task_id) REFERENCES tasks(id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_play_results_user_game ON play_results(user_id, game_id);
+-- Leaderboards pick the best result of every user in a game: the index keeps
+-- them grouped by user in ranking order and covers the window and task filters.
+CREATE INDEX IF NOT EXISTS idx_play_results_leaderboard
+  ON play_results(game_id, user_id, score DESC, duration_ms, submitted_at, task_id);
+CREATE INDEX IF NOT EXISTS idx_play_results_task_id ON play_results(task_id);
+
+-- Nonces of signed result requests, remembered per attempt so a captured
+-- request cannot be replayed.
+CREATE TABLE IF NOT EXISTS result_nonces (
+  attempt_id TEXT NOT NULL,
+  nonce TEXT NOT NULL,
+  used_at TEXT NOT NULL, -- sortableTime
+  PRIMARY KEY (attempt_id, nonce),
+  FOREIGN KEY (attempt_id) REFERENCES attempts(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+-- Change history of games and genres. Rows are append-only: number is the
+-- per-entity sequence, snapshot is the JSON state after the action.
+-- No foreign keys, so history outlives purged entities and users.
+CREATE TABLE IF NOT EXISTS revisions (
+  id TEXT PRIMARY KEY,
+  entity_type TEXT NOT NULL CHECK (entity_type IN ('game', 'genre')),
+  entity_id TEXT NOT NULL,
+  number INTEGER NOT NULL CHECK (number > 0),
+  action TEXT NOT NULL,
+  snapshot TEXT NOT NULL,
+  actor_id TEXT,
+  reverted_from INTEGER,
+  created_at TEXT NOT NULL, -- RFC3339Nano
+  UNIQUE (entity_type, entity_id, number)
+);
+
+-- Daily creation quota counts an admin's create revisions
+CREATE INDEX IF NOT EXISTS idx_revisions_actor ON revisions(actor_id, entity_type, action);
+
+CREATE TRIGGER IF NOT EXISTS revisions_no_update BEFORE UPDATE ON revisions BEGIN
+  SELECT RAISE(ABORT, 'revisions are immutable');
+END;
+
+CREATE TRIGGER IF NOT EXISTS revisions_no_delete BEFORE DELETE ON revisions BEGIN
+  SELECT RAISE(ABORT, 'revisions are immutable');
+END;
+
+-- Full-text index over game title/description (bm25 ranking, snippets).
+-- Kept in sync with games by triggers below.
+CREATE VIRTUAL TABLE IF NOT EXISTS games_fts USING fts5(
+  game_id UNINDEXED,
+  title,
+  description,
+  tokenize = 'unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS games_fts_after_insert AFTER INSERT ON games BEGIN
+  INSERT INTO games_fts (game_id, title, description) VALUES (new.id, new.title, new.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS games_fts_after_update AFTER UPDATE OF id, title, description ON games BEGIN
+  DELETE FROM games_fts WHERE game_id = old.id;
+  INSERT INTO games_fts (game_id, title, description) VALUES (new.id, new.title, new.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS games_fts_after_delete AFTER DELETE ON games BEGIN
+  DELETE FROM games_fts WHERE game_id = old.id;
+END;
+
+-- Backfill games created before the index existed
+INSERT INTO games_fts (game_id, title, description)
+SELECT id, title, description FROM games
+WHERE id NOT IN (SELECT game_id FROM games_fts);
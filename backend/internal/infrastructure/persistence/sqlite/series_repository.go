@@ -0,0 +1,300 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.SeriesRepository = (*SeriesRepository)(nil)
+
+type SeriesRepository struct {
+	db *sql.DB
+}
+
+func NewSeriesRepository(db *sql.DB) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+func (r *SeriesRepository) Create(ctx context.Context, series *model.Series) (*model.Series, error) {
+	if series == nil {
+		return nil, errors.New("series cannot be nil")
+	}
+	if series.ID == uuid.Nil {
+		series.ID = uuid.New()
+	}
+	if series.CreatedAt.IsZero() {
+		series.CreatedAt = time.Now().UTC()
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO series (id, title, created_at) VALUES (?, ?, ?)`,
+		series.ID.String(),
+		series.Title,
+		series.CreatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert series: %w", err)
+	}
+	if err := replaceSeriesGames(ctx, tx, series); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return series, nil
+}
+
+func (r *SeriesRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Series, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("series ID cannot be empty")
+	}
+
+	series := &model.Series{}
+	var idStr, createdAtStr string
+	err := r.db.QueryRowContext(ctx, `SELECT id, title, created_at FROM series WHERE id = ?`, id.String()).
+		Scan(&idStr, &series.Title, &createdAtStr)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrSeriesNotFound
+		}
+		return nil, fmt.Errorf("select series: %w", err)
+	}
+	if err := parseSeries(series, idStr, createdAtStr); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadGames(ctx, []*model.Series{series}); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func (r *SeriesRepository) FindAll(ctx context.Context, limit, offset int) ([]*model.Series, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM series`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count series: %w", err)
+	}
+
+	query, args := appendPage(`SELECT id, title, created_at FROM series ORDER BY title, id`, nil, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("select series: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.Series{}
+	for rows.Next() {
+		series := &model.Series{}
+		var idStr, createdAtStr string
+		if err := rows.Scan(&idStr, &series.Title, &createdAtStr); err != nil {
+			return nil, 0, fmt.Errorf("scan series: %w", err)
+		}
+		if err := parseSeries(series, idStr, createdAtStr); err != nil {
+			return nil, 0, err
+		}
+		res = append(res, series)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate series: %w", err)
+	}
+
+	if err := r.loadGames(ctx, res); err != nil {
+		return nil, 0, err
+	}
+	return res, total, nil
+}
+
+func (r *SeriesRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) (*model.Series, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	var seriesIDStr string
+	err := r.db.QueryRowContext(ctx, `SELECT series_id FROM series_games WHERE game_id = ?`, gameID.String()).Scan(&seriesIDStr)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrSeriesNotFound
+		}
+		return nil, fmt.Errorf("select game series: %w", err)
+	}
+	seriesID, err := uuid.Parse(seriesIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("parse series_id from db: %w", err)
+	}
+	return r.FindByID(ctx, seriesID)
+}
+
+func (r *SeriesRepository) Update(ctx context.Context, series *model.Series) (*model.Series, error) {
+	if series == nil {
+		return nil, errors.New("series cannot be nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `UPDATE series SET title = ? WHERE id = ?`, series.Title, series.ID.String())
+	if err != nil {
+		return nil, fmt.Errorf("update series: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return nil, repository.ErrSeriesNotFound
+	}
+	if err := replaceSeriesGames(ctx, tx, series); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return series, nil
+}
+
+func (r *SeriesRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("series ID cannot be empty")
+	}
+
+	res, err := r.db.ExecContext(ctx, `DELETE FROM series WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("delete series: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrSeriesNotFound
+	}
+	return nil
+}
+
+// replaceSeriesGames записывает состав серии заново. Все игры должны быть активными
+// и не входить в другие серии; игры из корзины, прежде входившие в серию, из нее выходят.
+func replaceSeriesGames(ctx context.Context, tx *sql.Tx, series *model.Series) error {
+	if len(series.GameIDs) > 0 {
+		args := make([]any, 0, len(series.GameIDs)+1)
+		for _, id := range series.GameIDs {
+			args = append(args, id.String())
+		}
+
+		var live int
+		err := tx.QueryRowContext(
+			ctx,
+			`SELECT COUNT(*) FROM games WHERE id IN (`+placeholders(len(series.GameIDs))+`) AND deleted_at IS NULL`,
+			args...,
+		).Scan(&live)
+		if err != nil {
+			return fmt.Errorf("check series games: %w", err)
+		}
+		if live != len(series.GameIDs) {
+			return repository.ErrNotFound
+		}
+
+		var taken int
+		err = tx.QueryRowContext(
+			ctx,
+			`SELECT COUNT(*) FROM series_games WHERE game_id IN (`+placeholders(len(series.GameIDs))+`) AND series_id <> ?`,
+			append(args, series.ID.String())...,
+		).Scan(&taken)
+		if err != nil {
+			return fmt.Errorf("check series membership: %w", err)
+		}
+		if taken > 0 {
+			return repository.ErrGameInAnotherSeries
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM series_games WHERE series_id = ?`, series.ID.String()); err != nil {
+		return fmt.Errorf("clear series games: %w", err)
+	}
+	for i, id := range series.GameIDs {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO series_games (series_id, game_id, position) VALUES (?, ?, ?)`,
+			series.ID.String(), id.String(), i+1,
+		)
+		if err != nil {
+			return fmt.Errorf("insert series game: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadGames заполняет GameIDs серий в порядке позиций.
+func (r *SeriesRepository) loadGames(ctx context.Context, series []*model.Series) error {
+	if len(series) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*model.Series, len(series))
+	args := make([]any, 0, len(series))
+	for _, s := range series {
+		s.GameIDs = []uuid.UUID{}
+		byID[s.ID.String()] = s
+		args = append(args, s.ID.String())
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT series_id, game_id FROM series_games WHERE series_id IN (`+placeholders(len(series))+`)
+		ORDER BY series_id, position`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("select series games: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var seriesIDStr, gameIDStr string
+		if err := rows.Scan(&seriesIDStr, &gameIDStr); err != nil {
+			return fmt.Errorf("scan series game: %w", err)
+		}
+		gameID, err := uuid.Parse(gameIDStr)
+		if err != nil {
+			return fmt.Errorf("parse game_id from db: %w", err)
+		}
+		if s, ok := byID[seriesIDStr]; ok {
+			s.GameIDs = append(s.GameIDs, gameID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate series games: %w", err)
+	}
+	return nil
+}
+
+func parseSeries(series *model.Series, idStr, createdAtStr string) error {
+	var err error
+	if series.ID, err = uuid.Parse(idStr); err != nil {
+		return fmt.Errorf("parse series id from db: %w", err)
+	}
+	if series.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAtStr); err != nil {
+		return fmt.Errorf("parse created_at from db: %w", err)
+	}
+	return nil
+}
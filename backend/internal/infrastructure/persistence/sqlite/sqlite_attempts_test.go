@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteAttemptRepository(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	games := NewGameRepository(db.SQL)
+	game := &model.Game{ID: uuid.New(), Title: "Tetris", ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), GenreID: genre.ID}
+	if _, err := games.Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+	user := &model.User{ID: uuid.New(), Username: "bob", Password: "pass", UserRole: specifictype.RoleUser}
+	if _, err := NewUserRepository(db.SQL).Create(ctx, user); err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+	tasks := NewTaskRepository(db.SQL)
+	task, _ := model.NewTaskWithValidate(game.ID, "Marathon", "", "", nil, model.TaskPassCriteria{}, 0)
+	if _, err := tasks.Create(ctx, task); err != nil {
+		t.Fatalf("Create task: %v", err)
+	}
+
+	repo := NewAttemptRepository(db.SQL)
+	free, _ := model.NewAttempt(user.ID, game.ID, uuid.Nil, 42, "hash-free", time.Now().Add(time.Minute))
+	withTask, _ := model.NewAttempt(user.ID, game.ID, task.ID, 7, "hash-task", time.Now().Add(time.Minute))
+	for _, attempt := range []*model.Attempt{free, withTask} {
+		if _, err := repo.Create(ctx, attempt); err != nil {
+			t.Fatalf("Create attempt: %v", err)
+		}
+	}
+
+	found, err := repo.FindByLaunchTokenHash(ctx, "hash-task")
+	if err != nil || found.ID != withTask.ID || found.TaskID != task.ID || found.Mode != model.AttemptModeTask || found.Seed != 7 {
+		t.Fatalf("FindByLaunchTokenHash: %+v, %v", found, err)
+	}
+
+	// Токен запуска предъявляется один раз: второй перевод из started не проходит, хэш стерт
+	if err := found.Run(time.Now(), "result-task", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if _, err := repo.Update(ctx, found, model.AttemptStarted); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, err := repo.Update(ctx, found, model.AttemptStarted); !errors.Is(err, repository.ErrAttemptStatusChanged) {
+		t.Fatalf("expected ErrAttemptStatusChanged, got %v", err)
+	}
+	if _, err := repo.FindByLaunchTokenHash(ctx, "hash-task"); !errors.Is(err, repository.ErrAttemptNotFound) {
+		t.Fatalf("expected ErrAttemptNotFound for used token, got %v", err)
+	}
+	got, err := repo.FindByID(ctx, withTask.ID)
+	if err != nil || got.Status != model.AttemptRunning || got.RunningAt == nil || got.LaunchTokenHash != "" ||
+		got.ResultExpiresAt == nil {
+		t.Fatalf("FindByID after run: %+v, %v", got, err)
+	}
+	if byResult, err := repo.FindByResultTokenHash(ctx, "result-task"); err != nil || byResult.ID != withTask.ID {
+		t.Fatalf("FindByResultTokenHash: %+v, %v", byResult, err)
+	}
+
+	open, err := repo.FindOpen(ctx, user.ID, game.ID)
+	if err != nil || len(open) != 2 {
+		t.Fatalf("FindOpen: %v, %v", open, err)
+	}
+	if err := free.Finish(model.AttemptAbandoned, time.Now()); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := repo.Update(ctx, free, model.AttemptStarted); err != nil {
+		t.Fatalf("Update abandoned: %v", err)
+	}
+	if open, err := repo.FindOpen(ctx, user.ID, game.ID); err != nil || len(open) != 1 || open[0].ID != withTask.ID {
+		t.Fatalf("FindOpen after abandon: %v, %v", open, err)
+	}
+
+	// Удаление задачи оставляет попытку без задачи, очистка корзины удаляет попытки игры
+	if err := tasks.Delete(ctx, task.ID); err != nil {
+		t.Fatalf("Delete task: %v", err)
+	}
+	if got, err := repo.FindByID(ctx, withTask.ID); err != nil || got.TaskID != uuid.Nil {
+		t.Fatalf("attempt of deleted task: %+v, %v", got, err)
+	}
+	if err := games.Delete(ctx, game.ID, game.Version); err != nil {
+		t.Fatalf("Delete game: %v", err)
+	}
+	late, _ := model.NewAttempt(user.ID, game.ID, uuid.Nil, 1, "hash-late", time.Now().Add(time.Minute))
+	if _, err := repo.Create(ctx, late); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for trashed game, got %v", err)
+	}
+	if _, err := games.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, free.ID); !errors.Is(err, repository.ErrAttemptNotFound) {
+		t.Fatalf("expected ErrAttemptNotFound after purge, got %v", err)
+	}
+}
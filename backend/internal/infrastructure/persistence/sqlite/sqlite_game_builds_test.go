@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteGameBuildRepository_CurrentPerPlatform(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	game := &model.Game{ID: uuid.New(), Title: "Tetris", Description: "d", ReleaseDate: time.Now().UTC(), GenreID: genre.ID}
+	gameRepo := NewGameRepository(db.SQL)
+	if _, err := gameRepo.Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+
+	repo := NewGameBuildRepository(db.SQL)
+	create := func(version, platform, key string, current bool) (*model.GameBuild, error) {
+		t.Helper()
+		build, err := model.NewGameBuildWithValidate(game.ID, version, platform, "Game.x86_64", "")
+		if err != nil {
+			t.Fatalf("NewGameBuildWithValidate: %v", err)
+		}
+		build.ArchiveFormat = model.ArchiveFormatZip
+		build.SHA256 = key
+		build.Size = 10
+		build.IsCurrent = current
+		build.Files = []model.BuildFile{
+			{Path: "Game_Data/level0", Size: 5, SHA256: key + "-level0"},
+			{Path: "Game.x86_64", Size: 5, SHA256: key + "-exe"},
+		}
+		return repo.Create(ctx, build)
+	}
+
+	first, err := create("1.0", model.BuildPlatformLinux, "a", true)
+	if err != nil {
+		t.Fatalf("Create build: %v", err)
+	}
+	second, err := create("1.1", model.BuildPlatformLinux, "b", true)
+	if err != nil {
+		t.Fatalf("Create build: %v", err)
+	}
+	windows, err := create("1.1", model.BuildPlatformWindows, "c", true)
+	if err != nil {
+		t.Fatalf("Create build: %v", err)
+	}
+	if _, err := create("1.1", model.BuildPlatformLinux, "d", false); !errors.Is(err, repository.ErrBuildAlreadyExists) {
+		t.Fatalf("expected ErrBuildAlreadyExists, got %v", err)
+	}
+
+	files, err := repo.FindFiles(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("FindFiles: %v", err)
+	}
+	if len(files) != 2 || files[0].Path != "Game.x86_64" || files[0].SHA256 != "a-exe" {
+		t.Fatalf("unexpected build files: %+v", files)
+	}
+	if _, err := repo.FindFiles(ctx, uuid.New()); !errors.Is(err, repository.ErrBuildNotFound) {
+		t.Fatalf("expected ErrBuildNotFound, got %v", err)
+	}
+
+	current, err := repo.FindCurrent(ctx, game.ID, model.BuildPlatformLinux)
+	if err != nil || current.ID != second.ID {
+		t.Fatalf("newer current build must replace the previous one: %+v, %v", current, err)
+	}
+	if current, err := repo.FindCurrent(ctx, game.ID, model.BuildPlatformWindows); err != nil || current.ID != windows.ID {
+		t.Fatalf("current builds of other platforms must stay: %+v, %v", current, err)
+	}
+	if _, err := repo.FindCurrent(ctx, game.ID, model.BuildPlatformMacOS); !errors.Is(err, repository.ErrBuildNotFound) {
+		t.Fatalf("expected ErrBuildNotFound, got %v", err)
+	}
+
+	if _, err := repo.SetCurrent(ctx, first.ID); err != nil {
+		t.Fatalf("SetCurrent: %v", err)
+	}
+	builds, err := repo.FindByGameID(ctx, game.ID, model.BuildPlatformLinux)
+	if err != nil {
+		t.Fatalf("FindByGameID: %v", err)
+	}
+	if len(builds) != 2 || builds[0].ID != second.ID || builds[0].IsCurrent || !builds[1].IsCurrent {
+		t.Fatalf("unexpected builds after SetCurrent: %+v", builds)
+	}
+	if all, _ := repo.FindByGameID(ctx, game.ID, ""); len(all) != 3 {
+		t.Fatalf("expected builds of all platforms, got %d", len(all))
+	}
+
+	if err := gameRepo.Delete(ctx, game.ID, game.Version); err != nil {
+		t.Fatalf("Delete game: %v", err)
+	}
+	purged, err := gameRepo.Purge(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if len(purged.BuildKeys) != 3 || len(purged.MediaKeys) != 0 {
+		t.Fatalf("unexpected purged keys: %+v", purged)
+	}
+	if inUse, _ := repo.BlobInUse(ctx, "a"); inUse {
+		t.Fatalf("archive of purged build must not be in use")
+	}
+}
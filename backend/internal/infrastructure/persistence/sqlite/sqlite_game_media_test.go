@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteGameMediaRepository_OrderCoverAndCascade(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	game := &model.Game{ID: uuid.New(), Title: "Tetris", Description: "d", ReleaseDate: time.Now().UTC(), GenreID: genre.ID}
+	gameRepo := NewGameRepository(db.SQL)
+	if _, err := gameRepo.Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+
+	repo := NewGameMediaRepository(db.SQL)
+	create := func(kind, key string) *model.GameMedia {
+		t.Helper()
+		media, err := model.NewGameMediaWithValidate(game.ID, kind)
+		if err != nil {
+			t.Fatalf("NewGameMediaWithValidate: %v", err)
+		}
+		media.BlobKey = key
+		media.ContentType = "image/png"
+		media.Width, media.Height = 10, 10
+		media.Thumbnails = []model.MediaThumbnail{{Size: "small", BlobKey: key + "-small", ContentType: "image/png", Width: 5, Height: 5}}
+		if _, err := repo.Create(ctx, media); err != nil {
+			t.Fatalf("Create media: %v", err)
+		}
+		return media
+	}
+
+	first := create(model.MediaKindScreenshot, "a")
+	second := create(model.MediaKindScreenshot, "b")
+	if first.Position != 1 || second.Position != 2 {
+		t.Fatalf("unexpected positions %d, %d", first.Position, second.Position)
+	}
+	create(model.MediaKindCover, "c")
+	cover := create(model.MediaKindCover, "d")
+
+	items, err := repo.FindByGameID(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("FindByGameID: %v", err)
+	}
+	if len(items) != 3 || items[0].ID != cover.ID || items[1].ID != first.ID || len(items[0].Thumbnails) != 1 {
+		t.Fatalf("cover must replace the previous one and come first: %+v", items)
+	}
+	if inUse, _ := repo.BlobInUse(ctx, "c-small"); inUse {
+		t.Fatalf("thumbnail of the replaced cover must not be in use")
+	}
+	if inUse, _ := repo.BlobInUse(ctx, "d-small"); !inUse {
+		t.Fatalf("thumbnail of the current cover must be in use")
+	}
+
+	if err := repo.Reorder(ctx, game.ID, []uuid.UUID{second.ID, second.ID}); !errors.Is(err, repository.ErrMediaOrderMismatch) {
+		t.Fatalf("expected ErrMediaOrderMismatch for duplicates, got %v", err)
+	}
+	if err := repo.Reorder(ctx, game.ID, []uuid.UUID{second.ID, cover.ID}); !errors.Is(err, repository.ErrMediaOrderMismatch) {
+		t.Fatalf("expected ErrMediaOrderMismatch for cover, got %v", err)
+	}
+	if err := repo.Reorder(ctx, game.ID, []uuid.UUID{second.ID, first.ID}); err != nil {
+		t.Fatalf("Reorder: %v", err)
+	}
+	items, _ = repo.FindByGameID(ctx, game.ID)
+	if items[1].ID != second.ID || items[2].ID != first.ID {
+		t.Fatalf("unexpected order after Reorder: %+v", items)
+	}
+
+	if err := gameRepo.Delete(ctx, game.ID, game.Version); err != nil {
+		t.Fatalf("Delete game: %v", err)
+	}
+	purged, err := gameRepo.Purge(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	// Ключи нужны, чтобы удалить файлы из хранилища; у замененной обложки их уже нет
+	slices.Sort(purged.MediaKeys)
+	if !slices.Equal(purged.MediaKeys, []string{"a", "a-small", "b", "b-small", "d", "d-small"}) {
+		t.Fatalf("unexpected purged media keys: %v", purged.MediaKeys)
+	}
+	if _, err := repo.FindByID(ctx, first.ID); !errors.Is(err, repository.ErrMediaNotFound) {
+		t.Fatalf("media must be purged with the game, got %v", err)
+	}
+	if inUse, _ := repo.BlobInUse(ctx, "a"); inUse {
+		t.Fatalf("blob of purged media must not be in use")
+	}
+}
@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteGameRelationsAndSeries(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "RPG"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	games := NewGameRepository(db.SQL)
+	create := func(title string) *model.Game {
+		game := &model.Game{ID: uuid.New(), Title: title, ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), GenreID: genre.ID}
+		if _, err := games.Create(ctx, game); err != nil {
+			t.Fatalf("Create %s: %v", title, err)
+		}
+		return game
+	}
+	first, second, third, dlc := create("Witcher"), create("Witcher 2"), create("Witcher 3"), create("Blood and Wine")
+
+	relations := NewGameRelationRepository(db.SQL)
+	set := func(game, related *model.Game, relationType string) {
+		relation, err := model.NewGameRelationWithValidate(game.ID, related.ID, relationType)
+		if err != nil {
+			t.Fatalf("NewGameRelationWithValidate: %v", err)
+		}
+		if _, err := relations.Set(ctx, relation); err != nil {
+			t.Fatalf("Set %s -> %s: %v", game.Title, related.Title, err)
+		}
+	}
+	set(second, first, model.RelationSequel)
+	set(third, first, model.RelationSequel)
+	set(dlc, third, model.RelationDLC)
+
+	// Вторая связь того же типа заменяет первую
+	set(third, second, model.RelationSequel)
+	found, err := relations.FindByGameID(ctx, third.ID)
+	if err != nil || len(found) != 2 {
+		t.Fatalf("FindByGameID: %v, %v", found, err)
+	}
+	for _, relation := range found {
+		if relation.Type == model.RelationSequel && relation.RelatedID != second.ID {
+			t.Fatalf("sequel relation not replaced: %+v", relation)
+		}
+	}
+
+	// Из дополнения достижима вся цепочка, из первой части - ничего
+	reachable, err := relations.FindReachable(ctx, dlc.ID)
+	if err != nil || len(reachable) != 3 {
+		t.Fatalf("FindReachable: %v, %v", reachable, err)
+	}
+	if found, err := relations.FindReachable(ctx, first.ID); err != nil || len(found) != 0 {
+		t.Fatalf("FindReachable from first: %v, %v", found, err)
+	}
+	// Цикл через сохраненные связи обнаруживается
+	cycle, _ := model.NewGameRelationWithValidate(first.ID, dlc.ID, model.RelationRemaster)
+	if err := model.CheckRelationCycle(reachable, cycle); !errors.Is(err, model.ErrRelationCycle) {
+		t.Fatalf("expected ErrRelationCycle, got %v", err)
+	}
+
+	// Связи игры из корзины скрыты, но учитываются при проверке циклов; очистка корзины их удаляет
+	if err := games.Delete(ctx, dlc.ID, dlc.Version); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if found, err := relations.FindByGameID(ctx, third.ID); err != nil || len(found) != 1 {
+		t.Fatalf("FindByGameID after delete: %v, %v", found, err)
+	}
+	if found, err := relations.FindReachable(ctx, dlc.ID); err != nil || len(found) != 3 {
+		t.Fatalf("FindReachable after delete: %v, %v", found, err)
+	}
+	relation, _ := model.NewGameRelationWithValidate(first.ID, dlc.ID, model.RelationRemaster)
+	if _, err := relations.Set(ctx, relation); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for trashed game, got %v", err)
+	}
+	if _, err := games.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if found, err := relations.FindReachable(ctx, third.ID); err != nil || len(found) != 2 {
+		t.Fatalf("FindReachable after purge: %v, %v", found, err)
+	}
+
+	if err := relations.Delete(ctx, third.ID, model.RelationSequel); err != nil {
+		t.Fatalf("Delete relation: %v", err)
+	}
+	if err := relations.Delete(ctx, third.ID, model.RelationSequel); !errors.Is(err, repository.ErrRelationNotFound) {
+		t.Fatalf("expected ErrRelationNotFound, got %v", err)
+	}
+
+	// Серии хранят порядок игр, игра входит только в одну серию
+	seriesRepo := NewSeriesRepository(db.SQL)
+	series, _ := model.NewSeriesWithValidate("The Witcher", []uuid.UUID{third.ID, first.ID, second.ID})
+	if _, err := seriesRepo.Create(ctx, series); err != nil {
+		t.Fatalf("Create series: %v", err)
+	}
+	loaded, err := seriesRepo.FindByGameID(ctx, first.ID)
+	if err != nil || loaded.ID != series.ID || len(loaded.GameIDs) != 3 || loaded.GameIDs[0] != third.ID || loaded.GameIDs[2] != second.ID {
+		t.Fatalf("FindByGameID: %+v, %v", loaded, err)
+	}
+
+	other, _ := model.NewSeriesWithValidate("Other", []uuid.UUID{second.ID})
+	if _, err := seriesRepo.Create(ctx, other); !errors.Is(err, repository.ErrGameInAnotherSeries) {
+		t.Fatalf("expected ErrGameInAnotherSeries, got %v", err)
+	}
+	missing, _ := model.NewSeriesWithValidate("Missing", []uuid.UUID{dlc.ID})
+	if _, err := seriesRepo.Create(ctx, missing); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := loaded.UpdateWithValidate("Witcher Saga", []uuid.UUID{first.ID, second.ID, third.ID}); err != nil {
+		t.Fatalf("UpdateWithValidate: %v", err)
+	}
+	if _, err := seriesRepo.Update(ctx, loaded); err != nil {
+		t.Fatalf("Update series: %v", err)
+	}
+	list, total, err := seriesRepo.FindAll(ctx, 10, 0)
+	if err != nil || total != 1 || list[0].Title != "Witcher Saga" || list[0].GameIDs[0] != first.ID {
+		t.Fatalf("FindAll series: %+v, %d, %v", list, total, err)
+	}
+
+	if err := seriesRepo.Delete(ctx, series.ID); err != nil {
+		t.Fatalf("Delete series: %v", err)
+	}
+	if _, err := seriesRepo.FindByGameID(ctx, first.ID); !errors.Is(err, repository.ErrSeriesNotFound) {
+		t.Fatalf("expected ErrSeriesNotFound, got %v", err)
+	}
+}
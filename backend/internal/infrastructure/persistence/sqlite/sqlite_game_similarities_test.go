@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteGameSimilarities(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genres := NewGenreRepository(db.SQL)
+	rpg, puzzle := &model.Genre{ID: uuid.New(), Title: "RPG"}, &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	for _, genre := range []*model.Genre{rpg, puzzle} {
+		if _, err := genres.Create(ctx, genre); err != nil {
+			t.Fatalf("Create genre: %v", err)
+		}
+	}
+	games := NewGameRepository(db.SQL)
+	create := func(title string, genreID uuid.UUID) *model.Game {
+		game := &model.Game{ID: uuid.New(), Title: title, ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), GenreID: genreID}
+		if _, err := games.Create(ctx, game); err != nil {
+			t.Fatalf("Create %s: %v", title, err)
+		}
+		return game
+	}
+	witcher, gothic, tetris := create("Witcher", rpg.ID), create("Gothic", rpg.ID), create("Tetris", puzzle.ID)
+
+	users := NewUserRepository(db.SQL)
+	ratings := NewUserRatingRepository(db.SQL)
+	rate := func(username string, values map[*model.Game]int) {
+		user := &model.User{ID: uuid.New(), Username: username, Password: "pass", UserRole: specifictype.RoleUser}
+		if _, err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Create user: %v", err)
+		}
+		for game, value := range values {
+			if _, err := ratings.Create(ctx, &model.UserRating{UserID: user.ID, GameID: game.ID, Rating: value}); err != nil {
+				t.Fatalf("Create rating: %v", err)
+			}
+		}
+	}
+	rate("bob", map[*model.Game]int{witcher: 5, gothic: 5, tetris: 1})
+	rate("alice", map[*model.Game]int{witcher: 4, gothic: 5, tetris: 2})
+
+	all, err := ratings.FindAll(ctx)
+	if err != nil || len(all) != 6 {
+		t.Fatalf("FindAll ratings: %v, %v", all, err)
+	}
+	allGames, _, err := games.FindByQuery(ctx, repository.GameQuery{})
+	if err != nil {
+		t.Fatalf("FindByQuery: %v", err)
+	}
+
+	// Игры одного жанра, которые пользователи оценили одинаково, похожи сильнее всего
+	items := model.ComputeSimilarities(allGames, all, model.SimilarityParams{GenreWeight: 0.4, Shrinkage: 5, TopK: 10})
+	similarities := NewGameSimilarityRepository(db.SQL)
+	if err := similarities.Replace(ctx, items, time.Now()); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	found, err := similarities.FindByGameIDs(ctx, []uuid.UUID{witcher.ID, tetris.ID})
+	if err != nil {
+		t.Fatalf("FindByGameIDs: %v", err)
+	}
+	if len(found[witcher.ID]) == 0 || found[witcher.ID][0].SimilarID != gothic.ID {
+		t.Fatalf("gothic must be the most similar to witcher: %+v", found[witcher.ID])
+	}
+	if got := found[witcher.ID][0]; got.GenreScore != 1 || got.CoRatings != 2 {
+		t.Fatalf("unexpected similarity: %+v", got)
+	}
+	for _, item := range found[tetris.ID] {
+		if item.RatingScore != 0 || item.GenreScore != 0 {
+			t.Fatalf("tetris must not be similar to rpg games: %+v", item)
+		}
+	}
+
+	// Повторный пересчет заменяет таблицу целиком
+	if err := similarities.Replace(ctx, items[:0], time.Now()); err != nil {
+		t.Fatalf("Replace empty: %v", err)
+	}
+	if found, err := similarities.FindByGameIDs(ctx, []uuid.UUID{witcher.ID}); err != nil || len(found[witcher.ID]) != 0 {
+		t.Fatalf("FindByGameIDs after empty replace: %v, %v", found, err)
+	}
+
+	// Игры из корзины не показываются как похожие, очистка корзины удаляет их пары
+	if err := similarities.Replace(ctx, items, time.Now()); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	if err := games.Delete(ctx, gothic.ID, gothic.Version); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if found, err := similarities.FindByGameIDs(ctx, []uuid.UUID{witcher.ID}); err != nil || len(found[witcher.ID]) != 0 {
+		t.Fatalf("FindByGameIDs after delete: %v, %v", found, err)
+	}
+	if _, err := games.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	var count int
+	if err := db.SQL.QueryRowContext(ctx, `SELECT COUNT(*) FROM game_similarities WHERE game_id = ? OR similar_id = ?`,
+		gothic.ID.String(), gothic.ID.String()).Scan(&count); err != nil || count != 0 {
+		t.Fatalf("similarities of purged game: %d, %v", count, err)
+	}
+}
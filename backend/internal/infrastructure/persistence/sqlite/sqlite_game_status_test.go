@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteGameRepository_PublishDue(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	repo := NewGameRepository(db.SQL)
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	create := func(title, status string, publishAt *time.Time) *model.Game {
+		game := &model.Game{ID: uuid.New(), Title: title, ReleaseDate: now, GenreID: genre.ID,
+			Status: status, PublishAt: publishAt}
+		if _, err := repo.Create(ctx, game); err != nil {
+			t.Fatalf("Create %s: %v", title, err)
+		}
+		return game
+	}
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+
+	draft := create("Draft", model.GameStatusDraft, nil)
+	due := create("Due", model.GameStatusScheduled, at(-time.Minute))
+	exact := create("Exact", model.GameStatusScheduled, at(0))
+	later := create("Later", model.GameStatusScheduled, at(time.Hour))
+	create("Published", model.GameStatusPublished, nil)
+
+	// Статус и момент публикации читаются обратно
+	found, err := repo.FindByID(ctx, later.ID)
+	if err != nil || found.Status != model.GameStatusScheduled || found.PublishAt == nil || !found.PublishAt.Equal(*later.PublishAt) {
+		t.Fatalf("FindByID: %+v, %v", found, err)
+	}
+
+	published, total, err := repo.FindByQuery(ctx, repository.GameQuery{Status: model.GameStatusPublished})
+	if err != nil || total != 1 || published[0].Title != "Published" {
+		t.Fatalf("FindByQuery published: total=%d err=%v", total, err)
+	}
+
+	next, err := repo.NextPublishAt(ctx)
+	if err != nil || next == nil || !next.Equal(*due.PublishAt) {
+		t.Fatalf("NextPublishAt: %v, %v", next, err)
+	}
+
+	games, err := repo.PublishDue(ctx, now)
+	if err != nil {
+		t.Fatalf("PublishDue: %v", err)
+	}
+	if len(games) != 2 {
+		t.Fatalf("expected 2 due games, got %d", len(games))
+	}
+	for _, game := range games {
+		if game.ID != due.ID && game.ID != exact.ID {
+			t.Fatalf("unexpected game published: %s", game.Title)
+		}
+		if game.Status != model.GameStatusPublished || game.PublishAt != nil || game.Version != 2 {
+			t.Fatalf("unexpected published game: %+v", game)
+		}
+	}
+
+	next, err = repo.NextPublishAt(ctx)
+	if err != nil || next == nil || !next.Equal(*later.PublishAt) {
+		t.Fatalf("NextPublishAt after publish: %v, %v", next, err)
+	}
+
+	// Смена статуса учитывает версию и не трогает остальные поля
+	draft.Status = model.GameStatusPublished
+	if _, err := repo.UpdateStatus(ctx, draft); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	if draft.Version != 2 {
+		t.Fatalf("expected version 2, got %d", draft.Version)
+	}
+	draft.Version = 1
+	if _, err := repo.UpdateStatus(ctx, draft); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+
+	// Удаленная запланированная игра не публикуется
+	if err := repo.Delete(ctx, later.ID, later.Version); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if games, err := repo.PublishDue(ctx, now.Add(2*time.Hour)); err != nil || len(games) != 0 {
+		t.Fatalf("PublishDue deleted: %v, %v", games, err)
+	}
+	if next, err := repo.NextPublishAt(ctx); err != nil || next != nil {
+		t.Fatalf("NextPublishAt with nothing scheduled: %v, %v", next, err)
+	}
+}
+
+func TestApplySchema_LegacyGamesArePublished(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "legacy.db")
+
+	legacy, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	id := uuid.New()
+	for _, stmt := range []string{
+		`CREATE TABLE games (id TEXT PRIMARY KEY, title TEXT NOT NULL, description TEXT NOT NULL, release_date TEXT NOT NULL,
+			deleted_at TEXT, version INTEGER NOT NULL DEFAULT 1)`,
+		`INSERT INTO games (id, title, description, release_date) VALUES ('` + id.String() + `', 'Tetris', 'd', '2024-01-01T00:00:00Z')`,
+	} {
+		if _, err := legacy.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("legacy schema: %v", err)
+		}
+	}
+	_ = legacy.Close()
+
+	db, err := Open(ctx, Config{Path: path})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	game, err := NewGameRepository(db.SQL).FindByID(ctx, id)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if game.Status != model.GameStatusPublished || game.PublishAt != nil {
+		t.Fatalf("legacy game must stay published: %+v", game)
+	}
+}
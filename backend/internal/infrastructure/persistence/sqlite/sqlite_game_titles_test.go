@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteGameRepository_UniqueTitle(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	repo := NewGameRepository(db.SQL)
+	newGame := func(title string) *model.Game {
+		return &model.Game{ID: uuid.New(), Title: title, Description: "d", ReleaseDate: time.Now().UTC(), GenreID: genre.ID}
+	}
+
+	tetris := newGame("Tetris")
+	if _, err := repo.Create(ctx, tetris); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Регистр, пробелы и полноширинные символы не делают название другим
+	for _, title := range []string{"tetris ", "  TETRIS", "ＴＥＴＲＩＳ"} {
+		if _, err := repo.Create(ctx, newGame(title)); !errors.Is(err, repository.ErrDuplicateTitle) {
+			t.Fatalf("Create %q: expected ErrDuplicateTitle, got %v", title, err)
+		}
+	}
+
+	found, err := repo.FindByTitle(ctx, " tEtRiS ")
+	if err != nil || found.ID != tetris.ID {
+		t.Fatalf("FindByTitle: %v, %v", found, err)
+	}
+
+	other := newGame("Tetris  Deluxe")
+	if _, err := repo.Create(ctx, other); err != nil {
+		t.Fatalf("Create other: %v", err)
+	}
+	other.Title = "tetris"
+	if _, err := repo.Update(ctx, other); !errors.Is(err, repository.ErrDuplicateTitle) {
+		t.Fatalf("Update: expected ErrDuplicateTitle, got %v", err)
+	}
+
+	// Игра в корзине название не занимает, но и восстановить ее при занятом названии нельзя
+	if err := repo.Delete(ctx, tetris.ID, tetris.Version); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	other.Title = "TETRIS"
+	if _, err := repo.Update(ctx, other); err != nil {
+		t.Fatalf("Update after delete: %v", err)
+	}
+	if err := repo.Restore(ctx, tetris.ID); !errors.Is(err, repository.ErrDuplicateTitle) {
+		t.Fatalf("Restore: expected ErrDuplicateTitle, got %v", err)
+	}
+
+	titles, err := repo.FindTitles(ctx)
+	if err != nil || len(titles) != 1 || titles[0].ID != other.ID {
+		t.Fatalf("FindTitles: %v, %v", titles, err)
+	}
+}
+
+func TestApplySchema_BackfillsTitleKeys(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "legacy.db")
+
+	legacy, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	first, second, deleted := uuid.New(), uuid.New(), uuid.New()
+	for _, stmt := range []string{
+		`CREATE TABLE games (id TEXT PRIMARY KEY, title TEXT NOT NULL, description TEXT NOT NULL, release_date TEXT NOT NULL,
+			deleted_at TEXT, version INTEGER NOT NULL DEFAULT 1)`,
+		`INSERT INTO games (id, title, description, release_date) VALUES ('` + first.String() + `', 'Tetris', 'd', '2024-01-01T00:00:00Z')`,
+		`INSERT INTO games (id, title, description, release_date) VALUES ('` + second.String() + `', 'tetris ', 'd', '2024-01-01T00:00:00Z')`,
+		`INSERT INTO games (id, title, description, release_date, deleted_at) VALUES ('` + deleted.String() + `', 'TETRIS', 'd', '2024-01-01T00:00:00Z', '2024-02-01T00:00:00.000000000Z')`,
+	} {
+		if _, err := legacy.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("legacy schema: %v", err)
+		}
+	}
+	_ = legacy.Close()
+
+	db, err := Open(ctx, Config{Path: path})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	// Ключ достается самой ранней игре, дубль остается без ключа
+	var firstKey, secondKey, deletedKey sql.NullString
+	for id, key := range map[uuid.UUID]*sql.NullString{first: &firstKey, second: &secondKey, deleted: &deletedKey} {
+		if err := db.SQL.QueryRowContext(ctx, `SELECT title_key FROM games WHERE id = ?`, id.String()).Scan(key); err != nil {
+			t.Fatalf("select title_key: %v", err)
+		}
+	}
+	if firstKey.String != "tetris" || secondKey.Valid || deletedKey.String != "tetris" {
+		t.Fatalf("unexpected keys: %v, %v, %v", firstKey, secondKey, deletedKey)
+	}
+
+	repo := NewGameRepository(db.SQL)
+	found, err := repo.FindByTitle(ctx, "Tetris")
+	if err != nil || found.ID != first {
+		t.Fatalf("FindByTitle: %v, %v", found, err)
+	}
+	if _, err := repo.Create(ctx, &model.Game{Title: "TeTrIs", ReleaseDate: time.Now().UTC()}); !errors.Is(err, repository.ErrDuplicateTitle) {
+		t.Fatalf("Create: expected ErrDuplicateTitle, got %v", err)
+	}
+
+	// Повторное открытие не трогает ключи
+	if err := ApplySchema(ctx, db.SQL); err != nil {
+		t.Fatalf("ApplySchema: %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLitePlayResultRepository(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	games := NewGameRepository(db.SQL)
+	game := &model.Game{ID: uuid.New(), Title: "Tetris", ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), GenreID: genre.ID}
+	if _, err := games.Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+	user := &model.User{ID: uuid.New(), Username: "bob", Password: "pass", UserRole: specifictype.RoleUser}
+	if _, err := NewUserRepository(db.SQL).Create(ctx, user); err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+	minScore := int64(100)
+	task, _ := model.NewTaskWithValidate(game.ID, "Sprint", "", "", nil, model.TaskPassCriteria{MinScore: &minScore}, 0)
+	if _, err := NewTaskRepository(db.SQL).Create(ctx, task); err != nil {
+		t.Fatalf("Create task: %v", err)
+	}
+
+	attempts := NewAttemptRepository(db.SQL)
+	attempt, _ := model.NewAttempt(user.ID, game.ID, task.ID, 1, "launch", time.Now().Add(time.Minute))
+	if _, err := attempts.Create(ctx, attempt); err != nil {
+		t.Fatalf("Create attempt: %v", err)
+	}
+	if err := attempt.Run(time.Now(), "result", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if _, err := attempts.Update(ctx, attempt, model.AttemptStarted); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	repo := NewPlayResultRepository(db.SQL)
+	if err := repo.UseNonce(ctx, attempt.ID, "nonce-1", time.Now()); err != nil {
+		t.Fatalf("UseNonce: %v", err)
+	}
+	if err := repo.UseNonce(ctx, attempt.ID, "nonce-1", time.Now()); !errors.Is(err, repository.ErrNonceUsed) {
+		t.Fatalf("expected ErrNonceUsed, got %v", err)
+	}
+
+	// Счет ниже порога задачи: игра сообщила об успехе, но попытка не засчитана
+	result, err := model.NewPlayResultWithValidate(attempt, task, 50, 90*time.Second, true, []byte(` {"lines": 3} `))
+	if err != nil || result.Passed || string(result.Details) != `{"lines":3}` {
+		t.Fatalf("NewPlayResultWithValidate: %+v, %v", result, err)
+	}
+	if err := attempt.Finish(result.AttemptStatus(), time.Now()); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	if _, err := repo.Submit(ctx, result, attempt); err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	got, err := attempts.FindByID(ctx, attempt.ID)
+	if err != nil || got.Status != model.AttemptFailed || got.FinishedAt == nil {
+		t.Fatalf("attempt after submit: %+v, %v", got, err)
+	}
+
+	// Второй результат той же попытки отклоняется, даже если попытку передали как running
+	again, _ := model.NewPlayResultWithValidate(attempt, task, 500, time.Minute, true, nil)
+	if _, err := repo.Submit(ctx, again, attempt); !errors.Is(err, repository.ErrResultAlreadySubmitted) {
+		t.Fatalf("expected ErrResultAlreadySubmitted, got %v", err)
+	}
+
+	// Очистка корзины удаляет попытки игры вместе с результатами и nonce
+	if err := games.Delete(ctx, game.ID, game.Version); err != nil {
+		t.Fatalf("Delete game: %v", err)
+	}
+	if _, err := games.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	var left int
+	if err := db.SQL.QueryRowContext(ctx,
+		`SELECT (SELECT COUNT(*) FROM play_results) + (SELECT COUNT(*) FROM result_nonces)`).Scan(&left); err != nil || left != 0 {
+		t.Fatalf("results after purge: %d, %v", left, err)
+	}
+}
+
+func TestSQLitePlayResultStats(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genres := NewGenreRepository(db.SQL)
+	puzzle := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	arcade := &model.Genre{ID: uuid.New(), Title: "Arcade"}
+	for _, genre := range []*model.Genre{puzzle, arcade} {
+		if _, err := genres.Create(ctx, genre); err != nil {
+			t.Fatalf("Create genre: %v", err)
+		}
+	}
+	games := NewGameRepository(db.SQL)
+	tetris := &model.Game{ID: uuid.New(), Title: "Tetris", ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	tetris.SetGenres(puzzle.ID, []uuid.UUID{arcade.ID})
+	snake := &model.Game{ID: uuid.New(), Title: "Snake", ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), GenreID: arcade.ID}
+	for _, game := range []*model.Game{tetris, snake} {
+		if _, err := games.Create(ctx, game); err != nil {
+			t.Fatalf("Create game: %v", err)
+		}
+	}
+	users := NewUserRepository(db.SQL)
+	bob := &model.User{ID: uuid.New(), Username: "bob", Password: "pass", UserRole: specifictype.RoleUser}
+	eve := &model.User{ID: uuid.New(), Username: "eve", Password: "pass", UserRole: specifictype.RoleUser}
+	for _, user := range []*model.User{bob, eve} {
+		if _, err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Create user: %v", err)
+		}
+	}
+
+	attempts := NewAttemptRepository(db.SQL)
+	repo := NewPlayResultRepository(db.SQL)
+	submit := func(user *model.User, game *model.Game, score int64, seconds int, success bool) {
+		t.Helper()
+		attempt, _ := model.NewAttempt(user.ID, game.ID, uuid.Nil, 1, uuid.NewString(), time.Now().Add(time.Minute))
+		if _, err := attempts.Create(ctx, attempt); err != nil {
+			t.Fatalf("Create attempt: %v", err)
+		}
+		_ = attempt.Run(time.Now(), uuid.NewString(), time.Now().Add(time.Hour))
+		if _, err := attempts.Update(ctx, attempt, model.AttemptStarted); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		result, _ := model.NewPlayResultWithValidate(attempt, nil, score, time.Duration(seconds)*time.Second, success, nil)
+		_ = attempt.Finish(result.AttemptStatus(), time.Now())
+		if _, err := repo.Submit(ctx, result, attempt); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+
+	// Медиана четного числа засчитанных попыток - среднее двух средних; провал в нее не входит
+	submit(bob, tetris, 10, 40, true)
+	submit(bob, tetris, 30, 10, true)
+	submit(bob, tetris, 20, 20, true)
+	submit(bob, tetris, 50, 30, true)
+	submit(bob, tetris, 90, 5, false)
+	submit(bob, snake, 7, 60, false)
+	submit(eve, snake, 100, 1, true)
+
+	stats, err := repo.FindGameStatsByUser(ctx, bob.ID)
+	if err != nil || len(stats) != 2 {
+		t.Fatalf("FindGameStatsByUser: %v, %v", stats, err)
+	}
+	// Snake сыгран последним
+	if s := stats[0]; s.GameID != snake.ID || s.Plays != 1 || s.Completions != 0 || s.BestScore != 7 ||
+		s.BestTime != nil || s.MedianTime != nil {
+		t.Fatalf("snake stats: %+v", s)
+	}
+	s := stats[1]
+	if s.GameID != tetris.ID || s.GameTitle != "Tetris" || s.Plays != 5 || s.Completions != 4 || s.BestScore != 90 {
+		t.Fatalf("tetris stats: %+v", s)
+	}
+	if s.BestTime == nil || *s.BestTime != 10*time.Second || s.MedianTime == nil || *s.MedianTime != 25*time.Second {
+		t.Fatalf("tetris times: %v, %v", s.BestTime, s.MedianTime)
+	}
+
+	genreStats, err := repo.FindGenreStatsByUser(ctx, bob.ID)
+	if err != nil || len(genreStats) != 2 {
+		t.Fatalf("FindGenreStatsByUser: %v, %v", genreStats, err)
+	}
+	if g := genreStats[0]; g.GenreID != arcade.ID || g.Games != 2 || g.Plays != 6 || g.Completions != 4 {
+		t.Fatalf("arcade stats: %+v", g)
+	}
+	if g := genreStats[1]; g.GenreID != puzzle.ID || g.Games != 1 || g.Plays != 5 {
+		t.Fatalf("puzzle stats: %+v", g)
+	}
+
+	// Игры из корзины в статистику не попадают
+	if err := games.Delete(ctx, tetris.ID, tetris.Version); err != nil {
+		t.Fatalf("Delete game: %v", err)
+	}
+	if stats, err := repo.FindGameStatsByUser(ctx, bob.ID); err != nil || len(stats) != 1 || stats[0].GameID != snake.ID {
+		t.Fatalf("stats after delete: %v, %v", stats, err)
+	}
+}
+
+func TestSQLitePlayResultLeaderboard(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	game := &model.Game{ID: uuid.New(), Title: "Tetris", ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), GenreID: genre.ID}
+	if _, err := NewGameRepository(db.SQL).Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+	users := NewUserRepository(db.SQL)
+	newUser := func(name string, role specifictype.UserRole) *model.User {
+		t.Helper()
+		user := &model.User{ID: uuid.New(), Username: name, Password: "pass", UserRole: role}
+		if _, err := users.Create(ctx, user); err != nil {
+			t.Fatalf("Create user: %v", err)
+		}
+		return user
+	}
+	ann := newUser("ann", specifictype.RoleUser)
+	bob := newUser("bob", specifictype.RoleUser)
+	eve := newUser("eve", specifictype.RoleUser)
+	root := newUser("root", specifictype.RoleAdmin)
+
+	attempts := NewAttemptRepository(db.SQL)
+	repo := NewPlayResultRepository(db.SQL)
+	now := time.Now().UTC()
+	submit := func(user *model.User, score int64, seconds int, at time.Time) {
+		t.Helper()
+		attempt, _ := model.NewAttempt(user.ID, game.ID, uuid.Nil, 1, uuid.NewString(), time.Now().Add(time.Minute))
+		if _, err := attempts.Create(ctx, attempt); err != nil {
+			t.Fatalf("Create attempt: %v", err)
+		}
+		_ = attempt.Run(time.Now(), uuid.NewString(), time.Now().Add(time.Hour))
+		if _, err := attempts.Update(ctx, attempt, model.AttemptStarted); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		result, _ := model.NewPlayResultWithValidate(attempt, nil, score, time.Duration(seconds)*time.Second, true, nil)
+		result.SubmittedAt = at
+		_ = attempt.Finish(result.AttemptStatus(), time.Now())
+		if _, err := repo.Submit(ctx, result, attempt); err != nil {
+			t.Fatalf("Submit: %v", err)
+		}
+	}
+
+	// ann и bob равны по счету и времени - выше тот, кто добился раньше; старый рекорд eve
+	// не попадает в окно недели
+	submit(ann, 50, 30, now.Add(-2*time.Hour))
+	submit(ann, 20, 5, now.Add(-time.Hour))
+	submit(bob, 50, 30, now.Add(-3*time.Hour))
+	submit(bob, 50, 40, now.Add(-4*time.Hour))
+	submit(eve, 90, 10, now.AddDate(0, 0, -20))
+	submit(eve, 10, 10, now.Add(-time.Hour))
+	submit(root, 70, 10, now.Add(-time.Hour))
+
+	board, err := repo.FindLeaderboard(ctx, repository.LeaderboardQuery{GameID: game.ID, Limit: 2, CallerID: ann.ID})
+	if err != nil || board.Participants != 4 || len(board.Entries) != 2 {
+		t.Fatalf("FindLeaderboard: %+v, %v", board, err)
+	}
+	if e := board.Entries[0]; e.UserID != eve.ID || e.Rank != 1 || e.Score != 90 {
+		t.Fatalf("first place: %+v", e)
+	}
+	if e := board.Entries[1]; e.UserID != root.ID || e.Rank != 2 {
+		t.Fatalf("second place: %+v", e)
+	}
+	// Место запросившего возвращается, даже если оно ниже первых Limit
+	if e := board.Caller; e == nil || e.UserID != ann.ID || e.Rank != 4 || e.Score != 50 ||
+		e.Duration != 30*time.Second || !e.AchievedAt.Equal(now.Add(-2*time.Hour)) {
+		t.Fatalf("caller: %+v", board.Caller)
+	}
+
+	board, err = repo.FindLeaderboard(ctx, repository.LeaderboardQuery{
+		GameID: game.ID, Since: now.AddDate(0, 0, -7), ExcludeAdmins: true, Limit: 10, CallerID: uuid.New(),
+	})
+	if err != nil || board.Participants != 3 || board.Caller != nil {
+		t.Fatalf("FindLeaderboard week: %+v, %v", board, err)
+	}
+	var order []uuid.UUID
+	for _, e := range board.Entries {
+		order = append(order, e.UserID)
+	}
+	if len(order) != 3 || order[0] != bob.ID || order[1] != ann.ID || order[2] != eve.ID || board.Entries[2].Score != 10 {
+		t.Fatalf("week order: %v", order)
+	}
+}
@@ -2,10 +2,15 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/constants"
 	"example/web-service-gin/internal/domain/model"
 
 	"github.com/google/uuid"
@@ -58,5 +63,570 @@ func TestSQLiteRepositories_GenresAndGames(t *testing.T) {
 	if len(genres) != 1 {
 		t.Fatalf("expected 1 genre, got %d", len(genres))
 	}
+
+	other := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := genreRepo.Create(ctx, other); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	// Загружаются только запрошенные жанры, неизвестный id пропускается
+	byIDs, err := genreRepo.FindByIDs(ctx, []uuid.UUID{other.ID, uuid.New()})
+	if err != nil {
+		t.Fatalf("FindByIDs: %v", err)
+	}
+	if len(byIDs) != 1 || byIDs[0].ID != other.ID {
+		t.Fatalf("expected only genre %s, got %v", other.ID, byIDs)
+	}
+	if byIDs, err = genreRepo.FindByIDs(ctx, nil); err != nil || len(byIDs) != 0 {
+		t.Fatalf("expected no genres for empty ids, got %v, %v", byIDs, err)
+	}
 }
 
+func TestSQLiteGameRepository_FindByQuery(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genreRepo := NewGenreRepository(db.SQL)
+	gameRepo := NewGameRepository(db.SQL)
+
+	action := &model.Genre{ID: uuid.New(), Title: "Action"}
+	puzzle := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	for _, g := range []*model.Genre{action, puzzle} {
+		if _, err := genreRepo.Create(ctx, g); err != nil {
+			t.Fatalf("Create genre: %v", err)
+		}
+	}
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	titles := []string{"Alpha", "Bravo", "Charlie", "Delta 100%"}
+	for i, title := range titles {
+		genreID := action.ID
+		if i%2 == 1 {
+			genreID = puzzle.ID
+		}
+		game := &model.Game{
+			ID:          uuid.New(),
+			Title:       title,
+			Description: "desc",
+			ReleaseDate: base.AddDate(0, i, 0),
+			GenreID:     genreID,
+		}
+		if _, err := gameRepo.Create(ctx, game); err != nil {
+			t.Fatalf("Create game: %v", err)
+		}
+	}
+
+	games, total, err := gameRepo.FindByQuery(ctx, repository.GameQuery{
+		Limit:    1,
+		GenreIDs: []uuid.UUID{action.ID},
+		SortBy:   repository.GameSortTitle,
+	})
+	if err != nil {
+		t.Fatalf("FindByQuery: %v", err)
+	}
+	if total != 2 || len(games) != 1 || games[0].Title != "Alpha" {
+		t.Fatalf("unexpected page: total=%d games=%v", total, games)
+	}
+
+	from := base.AddDate(0, 1, 0)
+	games, total, err = gameRepo.FindByQuery(ctx, repository.GameQuery{
+		ReleasedFrom: &from,
+		SortBy:       repository.GameSortReleaseDate,
+		SortDesc:     true,
+	})
+	if err != nil {
+		t.Fatalf("FindByQuery: %v", err)
+	}
+	if total != 3 || games[0].Title != "Delta 100%" {
+		t.Fatalf("unexpected result: total=%d first=%q", total, games[0].Title)
+	}
+
+	// "%" в поиске должен восприниматься буквально
+	_, total, err = gameRepo.FindByQuery(ctx, repository.GameQuery{Search: "0%"})
+	if err != nil {
+		t.Fatalf("FindByQuery: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("expected 1 match for literal %%, got %d", total)
+	}
+}
+
+func TestSQLiteGameRepository_SubSecondReleaseDates(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Action"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	gameRepo := NewGameRepository(db.SQL)
+
+	// В RFC3339Nano "…00.5Z" меньше "…00Z" как строка, хотя момент позже
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dates := []time.Time{base, base.Add(500 * time.Millisecond), base.Add(time.Second)}
+	ids := make([]uuid.UUID, len(dates))
+	for i, date := range dates {
+		game := &model.Game{ID: uuid.New(), Title: fmt.Sprintf("Game %d", i), ReleaseDate: date, GenreID: genre.ID}
+		if _, err := gameRepo.Create(ctx, game); err != nil {
+			t.Fatalf("Create game: %v", err)
+		}
+		ids[i] = game.ID
+	}
+
+	games, _, err := gameRepo.FindByQuery(ctx, repository.GameQuery{SortBy: repository.GameSortReleaseDate})
+	if err != nil {
+		t.Fatalf("FindByQuery: %v", err)
+	}
+	for i, game := range games {
+		if game.ID != ids[i] {
+			t.Fatalf("position %d: expected %s, got %s (%s)", i, ids[i], game.ID, game.ReleaseDate)
+		}
+	}
+
+	_, total, err := gameRepo.FindByQuery(ctx, repository.GameQuery{ReleasedFrom: &dates[1]})
+	if err != nil || total != 2 {
+		t.Fatalf("releasedFrom: expected 2 games, got %d, %v", total, err)
+	}
+	_, total, err = gameRepo.FindByQuery(ctx, repository.GameQuery{ReleasedTo: &dates[0]})
+	if err != nil || total != 1 {
+		t.Fatalf("releasedTo: expected 1 game, got %d, %v", total, err)
+	}
+
+	after := &repository.Cursor{Key: dates[1].Format(time.RFC3339Nano), ID: ids[1]}
+	games, _, err = gameRepo.FindByQuery(ctx, repository.GameQuery{SortBy: repository.GameSortReleaseDate, After: after})
+	if err != nil {
+		t.Fatalf("FindByQuery after cursor: %v", err)
+	}
+	if len(games) != 1 || games[0].ID != ids[2] {
+		t.Fatalf("expected only the last game after cursor, got %v", games)
+	}
+}
+
+func TestSQLiteGameRepository_Search(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genreRepo := NewGenreRepository(db.SQL)
+	gameRepo := NewGameRepository(db.SQL)
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := genreRepo.Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+
+	newGame := func(title, description string) *model.Game {
+		game := &model.Game{
+			ID:          uuid.New(),
+			Title:       title,
+			Description: description,
+			ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			GenreID:     genre.ID,
+		}
+		if _, err := gameRepo.Create(ctx, game); err != nil {
+			t.Fatalf("Create game: %v", err)
+		}
+		return game
+	}
+	inTitle := newGame("Space Puzzle", "Rotate blocks")
+	inDescription := newGame("Blocks", "A puzzle about space stations")
+	newGame("Racing", "Fast cars")
+
+	hits, total, err := gameRepo.Search(ctx, repository.GameSearchQuery{Text: "puzz"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if total != 2 || len(hits) != 2 {
+		t.Fatalf("unexpected hits: total=%d hits=%d", total, len(hits))
+	}
+	if hits[0].Game.ID != inTitle.ID || hits[1].Game.ID != inDescription.ID {
+		t.Fatalf("title match must rank first: %q, %q", hits[0].Game.Title, hits[1].Game.Title)
+	}
+	if hits[0].TitleHighlight != "Space <mark>Puzzle</mark>" {
+		t.Fatalf("unexpected highlight: %q", hits[0].TitleHighlight)
+	}
+
+	// Текст игры в выделении экранируется: разметкой остаются только маркеры
+	newGame(`Zork <img src=x onerror="alert(1)">`, "<script>zork</script>")
+	hits, _, err = gameRepo.Search(ctx, repository.GameSearchQuery{Text: "zork"})
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("Search zork: %v, %v", hits, err)
+	}
+	if want := `<mark>Zork</mark> &lt;img src=x onerror=&#34;alert(1)&#34;&gt;`; hits[0].TitleHighlight != want {
+		t.Fatalf("unexpected escaped highlight: %q", hits[0].TitleHighlight)
+	}
+	if want := `&lt;script&gt;<mark>zork</mark>&lt;/script&gt;`; hits[0].DescriptionSnippet != want {
+		t.Fatalf("unexpected escaped snippet: %q", hits[0].DescriptionSnippet)
+	}
+
+	// Обновление и удаление игры синхронизируют индекс
+	inTitle.Title = "Space Racer"
+	if _, err := gameRepo.Update(ctx, inTitle); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := gameRepo.Delete(ctx, inDescription.ID, inDescription.Version); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	_, total, err = gameRepo.Search(ctx, repository.GameSearchQuery{Text: `puzzle "OR`})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if total != 0 {
+		t.Fatalf("expected no hits after update/delete, got %d", total)
+	}
+}
+
+func TestSQLiteGameRepository_Genres(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genreRepo := NewGenreRepository(db.SQL)
+	gameRepo := NewGameRepository(db.SQL)
+
+	var genres []*model.Genre
+	for _, title := range []string{"Action", "Puzzle", "Strategy"} {
+		g := &model.Genre{ID: uuid.New(), Title: title}
+		if _, err := genreRepo.Create(ctx, g); err != nil {
+			t.Fatalf("Create genre: %v", err)
+		}
+		genres = append(genres, g)
+	}
+	action, puzzle, strategy := genres[0].ID, genres[1].ID, genres[2].ID
+
+	both := &model.Game{ID: uuid.New(), Title: "Both", Description: "d", ReleaseDate: time.Now().UTC()}
+	both.SetGenres(puzzle, []uuid.UUID{action, puzzle})
+	onlyAction := &model.Game{ID: uuid.New(), Title: "Action only", Description: "d", ReleaseDate: time.Now().UTC(), GenreID: action}
+	for _, game := range []*model.Game{both, onlyAction} {
+		if _, err := gameRepo.Create(ctx, game); err != nil {
+			t.Fatalf("Create game: %v", err)
+		}
+	}
+
+	got, err := gameRepo.FindByID(ctx, both.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.GenreID != puzzle || len(got.GenreIDs) != 2 || got.GenreIDs[0] != puzzle || got.GenreIDs[1] != action {
+		t.Fatalf("unexpected genres: primary=%v all=%v", got.GenreID, got.GenreIDs)
+	}
+
+	_, total, err := gameRepo.FindByQuery(ctx, repository.GameQuery{GenreIDs: []uuid.UUID{action, puzzle}})
+	if err != nil || total != 2 {
+		t.Fatalf("any: total=%d err=%v", total, err)
+	}
+	games, total, err := gameRepo.FindByQuery(ctx, repository.GameQuery{GenreIDs: []uuid.UUID{action, puzzle}, MatchAllGenres: true})
+	if err != nil || total != 1 || games[0].ID != both.ID {
+		t.Fatalf("all: total=%d err=%v", total, err)
+	}
+
+	got.SetGenres(strategy, nil)
+	if _, err := gameRepo.Update(ctx, got); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err = gameRepo.FindByID(ctx, both.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.GenreID != strategy || len(got.GenreIDs) != 1 {
+		t.Fatalf("genres not replaced: %v", got.GenreIDs)
+	}
+
+	missing := &model.Game{ID: uuid.New(), Title: "Orphan", Description: "d", ReleaseDate: time.Now().UTC(), GenreID: uuid.New()}
+	if _, err := gameRepo.Create(ctx, missing); err == nil || err.Error() != constants.ErrGenreNotFound {
+		t.Fatalf("expected genre not found, got %v", err)
+	}
+	if _, err := gameRepo.FindByID(ctx, missing.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("game with unknown genre must not be saved, got %v", err)
+	}
+}
+
+func TestApplySchema_MigratesLegacyGenreColumn(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "legacy.db")
+
+	legacy, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+	genreID, gameID, userID := uuid.New(), uuid.New(), uuid.New()
+	for _, stmt := range []string{
+		`CREATE TABLE genres (id TEXT PRIMARY KEY, title TEXT NOT NULL)`,
+		`CREATE TABLE games (id TEXT PRIMARY KEY, title TEXT NOT NULL, description TEXT NOT NULL, release_date TEXT NOT NULL,
+			genre_id TEXT NOT NULL, FOREIGN KEY (genre_id) REFERENCES genres(id) ON UPDATE CASCADE ON DELETE RESTRICT)`,
+		`CREATE INDEX idx_games_genre_id ON games(genre_id)`,
+		`CREATE TABLE users (id TEXT PRIMARY KEY, username TEXT NOT NULL UNIQUE, password TEXT NOT NULL, user_role TEXT NOT NULL)`,
+		`CREATE TABLE user_ratings (id TEXT PRIMARY KEY, user_id TEXT NOT NULL, game_id TEXT NOT NULL, rating INTEGER NOT NULL,
+			created_at TEXT NOT NULL, UNIQUE (user_id, game_id),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (game_id) REFERENCES games(id) ON DELETE CASCADE)`,
+		`INSERT INTO genres VALUES ('` + genreID.String() + `', 'Puzzle')`,
+		`INSERT INTO games VALUES ('` + gameID.String() + `', 'Old game', 'd', '2024-01-01T00:00:00Z', '` + genreID.String() + `')`,
+		`INSERT INTO users VALUES ('` + userID.String() + `', 'player', 'x', 'user')`,
+		`INSERT INTO user_ratings VALUES ('` + uuid.NewString() + `', '` + userID.String() + `', '` + gameID.String() + `', 4, '2024-01-02T00:00:00Z')`,
+	} {
+		if _, err := legacy.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("legacy schema: %v", err)
+		}
+	}
+	_ = legacy.Close()
+
+	db, err := Open(ctx, Config{Path: path})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	game, err := NewGameRepository(db.SQL).FindByID(ctx, gameID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if game.GenreID != genreID || len(game.GenreIDs) != 1 {
+		t.Fatalf("genre not migrated: %v", game.GenreIDs)
+	}
+
+	ratings, err := NewUserRatingRepository(db.SQL).FindByGameID(ctx, gameID)
+	if err != nil || len(ratings) != 1 {
+		t.Fatalf("ratings lost during migration: %d, %v", len(ratings), err)
+	}
+
+	hits, _, err := NewGameRepository(db.SQL).Search(ctx, repository.GameSearchQuery{Text: "old"})
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("search index not rebuilt: %d, %v", len(hits), err)
+	}
+
+	// Дата релиза переписана в формат фиксированной длины
+	var releaseDate string
+	if err := db.SQL.QueryRowContext(ctx, `SELECT release_date FROM games WHERE id = ?`, gameID.String()).Scan(&releaseDate); err != nil {
+		t.Fatalf("select release_date: %v", err)
+	}
+	if releaseDate != "2024-01-01T00:00:00.000000000Z" {
+		t.Fatalf("release_date not migrated: %q", releaseDate)
+	}
+}
+
+func TestSQLiteRepositories_TrashRestorePurge(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genreRepo := NewGenreRepository(db.SQL)
+	gameRepo := NewGameRepository(db.SQL)
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Arcade"}
+	if _, err := genreRepo.Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	game := &model.Game{ID: uuid.New(), Title: "Pong", Description: "d", ReleaseDate: time.Now().UTC(), GenreID: genre.ID}
+	if _, err := gameRepo.Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+
+	if err := gameRepo.Delete(ctx, game.ID, game.Version); err != nil {
+		t.Fatalf("Delete game: %v", err)
+	}
+	if _, err := gameRepo.FindByID(ctx, game.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("deleted game must be hidden, got %v", err)
+	}
+	if _, total, err := gameRepo.FindByQuery(ctx, repository.GameQuery{}); err != nil || total != 0 {
+		t.Fatalf("deleted game listed: total=%d err=%v", total, err)
+	}
+	deleted, err := gameRepo.FindDeleted(ctx)
+	if err != nil || len(deleted) != 1 || deleted[0].DeletedAt == nil {
+		t.Fatalf("FindDeleted: %v, %v", deleted, err)
+	}
+
+	if err := genreRepo.Delete(ctx, genre.ID, genre.Version); err != nil {
+		t.Fatalf("Delete genre: %v", err)
+	}
+	if err := gameRepo.Restore(ctx, game.ID); !errors.Is(err, repository.ErrGameGenreDeleted) {
+		t.Fatalf("expected ErrGameGenreDeleted, got %v", err)
+	}
+
+	// Жанр нельзя удалить окончательно, пока на него ссылается игра из корзины
+	future := time.Now().Add(time.Hour)
+	if n, err := genreRepo.Purge(ctx, future); err != nil || n != 0 {
+		t.Fatalf("genre in use purged: n=%d err=%v", n, err)
+	}
+
+	if err := genreRepo.Restore(ctx, genre.ID); err != nil {
+		t.Fatalf("Restore genre: %v", err)
+	}
+	if err := gameRepo.Restore(ctx, game.ID); err != nil {
+		t.Fatalf("Restore game: %v", err)
+	}
+	if err := gameRepo.Restore(ctx, game.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("restore of live game: %v", err)
+	}
+	if game, err = gameRepo.FindByID(ctx, game.ID); err != nil {
+		t.Fatalf("restored game: %v", err)
+	}
+	if genre, err = genreRepo.FindByID(ctx, genre.ID); err != nil {
+		t.Fatalf("restored genre: %v", err)
+	}
+
+	if err := gameRepo.Delete(ctx, game.ID, game.Version); err != nil {
+		t.Fatalf("Delete game: %v", err)
+	}
+	if err := genreRepo.Delete(ctx, genre.ID, genre.Version); err != nil {
+		t.Fatalf("Delete genre: %v", err)
+	}
+	if res, err := gameRepo.Purge(ctx, time.Now().Add(-time.Hour)); err != nil || res.Count != 0 {
+		t.Fatalf("fresh game purged: res=%+v err=%v", res, err)
+	}
+	if res, err := gameRepo.Purge(ctx, future); err != nil || res.Count != 1 {
+		t.Fatalf("game purge: res=%+v err=%v", res, err)
+	}
+	if n, err := genreRepo.Purge(ctx, future); err != nil || n != 1 {
+		t.Fatalf("genre purge: n=%d err=%v", n, err)
+	}
+}
+
+func TestSQLiteRepositories_VersionConflict(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genreRepo := NewGenreRepository(db.SQL)
+	gameRepo := NewGameRepository(db.SQL)
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Arcade"}
+	if _, err := genreRepo.Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	game := &model.Game{ID: uuid.New(), Title: "Pong", Description: "d", ReleaseDate: time.Now().UTC(), GenreID: genre.ID}
+	if _, err := gameRepo.Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+
+	// Два администратора прочитали одну и ту же версию
+	first, err := gameRepo.FindByID(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	second, err := gameRepo.FindByID(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if first.Version != 1 {
+		t.Fatalf("new game version: %d", first.Version)
+	}
+
+	first.Title = "Pong 2"
+	if _, err := gameRepo.Update(ctx, first); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if first.Version != 2 {
+		t.Fatalf("version after update: %d", first.Version)
+	}
+
+	second.Title = "Pong Deluxe"
+	if _, err := gameRepo.Update(ctx, second); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("stale update: expected ErrVersionConflict, got %v", err)
+	}
+	if err := gameRepo.Delete(ctx, game.ID, second.Version); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("stale delete: expected ErrVersionConflict, got %v", err)
+	}
+	if got, err := gameRepo.FindByID(ctx, game.ID); err != nil || got.Title != "Pong 2" || got.Version != 2 {
+		t.Fatalf("stale write applied: %+v, %v", got, err)
+	}
+
+	if err := genreRepo.Delete(ctx, genre.ID, genre.Version+1); !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("stale genre delete: expected ErrVersionConflict, got %v", err)
+	}
+
+	if err := gameRepo.Delete(ctx, game.ID, first.Version); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := gameRepo.Delete(ctx, game.ID, first.Version+1); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("delete of deleted game: expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSQLiteCatalogRepository_ApplyIsAtomic(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genreRepo := NewGenreRepository(db.SQL)
+	gameRepo := NewGameRepository(db.SQL)
+	catalogRepo := NewCatalogRepository(db.SQL)
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Arcade"}
+	if _, err := genreRepo.Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	game := &model.Game{ID: uuid.New(), Title: "Pong", Description: "d", ReleaseDate: time.Now().UTC(), GenreID: genre.ID}
+	if _, err := gameRepo.Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+
+	// Устаревшая версия игры в конце импорта откатывает и новые записи
+	stale := *game
+	stale.Title = "Pong 2"
+	stale.Version = 7
+	newGenre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	newGame := &model.Game{ID: uuid.New(), Title: "Tetris", Description: "d", ReleaseDate: time.Now().UTC(), GenreID: newGenre.ID}
+	err = catalogRepo.Apply(ctx, repository.CatalogImport{
+		CreateGenres: []*model.Genre{newGenre},
+		CreateGames:  []*model.Game{newGame},
+		UpdateGames:  []*model.Game{&stale},
+	})
+	if !errors.Is(err, repository.ErrVersionConflict) {
+		t.Fatalf("expected ErrVersionConflict, got %v", err)
+	}
+	if _, err := genreRepo.FindByID(ctx, newGenre.ID); !errors.Is(err, repository.ErrGenreNotFound) {
+		t.Fatalf("genre must be rolled back, got %v", err)
+	}
+	if _, err := gameRepo.FindByID(ctx, newGame.ID); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("game must be rolled back, got %v", err)
+	}
+
+	stale.Version = 1
+	if err := catalogRepo.Apply(ctx, repository.CatalogImport{
+		CreateGenres: []*model.Genre{newGenre},
+		CreateGames:  []*model.Game{newGame},
+		UpdateGames:  []*model.Game{&stale},
+	}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	got, err := gameRepo.FindByID(ctx, game.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.Title != "Pong 2" || got.Version != 2 || stale.Version != 2 {
+		t.Fatalf("unexpected updated game: %+v (stale version %d)", got, stale.Version)
+	}
+	if _, err := gameRepo.FindByID(ctx, newGame.ID); err != nil {
+		t.Fatalf("FindByID new game: %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteRevisionRepository_AppendOnly(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := NewRevisionRepository(db.SQL)
+	gameID, otherID, actorID := uuid.New(), uuid.New(), uuid.New()
+
+	for i, title := range []string{"First", "Second", "Third"} {
+		snapshot, _ := json.Marshal(model.GenreSnapshot{Title: title})
+		rev, err := repo.Append(ctx, &model.Revision{
+			EntityType: model.RevisionEntityGame,
+			EntityID:   gameID,
+			Action:     model.RevisionActionUpdate,
+			Snapshot:   snapshot,
+			ActorID:    actorID,
+		})
+		if err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+		if rev.Number != i+1 {
+			t.Fatalf("expected number %d, got %d", i+1, rev.Number)
+		}
+	}
+
+	// Нумерация у каждой сущности своя
+	other, err := repo.Append(ctx, &model.Revision{
+		EntityType: model.RevisionEntityGame,
+		EntityID:   otherID,
+		Action:     model.RevisionActionCreate,
+		Snapshot:   json.RawMessage(`{}`),
+	})
+	if err != nil || other.Number != 1 {
+		t.Fatalf("other entity: number=%d err=%v", other.Number, err)
+	}
+
+	revisions, total, err := repo.FindByEntity(ctx, model.RevisionEntityGame, gameID, 2, 0)
+	if err != nil {
+		t.Fatalf("FindByEntity: %v", err)
+	}
+	if total != 3 || len(revisions) != 2 || revisions[0].Number != 3 || revisions[1].Number != 2 {
+		t.Fatalf("unexpected page: total=%d len=%d", total, len(revisions))
+	}
+	if revisions[0].ActorID != actorID {
+		t.Fatalf("actor not stored: %v", revisions[0].ActorID)
+	}
+
+	first, err := repo.FindByNumber(ctx, model.RevisionEntityGame, gameID, 1)
+	if err != nil {
+		t.Fatalf("FindByNumber: %v", err)
+	}
+	if string(first.Snapshot) != `{"title":"First"}` {
+		t.Fatalf("unexpected snapshot: %s", first.Snapshot)
+	}
+	if other.ActorID != uuid.Nil {
+		t.Fatalf("unknown actor must stay empty")
+	}
+
+	if _, err := repo.FindByNumber(ctx, model.RevisionEntityGame, gameID, 4); !errors.Is(err, repository.ErrRevisionNotFound) {
+		t.Fatalf("expected ErrRevisionNotFound, got %v", err)
+	}
+
+	if _, err := db.SQL.ExecContext(ctx, `UPDATE revisions SET action = 'delete'`); err == nil {
+		t.Fatalf("revisions must not be updatable")
+	}
+	if _, err := db.SQL.ExecContext(ctx, `DELETE FROM revisions`); err == nil {
+		t.Fatalf("revisions must not be deletable")
+	}
+}
+
+func TestSQLiteRevisionRepository_CountByActor(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	repo := NewRevisionRepository(db.SQL)
+	actorID := uuid.New()
+	dayStart := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	// Дробная часть секунд у created_at разной длины: сравнение не должно зависеть от формата
+	for _, rev := range []*model.Revision{
+		{Action: model.RevisionActionCreate, ActorID: actorID, CreatedAt: dayStart.Add(-time.Nanosecond)},
+		{Action: model.RevisionActionCreate, ActorID: actorID, CreatedAt: dayStart},
+		{Action: model.RevisionActionCreate, ActorID: actorID, CreatedAt: dayStart.Add(500 * time.Millisecond)},
+		{Action: model.RevisionActionUpdate, ActorID: actorID, CreatedAt: dayStart.Add(time.Hour)},
+		{Action: model.RevisionActionCreate, ActorID: uuid.New(), CreatedAt: dayStart.Add(time.Hour)},
+	} {
+		rev.EntityType = model.RevisionEntityGame
+		rev.EntityID = uuid.New()
+		rev.Snapshot = json.RawMessage(`{}`)
+		if _, err := repo.Append(ctx, rev); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	count, err := repo.CountByActor(ctx, model.RevisionEntityGame, model.RevisionActionCreate, actorID, dayStart)
+	if err != nil {
+		t.Fatalf("CountByActor: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 creates since day start, got %d", count)
+	}
+}
+
+func TestSQLiteTransactor_RevisionWithChange(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	tx := NewTransactor(db.SQL)
+	genreRepo := NewGenreRepository(db.SQL)
+	revisionRepo := NewRevisionRepository(db.SQL)
+
+	// Ошибка после записи ревизии откатывает и жанр, и ревизию
+	failed := &model.Genre{ID: uuid.New(), Title: "Rolled back"}
+	errStop := errors.New("stop")
+	err = tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := genreRepo.Create(ctx, failed); err != nil {
+			return err
+		}
+		if _, err := revisionRepo.Append(ctx, &model.Revision{
+			EntityType: model.RevisionEntityGenre,
+			EntityID:   failed.ID,
+			Action:     model.RevisionActionCreate,
+			Snapshot:   json.RawMessage(`{}`),
+		}); err != nil {
+			return err
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected fn error, got %v", err)
+	}
+	if _, err := genreRepo.FindByID(ctx, failed.ID); !errors.Is(err, repository.ErrGenreNotFound) {
+		t.Fatalf("genre must be rolled back, got %v", err)
+	}
+	if _, total, _ := revisionRepo.FindByEntity(ctx, model.RevisionEntityGenre, failed.ID, 10, 0); total != 0 {
+		t.Fatalf("revision must be rolled back, got %d", total)
+	}
+
+	// Вложенный вызов работает во внешней транзакции, чтение внутри видит свои записи
+	kept := &model.Genre{ID: uuid.New(), Title: "Kept"}
+	err = tx.WithinTx(ctx, func(ctx context.Context) error {
+		return tx.WithinTx(ctx, func(ctx context.Context) error {
+			if _, err := genreRepo.Create(ctx, kept); err != nil {
+				return err
+			}
+			_, err := genreRepo.FindByID(ctx, kept.ID)
+			return err
+		})
+	})
+	if err != nil {
+		t.Fatalf("WithinTx: %v", err)
+	}
+	if _, err := genreRepo.FindByID(ctx, kept.ID); err != nil {
+		t.Fatalf("genre must be committed: %v", err)
+	}
+}
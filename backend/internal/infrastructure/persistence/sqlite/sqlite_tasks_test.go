@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteTaskRepository(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := Open(ctx, Config{Path: filepath.Join(t.TempDir(), "test.db")})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	games := NewGameRepository(db.SQL)
+	game := &model.Game{ID: uuid.New(), Title: "Tetris", ReleaseDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), GenreID: genre.ID}
+	if _, err := games.Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+
+	repo := NewTaskRepository(db.SQL)
+	create := func(title string, order int) *model.Task {
+		minScore := int64(1000)
+		task, err := model.NewTaskWithValidate(game.ID, title, "Clear lines", "hard",
+			map[string]string{"variant": "marathon"},
+			model.TaskPassCriteria{MinScore: &minScore, MaxDuration: 90 * time.Second, RequireSuccess: true}, order)
+		if err != nil {
+			t.Fatalf("NewTaskWithValidate: %v", err)
+		}
+		if _, err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("Create %s: %v", title, err)
+		}
+		return task
+	}
+
+	// Задачи без номера встают в конец списка
+	first, second := create("First", 0), create("Second", 0)
+	if first.Order != 1 || second.Order != 2 {
+		t.Fatalf("unexpected orders: %d, %d", first.Order, second.Order)
+	}
+	zero := create("Zero", 0)
+	zero.Order = 1
+	zero.Title = "Before first"
+	if _, err := repo.Update(ctx, zero); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	found, err := repo.FindByGameID(ctx, game.ID)
+	if err != nil || len(found) != 3 {
+		t.Fatalf("FindByGameID: %v, %v", found, err)
+	}
+	if found[0].ID != first.ID || found[1].ID != zero.ID || found[2].ID != second.ID {
+		t.Fatalf("unexpected task order: %s, %s, %s", found[0].Title, found[1].Title, found[2].Title)
+	}
+
+	got, err := repo.FindByID(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.LaunchParams["variant"] != "marathon" || got.PassCriteria.MinScore == nil || *got.PassCriteria.MinScore != 1000 ||
+		got.PassCriteria.MaxDuration != 90*time.Second || !got.PassCriteria.RequireSuccess || got.Difficulty != model.TaskDifficultyHard {
+		t.Fatalf("task not round-tripped: %+v", got)
+	}
+
+	if err := repo.Delete(ctx, second.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, second.ID); !errors.Is(err, repository.ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", err)
+	}
+
+	// Задачи игры из корзины скрыты, новые не создаются; очистка корзины их удаляет
+	if err := games.Delete(ctx, game.ID, game.Version); err != nil {
+		t.Fatalf("Delete game: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, first.ID); !errors.Is(err, repository.ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound for trashed game, got %v", err)
+	}
+	task, _ := model.NewTaskWithValidate(game.ID, "Late", "", "", nil, model.TaskPassCriteria{}, 0)
+	if _, err := repo.Create(ctx, task); !errors.Is(err, repository.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for trashed game, got %v", err)
+	}
+	if _, err := games.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	var count int
+	if err := db.SQL.QueryRowContext(ctx, `SELECT COUNT(*) FROM tasks`).Scan(&count); err != nil || count != 0 {
+		t.Fatalf("tasks of purged game: %d, %v", count, err)
+	}
+}
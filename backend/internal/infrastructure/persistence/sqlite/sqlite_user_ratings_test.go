@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+
+	"github.com/google/uuid"
+)
+
+func TestSQLiteUserRatingRepository_UniqueAndCascade(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := Open(ctx, Config{Path: dbPath})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	genre := &model.Genre{ID: uuid.New(), Title: "Puzzle"}
+	if _, err := NewGenreRepository(db.SQL).Create(ctx, genre); err != nil {
+		t.Fatalf("Create genre: %v", err)
+	}
+	game := &model.Game{
+		ID:          uuid.New(),
+		Title:       "Tetris",
+		Description: "desc",
+		ReleaseDate: time.Now().UTC().Truncate(time.Second),
+		GenreID:     genre.ID,
+	}
+	gameRepo := NewGameRepository(db.SQL)
+	if _, err := gameRepo.Create(ctx, game); err != nil {
+		t.Fatalf("Create game: %v", err)
+	}
+	user := &model.User{ID: uuid.New(), Username: "bob", Password: "pass", UserRole: specifictype.RoleUser}
+	if _, err := NewUserRepository(db.SQL).Create(ctx, user); err != nil {
+		t.Fatalf("Create user: %v", err)
+	}
+
+	repo := NewUserRatingRepository(db.SQL)
+
+	rating := &model.UserRating{UserID: user.ID, GameID: game.ID, Rating: 4}
+	if _, err := repo.Create(ctx, rating); err != nil {
+		t.Fatalf("Create rating: %v", err)
+	}
+
+	dup := &model.UserRating{UserID: user.ID, GameID: game.ID, Rating: 5}
+	if _, err := repo.Create(ctx, dup); err != repository.ErrUserRatingAlreadyExists {
+		t.Fatalf("expected ErrUserRatingAlreadyExists, got %v", err)
+	}
+
+	rating.Rating = 2
+	if _, err := repo.Update(ctx, rating); err != nil {
+		t.Fatalf("Update rating: %v", err)
+	}
+
+	got, err := repo.FindByUserAndGame(ctx, user.ID, game.ID)
+	if err != nil {
+		t.Fatalf("FindByUserAndGame: %v", err)
+	}
+	if got.Rating != 2 {
+		t.Fatalf("expected rating 2, got %d", got.Rating)
+	}
+
+	stats, err := repo.FindStatsByGameIDs(ctx, []uuid.UUID{game.ID})
+	if err != nil {
+		t.Fatalf("FindStatsByGameIDs: %v", err)
+	}
+	if stats[game.ID] == nil || stats[game.ID].Count != 1 || stats[game.ID].Average != 2 {
+		t.Fatalf("unexpected stats: %+v", stats[game.ID])
+	}
+
+	if err := gameRepo.Delete(ctx, game.ID, game.Version); err != nil {
+		t.Fatalf("Delete game: %v", err)
+	}
+	// Оценки удаляются вместе с игрой при окончательной очистке корзины
+	if _, err := gameRepo.Purge(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Purge games: %v", err)
+	}
+
+	byUser, err := repo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID: %v", err)
+	}
+	if len(byUser) != 0 {
+		t.Fatalf("expected ratings to be cascaded, got %d", len(byUser))
+	}
+}
@@ -0,0 +1,279 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.TaskRepository = (*TaskRepository)(nil)
+
+const taskColumns = `t.id, t.game_id, t.title, t.instructions, t.difficulty, t.launch_params, t.min_score, t.max_duration_ms,
+	t.require_success, t.position, t.created_at, t.updated_at`
+
+type TaskRepository struct {
+	db *sql.DB
+}
+
+func NewTaskRepository(db *sql.DB) *TaskRepository {
+	return &TaskRepository{db: db}
+}
+
+func (r *TaskRepository) Create(ctx context.Context, task *model.Task) (*model.Task, error) {
+	if task == nil {
+		return nil, errors.New("task cannot be nil")
+	}
+	if task.ID == uuid.Nil {
+		task.ID = uuid.New()
+	}
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = time.Now().UTC()
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = task.CreatedAt
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var live int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM games WHERE id = ? AND deleted_at IS NULL`, task.GameID.String()).Scan(&live)
+	if err != nil {
+		return nil, fmt.Errorf("check task game: %w", err)
+	}
+	if live == 0 {
+		return nil, repository.ErrNotFound
+	}
+	if err := assignTaskPosition(ctx, tx, task); err != nil {
+		return nil, err
+	}
+
+	params, err := json.Marshal(task.LaunchParams)
+	if err != nil {
+		return nil, fmt.Errorf("marshal launch params: %w", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO tasks (id, game_id, title, instructions, difficulty, launch_params, min_score, max_duration_ms,
+			require_success, position, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		task.ID.String(),
+		task.GameID.String(),
+		task.Title,
+		task.Instructions,
+		task.Difficulty,
+		string(params),
+		task.PassCriteria.MinScore,
+		task.PassCriteria.MaxDuration.Milliseconds(),
+		task.PassCriteria.RequireSuccess,
+		task.Order,
+		task.CreatedAt.UTC().Format(time.RFC3339Nano),
+		task.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("insert task: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return task, nil
+}
+
+func (r *TaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Task, error) {
+	if id == uuid.Nil {
+		return nil, errors.New("task ID cannot be empty")
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT `+taskColumns+` FROM tasks t
+		JOIN games g ON g.id = t.game_id AND g.deleted_at IS NULL
+		WHERE t.id = ?`,
+		id.String(),
+	)
+	task, err := scanTask(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrTaskNotFound
+		}
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *TaskRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.Task, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+taskColumns+` FROM tasks t
+		JOIN games g ON g.id = t.game_id AND g.deleted_at IS NULL
+		WHERE t.game_id = ?
+		ORDER BY t.position, t.created_at, t.id`,
+		gameID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select tasks: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tasks: %w", err)
+	}
+	return res, nil
+}
+
+func (r *TaskRepository) Update(ctx context.Context, task *model.Task) (*model.Task, error) {
+	if task == nil {
+		return nil, errors.New("task cannot be nil")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := assignTaskPosition(ctx, tx, task); err != nil {
+		return nil, err
+	}
+
+	params, err := json.Marshal(task.LaunchParams)
+	if err != nil {
+		return nil, fmt.Errorf("marshal launch params: %w", err)
+	}
+	res, err := tx.ExecContext(
+		ctx,
+		`UPDATE tasks SET title = ?, instructions = ?, difficulty = ?, launch_params = ?, min_score = ?,
+			max_duration_ms = ?, require_success = ?, position = ?, updated_at = ?
+		WHERE id = ? AND game_id IN (SELECT id FROM games WHERE deleted_at IS NULL)`,
+		task.Title,
+		task.Instructions,
+		task.Difficulty,
+		string(params),
+		task.PassCriteria.MinScore,
+		task.PassCriteria.MaxDuration.Milliseconds(),
+		task.PassCriteria.RequireSuccess,
+		task.Order,
+		task.UpdatedAt.UTC().Format(time.RFC3339Nano),
+		task.ID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update task: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return nil, repository.ErrTaskNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit tx: %w", err)
+	}
+	return task, nil
+}
+
+func (r *TaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("task ID cannot be empty")
+	}
+
+	res, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM tasks WHERE id = ? AND game_id IN (SELECT id FROM games WHERE deleted_at IS NULL)`,
+		id.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("delete task: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrTaskNotFound
+	}
+	return nil
+}
+
+// assignTaskPosition назначает задаче с Order 0 номер после последней задачи игры.
+func assignTaskPosition(ctx context.Context, tx *sql.Tx, task *model.Task) error {
+	if task.Order > 0 {
+		return nil
+	}
+	var last int
+	err := tx.QueryRowContext(
+		ctx,
+		`SELECT COALESCE(MAX(position), 0) FROM tasks WHERE game_id = ? AND id <> ?`,
+		task.GameID.String(), task.ID.String(),
+	).Scan(&last)
+	if err != nil {
+		return fmt.Errorf("select last task position: %w", err)
+	}
+	task.Order = last + 1
+	return nil
+}
+
+func scanTask(row rowScanner) (*model.Task, error) {
+	var idStr, gameIDStr, paramsStr, createdAtStr, updatedAtStr string
+	var minScore sql.NullInt64
+	var maxDurationMs int64
+	task := &model.Task{}
+	err := row.Scan(&idStr, &gameIDStr, &task.Title, &task.Instructions, &task.Difficulty, &paramsStr, &minScore,
+		&maxDurationMs, &task.PassCriteria.RequireSuccess, &task.Order, &createdAtStr, &updatedAtStr)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan task: %w", err)
+	}
+
+	if task.ID, err = uuid.Parse(idStr); err != nil {
+		return nil, fmt.Errorf("parse task id from db: %w", err)
+	}
+	if task.GameID, err = uuid.Parse(gameIDStr); err != nil {
+		return nil, fmt.Errorf("parse game_id from db: %w", err)
+	}
+	if err := json.Unmarshal([]byte(paramsStr), &task.LaunchParams); err != nil {
+		return nil, fmt.Errorf("parse launch_params from db: %w", err)
+	}
+	if task.LaunchParams == nil {
+		task.LaunchParams = map[string]string{}
+	}
+	if minScore.Valid {
+		task.PassCriteria.MinScore = &minScore.Int64
+	}
+	task.PassCriteria.MaxDuration = time.Duration(maxDurationMs) * time.Millisecond
+	if task.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAtStr); err != nil {
+		return nil, fmt.Errorf("parse created_at from db: %w", err)
+	}
+	if task.UpdatedAt, err = time.Parse(time.RFC3339Nano, updatedAtStr); err != nil {
+		return nil, fmt.Errorf("parse updated_at from db: %w", err)
+	}
+	return task, nil
+}
@@ -0,0 +1,247 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// compile-time check
+var _ repository.UserRatingRepository = (*UserRatingRepository)(nil)
+
+const userRatingColumns = `id, user_id, game_id, rating, created_at`
+
+type UserRatingRepository struct {
+	db *sql.DB
+}
+
+func NewUserRatingRepository(db *sql.DB) *UserRatingRepository {
+	return &UserRatingRepository{db: db}
+}
+
+func (r *UserRatingRepository) Create(ctx context.Context, rating *model.UserRating) (*model.UserRating, error) {
+	if rating == nil {
+		return nil, errors.New("rating cannot be nil")
+	}
+	if rating.ID == uuid.Nil {
+		rating.ID = uuid.New()
+	}
+	if rating.CreatedAt.IsZero() {
+		rating.CreatedAt = time.Now().UTC()
+	}
+
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO user_ratings (`+userRatingColumns+`) VALUES (?, ?, ?, ?, ?)`,
+		rating.ID.String(),
+		rating.UserID.String(),
+		rating.GameID.String(),
+		rating.Rating,
+		rating.CreatedAt.UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		msg := err.Error()
+		if strings.Contains(msg, "UNIQUE constraint failed") {
+			return nil, repository.ErrUserRatingAlreadyExists
+		}
+		if strings.Contains(msg, "FOREIGN KEY constraint failed") {
+			return nil, errors.New(constants.ErrInvalidData)
+		}
+		return nil, fmt.Errorf("insert user rating: %w", err)
+	}
+
+	return rating, nil
+}
+
+func (r *UserRatingRepository) Update(ctx context.Context, rating *model.UserRating) (*model.UserRating, error) {
+	if rating == nil {
+		return nil, errors.New("rating cannot be nil")
+	}
+	if rating.ID == uuid.Nil {
+		return nil, errors.New("rating ID cannot be empty")
+	}
+
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE user_ratings SET rating = ?, created_at = ? WHERE id = ?`,
+		rating.Rating,
+		rating.CreatedAt.UTC().Format(time.RFC3339Nano),
+		rating.ID.String(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update user rating: %w", err)
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return nil, repository.ErrUserRatingNotFound
+	}
+
+	return rating, nil
+}
+
+func (r *UserRatingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("rating ID cannot be empty")
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM user_ratings WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("delete user rating: %w", err)
+	}
+	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return repository.ErrUserRatingNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRatingRepository) FindByGameID(ctx context.Context, gameID uuid.UUID) ([]*model.UserRating, error) {
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+	return r.findMany(ctx, `SELECT `+userRatingColumns+` FROM user_ratings WHERE game_id = ? ORDER BY created_at`, gameID.String())
+}
+
+func (r *UserRatingRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*model.UserRating, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	return r.findMany(ctx, `SELECT `+userRatingColumns+` FROM user_ratings WHERE user_id = ? ORDER BY created_at`, userID.String())
+}
+
+func (r *UserRatingRepository) FindAll(ctx context.Context) ([]*model.UserRating, error) {
+	return r.findMany(ctx, `SELECT `+userRatingColumns+` FROM user_ratings ORDER BY created_at`)
+}
+
+func (r *UserRatingRepository) FindByUserAndGame(ctx context.Context, userID, gameID uuid.UUID) (*model.UserRating, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New("user ID cannot be empty")
+	}
+	if gameID == uuid.Nil {
+		return nil, errors.New("game ID cannot be empty")
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`SELECT `+userRatingColumns+` FROM user_ratings WHERE user_id = ? AND game_id = ?`,
+		userID.String(),
+		gameID.String(),
+	)
+	rating, err := scanUserRating(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrUserRatingNotFound
+		}
+		return nil, err
+	}
+	return rating, nil
+}
+
+func (r *UserRatingRepository) FindStatsByGameIDs(ctx context.Context, gameIDs []uuid.UUID) (map[uuid.UUID]*model.RatingStats, error) {
+	res := make(map[uuid.UUID]*model.RatingStats, len(gameIDs))
+	if len(gameIDs) == 0 {
+		return res, nil
+	}
+
+	placeholders := make([]string, len(gameIDs))
+	args := make([]any, len(gameIDs))
+	for i, id := range gameIDs {
+		placeholders[i] = "?"
+		args[i] = id.String()
+	}
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT game_id, AVG(rating), COUNT(*) FROM user_ratings WHERE game_id IN (`+strings.Join(placeholders, ", ")+`) GROUP BY game_id`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("select rating stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var gameIDStr string
+		var stats model.RatingStats
+		if err := rows.Scan(&gameIDStr, &stats.Average, &stats.Count); err != nil {
+			return nil, fmt.Errorf("scan rating stats: %w", err)
+		}
+		gameID, err := uuid.Parse(gameIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("parse game_id from db: %w", err)
+		}
+		stats.GameID = gameID
+		res[gameID] = &stats
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate rating stats: %w", err)
+	}
+	return res, nil
+}
+
+func (r *UserRatingRepository) findMany(ctx context.Context, query string, args ...any) ([]*model.UserRating, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select user ratings: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.UserRating{}
+	for rows.Next() {
+		rating, err := scanUserRating(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, rating)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate user ratings: %w", err)
+	}
+	return res, nil
+}
+
+func scanUserRating(row rowScanner) (*model.UserRating, error) {
+	var idStr, userIDStr, gameIDStr, createdAtStr string
+	var value int
+	if err := row.Scan(&idStr, &userIDStr, &gameIDStr, &value, &createdAtStr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan user rating: %w", err)
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, fmt.Errorf("parse rating id from db: %w", err)
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("parse user_id from db: %w", err)
+	}
+	gameID, err := uuid.Parse(gameIDStr)
+	if err != nil {
+		return nil, fmt.Errorf("parse game_id from db: %w", err)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return nil, fmt.Errorf("parse created_at from db: %w", err)
+	}
+
+	return &model.UserRating{
+		ID:        id,
+		UserID:    userID,
+		GameID:    gameID,
+		Rating:    value,
+		CreatedAt: createdAt,
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/domain/model"
@@ -16,6 +17,8 @@ import (
 
 var _ repository.UserRepository = (*UserRepository)(nil)
 
+const userColumns = `id, username, password, user_role, deleted_at, version`
+
 type UserRepository struct {
 	db *sql.DB
 }
@@ -31,10 +34,11 @@ func (r *UserRepository) Create(ctx context.Context, user *model.User) (*model.U
 	if user.ID == uuid.Nil {
 		user.ID = uuid.New()
 	}
+	user.Version = 1
 
 	_, err := r.db.ExecContext(
 		ctx,
-		`INSERT INTO users (id, username, password, user_role) VALUES (?, ?, ?, ?)`,
+		`INSERT INTO users (id, username, password, user_role, version) VALUES (?, ?, ?, ?, 1)`,
 		user.ID.String(),
 		user.Username,
 		user.Password,
@@ -54,7 +58,7 @@ func (r *UserRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Use
 	if id == uuid.Nil {
 		return nil, errors.New("user ID cannot be empty")
 	}
-	return r.findOne(ctx, `SELECT id, username, password, user_role FROM users WHERE id = ?`, id.String())
+	return r.findOne(ctx, `SELECT `+userColumns+` FROM users WHERE id = ? AND deleted_at IS NULL`, id.String())
 }
 
 func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
@@ -62,44 +66,51 @@ func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*
 	if username == "" {
 		return nil, errors.New("username cannot be empty")
 	}
-	return r.findOne(ctx, `SELECT id, username, password, user_role FROM users WHERE username = ?`, username)
+	return r.findOne(ctx, `SELECT `+userColumns+` FROM users WHERE username = ? AND deleted_at IS NULL`, username)
 }
 
 func (r *UserRepository) FindAll(ctx context.Context, limit, offset int) ([]*model.User, error) {
-	query := `SELECT id, username, password, user_role FROM users ORDER BY username`
+	query, args := appendPage(`SELECT `+userColumns+` FROM users WHERE deleted_at IS NULL ORDER BY username`, []any{}, limit, offset)
+	return r.findMany(ctx, query, args...)
+}
+
+func (r *UserRepository) FindByQuery(ctx context.Context, q repository.UserQuery) ([]*model.User, int, error) {
+	conds := []string{`deleted_at IS NULL`}
 	args := []any{}
-	if limit > 0 {
-		query += ` LIMIT ? OFFSET ?`
-		args = append(args, limit, offset)
+	if search := strings.TrimSpace(q.Search); search != "" {
+		conds = append(conds, `username LIKE ? ESCAPE '\'`)
+		args = append(args, containsPattern(search))
 	}
+	if q.Role != "" {
+		conds = append(conds, `user_role = ?`)
+		args = append(args, string(q.Role))
+	}
+	where := whereClause(conds)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("select users: %w", err)
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count users: %w", err)
 	}
-	defer rows.Close()
 
-	var res []*model.User
-	for rows.Next() {
-		var idStr, username, password, roleStr string
-		if err := rows.Scan(&idStr, &username, &password, &roleStr); err != nil {
-			return nil, fmt.Errorf("scan user: %w", err)
-		}
-		uid, err := uuid.Parse(idStr)
-		if err != nil {
-			return nil, fmt.Errorf("parse user id from db: %w", err)
-		}
-		res = append(res, &model.User{
-			ID:       uid,
-			Username: username,
-			Password: password,
-			UserRole: specifictype.UserRole(roleStr),
-		})
+	offset := q.Offset
+	if q.After != nil {
+		conds = append(conds, keysetCondition(`username`, `id`, q.SortDesc))
+		args = append(args, q.After.Key, q.After.Key, q.After.ID.String())
+		offset = 0
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate users: %w", err)
+
+	dir := sortDirection(q.SortDesc)
+	query, pageArgs := appendPage(
+		`SELECT `+userColumns+` FROM users`+whereClause(conds)+` ORDER BY username `+dir+`, id `+dir,
+		args,
+		q.Limit,
+		offset,
+	)
+	users, err := r.findMany(ctx, query, pageArgs...)
+	if err != nil {
+		return nil, 0, err
 	}
-	return res, nil
+	return users, total, nil
 }
 
 func (r *UserRepository) Update(ctx context.Context, user *model.User) (*model.User, error) {
@@ -112,11 +123,13 @@ func (r *UserRepository) Update(ctx context.Context, user *model.User) (*model.U
 
 	result, err := r.db.ExecContext(
 		ctx,
-		`UPDATE users SET username = ?, password = ?, user_role = ? WHERE id = ?`,
+		`UPDATE users SET username = ?, password = ?, user_role = ?, version = version + 1
+		WHERE id = ? AND version = ? AND deleted_at IS NULL`,
 		user.Username,
 		user.Password,
 		string(user.UserRole),
 		user.ID.String(),
+		user.Version,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
@@ -126,33 +139,75 @@ func (r *UserRepository) Update(ctx context.Context, user *model.User) (*model.U
 	}
 	affected, _ := result.RowsAffected()
 	if affected == 0 {
-		return nil, repository.ErrUserNotFound
+		return nil, versionMismatch(ctx, r.db, "users", user.ID, repository.ErrUserNotFound)
 	}
+
+	user.Version++
 	return user, nil
 }
 
-func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID, version int) error {
 	if id == uuid.Nil {
 		return errors.New("user ID cannot be empty")
 	}
 
-	result, err := r.db.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id.String())
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE users SET deleted_at = ?, version = version + 1 WHERE id = ? AND version = ? AND deleted_at IS NULL`,
+		formatSortableTime(time.Now()),
+		id.String(),
+		version,
+	)
 	if err != nil {
 		return fmt.Errorf("delete user: %w", err)
 	}
 	affected, _ := result.RowsAffected()
+	if affected == 0 {
+		return versionMismatch(ctx, r.db, "users", id, repository.ErrUserNotFound)
+	}
+	return nil
+}
+
+func (r *UserRepository) FindDeleted(ctx context.Context) ([]*model.User, error) {
+	return r.findMany(ctx, `SELECT `+userColumns+` FROM users WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC, id`)
+}
+
+func (r *UserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("user ID cannot be empty")
+	}
+
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET deleted_at = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL`, id.String())
+	if err != nil {
+		return fmt.Errorf("restore user: %w", err)
+	}
+	affected, _ := result.RowsAffected()
 	if affected == 0 {
 		return repository.ErrUserNotFound
 	}
 	return nil
 }
 
+func (r *UserRepository) Purge(ctx context.Context, before time.Time) (int, error) {
+	// Оценки пользователя удаляются каскадно
+	result, err := r.db.ExecContext(
+		ctx,
+		`DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at < ?`,
+		formatSortableTime(before),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("purge users: %w", err)
+	}
+	affected, _ := result.RowsAffected()
+	return int(affected), nil
+}
+
 func (r *UserRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	if id == uuid.Nil {
 		return false, errors.New("user ID cannot be empty")
 	}
 	var one int
-	err := r.db.QueryRowContext(ctx, `SELECT 1 FROM users WHERE id = ?`, id.String()).Scan(&one)
+	err := r.db.QueryRowContext(ctx, `SELECT 1 FROM users WHERE id = ? AND deleted_at IS NULL`, id.String()).Scan(&one)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return false, nil
@@ -162,24 +217,62 @@ func (r *UserRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error)
 	return true, nil
 }
 
+func (r *UserRepository) findMany(ctx context.Context, query string, args ...any) ([]*model.User, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("select users: %w", err)
+	}
+	defer rows.Close()
+
+	res := []*model.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, user)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate users: %w", err)
+	}
+	return res, nil
+}
+
 func (r *UserRepository) findOne(ctx context.Context, query string, arg any) (*model.User, error) {
-	var idStr, username, password, roleStr string
-	err := r.db.QueryRowContext(ctx, query, arg).Scan(&idStr, &username, &password, &roleStr)
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, arg))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, repository.ErrUserNotFound
 		}
-		return nil, fmt.Errorf("select user: %w", err)
+		return nil, err
+	}
+	return user, nil
+}
+
+func scanUser(row rowScanner) (*model.User, error) {
+	var idStr, username, password, roleStr string
+	var deletedAtStr sql.NullString
+	var version int
+	if err := row.Scan(&idStr, &username, &password, &roleStr, &deletedAtStr, &version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan user: %w", err)
 	}
 	uid, err := uuid.Parse(idStr)
 	if err != nil {
 		return nil, fmt.Errorf("parse user id from db: %w", err)
 	}
+	deletedAt, err := parseDeletedAt(deletedAtStr)
+	if err != nil {
+		return nil, err
+	}
 	return &model.User{
-		ID:       uid,
-		Username: username,
-		Password: password,
-		UserRole: specifictype.UserRole(roleStr),
+		ID:        uid,
+		Username:  username,
+		Password:  password,
+		UserRole:  specifictype.UserRole(roleStr),
+		DeletedAt: deletedAt,
+		Version:   version,
 	}, nil
 }
-
@@ -0,0 +1,53 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"example/web-service-gin/internal/application/abstraction/signing"
+)
+
+// compile-time check
+var _ signing.ContentSigner = (*Ed25519Signer)(nil)
+
+// Ed25519Signer подписывает документы ключом Ed25519 из 32-байтового seed.
+type Ed25519Signer struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+func NewEd25519Signer(seed []byte) (*Ed25519Signer, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("ed25519 seed must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+
+	key := ed25519.NewKeyFromSeed(seed)
+	sum := sha256.Sum256(key.Public().(ed25519.PublicKey))
+	return &Ed25519Signer{key: key, keyID: hex.EncodeToString(sum[:8])}, nil
+}
+
+// NewEd25519SignerFromSecret выводит ключ из общего секрета сервера.
+// Подходит для разработки; в продакшене ключ задается отдельно.
+func NewEd25519SignerFromSecret(secret string) *Ed25519Signer {
+	seed := sha256.Sum256([]byte("build-manifest:" + secret))
+	s, _ := NewEd25519Signer(seed[:])
+	return s
+}
+
+func (s *Ed25519Signer) Algorithm() string {
+	return "ed25519"
+}
+
+func (s *Ed25519Signer) KeyID() string {
+	return s.keyID
+}
+
+func (s *Ed25519Signer) PublicKey() []byte {
+	return append([]byte(nil), s.key.Public().(ed25519.PublicKey)...)
+}
+
+func (s *Ed25519Signer) Sign(data []byte) []byte {
+	return ed25519.Sign(s.key, data)
+}
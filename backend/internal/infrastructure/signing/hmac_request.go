@@ -0,0 +1,47 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"example/web-service-gin/internal/application/abstraction/signing"
+)
+
+// compile-time check
+var _ signing.RequestSigner = (*HMACRequestSigner)(nil)
+
+// requestKeyContext отделяет ключи клиентов от других подписей тем же секретом
+const requestKeyContext = "request-key"
+
+// HMACRequestSigner выдает клиенту ключ HMAC-SHA256(секрет, clientID) в base64url без паддинга;
+// клиент подписывает сообщение HMAC-SHA256 с байтами этой строки как ключом (hex).
+type HMACRequestSigner struct {
+	secret []byte
+}
+
+func NewHMACRequestSigner(secret string) *HMACRequestSigner {
+	return &HMACRequestSigner{secret: []byte(secret)}
+}
+
+func (s *HMACRequestSigner) ClientKey(clientID string) string {
+	m := hmac.New(sha256.New, s.secret)
+	m.Write([]byte(requestKeyContext))
+	m.Write([]byte{0})
+	m.Write([]byte(clientID))
+	return base64.RawURLEncoding.EncodeToString(m.Sum(nil))
+}
+
+func (s *HMACRequestSigner) Verify(clientID string, message []byte, signature string) error {
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return signing.ErrInvalidSignature
+	}
+	m := hmac.New(sha256.New, []byte(s.ClientKey(clientID)))
+	m.Write(message)
+	if !hmac.Equal(got, m.Sum(nil)) {
+		return signing.ErrInvalidSignature
+	}
+	return nil
+}
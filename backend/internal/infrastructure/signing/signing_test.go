@@ -0,0 +1,93 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/signing"
+)
+
+func TestHMACURLSigner(t *testing.T) {
+	s := NewHMACURLSigner("secret")
+	expires := time.Now().Add(time.Minute)
+
+	sig := s.Sign("build-1", expires)
+	if err := s.Verify("build-1", expires, sig); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := s.Verify("build-2", expires, sig); !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for other resource, got %v", err)
+	}
+	// Продлить ссылку, поменяв срок в URL, нельзя
+	if err := s.Verify("build-1", expires.Add(time.Hour), sig); !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for other expiry, got %v", err)
+	}
+	if err := NewHMACURLSigner("other").Verify("build-1", expires, sig); !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for other secret, got %v", err)
+	}
+	if err := s.Verify("build-1", expires, "%%%"); !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for malformed signature, got %v", err)
+	}
+
+	past := time.Now().Add(-time.Second)
+	if err := s.Verify("build-1", past, s.Sign("build-1", past)); !errors.Is(err, signing.ErrExpired) {
+		t.Fatalf("expected ErrExpired, got %v", err)
+	}
+}
+
+func TestHMACRequestSigner(t *testing.T) {
+	s := NewHMACRequestSigner("secret")
+	key := s.ClientKey("token-1")
+	if key == s.ClientKey("token-2") || key == NewHMACRequestSigner("other").ClientKey("token-1") {
+		t.Fatalf("client keys must depend on client and secret")
+	}
+
+	// Клиент подписывает сообщение выданным ключом, не зная секрета сервера
+	message := []byte("1700000000\nnonce\n{}")
+	m := hmac.New(sha256.New, []byte(key))
+	m.Write(message)
+	sig := hex.EncodeToString(m.Sum(nil))
+
+	if err := s.Verify("token-1", message, sig); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if err := s.Verify("token-2", message, sig); !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for other client, got %v", err)
+	}
+	if err := s.Verify("token-1", []byte("1700000000\nnonce\n{\"score\":1}"), sig); !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for other message, got %v", err)
+	}
+	if err := s.Verify("token-1", message, "zz"); !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature for malformed signature, got %v", err)
+	}
+}
+
+func TestEd25519Signer(t *testing.T) {
+	s := NewEd25519SignerFromSecret("secret")
+	data := []byte(`{"files":[]}`)
+
+	sig := s.Sign(data)
+	if !ed25519.Verify(s.PublicKey(), data, sig) {
+		t.Fatalf("signature must verify with the public key")
+	}
+	if ed25519.Verify(s.PublicKey(), []byte(`{"files":[1]}`), sig) {
+		t.Fatalf("signature must not verify for other data")
+	}
+
+	same := NewEd25519SignerFromSecret("secret")
+	if same.KeyID() != s.KeyID() || len(s.KeyID()) != 16 {
+		t.Fatalf("key must be derived deterministically: %s vs %s", same.KeyID(), s.KeyID())
+	}
+	if NewEd25519SignerFromSecret("other").KeyID() == s.KeyID() {
+		t.Fatalf("different secrets must give different keys")
+	}
+
+	if _, err := NewEd25519Signer([]byte("short")); err == nil {
+		t.Fatalf("expected error for short seed")
+	}
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+	"example/web-service-gin/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AttemptHandler struct {
+	attemptService *services.AttemptService
+}
+
+func NewAttemptHandler(attemptService *services.AttemptService) *AttemptHandler {
+	return &AttemptHandler{attemptService: attemptService}
+}
+
+// StartAttempt начинает попытку пройти игру
+// @Summary      Начать попытку
+// @Description  Создает попытку в статусе started и выдает данные для запуска игры: одноразовый токен запуска
+// @Description  (действует LAUNCH_TOKEN_TTL, по умолчанию 5 минут), зерно, режим, параметры задачи, callbackUrl и те же данные
+// @Description  в виде аргументов командной строки для Unity. Прежние незавершенные попытки пользователя в игре бросаются
+// @Tags         attempts
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        data body dto.StartAttemptDto false "Задача; без нее - свободная игра"
+// @Success      201 {object} dto.AttemptLaunchDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/attempts [post]
+func (h *AttemptHandler) StartAttempt(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		return
+	}
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	// Тело необязательно: без него игра запускается в свободном режиме
+	var req dto.StartAttemptDto
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	res, err := h.attemptService.StartAttempt(c.Request.Context(), userID, gameID, req, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// LaunchAttempt принимает токен запуска от игры
+// @Summary      Предъявить токен запуска
+// @Description  Игра вызывает callbackUrl с токеном из аргументов запуска; попытка переходит в running.
+// @Description  Токен действует один раз. Просроченный токен бросает попытку. В ответ игра получает токен
+// @Description  и ключ подписи для отправки результата (POST /games/{id}/results)
+// @Tags         attempts
+// @Accept       json
+// @Produce      json
+// @Param        data body dto.LaunchAttemptDto true "Токен запуска"
+// @Success      200 {object} dto.AttemptSessionDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /attempts/launch [post]
+func (h *AttemptHandler) LaunchAttempt(c *gin.Context) {
+	var req dto.LaunchAttemptDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	session, err := h.attemptService.LaunchAttempt(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, repository.ErrAttemptNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrLaunchTokenInvalid})
+			return
+		}
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// GetAttempt возвращает попытку
+// @Summary      Попытка
+// @Description  Пользователь видит свои попытки, администратор - любые
+// @Tags         attempts
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID попытки"
+// @Success      200 {object} dto.AttemptDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /attempts/{id} [get]
+func (h *AttemptHandler) GetAttempt(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		return
+	}
+	attemptID, ok := h.parseAttemptID(c)
+	if !ok {
+		return
+	}
+
+	attempt, err := h.attemptService.GetAttempt(c.Request.Context(), attemptID, userID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attempt)
+}
+
+// AbandonAttempt бросает попытку
+// @Summary      Бросить попытку
+// @Description  Незавершенная (started или running) попытка пользователя переходит в abandoned
+// @Tags         attempts
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID попытки"
+// @Success      200 {object} dto.AttemptDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /attempts/{id}/abandon [post]
+func (h *AttemptHandler) AbandonAttempt(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		return
+	}
+	attemptID, ok := h.parseAttemptID(c)
+	if !ok {
+		return
+	}
+
+	attempt, err := h.attemptService.AbandonAttempt(c.Request.Context(), attemptID, userID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attempt)
+}
+
+func (h *AttemptHandler) parseAttemptID(c *gin.Context) (uuid.UUID, bool) {
+	attemptID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID попытки"})
+		return uuid.Nil, false
+	}
+	return attemptID, true
+}
+
+func (h *AttemptHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+	case errors.Is(err, repository.ErrAttemptNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrAttemptNotFound})
+	case errors.Is(err, model.ErrLaunchTokenExpired):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrLaunchTokenExpired})
+	case errors.Is(err, model.ErrAttemptTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBusinessAttemptFinished})
+	case isQueryError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при работе с попытками"})
+	}
+}
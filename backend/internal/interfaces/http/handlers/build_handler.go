@@ -0,0 +1,418 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/archive"
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/abstraction/signing"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type BuildHandler struct {
+	buildService *services.BuildService
+	maxBytes     int64
+}
+
+func NewBuildHandler(buildService *services.BuildService, maxBytes int64) *BuildHandler {
+	return &BuildHandler{buildService: buildService, maxBytes: maxBytes}
+}
+
+// UploadBuild загружает сборку игры
+// @Summary      Загрузить сборку игры
+// @Description  Принимает архив zip или tar.gz (формат определяется по содержимому) и проверяет структуру сборки Unity:
+// @Description  для Windows и Linux рядом с исполняемым файлом должна быть папка <имя>_Data, для macOS файл лежит в <имя>.app/Contents/MacOS.
+// @Description  Если у платформы нет текущей сборки, новая становится текущей; иначе - только с current=true
+// @Tags         builds
+// @Security     ApiKeyAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        file formData file true "Архив сборки"
+// @Param        version formData string true "Версия сборки"
+// @Param        platform formData string true "Платформа" Enums(windows-x86_64, linux-x86_64, macos-x86_64, macos-arm64)
+// @Param        entrypoint formData string true "Путь к исполняемому файлу внутри архива"
+// @Param        releaseNotes formData string false "Описание изменений"
+// @Param        current formData bool false "Сразу сделать текущей" default(false)
+// @Success      201 {object} dto.GameBuildDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      413 {object} map[string]string
+// @Failure      415 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/builds [post]
+func (h *BuildHandler) UploadBuild(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverhead)
+
+	var req dto.UploadBuildDto
+	if err := c.ShouldBind(&req); err != nil {
+		h.writeFormError(c, err)
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		h.writeFormError(c, err)
+		return
+	}
+	// Большие файлы multipart лежат во временном файле, так что архив читается с диска
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrValidationBuildFile})
+		return
+	}
+	defer file.Close()
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	build, err := h.buildService.UploadBuild(c.Request.Context(), gameID, req, file, header.Size, userID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, build)
+}
+
+// GetBuilds возвращает сборки игры
+// @Summary      Сборки игры
+// @Description  Все сборки игры, новые первыми. Неопубликованные игры видят только администраторы
+// @Tags         builds
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        platform query string false "Только сборки платформы" Enums(windows-x86_64, linux-x86_64, macos-x86_64, macos-arm64)
+// @Success      200 {array} dto.GameBuildDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/builds [get]
+func (h *BuildHandler) GetBuilds(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+	var req dto.BuildListQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	builds, err := h.buildService.ListBuilds(c.Request.Context(), gameID, req, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, builds)
+}
+
+// GetCurrentBuild возвращает текущую сборку платформы
+// @Summary      Текущая сборка
+// @Description  Сборка, которую лаунчер должен скачать (archiveUrl), сверить по sha256 и запустить (entrypoint)
+// @Description  Неопубликованные игры видят только администраторы
+// @Tags         builds
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        platform query string true "Платформа" Enums(windows-x86_64, linux-x86_64, macos-x86_64, macos-arm64)
+// @Success      200 {object} dto.GameBuildDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/builds/current [get]
+func (h *BuildHandler) GetCurrentBuild(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+	var req dto.CurrentBuildQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrValidationBuildPlatform})
+		return
+	}
+
+	build, err := h.buildService.GetCurrentBuild(c.Request.Context(), gameID, req.Platform, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, build)
+}
+
+// GetBuild возвращает сборку игры
+// @Summary      Сборка игры
+// @Description  Неопубликованные игры видят только администраторы
+// @Tags         builds
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        buildId path string true "ID сборки"
+// @Success      200 {object} dto.GameBuildDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/builds/{buildId} [get]
+func (h *BuildHandler) GetBuild(c *gin.Context) {
+	gameID, buildID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	build, err := h.buildService.GetBuild(c.Request.Context(), gameID, buildID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, build)
+}
+
+// CreateDownloadURL выдает ссылку на архив сборки
+// @Summary      Ссылка на скачивание сборки
+// @Description  Подписанная ссылка на архив, действующая несколько минут. По ней архив скачивается без токена
+// @Description  и докачивается заголовком Range после обрыва, пока ссылка не устарела.
+// @Description  Неопубликованные игры видят только администраторы
+// @Tags         builds
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        buildId path string true "ID сборки"
+// @Success      200 {object} dto.BuildDownloadURLDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/builds/{buildId}/download-url [post]
+func (h *BuildHandler) CreateDownloadURL(c *gin.Context) {
+	gameID, buildID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.buildService.CreateDownloadURL(c.Request.Context(), gameID, buildID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// Download отдает архив сборки по подписанной ссылке
+// @Summary      Скачать архив сборки
+// @Description  Работает только по ссылке из download-url. Поддерживаются Range и If-Range для докачки:
+// @Description  ETag - SHA-256 архива, поэтому докачка с другим содержимым невозможна. HEAD возвращает размер без тела
+// @Tags         builds
+// @Produce      application/zip
+// @Produce      application/gzip
+// @Param        id path string true "ID игры"
+// @Param        buildId path string true "ID сборки"
+// @Param        expires query int true "Срок действия ссылки (Unix-время)"
+// @Param        signature query string true "Подпись ссылки"
+// @Param        Range header string false "Диапазон байтов, например bytes=1048576-"
+// @Param        If-Range header string false "ETag из первого ответа"
+// @Success      200 {file} file
+// @Success      206 {file} file
+// @Header       200 {string} ETag "SHA-256 архива"
+// @Failure      304
+// @Failure      400 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      416
+// @Router       /games/{id}/builds/{buildId}/download [get]
+func (h *BuildHandler) Download(c *gin.Context) {
+	gameID, buildID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+	var req dto.BuildDownloadQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrValidationBuildLink})
+		return
+	}
+
+	content, err := h.buildService.OpenDownload(c.Request.Context(), gameID, buildID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	defer content.Content.Close()
+
+	// Ссылка подписана на короткий срок, поэтому в общие кэши ответ не попадает
+	c.Header("Cache-Control", "private, max-age=0")
+	c.Header("ETag", `"`+content.SHA256+`"`)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", content.Filename))
+	// ServeContent подберет Content-Type по расширению имени и обработает Range, If-Range и HEAD
+	http.ServeContent(c.Writer, c.Request, content.Filename, content.ModTime, content.Content)
+}
+
+// GetManifest возвращает подписанный манифест сборки
+// @Summary      Манифест сборки
+// @Description  SHA-256 и размер архива и каждого файла в нем. Тело подписано ключом из /builds/signing-key:
+// @Description  подпись (base64) - в заголовке X-Signature, проверяется по байтам тела как есть.
+// @Description  Неопубликованные игры видят только администраторы
+// @Tags         builds
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        buildId path string true "ID сборки"
+// @Success      200 {object} dto.BuildManifestDto
+// @Header       200 {string} X-Signature "Подпись тела в base64"
+// @Header       200 {string} X-Signature-Algorithm "Алгоритм подписи"
+// @Header       200 {string} X-Signature-Key-Id "ID ключа подписи"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/builds/{buildId}/manifest [get]
+func (h *BuildHandler) GetManifest(c *gin.Context) {
+	gameID, buildID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	manifest, err := h.buildService.GetManifest(c.Request.Context(), gameID, buildID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Header("X-Signature", manifest.Signature)
+	c.Header("X-Signature-Algorithm", manifest.Algorithm)
+	c.Header("X-Signature-Key-Id", manifest.KeyID)
+	c.Data(http.StatusOK, "application/json; charset=utf-8", manifest.Body)
+}
+
+// GetSigningKey возвращает открытый ключ подписи манифестов
+// @Summary      Ключ подписи манифестов
+// @Description  Лаунчер сохраняет ключ заранее и проверяет им заголовок X-Signature у манифестов
+// @Tags         builds
+// @Produce      json
+// @Success      200 {object} dto.BuildSigningKeyDto
+// @Router       /builds/signing-key [get]
+func (h *BuildHandler) GetSigningKey(c *gin.Context) {
+	c.JSON(http.StatusOK, h.buildService.SigningKey())
+}
+
+// SetCurrentBuild делает сборку текущей
+// @Summary      Сделать сборку текущей
+// @Description  Прежняя текущая сборка той же платформы перестает быть текущей; так же выполняется откат на старую версию
+// @Tags         builds
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        buildId path string true "ID сборки"
+// @Success      200 {object} dto.GameBuildDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/builds/{buildId}/current [post]
+func (h *BuildHandler) SetCurrentBuild(c *gin.Context) {
+	gameID, buildID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	build, err := h.buildService.SetCurrentBuild(c.Request.Context(), gameID, buildID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, build)
+}
+
+// DeleteBuild удаляет сборку
+// @Summary      Удалить сборку
+// @Description  После удаления текущей сборки у платформы нет текущей, пока ее не назначат
+// @Tags         builds
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        buildId path string true "ID сборки"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/builds/{buildId} [delete]
+func (h *BuildHandler) DeleteBuild(c *gin.Context) {
+	gameID, buildID, ok := h.parseIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.buildService.DeleteBuild(c.Request.Context(), gameID, buildID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BuildHandler) parseGameID(c *gin.Context) (uuid.UUID, bool) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return uuid.Nil, false
+	}
+	return gameID, true
+}
+
+func (h *BuildHandler) parseIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	buildID, err := uuid.Parse(c.Param("buildId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID сборки"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return gameID, buildID, true
+}
+
+func (h *BuildHandler) writeFormError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": constants.ErrBuildTooLarge})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrValidationBuildFile})
+}
+
+func (h *BuildHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+	case errors.Is(err, repository.ErrBuildNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrBuildNotFound})
+	case errors.Is(err, repository.ErrBuildAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBuildAlreadyExists})
+	case errors.Is(err, signing.ErrExpired):
+		c.JSON(http.StatusForbidden, gin.H{"error": constants.ErrBuildLinkExpired})
+	case errors.Is(err, signing.ErrInvalidSignature):
+		c.JSON(http.StatusForbidden, gin.H{"error": constants.ErrBuildLinkInvalid})
+	case errors.Is(err, archive.ErrTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": constants.ErrBuildTooLarge})
+	case errors.Is(err, archive.ErrUnsupported):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": constants.ErrBuildUnsupported})
+	case isQueryError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при работе со сборками"})
+	}
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCatalogImportSize - предельный размер файла импорта каталога
+const maxCatalogImportSize = 10 << 20
+
+type CatalogHandler struct {
+	catalogService *services.CatalogService
+}
+
+func NewCatalogHandler(catalogService *services.CatalogService) *CatalogHandler {
+	return &CatalogHandler{catalogService: catalogService}
+}
+
+// ExportCatalog выгружает жанры и игры
+// @Summary      Выгрузить каталог
+// @Description  Выгружает все активные жанры и игры в CSV, JSON или NDJSON. Игры ссылаются на жанры по названию,
+// @Description  у игр выгружаются статус публикации и publishAt
+// @Tags         catalog
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        format query string false "Формат файла" Enums(csv, json, ndjson) default(json)
+// @Success      200 {array} catalog.Record
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /admin/catalog/export [get]
+func (h *CatalogHandler) ExportCatalog(c *gin.Context) {
+	var req dto.CatalogExportQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	contentType, err := h.catalogService.ContentType(req.Format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(req.Format))
+	if format == "" {
+		format = "json"
+	}
+	filename := fmt.Sprintf("catalog-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := h.catalogService.ExportCatalog(c.Request.Context(), req.Format, c.Writer); err != nil {
+		// Если часть файла уже отправлена, статус поменять нельзя
+		if !c.Writer.Written() {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при выгрузке каталога"})
+		}
+		return
+	}
+}
+
+// ImportCatalog загружает жанры и игры из файла
+// @Summary      Загрузить каталог
+// @Description  Загружает жанры и игры из тела запроса. Записи с id обновляют существующие, без id - создаются.
+// @Description  Жанры игр ищутся по названию; с createGenres недостающие жанры создаются.
+// @Description  Статус игры и publishAt берутся из файла; новая игра без статуса создается черновиком, у существующей статус не меняется.
+// @Description  Все записи сохраняются одной транзакцией: при любой ошибке в записях ничего не меняется (422 с отчетом).
+// @Description  С dryRun файл только проверяется, отчет возвращается с кодом 200.
+// @Description  Если дневную квоту создания успели исчерпать параллельно с импортом - 429
+// @Tags         catalog
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        format query string false "Формат файла" Enums(csv, json, ndjson) default(json)
+// @Param        dryRun query bool false "Только проверить файл" default(false)
+// @Param        createGenres query bool false "Создавать недостающие жанры" default(false)
+// @Param        file body []catalog.Record true "Содержимое файла"
+// @Success      200 {object} dto.CatalogImportResultDto
+// @Failure      400 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      413 {object} map[string]string
+// @Failure      422 {object} dto.CatalogImportResultDto
+// @Failure      429 {object} map[string]any
+// @Failure      500 {object} map[string]string
+// @Router       /admin/catalog/import [post]
+func (h *CatalogHandler) ImportCatalog(c *gin.Context) {
+	var req dto.CatalogImportQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxCatalogImportSize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Файл импорта слишком большой"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Не удалось прочитать файл импорта"})
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	result, err := h.catalogService.ImportCatalog(c.Request.Context(), req, bytes.NewReader(body), userID)
+	if err != nil {
+		switch {
+		case isQueryError(err):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case policyViolation(c, err):
+		case errors.Is(err, repository.ErrVersionConflict) || errors.Is(err, repository.ErrDuplicateTitle):
+			c.JSON(http.StatusConflict, gin.H{"error": "Каталог изменился во время импорта, повторите попытку"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при импорте каталога"})
+		}
+		return
+	}
+
+	if !result.DryRun && len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
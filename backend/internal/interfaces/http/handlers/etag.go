@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"example/web-service-gin/internal/constants"
+)
+
+// setETag отдает версию записи как сильный ETag: "3".
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion читает ожидаемую версию из If-Match; "*" - любая версия (0).
+// Без заголовка отвечает 428, при неверном формате - 400; в этих случаях ok = false.
+func ifMatchVersion(c *gin.Context) (version int, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": constants.ErrValidationNoIfMatch})
+		return 0, false
+	}
+	if header == "*" {
+		return 0, true
+	}
+
+	raw, err := strconv.Unquote(header)
+	if err == nil {
+		version, err = strconv.Atoi(raw)
+	}
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrValidationIfMatch})
+		return 0, false
+	}
+	return version, true
+}
+
+// preconditionFailed отвечает 412 с актуальным состоянием записи, чтобы клиент мог слить изменения.
+func preconditionFailed(c *gin.Context, current any, version int) {
+	setETag(c, version)
+	c.JSON(http.StatusPreconditionFailed, gin.H{
+		"error":   constants.ErrBusinessVersionConflict,
+		"current": current,
+	})
+}
@@ -2,13 +2,20 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/application/dto"
 	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+	"example/web-service-gin/internal/interfaces/http/middleware"
 )
 
 type GameHandler struct {
@@ -23,14 +30,17 @@ func NewGameHandler(gameService *services.GameService) *GameHandler {
 
 // CreateGame создает новую игру
 // @Summary      Создать игру
-// @Description  Создает новую игру в системе
+// @Description  Создает черновик игры; с publishAt игра будет опубликована в этот момент. Название должно быть уникальным без учета регистра, лишних пробелов и формы Unicode: иначе 409 с существующей игрой в поле conflict. Нарушение политики игр (длина полей, дата релиза) - 400, исчерпанная дневная квота администратора - 429; в поле rule имя правила, в limit его предел
 // @Tags         games
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        data body dto.CreateGameDto true "Данные для создания игры"
 // @Success      201 {object} dto.GameDto
+// @Header       201 {string} ETag "Версия игры"
 // @Failure      400 {object} map[string]string
+// @Failure      409 {object} map[string]any
+// @Failure      429 {object} map[string]any
 // @Failure      500 {object} map[string]string
 // @Router       /games [post]
 func (h *GameHandler) CreateGame(c *gin.Context) {
@@ -41,23 +51,31 @@ func (h *GameHandler) CreateGame(c *gin.Context) {
 		return
 	}
 
-	game, err := h.gameService.CreateGame(c.Request.Context(), req)
+	userID, _ := middleware.CurrentUserID(c)
+
+	game, err := h.gameService.CreateGame(c.Request.Context(), req, userID)
 	if err != nil {
+		if duplicateTitle(c, err) || policyViolation(c, err) {
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(c, game.Version)
 	c.JSON(http.StatusCreated, game)
 }
 
 // GetGame получает игру по ID
 // @Summary      Получить игру
-// @Description  Получает информацию об игре по её идентификатору
+// @Description  Получает информацию об игре по её идентификатору. Неопубликованные игры видят только администраторы, остальным - 404
 // @Tags         games
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID игры"
 // @Success      200 {object} dto.GameDto
+// @Header       200 {string} ETag "Версия игры для If-Match"
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
 // @Router       /games/{id} [get]
@@ -68,27 +86,132 @@ func (h *GameHandler) GetGame(c *gin.Context) {
 		return
 	}
 
-	game, err := h.gameService.GetGameByID(c.Request.Context(), gameID)
+	game, err := h.gameService.GetGameByID(c.Request.Context(), gameID, canSeeUnpublished(c))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Игра не найдена"})
 		return
 	}
 
+	setETag(c, game.Version)
+	c.JSON(http.StatusOK, game)
+}
+
+// GetSimilarTitles ищет игры с похожими названиями
+// @Summary      Похожие названия
+// @Description  Активные игры в любом статусе, название которых похоже на title (триграммы и расстояние Левенштейна после нормализации), самые похожие первыми. exact=true - название совпадает, создать игру с ним нельзя
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        title query string true "Название новой игры"
+// @Param        limit query int false "Максимум игр в ответе (до 100)" default(10)
+// @Success      200 {array} dto.SimilarTitleDto
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/similar-titles [get]
+func (h *GameHandler) GetSimilarTitles(c *gin.Context) {
+	var req dto.SimilarTitlesQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	titles, err := h.gameService.FindSimilarTitles(c.Request.Context(), req)
+	if err != nil {
+		if isQueryError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при поиске похожих названий"})
+		return
+	}
+
+	c.JSON(http.StatusOK, titles)
+}
+
+// GetGameDetails получает игру с жанром и статистикой оценок
+// @Summary      Получить детали игры
+// @Description  Возвращает игру вместе с жанром, средней оценкой, числом оценок и оценкой текущего пользователя. Неопубликованные игры видят только администраторы
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Success      200 {object} dto.GameDtoWithStats
+// @Header       200 {string} ETag "Версия игры для If-Match"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/details [get]
+func (h *GameHandler) GetGameDetails(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	game, err := h.gameService.GetGameDetails(c.Request.Context(), gameID, userID, canSeeUnpublished(c))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Игра не найдена"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при получении игры"})
+		return
+	}
+
+	setETag(c, game.Version)
 	c.JSON(http.StatusOK, game)
 }
 
-// GetAllGames получает список всех игр
-// @Summary      Получить все игры
-// @Description  Возвращает список всех игр в системе
+// GetAllGames получает список игр
+// @Summary      Получить игры
+// @Description  Возвращает страницу игр с фильтрами и сортировкой; с withStats=true элементы содержат жанр и статистику оценок (dto.GameDtoWithStats). Пользователи видят только опубликованные игры, администраторы - все и могут фильтровать по status
 // @Tags         games
+// @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
-// @Success      200 {array} dto.GameDto
+// @Param        page query int false "Номер страницы (с 1)" default(1)
+// @Param        pageSize query int false "Размер страницы (максимум 100)" default(20)
+// @Param        after query string false "Курсор следующей страницы (nextCursor из предыдущего ответа; действителен только с теми же фильтрами и сортировкой)"
+// @Param        genreId query string false "ID жанра"
+// @Param        genreIds query []string false "Несколько ID жанров (повтор параметра или через запятую)" collectionFormat(multi)
+// @Param        genreMatch query string false "any - хотя бы один из жанров, all - все сразу" Enums(any, all) default(any)
+// @Param        releasedFrom query string false "Дата релиза от (YYYY-MM-DD или RFC3339)"
+// @Param        releasedTo query string false "Дата релиза до (YYYY-MM-DD или RFC3339)"
+// @Param        q query string false "Поиск по названию и описанию"
+// @Param        sort query string false "title | releaseDate | rating, префикс - для убывания" default(-releaseDate)
+// @Param        withStats query bool false "Добавить жанр и статистику оценок"
+// @Param        status query string false "Статус публикации (только для администраторов)" Enums(draft, scheduled, published, archived)
+// @Success      200 {object} dto.PaginatedResponse{items=[]dto.GameDto}
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /games [get]
 func (h *GameHandler) GetAllGames(c *gin.Context) {
-	games, err := h.gameService.GetAllGames(c.Request.Context())
+	var req dto.GameListQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	var (
+		games any
+		err   error
+	)
+	withUnpublished := canSeeUnpublished(c)
+	if req.WithStats {
+		userID, _ := middleware.CurrentUserID(c)
+		games, err = h.gameService.GetAllGamesWithStats(c.Request.Context(), req, userID, withUnpublished)
+	} else {
+		games, err = h.gameService.GetAllGames(c.Request.Context(), req, withUnpublished)
+	}
 	if err != nil {
+		if isQueryError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при получении игр"})
 		return
 	}
@@ -96,18 +219,57 @@ func (h *GameHandler) GetAllGames(c *gin.Context) {
 	c.JSON(http.StatusOK, games)
 }
 
+// SearchGames ищет игры по названию и описанию
+// @Summary      Поиск игр
+// @Description  Полнотекстовый поиск по названию и описанию с ранжированием по релевантности; найденные слова выделены тегом <mark>. Пользователи находят только опубликованные игры
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        q query string true "Поисковый запрос"
+// @Param        page query int false "Номер страницы (с 1)" default(1)
+// @Param        pageSize query int false "Размер страницы (максимум 100)" default(20)
+// @Success      200 {object} dto.PaginatedResponse{items=[]dto.GameSearchHitDto}
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/search [get]
+func (h *GameHandler) SearchGames(c *gin.Context) {
+	var req dto.GameSearchQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	result, err := h.gameService.SearchGames(c.Request.Context(), req, canSeeUnpublished(c))
+	if err != nil {
+		if isQueryError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при поиске игр"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // UpdateGame обновляет игру
 // @Summary      Обновить игру
-// @Description  Обновляет информацию об игре
+// @Description  Обновляет информацию об игре, если с момента чтения ее никто не изменил. При конфликте версий возвращает 412 с актуальной игрой в поле current
 // @Tags         games
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID игры"
+// @Param        If-Match header string true "ETag игры из GET или * для любой версии"
 // @Param        data body dto.UpdateGameDto true "Данные для обновления игры"
 // @Success      200 {object} dto.GameDto
+// @Header       200 {string} ETag "Новая версия игры"
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]any
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
 // @Router       /games/{id} [put]
 func (h *GameHandler) UpdateGame(c *gin.Context) {
 	gameID, err := uuid.Parse(c.Param("id"))
@@ -116,6 +278,11 @@ func (h *GameHandler) UpdateGame(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var req dto.UpdateGameDto
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -124,27 +291,100 @@ func (h *GameHandler) UpdateGame(c *gin.Context) {
 	}
 
 	req.ID = gameID
+	userID, _ := middleware.CurrentUserID(c)
 
-	game, err := h.gameService.UpdateGame(c.Request.Context(), req)
+	game, err := h.gameService.UpdateGame(c.Request.Context(), req, version, userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if duplicateTitle(c, err) || policyViolation(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, repository.ErrVersionConflict):
+			h.gameConflict(c, gameID)
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, game.Version)
+	c.JSON(http.StatusOK, game)
+}
+
+// PatchGame частично обновляет игру
+// @Summary      Частично обновить игру
+// @Description  JSON Merge Patch (RFC 7396): меняются только переданные поля, null в description очищает описание, null в genreIds оставляет только основной жанр. Итог проверяется как при полном обновлении
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        If-Match header string true "ETag игры из GET или * для любой версии"
+// @Param        data body dto.PatchGameDto true "Изменяемые поля игры"
+// @Success      200 {object} dto.GameDto
+// @Header       200 {string} ETag "Новая версия игры"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]any
+// @Failure      412 {object} map[string]any
+// @Failure      415 {object} map[string]string
+// @Failure      428 {object} map[string]string
+// @Router       /games/{id} [patch]
+func (h *GameHandler) PatchGame(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
+	var req dto.PatchGameDto
+	if !bindMergePatch(c, &req) {
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	game, err := h.gameService.PatchGame(c.Request.Context(), gameID, req, version, userID)
+	if err != nil {
+		if duplicateTitle(c, err) || policyViolation(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, repository.ErrVersionConflict):
+			h.gameConflict(c, gameID)
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, game.Version)
 	c.JSON(http.StatusOK, game)
 }
 
 // DeleteGame удаляет игру
 // @Summary      Удалить игру
-// @Description  Удаляет игру из системы по её идентификатору
+// @Description  Перемещает игру в корзину; восстановить можно через POST /games/{id}/restore. При конфликте версий возвращает 412 с актуальной игрой в поле current
 // @Tags         games
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID игры"
+// @Param        If-Match header string true "ETag игры из GET или * для любой версии"
 // @Success      200 {object} map[string]string
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /games/{id} [delete]
 func (h *GameHandler) DeleteGame(c *gin.Context) {
@@ -154,15 +394,386 @@ func (h *GameHandler) DeleteGame(c *gin.Context) {
 		return
 	}
 
-	err = h.gameService.DeleteGame(c.Request.Context(), gameID)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	err = h.gameService.DeleteGame(c.Request.Context(), gameID, version, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при удалении игры"})
+		switch {
+		case errors.Is(err, repository.ErrVersionConflict):
+			h.gameConflict(c, gameID)
+		case errors.Is(err, repository.ErrNotFound) || err.Error() == constants.ErrGameNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при удалении игры"})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Игра успешно удалена"})
 }
 
+// RestoreGame восстанавливает игру из корзины
+// @Summary      Восстановить игру
+// @Description  Возвращает игру из корзины; жанры игры не должны находиться в корзине, а название не должно быть занято другой активной игрой
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Success      200 {object} dto.GameDto
+// @Header       200 {string} ETag "Новая версия игры"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]any
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/restore [post]
+func (h *GameHandler) RestoreGame(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	game, err := h.gameService.RestoreGame(c.Request.Context(), gameID, userID)
+	if err != nil {
+		if duplicateTitle(c, err) || policyViolation(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Игра не найдена в корзине"})
+		case errors.Is(err, repository.ErrGameGenreDeleted):
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBusinessGameGenreDeleted})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при восстановлении игры"})
+		}
+		return
+	}
+
+	setETag(c, game.Version)
+	c.JSON(http.StatusOK, game)
+}
+
+// PublishGame публикует игру
+// @Summary      Опубликовать игру
+// @Description  Делает черновик, запланированную или архивную игру видимой всем сразу. Недопустимый переход статуса - 409, конфликт версий - 412 с актуальной игрой в поле current
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        If-Match header string true "ETag игры из GET или * для любой версии"
+// @Success      200 {object} dto.GameDto
+// @Header       200 {string} ETag "Новая версия игры"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
+// @Router       /games/{id}/publish [post]
+func (h *GameHandler) PublishGame(c *gin.Context) {
+	h.changeStatus(c, h.gameService.PublishGame)
+}
+
+// ScheduleGame планирует публикацию игры
+// @Summary      Запланировать публикацию
+// @Description  Назначает черновику момент публикации или переносит уже запланированную; publishAt должен быть в будущем. В этот момент игра будет опубликована автоматически, в том числе после перезапуска сервиса
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        If-Match header string true "ETag игры из GET или * для любой версии"
+// @Param        data body dto.ScheduleGameDto true "Момент публикации"
+// @Success      200 {object} dto.GameDto
+// @Header       200 {string} ETag "Новая версия игры"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
+// @Router       /games/{id}/schedule [post]
+func (h *GameHandler) ScheduleGame(c *gin.Context) {
+	var req dto.ScheduleGameDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	h.changeStatus(c, func(ctx context.Context, gameID uuid.UUID, version int, actorID uuid.UUID) (*dto.GameDto, error) {
+		return h.gameService.ScheduleGame(ctx, gameID, req.PublishAt, version, actorID)
+	})
+}
+
+// UnpublishGame снимает игру с публикации
+// @Summary      Снять с публикации
+// @Description  Возвращает опубликованную или запланированную игру в черновики; запланированная публикация отменяется
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        If-Match header string true "ETag игры из GET или * для любой версии"
+// @Success      200 {object} dto.GameDto
+// @Header       200 {string} ETag "Новая версия игры"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
+// @Router       /games/{id}/unpublish [post]
+func (h *GameHandler) UnpublishGame(c *gin.Context) {
+	h.changeStatus(c, h.gameService.UnpublishGame)
+}
+
+// ArchiveGame переносит игру в архив
+// @Summary      Архивировать игру
+// @Description  Скрывает опубликованную игру из каталога, не удаляя ее; вернуть можно через publish
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        If-Match header string true "ETag игры из GET или * для любой версии"
+// @Success      200 {object} dto.GameDto
+// @Header       200 {string} ETag "Новая версия игры"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
+// @Router       /games/{id}/archive [post]
+func (h *GameHandler) ArchiveGame(c *gin.Context) {
+	h.changeStatus(c, h.gameService.ArchiveGame)
+}
+
+// changeStatus - общая часть обработчиков смены статуса: ID из пути, версия из If-Match
+// и ответы на ошибки перехода.
+func (h *GameHandler) changeStatus(c *gin.Context,
+	change func(ctx context.Context, gameID uuid.UUID, version int, actorID uuid.UUID) (*dto.GameDto, error)) {
+
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	game, err := change(c.Request.Context(), gameID, version, userID)
+	if err != nil {
+		var transition *services.StatusTransitionError
+		switch {
+		case errors.As(err, &transition):
+			c.JSON(http.StatusConflict, gin.H{"error": transition.Error()})
+		case errors.Is(err, repository.ErrVersionConflict):
+			h.gameConflict(c, gameID)
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, game.Version)
+	c.JSON(http.StatusOK, game)
+}
+
+// GetGameRevisions возвращает историю изменений игры
+// @Summary      История изменений игры
+// @Description  Ревизии игры (создание, изменения, удаление, восстановление, откаты), последние первыми; snapshot - состояние игры после изменения
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        page query int false "Номер страницы (с 1)" default(1)
+// @Param        pageSize query int false "Размер страницы (максимум 100)" default(20)
+// @Success      200 {object} dto.PaginatedResponse{items=[]dto.RevisionDto}
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/revisions [get]
+func (h *GameHandler) GetGameRevisions(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	var req dto.RevisionListQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	revisions, err := h.gameService.GetGameRevisions(c.Request.Context(), gameID, req)
+	if err != nil {
+		if isQueryError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при получении истории игры"})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// DiffGameRevisions сравнивает две ревизии игры
+// @Summary      Сравнить ревизии игры
+// @Description  Поля, отличающиеся между ревизиями from и to; по умолчанию сравниваются последняя и предыдущая ревизии
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        from query int false "Номер исходной ревизии (по умолчанию to-1)"
+// @Param        to query int false "Номер конечной ревизии (по умолчанию последняя)"
+// @Success      200 {object} dto.RevisionDiffDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/revisions/diff [get]
+func (h *GameHandler) DiffGameRevisions(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	var req dto.RevisionDiffQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	diff, err := h.gameService.DiffGameRevisions(c.Request.Context(), gameID, req)
+	if err != nil {
+		switch {
+		case isQueryError(err):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrRevisionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Ревизия не найдена"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при сравнении ревизий"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertGame откатывает игру к ревизии
+// @Summary      Откатить игру к ревизии
+// @Description  Применяет состояние игры из ревизии rev с обычной валидацией; откат сохраняется новой ревизией
+// @Tags         games
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        rev path int true "Номер ревизии"
+// @Success      200 {object} dto.GameDto
+// @Header       200 {string} ETag "Новая версия игры"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]any
+// @Router       /games/{id}/revisions/{rev}/revert [post]
+func (h *GameHandler) RevertGame(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный номер ревизии"})
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	game, err := h.gameService.RevertGame(c.Request.Context(), gameID, rev, userID)
+	if err != nil {
+		if duplicateTitle(c, err) || policyViolation(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, repository.ErrRevisionNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Ревизия не найдена"})
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+		case errors.Is(err, repository.ErrVersionConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBusinessVersionConflict})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, game.Version)
+	c.JSON(http.StatusOK, game)
+}
+
+// canSeeUnpublished - черновики, запланированные и архивные игры видят только администраторы.
+func canSeeUnpublished(c *gin.Context) bool {
+	role, ok := middleware.CurrentUserRole(c)
+	return ok && role == specifictype.RoleAdmin
+}
+
+// duplicateTitle отвечает 409 с игрой, которая уже носит это название, в поле conflict;
+// false, если ошибка о другом.
+func duplicateTitle(c *gin.Context, err error) bool {
+	var duplicate *services.DuplicateTitleError
+	if !errors.As(err, &duplicate) {
+		return false
+	}
+	c.JSON(http.StatusConflict, gin.H{
+		"error":    duplicate.Error(),
+		"conflict": duplicate.Existing,
+	})
+	return true
+}
+
+// policyViolation отвечает на нарушение политики игр текстом, именем правила и пределом:
+// 429 для дневной квоты создания, 400 для остальных правил; false, если ошибка о другом.
+func policyViolation(c *gin.Context, err error) bool {
+	var violation *services.PolicyError
+	if !errors.As(err, &violation) {
+		return false
+	}
+	status := http.StatusBadRequest
+	if violation.QuotaExceeded() {
+		status = http.StatusTooManyRequests
+	}
+	c.JSON(status, gin.H{
+		"error": violation.Message,
+		"rule":  violation.Rule,
+		"limit": violation.Limit,
+	})
+	return true
+}
+
+// gameConflict отвечает 412 с актуальной версией игры; если игру успели удалить - 404.
+func (h *GameHandler) gameConflict(c *gin.Context, gameID uuid.UUID) {
+	current, err := h.gameService.GetGameByID(c.Request.Context(), gameID, true)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+		return
+	}
+	preconditionFailed(c, current, current.Version)
+}
+
 // HealthCheck проверяет состояние сервиса
 // @Summary      Проверка здоровья
 // @Description  Проверяет, что сервис работает
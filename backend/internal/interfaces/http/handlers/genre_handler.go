@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"example/web-service-gin/internal/application/dto"
 	"example/web-service-gin/internal/application/services"
 	"example/web-service-gin/internal/application/abstraction/repository"
 	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/interfaces/http/middleware"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -29,6 +31,7 @@ func NewGenreHandler(genreService *services.GenreService) *GenreHandler {
 // @Produce      json
 // @Param        data body dto.CreateGenreDto true "Данные для создания жанра"
 // @Success      201 {object} dto.GenreDto
+// @Header       201 {string} ETag "Версия жанра"
 // @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /genres [post]
@@ -39,12 +42,15 @@ func (h *GenreHandler) CreateGenre(c *gin.Context) {
 		return
 	}
 
-	genre, err := h.genreService.CreateGenre(c.Request.Context(), req)
+	userID, _ := middleware.CurrentUserID(c)
+
+	genre, err := h.genreService.CreateGenre(c.Request.Context(), req, userID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(c, genre.Version)
 	c.JSON(http.StatusCreated, genre)
 }
 
@@ -56,6 +62,7 @@ func (h *GenreHandler) CreateGenre(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "ID жанра"
 // @Success      200 {object} dto.GenreDto
+// @Header       200 {string} ETag "Версия жанра для If-Match"
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
 // @Router       /genres/{id} [get]
@@ -76,21 +83,38 @@ func (h *GenreHandler) GetGenre(c *gin.Context) {
 		return
 	}
 
+	setETag(c, genre.Version)
 	c.JSON(http.StatusOK, genre)
 }
 
-// GetAllGenres получает список всех жанров
-// @Summary      Получить все жанры
-// @Description  Возвращает список всех жанров в системе
+// GetAllGenres получает список жанров
+// @Summary      Получить жанры
+// @Description  Возвращает страницу жанров с поиском и сортировкой по названию
 // @Tags         genres
 // @Accept       json
 // @Produce      json
-// @Success      200 {array} dto.GenreDto
+// @Param        page query int false "Номер страницы (с 1)" default(1)
+// @Param        pageSize query int false "Размер страницы (максимум 100)" default(20)
+// @Param        after query string false "Курсор следующей страницы (nextCursor из предыдущего ответа; действителен только с теми же фильтрами и сортировкой)"
+// @Param        q query string false "Поиск по названию"
+// @Param        sort query string false "title | -title" default(title)
+// @Success      200 {object} dto.PaginatedResponse{items=[]dto.GenreDto}
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /genres [get]
 func (h *GenreHandler) GetAllGenres(c *gin.Context) {
-	genres, err := h.genreService.GetAllGenres(c.Request.Context())
+	var req dto.GenreListQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	genres, err := h.genreService.GetAllGenres(c.Request.Context(), req)
 	if err != nil {
+		if isQueryError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при получении жанров"})
 		return
 	}
@@ -100,16 +124,20 @@ func (h *GenreHandler) GetAllGenres(c *gin.Context) {
 
 // UpdateGenre обновляет жанр
 // @Summary      Обновить жанр
-// @Description  Обновляет информацию о жанре
+// @Description  Обновляет информацию о жанре, если с момента чтения его никто не изменил. При конфликте версий возвращает 412 с актуальным жанром в поле current
 // @Tags         genres
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID жанра"
+// @Param        If-Match header string true "ETag жанра из GET или * для любой версии"
 // @Param        data body dto.UpdateGenreDto true "Данные для обновления жанра"
 // @Success      200 {object} dto.GenreDto
+// @Header       200 {string} ETag "Новая версия жанра"
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
 // @Router       /genres/{id} [put]
 func (h *GenreHandler) UpdateGenre(c *gin.Context) {
 	genreID, err := uuid.Parse(c.Param("id"))
@@ -118,6 +146,11 @@ func (h *GenreHandler) UpdateGenre(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var req dto.UpdateGenreDto
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
@@ -125,31 +158,96 @@ func (h *GenreHandler) UpdateGenre(c *gin.Context) {
 	}
 
 	req.ID = genreID
+	userID, _ := middleware.CurrentUserID(c)
 
-	genre, err := h.genreService.UpdateGenre(c.Request.Context(), req)
+	genre, err := h.genreService.UpdateGenre(c.Request.Context(), req, version, userID)
 	if err != nil {
 		if err == repository.ErrGenreNotFound || err.Error() == constants.ErrGenreNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGenreNotFound})
 			return
 		}
+		if err == repository.ErrVersionConflict {
+			h.genreConflict(c, genreID)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	setETag(c, genre.Version)
+	c.JSON(http.StatusOK, genre)
+}
+
+// PatchGenre частично обновляет жанр
+// @Summary      Частично обновить жанр
+// @Description  JSON Merge Patch (RFC 7396): меняются только переданные поля
+// @Tags         genres
+// @Security     ApiKeyAuth
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id path string true "ID жанра"
+// @Param        If-Match header string true "ETag жанра из GET или * для любой версии"
+// @Param        data body dto.PatchGenreDto true "Изменяемые поля жанра"
+// @Success      200 {object} dto.GenreDto
+// @Header       200 {string} ETag "Новая версия жанра"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      415 {object} map[string]string
+// @Failure      428 {object} map[string]string
+// @Router       /genres/{id} [patch]
+func (h *GenreHandler) PatchGenre(c *gin.Context) {
+	genreID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID жанра"})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PatchGenreDto
+	if !bindMergePatch(c, &req) {
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	genre, err := h.genreService.PatchGenre(c.Request.Context(), genreID, req, version, userID)
+	if err != nil {
+		if err == repository.ErrGenreNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGenreNotFound})
+			return
+		}
+		if err == repository.ErrVersionConflict {
+			h.genreConflict(c, genreID)
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(c, genre.Version)
 	c.JSON(http.StatusOK, genre)
 }
 
 // DeleteGenre удаляет жанр
 // @Summary      Удалить жанр
-// @Description  Удаляет жанр из системы по его идентификатору
+// @Description  Перемещает жанр в корзину; жанр, к которому относятся игры, удалить нельзя. При конфликте версий возвращает 412 с актуальным жанром в поле current
 // @Tags         genres
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID жанра"
+// @Param        If-Match header string true "ETag жанра из GET или * для любой версии"
 // @Success      200 {object} map[string]string
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /genres/{id} [delete]
 func (h *GenreHandler) DeleteGenre(c *gin.Context) {
@@ -159,12 +257,27 @@ func (h *GenreHandler) DeleteGenre(c *gin.Context) {
 		return
 	}
 
-	err = h.genreService.DeleteGenre(c.Request.Context(), genreID)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	err = h.genreService.DeleteGenre(c.Request.Context(), genreID, version, userID)
 	if err != nil {
 		if err == repository.ErrGenreNotFound || err.Error() == constants.ErrGenreNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGenreNotFound})
 			return
 		}
+		if err == repository.ErrGenreInUse {
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBusinessGenreInUse})
+			return
+		}
+		if err == repository.ErrVersionConflict {
+			h.genreConflict(c, genreID)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при удалении жанра"})
 		return
 	}
@@ -172,3 +285,183 @@ func (h *GenreHandler) DeleteGenre(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Жанр успешно удален"})
 }
 
+// RestoreGenre восстанавливает жанр из корзины
+// @Summary      Восстановить жанр
+// @Description  Возвращает жанр из корзины
+// @Tags         genres
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID жанра"
+// @Success      200 {object} dto.GenreDto
+// @Header       200 {string} ETag "Новая версия жанра"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /genres/{id}/restore [post]
+func (h *GenreHandler) RestoreGenre(c *gin.Context) {
+	genreID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID жанра"})
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	genre, err := h.genreService.RestoreGenre(c.Request.Context(), genreID, userID)
+	if err != nil {
+		if err == repository.ErrGenreNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Жанр не найден в корзине"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при восстановлении жанра"})
+		return
+	}
+
+	setETag(c, genre.Version)
+	c.JSON(http.StatusOK, genre)
+}
+
+
+// GetGenreRevisions возвращает историю изменений жанра
+// @Summary      История изменений жанра
+// @Description  Ревизии жанра, последние первыми; snapshot - состояние жанра после изменения
+// @Tags         genres
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID жанра"
+// @Param        page query int false "Номер страницы (с 1)" default(1)
+// @Param        pageSize query int false "Размер страницы (максимум 100)" default(20)
+// @Success      200 {object} dto.PaginatedResponse{items=[]dto.RevisionDto}
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /genres/{id}/revisions [get]
+func (h *GenreHandler) GetGenreRevisions(c *gin.Context) {
+	genreID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID жанра"})
+		return
+	}
+
+	var req dto.RevisionListQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	revisions, err := h.genreService.GetGenreRevisions(c.Request.Context(), genreID, req)
+	if err != nil {
+		if isQueryError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при получении истории жанра"})
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+// DiffGenreRevisions сравнивает две ревизии жанра
+// @Summary      Сравнить ревизии жанра
+// @Description  Поля, отличающиеся между ревизиями from и to; по умолчанию сравниваются последняя и предыдущая ревизии
+// @Tags         genres
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID жанра"
+// @Param        from query int false "Номер исходной ревизии (по умолчанию to-1)"
+// @Param        to query int false "Номер конечной ревизии (по умолчанию последняя)"
+// @Success      200 {object} dto.RevisionDiffDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /genres/{id}/revisions/diff [get]
+func (h *GenreHandler) DiffGenreRevisions(c *gin.Context) {
+	genreID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID жанра"})
+		return
+	}
+
+	var req dto.RevisionDiffQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	diff, err := h.genreService.DiffGenreRevisions(c.Request.Context(), genreID, req)
+	if err != nil {
+		switch {
+		case isQueryError(err):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case err == repository.ErrRevisionNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Ревизия не найдена"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при сравнении ревизий"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
+
+// RevertGenre откатывает жанр к ревизии
+// @Summary      Откатить жанр к ревизии
+// @Description  Применяет состояние жанра из ревизии rev с обычной валидацией; откат сохраняется новой ревизией
+// @Tags         genres
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID жанра"
+// @Param        rev path int true "Номер ревизии"
+// @Success      200 {object} dto.GenreDto
+// @Header       200 {string} ETag "Новая версия жанра"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Router       /genres/{id}/revisions/{rev}/revert [post]
+func (h *GenreHandler) RevertGenre(c *gin.Context) {
+	genreID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID жанра"})
+		return
+	}
+
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный номер ревизии"})
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	genre, err := h.genreService.RevertGenre(c.Request.Context(), genreID, rev, userID)
+	if err != nil {
+		switch {
+		case err == repository.ErrRevisionNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "Ревизия не найдена"})
+		case err == repository.ErrGenreNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGenreNotFound})
+		case err == repository.ErrVersionConflict:
+			c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBusinessVersionConflict})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, genre.Version)
+	c.JSON(http.StatusOK, genre)
+}
+
+// genreConflict отвечает 412 с актуальной версией жанра; если жанр успели удалить - 404.
+func (h *GenreHandler) genreConflict(c *gin.Context, genreID uuid.UUID) {
+	current, err := h.genreService.GetGenreByID(c.Request.Context(), genreID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGenreNotFound})
+		return
+	}
+	preconditionFailed(c, current, current.Version)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LeaderboardHandler struct {
+	leaderboardService *services.LeaderboardService
+}
+
+func NewLeaderboardHandler(leaderboardService *services.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{leaderboardService: leaderboardService}
+}
+
+// GetLeaderboard возвращает таблицу рекордов игры
+// @Summary      Таблица рекордов игры
+// @Description  Пользователи по лучшему результату: больший счет выше, при равном счете - меньшее время, затем более раннее
+// @Description  достижение. window ограничивает результаты последним месяцем или неделей, taskId - одной задачей игры.
+// @Description  С токеном в me возвращается место пользователя, даже если оно ниже первых limit.
+// @Description  Неопубликованные игры видят только администраторы
+// @Tags         leaderboards
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        window query string false "Окно: all, month или week" default(all)
+// @Param        limit query int false "Сколько первых мест вернуть (до 100)" default(10)
+// @Param        taskId query string false "ID задачи игры"
+// @Param        excludeAdmins query bool false "Не показывать администраторов"
+// @Success      200 {object} dto.LeaderboardDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/leaderboard [get]
+func (h *LeaderboardHandler) GetLeaderboard(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+	var req dto.LeaderboardQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	board, err := h.leaderboardService.GetLeaderboard(c.Request.Context(), gameID, req, userID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+func (h *LeaderboardHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+	case isQueryError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при построении таблицы рекордов"})
+	}
+}
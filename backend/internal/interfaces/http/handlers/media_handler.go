@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"example/web-service-gin/internal/application/abstraction/media"
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	// multipartOverhead - запас на заголовки multipart сверх размера самого файла
+	multipartOverhead = 1 << 20
+
+	// Изображение по ID никогда не меняется, а обложку игры могут заменить
+	mediaCacheControl = "public, max-age=31536000, immutable"
+	coverCacheControl = "public, max-age=300"
+)
+
+type MediaHandler struct {
+	mediaService *services.MediaService
+	maxBytes     int64
+}
+
+func NewMediaHandler(mediaService *services.MediaService, maxBytes int64) *MediaHandler {
+	return &MediaHandler{mediaService: mediaService, maxBytes: maxBytes}
+}
+
+// UploadMedia загружает обложку или скриншот игры
+// @Summary      Загрузить изображение игры
+// @Description  Принимает JPEG, PNG или GIF (тип определяется по содержимому) и строит миниатюры small, medium и large.
+// @Description  Новая обложка заменяет прежнюю, скриншот добавляется в конец списка
+// @Tags         media
+// @Security     ApiKeyAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        file formData file true "Изображение"
+// @Param        kind formData string false "Вид изображения" Enums(cover, screenshot) default(screenshot)
+// @Success      201 {object} dto.GameMediaDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      413 {object} map[string]string
+// @Failure      415 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/media [post]
+func (h *MediaHandler) UploadMedia(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+multipartOverhead)
+
+	var req dto.UploadMediaDto
+	if err := c.ShouldBind(&req); err != nil {
+		h.writeFormError(c, err)
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		h.writeFormError(c, err)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrValidationMediaFile})
+		return
+	}
+	defer file.Close()
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	item, err := h.mediaService.UploadMedia(c.Request.Context(), gameID, req.Kind, file, userID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// GetGameMedia возвращает изображения игры
+// @Summary      Изображения игры
+// @Description  Обложка (первой) и скриншоты по порядку, со ссылками на оригиналы и миниатюры.
+// @Description  Неопубликованные игры видят только администраторы
+// @Tags         media
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Success      200 {array} dto.GameMediaDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/media [get]
+func (h *MediaHandler) GetGameMedia(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+
+	items, err := h.mediaService.ListMedia(c.Request.Context(), gameID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// ReorderScreenshots меняет порядок скриншотов игры
+// @Summary      Порядок скриншотов
+// @Description  Принимает ID всех скриншотов игры в новом порядке
+// @Tags         media
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        data body dto.ReorderMediaDto true "Новый порядок"
+// @Success      200 {array} dto.GameMediaDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/media/order [put]
+func (h *MediaHandler) ReorderScreenshots(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReorderMediaDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	items, err := h.mediaService.ReorderScreenshots(c.Request.Context(), gameID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// DeleteMedia удаляет изображение игры
+// @Summary      Удалить изображение игры
+// @Tags         media
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        mediaId path string true "ID изображения"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/media/{mediaId} [delete]
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+	mediaID, err := uuid.Parse(c.Param("mediaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID изображения"})
+		return
+	}
+
+	if err := h.mediaService.DeleteMedia(c.Request.Context(), gameID, mediaID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMediaFile отдает изображение
+// @Summary      Файл изображения
+// @Description  Оригинал или миниатюра. Содержимое по ID не меняется, поэтому кэшируется надолго; поддерживаются If-None-Match и Range.
+// @Description  Неопубликованные игры видят только администраторы
+// @Tags         media
+// @Security     ApiKeyAuth
+// @Produce      image/jpeg
+// @Produce      image/png
+// @Produce      image/gif
+// @Param        id path string true "ID изображения"
+// @Param        size query string false "Миниатюра; без параметра - оригинал" Enums(small, medium, large)
+// @Success      200 {file} file
+// @Header       200 {string} ETag "Ключ содержимого"
+// @Failure      304
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /media/{id} [get]
+func (h *MediaHandler) GetMediaFile(c *gin.Context) {
+	mediaID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID изображения"})
+		return
+	}
+	var req dto.MediaFileQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	content, err := h.mediaService.OpenMedia(c.Request.Context(), mediaID, req.Size, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	serveMedia(c, content, mediaCacheControl)
+}
+
+// GetGameCover отдает обложку игры
+// @Summary      Обложка игры
+// @Description  Оригинал или миниатюра обложки; удобно для img src в списке игр.
+// @Description  Неопубликованные игры видят только администраторы
+// @Tags         media
+// @Security     ApiKeyAuth
+// @Produce      image/jpeg
+// @Produce      image/png
+// @Produce      image/gif
+// @Param        id path string true "ID игры"
+// @Param        size query string false "Миниатюра; без параметра - оригинал" Enums(small, medium, large)
+// @Success      200 {file} file
+// @Header       200 {string} ETag "Ключ содержимого"
+// @Failure      304
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /games/{id}/cover [get]
+func (h *MediaHandler) GetGameCover(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+	var req dto.MediaFileQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	content, err := h.mediaService.OpenCover(c.Request.Context(), gameID, req.Size, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	serveMedia(c, content, coverCacheControl)
+}
+
+// serveMedia отдает содержимое с заголовками кэширования; http.ServeContent
+// сам отвечает 304 на If-None-Match и обрабатывает Range.
+func serveMedia(c *gin.Context, content *dto.MediaContent, cacheControl string) {
+	defer content.Content.Close()
+
+	c.Header("Content-Type", content.ContentType)
+	// Администратору могут отдаваться изображения неопубликованных игр: такой ответ
+	// не должен попасть в общий кэш и достаться другим
+	if canSeeUnpublished(c) {
+		cacheControl = strings.Replace(cacheControl, "public", "private", 1)
+	}
+	c.Header("Cache-Control", cacheControl)
+	c.Header("ETag", `"`+content.Key+`"`)
+	c.Header("X-Content-Type-Options", "nosniff")
+	http.ServeContent(c.Writer, c.Request, "", content.ModTime, content.Content)
+}
+
+func (h *MediaHandler) parseGameID(c *gin.Context) (uuid.UUID, bool) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return uuid.Nil, false
+	}
+	return gameID, true
+}
+
+func (h *MediaHandler) writeFormError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": constants.ErrMediaTooLarge})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrValidationMediaFile})
+}
+
+func (h *MediaHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+	case errors.Is(err, repository.ErrMediaNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrMediaNotFound})
+	case errors.Is(err, media.ErrTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": constants.ErrMediaTooLarge})
+	case errors.Is(err, media.ErrUnsupportedImage):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": constants.ErrMediaUnsupported})
+	case isQueryError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при работе с изображениями"})
+	}
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"example/web-service-gin/internal/constants"
+)
+
+const mergePatchContentType = "application/merge-patch+json"
+
+// bindMergePatch разбирает тело PATCH как JSON Merge Patch (RFC 7396).
+// application/json тоже принимается: клиенты часто не меняют тип по умолчанию.
+func bindMergePatch(c *gin.Context, dst any) bool {
+	switch c.ContentType() {
+	case mergePatchContentType, gin.MIMEJSON:
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": constants.ErrValidationPatchType})
+		return false
+	}
+
+	if err := c.ShouldBindJSON(dst); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/abstraction/signing"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxResultBodySize - предельный размер тела запроса с результатом (подробности - до 16 КБ)
+const maxResultBodySize = 64 << 10
+
+type PlayResultHandler struct {
+	resultService *services.PlayResultService
+}
+
+func NewPlayResultHandler(resultService *services.PlayResultService) *PlayResultHandler {
+	return &PlayResultHandler{resultService: resultService}
+}
+
+// SubmitResult принимает результат попытки от игры
+// @Summary      Отправить результат попытки
+// @Description  Игра отправляет результат с токеном результата, полученным при предъявлении токена запуска.
+// @Description  Запрос подписывается ключом resultKey: X-Result-Signature = hex(HMAC-SHA256(resultKey,
+// @Description  X-Result-Timestamp + "\n" + X-Result-Nonce + "\n" + тело)). Метка времени - секунды Unix,
+// @Description  не дальше 5 минут от часов сервера; nonce у каждого запроса свой. Результат принимается один раз,
+// @Description  до истечения токена результата (RESULT_TOKEN_TTL); попытка переходит в completed, если выполнены
+// @Description  условия задачи (в свободной игре - success), иначе в failed
+// @Tags         attempts
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        X-Result-Token header string true "Токен результата"
+// @Param        X-Result-Nonce header string true "Уникальная строка запроса, 16-128 символов: латиница, цифры, _ и -"
+// @Param        X-Result-Timestamp header integer true "Время отправки в секундах Unix"
+// @Param        X-Result-Signature header string true "Подпись запроса в hex"
+// @Param        data body dto.SubmitResultDto true "Результат"
+// @Success      201 {object} dto.PlayResultDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      413 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/results [post]
+func (h *PlayResultHandler) SubmitResult(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	// Подпись считается по телу как есть, поэтому оно читается целиком до разбора
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxResultBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Результат слишком большой"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Не удалось прочитать тело запроса"})
+		return
+	}
+
+	sig := dto.ResultSignatureDto{
+		Token:     c.GetHeader("X-Result-Token"),
+		Nonce:     c.GetHeader("X-Result-Nonce"),
+		Timestamp: c.GetHeader("X-Result-Timestamp"),
+		Signature: c.GetHeader("X-Result-Signature"),
+	}
+	result, err := h.resultService.SubmitResult(c.Request.Context(), gameID, sig, body)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+func (h *PlayResultHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrAttemptNotFound):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrResultTokenInvalid})
+	case errors.Is(err, signing.ErrInvalidSignature):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrResultSignatureInvalid})
+	case errors.Is(err, signing.ErrExpired):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrResultRequestExpired})
+	case errors.Is(err, model.ErrResultTokenExpired):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrResultTokenExpired})
+	case errors.Is(err, repository.ErrNonceUsed):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrResultNonceUsed})
+	case errors.Is(err, repository.ErrResultAlreadySubmitted):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBusinessResultSubmitted})
+	case errors.Is(err, model.ErrAttemptTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBusinessAttemptFinished})
+	case isQueryError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при сохранении результата"})
+	}
+}
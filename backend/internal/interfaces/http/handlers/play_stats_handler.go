@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PlayStatsHandler struct {
+	statsService *services.PlayStatsService
+}
+
+func NewPlayStatsHandler(statsService *services.PlayStatsService) *PlayStatsHandler {
+	return &PlayStatsHandler{statsService: statsService}
+}
+
+// GetMyStats возвращает статистику текущего пользователя
+// @Summary      Моя статистика
+// @Description  По каждой сыгранной игре: число результатов, засчитанных попыток, лучший счет, лучшее и медианное время
+// @Description  засчитанных попыток, когда играл последний раз; итоги по жанрам (игра учитывается во всех своих жанрах).
+// @Description  Ответ кэшируется на STATS_CACHE_TTL, новый результат пользователя сбрасывает кэш
+// @Tags         stats
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200 {object} dto.UserStatsDto
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /me/stats [get]
+func (h *PlayStatsHandler) GetMyStats(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		return
+	}
+
+	stats, err := h.statsService.GetUserStats(c.Request.Context(), userID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetUserStats возвращает статистику пользователя
+// @Summary      Статистика пользователя
+// @Description  То же, что GET /me/stats, для любого пользователя. Только для администраторов
+// @Tags         stats
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID пользователя"
+// @Success      200 {object} dto.UserStatsDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      403 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /users/{id}/stats [get]
+func (h *PlayStatsHandler) GetUserStats(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID пользователя"})
+		return
+	}
+
+	stats, err := h.statsService.GetUserStats(c.Request.Context(), userID)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *PlayStatsHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrUserNotFound})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при подсчете статистики"})
+	}
+}
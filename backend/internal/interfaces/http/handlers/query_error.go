@@ -0,0 +1,13 @@
+package handlers
+
+import (
+	"errors"
+
+	"example/web-service-gin/internal/application/services"
+)
+
+// isQueryError - ошибка в параметрах списка (страница, фильтры, сортировка), отдается как 400.
+func isQueryError(err error) bool {
+	var qErr *services.QueryError
+	return errors.As(err, &qErr)
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/aggregate"
+	"example/web-service-gin/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RatingHandler struct {
+	ratingService *services.RatingService
+}
+
+func NewRatingHandler(ratingService *services.RatingService) *RatingHandler {
+	return &RatingHandler{ratingService: ratingService}
+}
+
+// RateGame ставит оценку игре от имени текущего пользователя
+// @Summary      Оценить игру
+// @Description  Ставит оценку от 1 до 5; пользователь берется из JWT
+// @Tags         ratings
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        data body dto.CreateRatingDto true "Оценка"
+// @Success      201 {object} dto.RatingDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Router       /games/{id}/ratings [post]
+func (h *RatingHandler) RateGame(c *gin.Context) {
+	userID, gameID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateRatingDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	rating, err := h.ratingService.RateGame(c.Request.Context(), userID, gameID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rating)
+}
+
+// UpdateRating изменяет оценку текущего пользователя
+// @Summary      Изменить оценку
+// @Description  Изменяет ранее поставленную оценку; пользователь берется из JWT
+// @Tags         ratings
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        data body dto.UpdateRatingDto true "Новая оценка"
+// @Success      200 {object} dto.RatingDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /games/{id}/ratings [put]
+func (h *RatingHandler) UpdateRating(c *gin.Context) {
+	userID, gameID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateRatingDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	rating, err := h.ratingService.UpdateRating(c.Request.Context(), userID, gameID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rating)
+}
+
+// DeleteRating удаляет оценку текущего пользователя
+// @Summary      Удалить оценку
+// @Description  Удаляет оценку игры, поставленную текущим пользователем
+// @Tags         ratings
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Success      200 {object} map[string]string
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Router       /games/{id}/ratings [delete]
+func (h *RatingHandler) DeleteRating(c *gin.Context) {
+	userID, gameID, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.ratingService.DeleteRating(c.Request.Context(), userID, gameID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Оценка успешно удалена"})
+}
+
+func (h *RatingHandler) parseRequest(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, gameID, true
+}
+
+func (h *RatingHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+	case errors.Is(err, aggregate.ErrRatingNotFound), errors.Is(err, repository.ErrUserRatingNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrRatingNotFound})
+	case errors.Is(err, aggregate.ErrRatingAlreadyExists), errors.Is(err, repository.ErrUserRatingAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrRatingAlreadyExists})
+	case err.Error() == constants.ErrUnauthorized:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/interfaces/http/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RecommendationHandler struct {
+	recommendationService *services.RecommendationService
+}
+
+func NewRecommendationHandler(recommendationService *services.RecommendationService) *RecommendationHandler {
+	return &RecommendationHandler{recommendationService: recommendationService}
+}
+
+// GetSimilarGames возвращает игры, похожие на игру
+// @Summary      Похожие игры
+// @Description  Игры с общими жанрами и похожими оценками пользователей, самые похожие первыми. Похожесть пересчитывается
+// @Description  фоновой задачей (SIMILARITY_REBUILD_INTERVAL), поэтому новые игры и оценки учитываются с задержкой.
+// @Description  С токеном пропускаются игры, которые пользователь уже оценил. Неопубликованные игры видят только администраторы
+// @Tags         recommendations
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        limit query int false "Максимум игр в ответе (до 100)" default(10)
+// @Success      200 {array} dto.SimilarGameDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/similar [get]
+func (h *RecommendationHandler) GetSimilarGames(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+	var req dto.RecommendationQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	userID, _ := middleware.CurrentUserID(c)
+
+	games, err := h.recommendationService.GetSimilarGames(c.Request.Context(), gameID, req, userID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, games)
+}
+
+// GetMyRecommendations возвращает рекомендации текущему пользователю
+// @Summary      Мои рекомендации
+// @Description  Опубликованные игры, которые пользователь еще не оценил: похожие на игры с высокой оценкой (source=similar),
+// @Description  а если таких не хватает - самые высоко оцененные (source=popular)
+// @Tags         recommendations
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        limit query int false "Максимум игр в ответе (до 100)" default(10)
+// @Success      200 {array} dto.RecommendedGameDto
+// @Failure      400 {object} map[string]string
+// @Failure      401 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /me/recommendations [get]
+func (h *RecommendationHandler) GetMyRecommendations(c *gin.Context) {
+	var req dto.RecommendationQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		return
+	}
+
+	games, err := h.recommendationService.GetRecommendations(c.Request.Context(), userID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, games)
+}
+
+func (h *RecommendationHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+	case isQueryError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при подборе рекомендаций"})
+	}
+}
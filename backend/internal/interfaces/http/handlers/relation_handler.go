@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+	"example/web-service-gin/internal/domain/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RelationHandler struct {
+	relationService *services.RelationService
+}
+
+func NewRelationHandler(relationService *services.RelationService) *RelationHandler {
+	return &RelationHandler{relationService: relationService}
+}
+
+// GetRelatedGames возвращает серию и связанные игры
+// @Summary      Связанные игры
+// @Description  Серия игры (игры по порядку) и игры, связанные с ней в обе стороны. relation - кем связанная игра приходится запрошенной:
+// @Description  prequel/sequel, base/dlc, original/remaster. Неопубликованные игры видят только администраторы
+// @Tags         relations
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Success      200 {object} dto.RelatedGamesDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/related [get]
+func (h *RelationHandler) GetRelatedGames(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+
+	related, err := h.relationService.GetRelatedGames(c.Request.Context(), gameID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, related)
+}
+
+// SetGameRelation связывает игру с другой
+// @Summary      Связать игры
+// @Description  Делает игру продолжением (sequel), дополнением (dlc) или переизданием (remaster) игры relatedGameId.
+// @Description  Прежняя связь игры того же типа заменяется. Связь, после которой игра через цепочку связей стала бы связана сама с собой, отклоняется с 409
+// @Tags         relations
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        type path string true "Тип связи" Enums(sequel, dlc, remaster)
+// @Param        data body dto.SetGameRelationDto true "Связанная игра"
+// @Success      200 {object} dto.GameRelationDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/relations/{type} [put]
+func (h *RelationHandler) SetGameRelation(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetGameRelationDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	relation, err := h.relationService.SetRelation(c.Request.Context(), gameID, c.Param("type"), req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, relation)
+}
+
+// DeleteGameRelation удаляет связь игры
+// @Summary      Удалить связь игр
+// @Tags         relations
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        type path string true "Тип связи" Enums(sequel, dlc, remaster)
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/relations/{type} [delete]
+func (h *RelationHandler) DeleteGameRelation(c *gin.Context) {
+	gameID, ok := h.parseGameID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.relationService.DeleteRelation(c.Request.Context(), gameID, c.Param("type")); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAllSeries возвращает серии игр
+// @Summary      Серии игр
+// @Description  Страница серий по названию, в каждой - игры по порядку. Неопубликованные игры видят только администраторы
+// @Tags         relations
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        page query int false "Номер страницы" default(1)
+// @Param        pageSize query int false "Размер страницы (до 100)" default(20)
+// @Success      200 {object} dto.PaginatedResponse{items=[]dto.SeriesDto}
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /series [get]
+func (h *RelationHandler) GetAllSeries(c *gin.Context) {
+	var req dto.SeriesListQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	res, err := h.relationService.ListSeries(c.Request.Context(), req, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetSeries возвращает серию
+// @Summary      Серия игр
+// @Description  Серия с играми по порядку. Неопубликованные игры видят только администраторы
+// @Tags         relations
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID серии"
+// @Success      200 {object} dto.SeriesDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /series/{id} [get]
+func (h *RelationHandler) GetSeries(c *gin.Context) {
+	seriesID, ok := h.parseSeriesID(c)
+	if !ok {
+		return
+	}
+
+	series, err := h.relationService.GetSeries(c.Request.Context(), seriesID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// CreateSeries создает серию
+// @Summary      Создать серию
+// @Description  Игры gameIds входят в серию в указанном порядке; игра может входить только в одну серию
+// @Tags         relations
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        data body dto.SaveSeriesDto true "Название и игры серии"
+// @Success      201 {object} dto.SeriesDto
+// @Failure      400 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /series [post]
+func (h *RelationHandler) CreateSeries(c *gin.Context) {
+	var req dto.SaveSeriesDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	series, err := h.relationService.CreateSeries(c.Request.Context(), req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+// UpdateSeries заменяет название и состав серии
+// @Summary      Обновить серию
+// @Description  Состав серии заменяется целиком: игры gameIds в указанном порядке
+// @Tags         relations
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID серии"
+// @Param        data body dto.SaveSeriesDto true "Название и игры серии"
+// @Success      200 {object} dto.SeriesDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      409 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /series/{id} [put]
+func (h *RelationHandler) UpdateSeries(c *gin.Context) {
+	seriesID, ok := h.parseSeriesID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SaveSeriesDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	series, err := h.relationService.UpdateSeries(c.Request.Context(), seriesID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// DeleteSeries удаляет серию
+// @Summary      Удалить серию
+// @Description  Игры серии остаются в каталоге
+// @Tags         relations
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID серии"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /series/{id} [delete]
+func (h *RelationHandler) DeleteSeries(c *gin.Context) {
+	seriesID, ok := h.parseSeriesID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.relationService.DeleteSeries(c.Request.Context(), seriesID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RelationHandler) parseGameID(c *gin.Context) (uuid.UUID, bool) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return uuid.Nil, false
+	}
+	return gameID, true
+}
+
+func (h *RelationHandler) parseSeriesID(c *gin.Context) (uuid.UUID, bool) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID серии"})
+		return uuid.Nil, false
+	}
+	return seriesID, true
+}
+
+func (h *RelationHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+	case errors.Is(err, repository.ErrRelationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrRelationNotFound})
+	case errors.Is(err, repository.ErrSeriesNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrSeriesNotFound})
+	case errors.Is(err, model.ErrRelationCycle):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBusinessRelationCycle})
+	case errors.Is(err, repository.ErrGameInAnotherSeries):
+		c.JSON(http.StatusConflict, gin.H{"error": constants.ErrBusinessGameInAnotherSeries})
+	case isQueryError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при работе со связями игр"})
+	}
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"example/web-service-gin/internal/application/abstraction/repository"
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+	"example/web-service-gin/internal/constants"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TaskHandler struct {
+	taskService *services.TaskService
+}
+
+func NewTaskHandler(taskService *services.TaskService) *TaskHandler {
+	return &TaskHandler{taskService: taskService}
+}
+
+// GetGameTasks возвращает задачи игры
+// @Summary      Задачи игры
+// @Description  Задачи игры по порядку. Задачи неопубликованных игр видят только администраторы
+// @Tags         tasks
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Success      200 {array} dto.TaskDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/tasks [get]
+func (h *TaskHandler) GetGameTasks(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	tasks, err := h.taskService.ListTasks(c.Request.Context(), gameID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tasks)
+}
+
+// CreateTask добавляет задачу в игру
+// @Summary      Создать задачу
+// @Description  Без order задача встает в конец списка задач игры. launchParams передаются игре при запуске,
+// @Description  passCriteria определяют, когда результат засчитывается как прохождение.
+// @Description  Имена launchParams - латинские буквы, цифры и _; имена данных попытки (attemptId, gameId, userId,
+// @Description  taskId, mode, seed, callbackUrl, launchToken) заняты в любом регистре
+// @Tags         tasks
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID игры"
+// @Param        data body dto.SaveTaskDto true "Задача"
+// @Success      201 {object} dto.TaskDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /games/{id}/tasks [post]
+func (h *TaskHandler) CreateTask(c *gin.Context) {
+	gameID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID игры"})
+		return
+	}
+
+	var req dto.SaveTaskDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	task, err := h.taskService.CreateTask(c.Request.Context(), gameID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+// GetTask возвращает задачу
+// @Summary      Задача
+// @Description  Задачи неопубликованных игр видят только администраторы
+// @Tags         tasks
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID задачи"
+// @Success      200 {object} dto.TaskDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /tasks/{id} [get]
+func (h *TaskHandler) GetTask(c *gin.Context) {
+	taskID, ok := h.parseTaskID(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.taskService.GetTask(c.Request.Context(), taskID, canSeeUnpublished(c))
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// UpdateTask заменяет задачу
+// @Summary      Обновить задачу
+// @Description  Все поля задачи заменяются; без order задача остается на своем месте.
+// @Description  Имена launchParams проверяются так же, как при создании
+// @Tags         tasks
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID задачи"
+// @Param        data body dto.SaveTaskDto true "Задача"
+// @Success      200 {object} dto.TaskDto
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /tasks/{id} [put]
+func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	taskID, ok := h.parseTaskID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SaveTaskDto
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
+		return
+	}
+
+	task, err := h.taskService.UpdateTask(c.Request.Context(), taskID, req)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, task)
+}
+
+// DeleteTask удаляет задачу
+// @Summary      Удалить задачу
+// @Tags         tasks
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path string true "ID задачи"
+// @Success      204
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /tasks/{id} [delete]
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	taskID, ok := h.parseTaskID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.taskService.DeleteTask(c.Request.Context(), taskID); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TaskHandler) parseTaskID(c *gin.Context) (uuid.UUID, bool) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID задачи"})
+		return uuid.Nil, false
+	}
+	return taskID, true
+}
+
+func (h *TaskHandler) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrGameNotFound})
+	case errors.Is(err, repository.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrTaskNotFound})
+	case isQueryError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при работе с задачами"})
+	}
+}
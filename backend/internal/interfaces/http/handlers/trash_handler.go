@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"example/web-service-gin/internal/application/dto"
+	"example/web-service-gin/internal/application/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	trashService *services.TrashService
+}
+
+func NewTrashHandler(trashService *services.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+// GetTrash возвращает содержимое корзины
+// @Summary      Корзина
+// @Description  Удаленные игры, жанры и пользователи, недавно удаленные первыми
+// @Tags         trash
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "Номер страницы (с 1)" default(1)
+// @Param        pageSize query int false "Размер страницы (максимум 100)" default(20)
+// @Param        kind query string false "Тип записей" Enums(game, genre, user)
+// @Success      200 {object} dto.PaginatedResponse{items=[]dto.TrashItemDto}
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /admin/trash [get]
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	var req dto.TrashQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	result, err := h.trashService.GetTrash(c.Request.Context(), req)
+	if err != nil {
+		if isQueryError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при получении корзины"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// PurgeTrash окончательно удаляет старые записи из корзины
+// @Summary      Очистить корзину
+// @Description  Окончательно удаляет записи, лежащие в корзине дольше olderThanDays дней; жанры, к которым еще относятся игры, пропускаются
+// @Description  Изображения и архивы сборок удаленных игр удаляются из хранилищ, если на них больше ничто не ссылается
+// @Tags         trash
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        olderThanDays query int false "Срок хранения в днях; 0 - настройка сервера" default(0)
+// @Success      200 {object} dto.PurgeResultDto
+// @Failure      400 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /admin/trash/purge [post]
+func (h *TrashHandler) PurgeTrash(c *gin.Context) {
+	var req dto.PurgeTrashDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	result, err := h.trashService.Purge(c.Request.Context(), time.Duration(req.OlderThanDays)*24*time.Hour)
+	if err != nil {
+		if isQueryError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при очистке корзины"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -29,6 +29,7 @@ func NewUserHandler(userService *services.UserService) *UserHandler {
 // @Produce      json
 // @Param        data body dto.CreateUserDto true "Данные для создания пользователя"
 // @Success      201 {object} dto.UserDto
+// @Header       201 {string} ETag "Версия пользователя"
 // @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /users [post]
@@ -49,6 +50,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
+	setETag(c, created.Version)
 	c.JSON(http.StatusCreated, created)
 }
 
@@ -60,6 +62,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "ID пользователя"
 // @Success      200 {object} dto.UserDto
+// @Header       200 {string} ETag "Версия пользователя для If-Match"
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
 // @Router       /users/{id} [get]
@@ -80,21 +83,39 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
+	setETag(c, u.Version)
 	c.JSON(http.StatusOK, u)
 }
 
-// GetAllUsers получает список всех пользователей
-// @Summary      Получить всех пользователей
-// @Description  Возвращает список всех пользователей в системе
+// GetAllUsers получает список пользователей
+// @Summary      Получить пользователей
+// @Description  Возвращает страницу пользователей с поиском по логину, фильтром по роли и сортировкой
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Success      200 {array} dto.UserDto
+// @Param        page query int false "Номер страницы (с 1)" default(1)
+// @Param        pageSize query int false "Размер страницы (максимум 100)" default(20)
+// @Param        after query string false "Курсор следующей страницы (nextCursor из предыдущего ответа; действителен только с теми же фильтрами и сортировкой)"
+// @Param        q query string false "Поиск по логину"
+// @Param        userRole query string false "Роль (user | admin)"
+// @Param        sort query string false "username | -username" default(username)
+// @Success      200 {object} dto.PaginatedResponse{items=[]dto.UserDto}
+// @Failure      400 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /users [get]
 func (h *UserHandler) GetAllUsers(c *gin.Context) {
-	users, err := h.userService.GetAllUsers(c.Request.Context())
+	var req dto.UserListQueryDto
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверные параметры запроса"})
+		return
+	}
+
+	users, err := h.userService.GetAllUsers(c.Request.Context(), req)
 	if err != nil {
+		if isQueryError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при получении пользователей"})
 		return
 	}
@@ -103,16 +124,20 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 
 // UpdateUser обновляет пользователя
 // @Summary      Обновить пользователя
-// @Description  Обновляет информацию о пользователе
+// @Description  Обновляет информацию о пользователе, если с момента чтения его никто не изменил. При конфликте версий возвращает 412 с актуальным пользователем в поле current
 // @Tags         users
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID пользователя"
+// @Param        If-Match header string true "ETag пользователя из GET или * для любой версии"
 // @Param        data body dto.UpdateUserDto true "Данные для обновления пользователя"
 // @Success      200 {object} dto.UserDto
+// @Header       200 {string} ETag "Новая версия пользователя"
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
 // @Router       /users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	userID, err := uuid.Parse(c.Param("id"))
@@ -121,6 +146,11 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var req dto.UpdateUserDto
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат JSON"})
@@ -128,7 +158,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 	req.ID = userID
 
-	updated, err := h.userService.UpdateUser(c.Request.Context(), req)
+	updated, err := h.userService.UpdateUser(c.Request.Context(), req, version)
 	if err != nil {
 		if err == repository.ErrUserNotFound || err.Error() == constants.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrUserNotFound})
@@ -138,24 +168,89 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrUserAlreadyExists})
 			return
 		}
+		if err == repository.ErrVersionConflict {
+			h.userConflict(c, userID)
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(c, updated.Version)
+	c.JSON(http.StatusOK, updated)
+}
+
+// PatchUser частично обновляет пользователя
+// @Summary      Частично обновить пользователя
+// @Description  JSON Merge Patch (RFC 7396): меняются только переданные поля; password передается, только если его меняют
+// @Tags         users
+// @Security     ApiKeyAuth
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        id path string true "ID пользователя"
+// @Param        If-Match header string true "ETag пользователя из GET или * для любой версии"
+// @Param        data body dto.PatchUserDto true "Изменяемые поля пользователя"
+// @Success      200 {object} dto.UserDto
+// @Header       200 {string} ETag "Новая версия пользователя"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      415 {object} map[string]string
+// @Failure      428 {object} map[string]string
+// @Router       /users/{id} [patch]
+func (h *UserHandler) PatchUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID пользователя"})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PatchUserDto
+	if !bindMergePatch(c, &req) {
+		return
+	}
+
+	updated, err := h.userService.PatchUser(c.Request.Context(), userID, req, version)
+	if err != nil {
+		if err == repository.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrUserNotFound})
+			return
+		}
+		if err == repository.ErrUserAlreadyExists {
+			c.JSON(http.StatusBadRequest, gin.H{"error": constants.ErrUserAlreadyExists})
+			return
+		}
+		if err == repository.ErrVersionConflict {
+			h.userConflict(c, userID)
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	setETag(c, updated.Version)
 	c.JSON(http.StatusOK, updated)
 }
 
 // DeleteUser удаляет пользователя
 // @Summary      Удалить пользователя
-// @Description  Удаляет пользователя из системы по его идентификатору
+// @Description  Перемещает пользователя в корзину; войти под ним нельзя, пока он не восстановлен. При конфликте версий возвращает 412 с актуальным пользователем в поле current
 // @Tags         users
 // @Security     ApiKeyAuth
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "ID пользователя"
+// @Param        If-Match header string true "ETag пользователя из GET или * для любой версии"
 // @Success      200 {object} map[string]string
 // @Failure      400 {object} map[string]string
 // @Failure      404 {object} map[string]string
+// @Failure      412 {object} map[string]any
+// @Failure      428 {object} map[string]string
 // @Failure      500 {object} map[string]string
 // @Router       /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
@@ -165,11 +260,20 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.DeleteUser(c.Request.Context(), userID); err != nil {
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.DeleteUser(c.Request.Context(), userID, version); err != nil {
 		if err == repository.ErrUserNotFound || err.Error() == constants.ErrUserNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrUserNotFound})
 			return
 		}
+		if err == repository.ErrVersionConflict {
+			h.userConflict(c, userID)
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при удалении пользователя"})
 		return
 	}
@@ -177,3 +281,48 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Пользователь успешно удален"})
 }
 
+// RestoreUser восстанавливает пользователя из корзины
+// @Summary      Восстановить пользователя
+// @Description  Возвращает пользователя из корзины
+// @Tags         users
+// @Security     ApiKeyAuth
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "ID пользователя"
+// @Success      200 {object} dto.UserDto
+// @Header       200 {string} ETag "Новая версия пользователя"
+// @Failure      400 {object} map[string]string
+// @Failure      404 {object} map[string]string
+// @Failure      500 {object} map[string]string
+// @Router       /users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Неверный формат ID пользователя"})
+		return
+	}
+
+	user, err := h.userService.RestoreUser(c.Request.Context(), userID)
+	if err != nil {
+		if err == repository.ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Пользователь не найден в корзине"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Ошибка при восстановлении пользователя"})
+		return
+	}
+
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
+
+// userConflict отвечает 412 с актуальной версией пользователя; если его успели удалить - 404.
+func (h *UserHandler) userConflict(c *gin.Context, userID uuid.UUID) {
+	current, err := h.userService.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": constants.ErrUserNotFound})
+		return
+	}
+	preconditionFailed(c, current, current.Version)
+}
+
@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"net/http"
-	"strings"
 
 	appauth "example/web-service-gin/internal/application/abstraction/auth"
 	"example/web-service-gin/internal/constants"
@@ -13,25 +12,11 @@ import (
 
 func RequireAdmin(verifier appauth.TokenVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := strings.TrimSpace(c.GetHeader("Authorization"))
-		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		if !authenticate(c, verifier) {
 			return
 		}
 
-		token := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
-		if token == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
-			return
-		}
-
-		_, role, err := verifier.Verify(c.Request.Context(), token)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
-			return
-		}
-
-		if role != specifictype.RoleAdmin {
+		if role, _ := CurrentUserRole(c); role != specifictype.RoleAdmin {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": constants.ErrForbidden})
 			return
 		}
@@ -39,4 +24,3 @@ func RequireAdmin(verifier appauth.TokenVerifier) gin.HandlerFunc {
 		c.Next()
 	}
 }
-
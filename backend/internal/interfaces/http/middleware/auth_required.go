@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	appauth "example/web-service-gin/internal/application/abstraction/auth"
+	"example/web-service-gin/internal/constants"
+	specifictype "example/web-service-gin/internal/domain/specific_type"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	ContextUserIDKey   = "userID"
+	ContextUserRoleKey = "userRole"
+)
+
+// RequireAuth пропускает любого пользователя с валидным токеном
+// и кладет его ID и роль в контекст запроса.
+func RequireAuth(verifier appauth.TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !authenticate(c, verifier) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// OptionalAuth не требует токена, но если валидный токен передан,
+// кладет ID и роль пользователя в контекст (для публичных эндпоинтов с персонализацией).
+func OptionalAuth(verifier appauth.TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := strings.TrimSpace(c.GetHeader("Authorization"))
+		token := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
+		if token != "" && strings.HasPrefix(authHeader, "Bearer ") {
+			if userID, role, err := verifier.Verify(c.Request.Context(), token); err == nil {
+				c.Set(ContextUserIDKey, userID)
+				c.Set(ContextUserRoleKey, role)
+			}
+		}
+		c.Next()
+	}
+}
+
+// CurrentUserID возвращает ID пользователя из subject JWT.
+func CurrentUserID(c *gin.Context) (uuid.UUID, bool) {
+	v, ok := c.Get(ContextUserIDKey)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, ok := v.(uuid.UUID)
+	return id, ok && id != uuid.Nil
+}
+
+// CurrentUserRole возвращает роль пользователя из JWT.
+func CurrentUserRole(c *gin.Context) (specifictype.UserRole, bool) {
+	v, ok := c.Get(ContextUserRoleKey)
+	if !ok {
+		return "", false
+	}
+	role, ok := v.(specifictype.UserRole)
+	return role, ok
+}
+
+func authenticate(c *gin.Context, verifier appauth.TokenVerifier) bool {
+	authHeader := strings.TrimSpace(c.GetHeader("Authorization"))
+	if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		return false
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
+	if token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		return false
+	}
+
+	userID, role, err := verifier.Verify(c.Request.Context(), token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": constants.ErrUnauthorized})
+		return false
+	}
+
+	c.Set(ContextUserIDKey, userID)
+	c.Set(ContextUserRoleKey, role)
+	return true
+}
@@ -14,7 +14,21 @@ func NewRouter(
 	genreHandler *handlers.GenreHandler,
 	userHandler *handlers.UserHandler,
 	authHandler *handlers.AuthHandler,
+	ratingHandler *handlers.RatingHandler,
+	trashHandler *handlers.TrashHandler,
+	catalogHandler *handlers.CatalogHandler,
+	mediaHandler *handlers.MediaHandler,
+	buildHandler *handlers.BuildHandler,
+	relationHandler *handlers.RelationHandler,
+	recommendationHandler *handlers.RecommendationHandler,
+	taskHandler *handlers.TaskHandler,
+	attemptHandler *handlers.AttemptHandler,
+	playResultHandler *handlers.PlayResultHandler,
+	playStatsHandler *handlers.PlayStatsHandler,
+	leaderboardHandler *handlers.LeaderboardHandler,
 	adminOnly gin.HandlerFunc,
+	authRequired gin.HandlerFunc,
+	optionalAuth gin.HandlerFunc,
 ) *gin.Engine {
 
 	gin.SetMode(gin.ReleaseMode)
@@ -23,7 +37,7 @@ func NewRouter(
 
 	r.Use(cors.New(cors.Config{
 		AllowAllOrigins: true,
-		AllowMethods:    []string{"GET", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
+		AllowMethods:    []string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS", "PATCH"},
 		AllowHeaders: []string{
 			"Origin",
 			"Content-Type",
@@ -34,9 +48,28 @@ func NewRouter(
 			"Accept",
 			"X-Requested-With",
 			"Cache-Control",
+			"If-Match",
+			"Range",
+			"If-Range",
+			// Подписанная отправка результатов игр
+			"X-Result-Token",
+			"X-Result-Nonce",
+			"X-Result-Timestamp",
+			"X-Result-Signature",
 			// Можно добавить любые кастомные заголовки
 		},
-		ExposeHeaders:    []string{"Content-Length", "Authorization"},
+		ExposeHeaders: []string{
+			"Content-Length",
+			"Authorization",
+			"ETag",
+			// Докачка и проверка сборок
+			"Accept-Ranges",
+			"Content-Range",
+			"Content-Disposition",
+			"X-Signature",
+			"X-Signature-Algorithm",
+			"X-Signature-Key-Id",
+		},
 		AllowCredentials: true,
 		MaxAge:           12 * 60 * 60, // 12 часов
 	}))
@@ -48,14 +81,55 @@ func NewRouter(
 	} else {
 		r.POST("/games", gameHandler.CreateGame)
 	}
-	r.GET("/games", gameHandler.GetAllGames)
-	r.GET("/games/:id", gameHandler.GetGame)
+	// Токен необязателен: администратор видит и неопубликованные игры
+	if optionalAuth != nil {
+		r.GET("/games", optionalAuth, gameHandler.GetAllGames)
+		r.GET("/games/search", optionalAuth, gameHandler.SearchGames)
+		r.GET("/games/:id/details", optionalAuth, gameHandler.GetGameDetails)
+		r.GET("/games/:id", optionalAuth, gameHandler.GetGame)
+	} else {
+		r.GET("/games", gameHandler.GetAllGames)
+		r.GET("/games/search", gameHandler.SearchGames)
+		r.GET("/games/:id/details", gameHandler.GetGameDetails)
+		r.GET("/games/:id", gameHandler.GetGame)
+	}
 	if adminOnly != nil {
+		// Похожие названия ищутся и среди черновиков, поэтому поиск только для администраторов
+		r.GET("/games/similar-titles", adminOnly, gameHandler.GetSimilarTitles)
+		r.POST("/games/:id/publish", adminOnly, gameHandler.PublishGame)
+		r.POST("/games/:id/schedule", adminOnly, gameHandler.ScheduleGame)
+		r.POST("/games/:id/unpublish", adminOnly, gameHandler.UnpublishGame)
+		r.POST("/games/:id/archive", adminOnly, gameHandler.ArchiveGame)
 		r.PUT("/games/:id", adminOnly, gameHandler.UpdateGame)
+		r.PATCH("/games/:id", adminOnly, gameHandler.PatchGame)
 		r.DELETE("/games/:id", adminOnly, gameHandler.DeleteGame)
+		r.POST("/games/:id/restore", adminOnly, gameHandler.RestoreGame)
+		r.GET("/games/:id/revisions", adminOnly, gameHandler.GetGameRevisions)
+		r.GET("/games/:id/revisions/diff", adminOnly, gameHandler.DiffGameRevisions)
+		r.POST("/games/:id/revisions/:rev/revert", adminOnly, gameHandler.RevertGame)
 	} else {
+		r.GET("/games/similar-titles", gameHandler.GetSimilarTitles)
+		r.POST("/games/:id/publish", gameHandler.PublishGame)
+		r.POST("/games/:id/schedule", gameHandler.ScheduleGame)
+		r.POST("/games/:id/unpublish", gameHandler.UnpublishGame)
+		r.POST("/games/:id/archive", gameHandler.ArchiveGame)
 		r.PUT("/games/:id", gameHandler.UpdateGame)
+		r.PATCH("/games/:id", gameHandler.PatchGame)
 		r.DELETE("/games/:id", gameHandler.DeleteGame)
+		r.POST("/games/:id/restore", gameHandler.RestoreGame)
+		r.GET("/games/:id/revisions", gameHandler.GetGameRevisions)
+		r.GET("/games/:id/revisions/diff", gameHandler.DiffGameRevisions)
+		r.POST("/games/:id/revisions/:rev/revert", gameHandler.RevertGame)
+	}
+
+	if authRequired != nil {
+		r.POST("/games/:id/ratings", authRequired, ratingHandler.RateGame)
+		r.PUT("/games/:id/ratings", authRequired, ratingHandler.UpdateRating)
+		r.DELETE("/games/:id/ratings", authRequired, ratingHandler.DeleteRating)
+	} else {
+		r.POST("/games/:id/ratings", ratingHandler.RateGame)
+		r.PUT("/games/:id/ratings", ratingHandler.UpdateRating)
+		r.DELETE("/games/:id/ratings", ratingHandler.DeleteRating)
 	}
 
 	if adminOnly != nil {
@@ -67,10 +141,20 @@ func NewRouter(
 	r.GET("/genres/:id", genreHandler.GetGenre)
 	if adminOnly != nil {
 		r.PUT("/genres/:id", adminOnly, genreHandler.UpdateGenre)
+		r.PATCH("/genres/:id", adminOnly, genreHandler.PatchGenre)
 		r.DELETE("/genres/:id", adminOnly, genreHandler.DeleteGenre)
+		r.POST("/genres/:id/restore", adminOnly, genreHandler.RestoreGenre)
+		r.GET("/genres/:id/revisions", adminOnly, genreHandler.GetGenreRevisions)
+		r.GET("/genres/:id/revisions/diff", adminOnly, genreHandler.DiffGenreRevisions)
+		r.POST("/genres/:id/revisions/:rev/revert", adminOnly, genreHandler.RevertGenre)
 	} else {
 		r.PUT("/genres/:id", genreHandler.UpdateGenre)
+		r.PATCH("/genres/:id", genreHandler.PatchGenre)
 		r.DELETE("/genres/:id", genreHandler.DeleteGenre)
+		r.POST("/genres/:id/restore", genreHandler.RestoreGenre)
+		r.GET("/genres/:id/revisions", genreHandler.GetGenreRevisions)
+		r.GET("/genres/:id/revisions/diff", genreHandler.DiffGenreRevisions)
+		r.POST("/genres/:id/revisions/:rev/revert", genreHandler.RevertGenre)
 	}
 
 	if adminOnly != nil {
@@ -82,10 +166,160 @@ func NewRouter(
 	r.GET("/users/:id", userHandler.GetUser)
 	if adminOnly != nil {
 		r.PUT("/users/:id", adminOnly, userHandler.UpdateUser)
+		r.PATCH("/users/:id", adminOnly, userHandler.PatchUser)
 		r.DELETE("/users/:id", adminOnly, userHandler.DeleteUser)
+		r.POST("/users/:id/restore", adminOnly, userHandler.RestoreUser)
 	} else {
 		r.PUT("/users/:id", userHandler.UpdateUser)
+		r.PATCH("/users/:id", userHandler.PatchUser)
 		r.DELETE("/users/:id", userHandler.DeleteUser)
+		r.POST("/users/:id/restore", userHandler.RestoreUser)
+	}
+
+	if adminOnly != nil {
+		r.GET("/admin/trash", adminOnly, trashHandler.GetTrash)
+		r.POST("/admin/trash/purge", adminOnly, trashHandler.PurgeTrash)
+	} else {
+		r.GET("/admin/trash", trashHandler.GetTrash)
+		r.POST("/admin/trash/purge", trashHandler.PurgeTrash)
+	}
+
+	if optionalAuth != nil {
+		r.GET("/games/:id/media", optionalAuth, mediaHandler.GetGameMedia)
+		r.GET("/games/:id/cover", optionalAuth, mediaHandler.GetGameCover)
+		r.GET("/media/:id", optionalAuth, mediaHandler.GetMediaFile)
+	} else {
+		r.GET("/games/:id/media", mediaHandler.GetGameMedia)
+		r.GET("/games/:id/cover", mediaHandler.GetGameCover)
+		r.GET("/media/:id", mediaHandler.GetMediaFile)
+	}
+	if adminOnly != nil {
+		r.POST("/games/:id/media", adminOnly, mediaHandler.UploadMedia)
+		r.PUT("/games/:id/media/order", adminOnly, mediaHandler.ReorderScreenshots)
+		r.DELETE("/games/:id/media/:mediaId", adminOnly, mediaHandler.DeleteMedia)
+	} else {
+		r.POST("/games/:id/media", mediaHandler.UploadMedia)
+		r.PUT("/games/:id/media/order", mediaHandler.ReorderScreenshots)
+		r.DELETE("/games/:id/media/:mediaId", mediaHandler.DeleteMedia)
+	}
+
+	if optionalAuth != nil {
+		r.GET("/games/:id/builds", optionalAuth, buildHandler.GetBuilds)
+		r.GET("/games/:id/builds/current", optionalAuth, buildHandler.GetCurrentBuild)
+		r.GET("/games/:id/builds/:buildId", optionalAuth, buildHandler.GetBuild)
+		r.GET("/games/:id/builds/:buildId/manifest", optionalAuth, buildHandler.GetManifest)
+	} else {
+		r.GET("/games/:id/builds", buildHandler.GetBuilds)
+		r.GET("/games/:id/builds/current", buildHandler.GetCurrentBuild)
+		r.GET("/games/:id/builds/:buildId", buildHandler.GetBuild)
+		r.GET("/games/:id/builds/:buildId/manifest", buildHandler.GetManifest)
+	}
+	r.GET("/games/:id/builds/:buildId/download", buildHandler.Download)
+	r.HEAD("/games/:id/builds/:buildId/download", buildHandler.Download)
+	r.GET("/builds/signing-key", buildHandler.GetSigningKey)
+	if authRequired != nil {
+		r.POST("/games/:id/builds/:buildId/download-url", authRequired, buildHandler.CreateDownloadURL)
+	} else {
+		r.POST("/games/:id/builds/:buildId/download-url", buildHandler.CreateDownloadURL)
+	}
+	if adminOnly != nil {
+		r.POST("/games/:id/builds", adminOnly, buildHandler.UploadBuild)
+		r.POST("/games/:id/builds/:buildId/current", adminOnly, buildHandler.SetCurrentBuild)
+		r.DELETE("/games/:id/builds/:buildId", adminOnly, buildHandler.DeleteBuild)
+	} else {
+		r.POST("/games/:id/builds", buildHandler.UploadBuild)
+		r.POST("/games/:id/builds/:buildId/current", buildHandler.SetCurrentBuild)
+		r.DELETE("/games/:id/builds/:buildId", buildHandler.DeleteBuild)
+	}
+
+	if optionalAuth != nil {
+		r.GET("/games/:id/related", optionalAuth, relationHandler.GetRelatedGames)
+		r.GET("/series", optionalAuth, relationHandler.GetAllSeries)
+		r.GET("/series/:id", optionalAuth, relationHandler.GetSeries)
+	} else {
+		r.GET("/games/:id/related", relationHandler.GetRelatedGames)
+		r.GET("/series", relationHandler.GetAllSeries)
+		r.GET("/series/:id", relationHandler.GetSeries)
+	}
+	if adminOnly != nil {
+		r.PUT("/games/:id/relations/:type", adminOnly, relationHandler.SetGameRelation)
+		r.DELETE("/games/:id/relations/:type", adminOnly, relationHandler.DeleteGameRelation)
+		r.POST("/series", adminOnly, relationHandler.CreateSeries)
+		r.PUT("/series/:id", adminOnly, relationHandler.UpdateSeries)
+		r.DELETE("/series/:id", adminOnly, relationHandler.DeleteSeries)
+	} else {
+		r.PUT("/games/:id/relations/:type", relationHandler.SetGameRelation)
+		r.DELETE("/games/:id/relations/:type", relationHandler.DeleteGameRelation)
+		r.POST("/series", relationHandler.CreateSeries)
+		r.PUT("/series/:id", relationHandler.UpdateSeries)
+		r.DELETE("/series/:id", relationHandler.DeleteSeries)
+	}
+
+	if optionalAuth != nil {
+		r.GET("/games/:id/similar", optionalAuth, recommendationHandler.GetSimilarGames)
+	} else {
+		r.GET("/games/:id/similar", recommendationHandler.GetSimilarGames)
+	}
+	if authRequired != nil {
+		r.GET("/me/recommendations", authRequired, recommendationHandler.GetMyRecommendations)
+	} else {
+		r.GET("/me/recommendations", recommendationHandler.GetMyRecommendations)
+	}
+
+	if optionalAuth != nil {
+		r.GET("/games/:id/tasks", optionalAuth, taskHandler.GetGameTasks)
+		r.GET("/tasks/:id", optionalAuth, taskHandler.GetTask)
+	} else {
+		r.GET("/games/:id/tasks", taskHandler.GetGameTasks)
+		r.GET("/tasks/:id", taskHandler.GetTask)
+	}
+	if adminOnly != nil {
+		r.POST("/games/:id/tasks", adminOnly, taskHandler.CreateTask)
+		r.PUT("/tasks/:id", adminOnly, taskHandler.UpdateTask)
+		r.DELETE("/tasks/:id", adminOnly, taskHandler.DeleteTask)
+	} else {
+		r.POST("/games/:id/tasks", taskHandler.CreateTask)
+		r.PUT("/tasks/:id", taskHandler.UpdateTask)
+		r.DELETE("/tasks/:id", taskHandler.DeleteTask)
+	}
+
+	if authRequired != nil {
+		r.POST("/games/:id/attempts", authRequired, attemptHandler.StartAttempt)
+		r.GET("/attempts/:id", authRequired, attemptHandler.GetAttempt)
+		r.POST("/attempts/:id/abandon", authRequired, attemptHandler.AbandonAttempt)
+	} else {
+		r.POST("/games/:id/attempts", attemptHandler.StartAttempt)
+		r.GET("/attempts/:id", attemptHandler.GetAttempt)
+		r.POST("/attempts/:id/abandon", attemptHandler.AbandonAttempt)
+	}
+	// Игра предъявляет одноразовый токен запуска без JWT пользователя
+	r.POST("/attempts/launch", attemptHandler.LaunchAttempt)
+	// Игра подписывает результат ключом, выданным при запуске, а не токеном пользователя
+	r.POST("/games/:id/results", playResultHandler.SubmitResult)
+
+	if authRequired != nil {
+		r.GET("/me/stats", authRequired, playStatsHandler.GetMyStats)
+	} else {
+		r.GET("/me/stats", playStatsHandler.GetMyStats)
+	}
+	if adminOnly != nil {
+		r.GET("/users/:id/stats", adminOnly, playStatsHandler.GetUserStats)
+	} else {
+		r.GET("/users/:id/stats", playStatsHandler.GetUserStats)
+	}
+
+	if optionalAuth != nil {
+		r.GET("/games/:id/leaderboard", optionalAuth, leaderboardHandler.GetLeaderboard)
+	} else {
+		r.GET("/games/:id/leaderboard", leaderboardHandler.GetLeaderboard)
+	}
+
+	if adminOnly != nil {
+		r.GET("/admin/catalog/export", adminOnly, catalogHandler.ExportCatalog)
+		r.POST("/admin/catalog/import", adminOnly, catalogHandler.ImportCatalog)
+	} else {
+		r.GET("/admin/catalog/export", catalogHandler.ExportCatalog)
+		r.POST("/admin/catalog/import", catalogHandler.ImportCatalog)
 	}
 
 	r.POST("/auth/login", authHandler.Login)
@@ -15,6 +15,11 @@ export interface ApiConfig {
             detail: ApiEndpoint;
             update: ApiEndpoint;
             delete: ApiEndpoint;
+            cover: ApiEndpoint;
+        };
+        builds: {
+            current: ApiEndpoint;
+            downloadUrl: ApiEndpoint;
         };
         genres: {
             list: ApiEndpoint;
@@ -53,6 +58,23 @@ export const apiEndpoints = {
             method: 'DELETE',
             requiresAuth: true,
         },
+        cover: {
+            path: '/games/:id/cover',
+            method: 'GET',
+            requiresAuth: false,
+        },
+    },
+    builds: {
+        current: {
+            path: '/games/:id/builds/current',
+            method: 'GET',
+            requiresAuth: false,
+        },
+        downloadUrl: {
+            path: '/games/:id/builds/:buildId/download-url',
+            method: 'POST',
+            requiresAuth: true,
+        },
     },
     genres: {
         list: {
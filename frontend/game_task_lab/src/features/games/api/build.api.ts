@@ -0,0 +1,46 @@
+import {ApiHelper, getApiConfig} from "../../../app/config/api.config.ts";
+import {BuildDownloadUrlDto, BuildPlatform, GameBuildDto} from "../types/build.types.ts";
+
+export class BuildApi {
+    private config = getApiConfig();
+
+    // Текущая сборка игры для платформы; null, если для платформы сборки еще нет
+    async getCurrentBuild(gameId: string, platform: BuildPlatform): Promise<GameBuildDto | null> {
+        const { builds } = this.config.endpoints;
+        const url = `${ApiHelper.buildUrl(this.config.baseURL, builds.current, { id: gameId })}?platform=${platform}`;
+
+        const response = await fetch(url, {
+            method: builds.current.method,
+            headers: ApiHelper.getHeaders(builds.current),
+        });
+
+        if (response.status === 404) {
+            return null;
+        }
+        if (!response.ok) {
+            throw new Error(`Failed to fetch current build: ${response.statusText}`);
+        }
+
+        return response.json();
+    }
+
+    // Подписанная ссылка на архив сборки; действует несколько минут и не требует токена
+    async getDownloadUrl(build: GameBuildDto): Promise<BuildDownloadUrlDto> {
+        const { builds } = this.config.endpoints;
+        const url = ApiHelper.buildUrl(this.config.baseURL, builds.downloadUrl, { id: build.gameId, buildId: build.id });
+
+        const response = await fetch(url, {
+            method: builds.downloadUrl.method,
+            headers: ApiHelper.getHeaders(builds.downloadUrl),
+        });
+
+        if (!response.ok) {
+            throw new Error(`Failed to get download link: ${response.statusText}`);
+        }
+
+        const link: BuildDownloadUrlDto = await response.json();
+        return { ...link, url: `${this.config.baseURL}${link.url}` };
+    }
+}
+
+export const buildApi = new BuildApi();
@@ -1,12 +1,34 @@
 import {ApiHelper, getApiConfig} from "../../../app/config/api.config.ts";
 import {CreateGameDto, GameDto, UpdateGameDto} from "../types/game.types.ts";
+import {PaginatedResponse} from "../../../types/global";
+
+// Бэкенд отдает список постранично; UI пока показывает одну страницу максимального размера
+const MAX_PAGE_SIZE = 100;
+
+// Игру успели изменить с момента загрузки; current - ее актуальное состояние
+export class VersionConflictError extends Error {
+    constructor(message: string, public readonly current: GameDto) {
+        super(message);
+        this.name = 'VersionConflictError';
+    }
+}
+
+const ifMatch = (version: number) => ({ 'If-Match': `"${version}"` });
+
+export type CoverSize = 'small' | 'medium' | 'large';
 
 export class GameApi {
     private config = getApiConfig();
 
+    // Ссылка для <img>: сервер отдает миниатюру обложки или 404, если обложки нет
+    coverUrl(id: string, size: CoverSize = 'small'): string {
+        const { games } = this.config.endpoints;
+        return `${ApiHelper.buildUrl(this.config.baseURL, games.cover, { id })}?size=${size}`;
+    }
+
     async getAllGames(): Promise<GameDto[]> {
         const { games } = this.config.endpoints;
-        const url = ApiHelper.buildUrl(this.config.baseURL, games.list);
+        const url = `${ApiHelper.buildUrl(this.config.baseURL, games.list)}?pageSize=${MAX_PAGE_SIZE}`;
 
         const response = await fetch(url, {
             method: 'GET',
@@ -17,7 +39,8 @@ export class GameApi {
             throw new Error(`Failed to fetch games: ${response.statusText}`);
         }
 
-        return response.json();
+        const page: PaginatedResponse<GameDto> = await response.json();
+        return page.items;
     }
 
     // Получить игру по ID
@@ -56,6 +79,10 @@ export class GameApi {
             payload.description = dto.description;
         }
 
+        if (dto.genreIds) {
+            payload.genreIds = dto.genreIds;
+        }
+
         const response = await fetch(url, {
             method: games.create.method,
             headers: ApiHelper.getHeaders(games.create),
@@ -72,7 +99,7 @@ export class GameApi {
     }
 
     // Обновить игру
-    async updateGame(id: string, dto: UpdateGameDto): Promise<GameDto> {
+    async updateGame(id: string, dto: UpdateGameDto, version: number): Promise<GameDto> {
         const { games } = this.config.endpoints;
         const url = ApiHelper.buildUrl(this.config.baseURL, games.update, { id });
 
@@ -87,15 +114,22 @@ export class GameApi {
             payload.description = dto.description;
         }
 
+        if (dto.genreIds) {
+            payload.genreIds = dto.genreIds;
+        }
+
         const response = await fetch(url, {
             method: games.update.method,
-            headers: ApiHelper.getHeaders(games.update),
+            headers: ApiHelper.getHeaders(games.update, ifMatch(version)),
             body: JSON.stringify(payload),
         });
 
         if (!response.ok) {
             const errorData = await response.json().catch(() => ({}));
             const errorMessage = errorData.error || errorData.message || `Failed to update game: ${response.statusText}`;
+            if (response.status === 412 && errorData.current) {
+                throw new VersionConflictError(errorMessage, errorData.current);
+            }
             throw new Error(errorMessage);
         }
 
@@ -103,17 +137,22 @@ export class GameApi {
     }
 
     // Удалить игру
-    async deleteGame(id: string): Promise<void> {
+    async deleteGame(id: string, version: number): Promise<void> {
         const { games } = this.config.endpoints;
         const url = ApiHelper.buildUrl(this.config.baseURL, games.delete, { id });
 
         const response = await fetch(url, {
             method: games.delete.method,
-            headers: ApiHelper.getHeaders(games.delete),
+            headers: ApiHelper.getHeaders(games.delete, ifMatch(version)),
         });
 
         if (!response.ok) {
-            throw new Error('Failed to delete game');
+            const errorData = await response.json().catch(() => ({}));
+            const errorMessage = errorData.error || errorData.message || 'Failed to delete game';
+            if (response.status === 412 && errorData.current) {
+                throw new VersionConflictError(errorMessage, errorData.current);
+            }
+            throw new Error(errorMessage);
         }
     }
 }
@@ -1,12 +1,16 @@
 import { ApiHelper, getApiConfig } from "../../../app/config/api.config";
 import type { GenreDto } from "../types/genre.types";
+import type { PaginatedResponse } from "../../../types/global";
+
+// Бэкенд отдает список постранично; жанров немного, берем одну страницу максимального размера
+const MAX_PAGE_SIZE = 100;
 
 export class GenreApi {
   private config = getApiConfig();
 
   async getAllGenres(): Promise<GenreDto[]> {
     const { genres } = this.config.endpoints;
-    const url = ApiHelper.buildUrl(this.config.baseURL, genres.list);
+    const url = `${ApiHelper.buildUrl(this.config.baseURL, genres.list)}?pageSize=${MAX_PAGE_SIZE}`;
 
     const response = await fetch(url, {
       method: genres.list.method,
@@ -17,7 +21,8 @@ export class GenreApi {
       throw new Error(`Failed to fetch genres: ${response.statusText}`);
     }
 
-    return response.json();
+    const page: PaginatedResponse<GenreDto> = await response.json();
+    return page.items;
   }
 }
 
@@ -3,6 +3,7 @@ import { onMount } from "solid-js";
 import {GameStore} from "../store/game.store";
 import { Table, type TableColumn } from "../../../shared/components/table/Table.tsx";
 import { Modal } from "../../../shared/components/modal/Modal.tsx";
+import { gameApi } from "../api/game.api.ts";
 import "./GameList.css";
 
 const formatDate = (dateString: string): string => {
@@ -28,6 +29,20 @@ export const GameList = (gameStore: GameStore) => {
   });
 
   const columns: TableColumn[] = [
+    {
+      key: "cover",
+      header: "",
+      render: (game) => (
+        <img
+          class="game-cover"
+          src={gameApi.coverUrl(game.id)}
+          alt=""
+          loading="lazy"
+          // У игры может не быть обложки
+          onError={(e) => (e.currentTarget.style.visibility = "hidden")}
+        />
+      ),
+    },
     {
       key: "title",
       header: "Название",
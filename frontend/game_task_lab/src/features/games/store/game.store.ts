@@ -1,7 +1,7 @@
 // features/games/stores/game.store.ts
 import { createStore } from 'solid-js/store';
 import type {CreateGameDto, GameDto, UpdateGameDto} from '../types/game.types.ts';
-import { gameApi } from '../api/game.api';
+import { gameApi, VersionConflictError } from '../api/game.api';
 
 interface GameState {
     games: GameDto[];
@@ -38,6 +38,25 @@ export type GameStore = {
 export const createGameStore  = () : GameStore => {
     const [state, setState] = createStore(initialState);
 
+    // Версия, которую видел пользователь; ее сервер сверяет через If-Match
+    const knownVersion = (id: string): number => {
+        const game = state.data.find(game => game.id === id) ?? state.selectedGame;
+        return game?.id === id ? game.version : 0;
+    };
+
+    // При конфликте версий подменяем игру актуальной, чтобы пользователь увидел чужие изменения
+    const applyConflict = (error: unknown) => {
+        if (!(error instanceof VersionConflictError)) {
+            return;
+        }
+        const current = error.current;
+        setState('data', state.data.map(game => game.id === current.id ? current : game));
+        setState('games', state.games.map(game => game.id === current.id ? current : game));
+        if (state.selectedGame?.id === current.id) {
+            setState('selectedGame', current);
+        }
+    };
+
     const actions = {
         async loadGames() {
             setState('isLoading', true);
@@ -83,7 +102,7 @@ export const createGameStore  = () : GameStore => {
             setState('errorConsumed', false);
 
             try {
-                const updatedGame = await gameApi.updateGame(id, dto);
+                const updatedGame = await gameApi.updateGame(id, dto, knownVersion(id));
                 setState('data', state.data.map(game => game.id === id ? updatedGame : game));
                 setState('games', state.games.map(game => game.id === id ? updatedGame : game));
                 if (state.selectedGame?.id === id) {
@@ -91,6 +110,7 @@ export const createGameStore  = () : GameStore => {
                 }
                 return updatedGame;
             } catch (error) {
+                applyConflict(error);
                 const errorMessage = error instanceof Error ? error.message : 'Failed to update game';
                 setState('error', errorMessage);
                 setState('errorConsumed', false);
@@ -106,13 +126,14 @@ export const createGameStore  = () : GameStore => {
             setState('errorConsumed', false);
 
             try {
-                await gameApi.deleteGame(id);
+                await gameApi.deleteGame(id, knownVersion(id));
                 setState('data', state.data.filter(game => game.id !== id));
                 setState('games', state.games.filter(game => game.id !== id));
                 if (state.selectedGame?.id === id) {
                     setState('selectedGame', null);
                 }
             } catch (error) {
+                applyConflict(error);
                 const errorMessage = error instanceof Error ? error.message : 'Failed to delete game';
                 setState('error', errorMessage);
                 setState('errorConsumed', false);
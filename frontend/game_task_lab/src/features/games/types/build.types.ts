@@ -0,0 +1,26 @@
+import {DateTime, UUID} from "../../../types/global";
+
+export type BuildPlatform = 'windows-x86_64' | 'linux-x86_64' | 'macos-x86_64' | 'macos-arm64';
+
+export interface GameBuildDto {
+    id: UUID;
+    gameId: UUID;
+    version: string;
+    platform: BuildPlatform;
+    // Путь к исполняемому файлу относительно корня распакованного архива
+    entrypoint: string;
+    archiveFormat: 'zip' | 'tar.gz';
+    // Хеш архива; сверяется после скачивания
+    sha256: string;
+    size: number;
+    releaseNotes: string;
+    uploadedBy: UUID;
+    isCurrent: boolean;
+    createdAt: DateTime;
+}
+
+export interface BuildDownloadUrlDto {
+    // Путь относительно адреса API; по нему можно докачивать, пока ссылка не устарела
+    url: string;
+    expiresAt: DateTime;
+}
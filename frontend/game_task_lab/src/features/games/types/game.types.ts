@@ -5,7 +5,12 @@ export interface GameDto {
     title: string;
     description: string;
     releaseDate: DateTime;
+    // Основной жанр
     genreId: UUID;
+    // Все жанры игры, основной первый
+    genreIds: UUID[];
+    // Версия записи; отправляется обратно в If-Match при изменении и удалении
+    version: number;
 }
 
 export interface GameDtoWithStats extends GameDto {
@@ -18,6 +23,8 @@ export interface CreateGameDto {
     description?: string;
     releaseDate: DateTime;
     genreId: UUID;
+    // Дополнительные жанры
+    genreIds?: UUID[];
 }
 
 export interface UpdateGameDto extends CreateGameDto {
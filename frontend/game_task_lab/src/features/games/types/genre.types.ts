@@ -3,5 +3,6 @@ import { UUID } from "../../../types/global";
 export interface GenreDto {
   id: UUID;
   title: string;
+  version: number;
 }
 
@@ -1,2 +1,10 @@
 export type UUID = string;
 export type DateTime = string;
+
+export interface PaginatedResponse<T> {
+    items: T[];
+    total: number;
+    page: number;
+    pageSize: number;
+    totalPages: number;
+}